@@ -3,20 +3,24 @@ package auth
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
+	"poll-app/config"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+// Token types, in the token_type claim. Both kinds of token are signed with
+// the same keys, so each is only accepted where its type is expected.
 const (
-	accessTokenTTL  = 15 * time.Minute
-	refreshTokenTTL = 7 * 24 * time.Hour
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
 )
 
 // JWTClaims represents the JWT token claims
@@ -24,6 +28,13 @@ type JWTClaims struct {
 	UserID   uuid.UUID `json:"user_id"`
 	Email    string    `json:"email"`
 	Username string    `json:"username"`
+	// SessionID is the session the token was issued to; empty for tokens
+	// issued before sessions were tracked
+	SessionID string `json:"session_id,omitempty"`
+	TokenType string `json:"token_type"`
+	// IssuedAtMicro is the issue time in microseconds since the Unix epoch,
+	// as iat only has seconds; zero for tokens issued before it was added
+	IssuedAtMicro int64 `json:"iat_us,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -31,47 +42,131 @@ type JWTClaims struct {
 type RefreshTokenClaims struct {
 	UserID    uuid.UUID `json:"user_id"`
 	SessionID string    `json:"session_id"`
+	// TokenType is empty for refresh tokens issued before tokens were typed
+	TokenType string `json:"token_type,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // JWTManager handles JWT token operations
 type JWTManager struct {
+	// secretKey signs tokens when there is no key ring; with a key ring it only
+	// verifies HS256 tokens without a kid header until secretUntil, and may be nil
 	secretKey       []byte
+	secretUntil     time.Time
+	keys            *KeyRing
 	redisClient     *redis.Client
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
+	reuseGrace      time.Duration
+	revocations     *revocationCache
+}
+
+// RefreshTokenReusedError is returned when a refresh token is presented again
+// after it was rotated, outside the grace window. Either the client or someone
+// who stole the token already holds its successor, so the session is revoked.
+type RefreshTokenReusedError struct {
+	UserID    uuid.UUID
+	SessionID string
 }
 
-// NewJWTManager creates a new JWT manager
-func NewJWTManager(redisClient *redis.Client) (*JWTManager, error) {
-	secretKey := os.Getenv("JWT_SECRET_KEY")
-	if secretKey == "" {
-		secretKey = "default-secret-key-change-in-production"
+func (e *RefreshTokenReusedError) Error() string {
+	return fmt.Sprintf("refresh token of session %s was reused; the session was revoked", e.SessionID)
+}
+
+// NewJWTManager creates a new JWT manager. Tokens are signed with keys from
+// the key ring if one is given, and with the configured secret otherwise.
+func NewJWTManager(redisClient *redis.Client, cfg config.JWTConfig, keys *KeyRing) (*JWTManager, error) {
+	if keys == nil && cfg.Secret == "" {
+		return nil, errors.New("JWT secret is not configured")
 	}
 
-	return &JWTManager{
-		secretKey:       []byte(secretKey),
+	m := &JWTManager{
+		keys:            keys,
+		secretUntil:     cfg.LegacySecretUntil,
 		redisClient:     redisClient,
-		accessTokenTTL:  accessTokenTTL,
-		refreshTokenTTL: refreshTokenTTL,
-	}, nil
+		accessTokenTTL:  cfg.AccessTokenTTL,
+		refreshTokenTTL: cfg.RefreshTokenTTL,
+		reuseGrace:      cfg.RefreshReuseGrace,
+		revocations:     newRevocationCache(),
+	}
+	if cfg.Secret != "" {
+		m.secretKey = []byte(cfg.Secret)
+	}
+	return m, nil
 }
 
-// GenerateAccessToken generates a new access token
-func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email, username string) (string, error) {
+// PublicKeys returns the keys whose tokens are accepted, to be published as a
+// JSON Web Key Set. It is empty when tokens are signed with the secret.
+func (m *JWTManager) PublicKeys() []*SigningKey {
+	if m.keys == nil {
+		return nil
+	}
+	return m.keys.PublicKeys()
+}
+
+// sign signs the claims with the current signing key, naming it in the kid header
+func (m *JWTManager) sign(claims jwt.Claims) (string, error) {
+	if m.keys == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secretKey)
+	}
+
+	key, err := m.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// verificationKey returns the key to verify a token with: the key ring's key
+// named in its kid header, or the secret for HS256 tokens without one. With a
+// key ring the secret is only used until the configured cutoff.
+func (m *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		if _, hmac := token.Method.(*jwt.SigningMethodHMAC); !hmac || m.secretKey == nil {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		if m.keys != nil && !time.Now().Before(m.secretUntil) {
+			return nil, errors.New("tokens signed with the secret are no longer accepted")
+		}
+		return m.secretKey, nil
+	}
+
+	if m.keys == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	key, ok := m.keys.Key(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.Private.Public(), nil
+}
+
+// GenerateAccessToken generates a new access token for a session
+func (m *JWTManager) GenerateAccessToken(userID uuid.UUID, email, username, sessionID string) (string, error) {
+	now := time.Now()
 	claims := &JWTClaims{
-		UserID:   userID,
-		Email:    email,
-		Username: username,
+		UserID:        userID,
+		Email:         email,
+		Username:      username,
+		SessionID:     sessionID,
+		TokenType:     tokenTypeAccess,
+		IssuedAtMicro: now.UnixMicro(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.accessTokenTTL)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			// The token ID lets the token be revoked on its own
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(m.secretKey)
+	return m.sign(claims)
 }
 
 // generateSessionID generates a unique session ID
@@ -83,90 +178,122 @@ func generateSessionID() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
-// GenerateRefreshToken generates a new refresh token with session ID
-func (m *JWTManager) GenerateRefreshToken(ctx context.Context, userID uuid.UUID) (string, string, error) {
-	sessionID, err := generateSessionID()
-	if err != nil {
-		return "", "", fmt.Errorf("failed to generate session ID: %w", err)
+// Redis keys of a user's sessions. The refresh token key holds the only
+// refresh token of the session that is still valid; the session key holds
+// what is known about the client. The used key holds the digests of the
+// session's rotated tokens, and the previous key the last rotation while its
+// grace window lasts.
+func refreshTokenKey(userID uuid.UUID, sessionID string) string {
+	return fmt.Sprintf("refresh_token:%s:%s", userID.String(), sessionID)
+}
+
+func usedRefreshTokensKey(userID uuid.UUID, sessionID string) string {
+	return fmt.Sprintf("refresh_token_used:%s:%s", userID.String(), sessionID)
+}
+
+func previousRefreshTokenKey(userID uuid.UUID, sessionID string) string {
+	return fmt.Sprintf("refresh_token_previous:%s:%s", userID.String(), sessionID)
+}
+
+// sessionKeys returns every key holding state of a session
+func sessionKeys(userID uuid.UUID, sessionID string) []string {
+	return []string{
+		refreshTokenKey(userID, sessionID),
+		sessionKey(userID, sessionID),
+		usedRefreshTokensKey(userID, sessionID),
+		previousRefreshTokenKey(userID, sessionID),
 	}
+}
+
+// tokenDigest identifies a rotated token without keeping the token itself
+func tokenDigest(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
 
+func userSessionsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user_sessions:%s", userID.String())
+}
+
+// signRefreshToken signs a new refresh token for the session
+func (m *JWTManager) signRefreshToken(userID uuid.UUID, sessionID string) (string, error) {
 	claims := &RefreshTokenClaims{
 		UserID:    userID,
 		SessionID: sessionID,
+		TokenType: tokenTypeRefresh,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// Tokens of a session signed in the same second must still differ,
+			// so a rotated token is never mistaken for its successor
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.refreshTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	refreshToken, err := token.SignedString(m.secretKey)
+	return m.sign(claims)
+}
+
+// GenerateRefreshToken starts a new session for the client and returns its
+// first refresh token and the session ID
+func (m *JWTManager) GenerateRefreshToken(ctx context.Context, userID uuid.UUID, client ClientInfo) (string, string, error) {
+	sessionID, err := generateSessionID()
 	if err != nil {
-		return "", "", err
+		return "", "", fmt.Errorf("failed to generate session ID: %w", err)
 	}
 
-	// Store refresh token in Redis with session ID as part of the key
-	// This allows multiple sessions per user
-	key := fmt.Sprintf("refresh_token:%s:%s", userID.String(), sessionID)
-	if err := m.redisClient.Set(ctx, key, refreshToken, m.refreshTokenTTL).Err(); err != nil {
-		return "", "", fmt.Errorf("failed to store refresh token: %w", err)
+	refreshToken, err := m.signRefreshToken(userID, sessionID)
+	if err != nil {
+		return "", "", err
 	}
 
-	// Also maintain a set of all session IDs for a user (for session management)
-	userSessionsKey := fmt.Sprintf("user_sessions:%s", userID.String())
-	if err := m.redisClient.SAdd(ctx, userSessionsKey, sessionID).Err(); err != nil {
-		// If this fails, we should still clean up the token we just created
-		m.redisClient.Del(ctx, key)
-		return "", "", fmt.Errorf("failed to add session to user sessions: %w", err)
+	// Store the token and the session together, and add the session to the
+	// user's set of sessions so they can be listed and revoked
+	now := time.Now()
+	if _, err := m.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, refreshTokenKey(userID, sessionID), refreshToken, m.refreshTokenTTL)
+		pipe.HSet(ctx, sessionKey(userID, sessionID), client.fields(now, now))
+		pipe.Expire(ctx, sessionKey(userID, sessionID), m.refreshTokenTTL)
+		pipe.SAdd(ctx, userSessionsKey(userID), sessionID)
+		pipe.Expire(ctx, userSessionsKey(userID), m.refreshTokenTTL)
+		return nil
+	}); err != nil {
+		return "", "", fmt.Errorf("failed to store refresh token: %w", err)
 	}
-	// Set expiration on the sessions set to match refresh token TTL
-	m.redisClient.Expire(ctx, userSessionsKey, m.refreshTokenTTL)
 
 	return refreshToken, sessionID, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
+// ValidateToken validates an access token and returns the claims. Access
+// tokens issued before tokens were typed are refused; they are short-lived,
+// and their clients only need to refresh.
 func (m *JWTManager) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, m.verificationKey)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
-		return claims, nil
+	claims, ok := token.Claims.(*JWTClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if claims.TokenType != tokenTypeAccess {
+		return nil, errors.New("not an access token")
 	}
 
-	return nil, errors.New("invalid token")
+	return claims, nil
 }
 
 // ValidateRefreshToken validates a refresh token and checks Redis
 func (m *JWTManager) ValidateRefreshToken(ctx context.Context, tokenString string) (*RefreshTokenClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &RefreshTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return m.secretKey, nil
-	})
-
+	claims, err := m.parseRefreshToken(tokenString)
 	if err != nil {
 		return nil, err
 	}
 
-	claims, ok := token.Claims.(*RefreshTokenClaims)
-	if !ok || !token.Valid {
-		return nil, errors.New("invalid token")
-	}
-
 	// Check if refresh token exists in Redis
-	key := fmt.Sprintf("refresh_token:%s:%s", claims.UserID.String(), claims.SessionID)
-	storedToken, err := m.redisClient.Get(ctx, key).Result()
+	storedToken, err := m.redisClient.Get(ctx, refreshTokenKey(claims.UserID, claims.SessionID)).Result()
 	if err == redis.Nil {
 		return nil, errors.New("refresh token not found")
 	}
@@ -181,71 +308,146 @@ func (m *JWTManager) ValidateRefreshToken(ctx context.Context, tokenString strin
 	return claims, nil
 }
 
-// RevokeRefreshToken revokes a specific refresh token by session ID
-func (m *JWTManager) RevokeRefreshToken(ctx context.Context, userID uuid.UUID, sessionID string) error {
-	key := fmt.Sprintf("refresh_token:%s:%s", userID.String(), sessionID)
+// parseRefreshToken verifies a refresh token's signature and expiry
+func (m *JWTManager) parseRefreshToken(tokenString string) (*RefreshTokenClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &RefreshTokenClaims{}, m.verificationKey)
 
-	// Remove from Redis
-	if err := m.redisClient.Del(ctx, key).Err(); err != nil {
-		return fmt.Errorf("failed to delete refresh token: %w", err)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*RefreshTokenClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	// Untyped tokens are refresh tokens from before tokens were typed; access
+	// tokens of that time are rejected by the stored token comparison
+	if claims.TokenType != tokenTypeRefresh && claims.TokenType != "" {
+		return nil, errors.New("not a refresh token")
 	}
 
-	// Remove from user sessions set
-	userSessionsKey := fmt.Sprintf("user_sessions:%s", userID.String())
-	if err := m.redisClient.SRem(ctx, userSessionsKey, sessionID).Err(); err != nil {
-		// Log but don't fail - the token is already deleted
+	return claims, nil
+}
+
+// RevokeRefreshToken ends a session by revoking its refresh token and every
+// access token issued to it
+func (m *JWTManager) RevokeRefreshToken(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	if _, err := m.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKeys(userID, sessionID)...)
+		pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+		// The mark is kept until the last access token it revokes has expired
+		pipe.Set(ctx, sessionAccessTokensRevokedKey(sessionID), 1, m.accessTokenTTL+time.Second)
 		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to delete refresh token: %w", err)
 	}
 
+	m.announce(ctx, revocation{SessionID: sessionID})
 	return nil
 }
 
-// RevokeAllUserRefreshTokens revokes all refresh tokens for a user
+// RevokeAllUserRefreshTokens ends all sessions of a user
 func (m *JWTManager) RevokeAllUserRefreshTokens(ctx context.Context, userID uuid.UUID) error {
-	userSessionsKey := fmt.Sprintf("user_sessions:%s", userID.String())
-
 	// Get all session IDs for this user
-	sessionIDs, err := m.redisClient.SMembers(ctx, userSessionsKey).Result()
+	sessionIDs, err := m.redisClient.SMembers(ctx, userSessionsKey(userID)).Result()
 	if err != nil && err != redis.Nil {
 		return fmt.Errorf("failed to get user sessions: %w", err)
 	}
 
-	// Delete all refresh tokens
+	keys := []string{userSessionsKey(userID)}
 	for _, sessionID := range sessionIDs {
-		key := fmt.Sprintf("refresh_token:%s:%s", userID.String(), sessionID)
-		if err := m.redisClient.Del(ctx, key).Err(); err != nil {
-			// Continue deleting others even if one fails
-			continue
-		}
+		keys = append(keys, sessionKeys(userID, sessionID)...)
 	}
-
-	// Delete the sessions set
-	if err := m.redisClient.Del(ctx, userSessionsKey).Err(); err != nil && err != redis.Nil {
-		return fmt.Errorf("failed to delete user sessions set: %w", err)
+	if err := m.redisClient.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
 	}
 
 	return nil
 }
 
-// RotateRefreshToken revokes the old refresh token and generates a new one
-// Returns: newToken, newSessionID, userID, error
-func (m *JWTManager) RotateRefreshToken(ctx context.Context, oldTokenString string) (string, string, uuid.UUID, error) {
-	// Validate old token
-	oldClaims, err := m.ValidateRefreshToken(ctx, oldTokenString)
+// RotateRefreshToken replaces a refresh token with a new one for the same
+// session, so the old one can no longer be used, and records the session's use
+// by the client. A token presented again within the grace window after its
+// rotation gets the same successor; presented later, the session is revoked
+// and a *RefreshTokenReusedError returned. Returns: newToken, sessionID, userID, error
+func (m *JWTManager) RotateRefreshToken(ctx context.Context, oldTokenString string, client ClientInfo) (string, string, uuid.UUID, error) {
+	oldClaims, err := m.parseRefreshToken(oldTokenString)
 	if err != nil {
 		return "", "", uuid.Nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
+	userID, sessionID := oldClaims.UserID, oldClaims.SessionID
 
-	// Revoke old token
-	if err := m.RevokeRefreshToken(ctx, oldClaims.UserID, oldClaims.SessionID); err != nil {
-		return "", "", uuid.Nil, fmt.Errorf("failed to revoke old refresh token: %w", err)
+	newToken, err := m.signRefreshToken(userID, sessionID)
+	if err != nil {
+		return "", "", uuid.Nil, fmt.Errorf("failed to generate new refresh token: %w", err)
 	}
 
-	// Generate new token
-	newToken, newSessionID, err := m.GenerateRefreshToken(ctx, oldClaims.UserID)
+	digest := tokenDigest(oldTokenString)
+	key := refreshTokenKey(userID, sessionID)
+	usedKey := usedRefreshTokensKey(userID, sessionID)
+	previousKey := previousRefreshTokenKey(userID, sessionID)
+
+	// Replace the token only if it is still the one presented, so a session
+	// revoked or rotated meanwhile is not brought back
+	var token string
+	reused := false
+	err = m.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		storedToken, err := tx.Get(ctx, key).Result()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+
+		if err == nil && storedToken == oldTokenString {
+			_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, newToken, m.refreshTokenTTL)
+				// Rotated tokens are remembered until they would have expired
+				pipe.SAdd(ctx, usedKey, digest)
+				pipe.Expire(ctx, usedKey, m.refreshTokenTTL)
+				if m.reuseGrace > 0 {
+					pipe.HSet(ctx, previousKey, "digest", digest, "successor", newToken)
+					pipe.Expire(ctx, previousKey, m.reuseGrace)
+				}
+				pipe.HSet(ctx, sessionKey(userID, sessionID), client.usedFields(time.Now()))
+				pipe.Expire(ctx, sessionKey(userID, sessionID), m.refreshTokenTTL)
+				pipe.SAdd(ctx, userSessionsKey(userID), sessionID)
+				pipe.Expire(ctx, userSessionsKey(userID), m.refreshTokenTTL)
+				return nil
+			})
+			token = newToken
+			return err
+		}
+
+		// A concurrent refresh with the same token rotated it just now: hand
+		// out the successor it got, as long as that is still current
+		previous, err := tx.HGetAll(ctx, previousKey).Result()
+		if err != nil {
+			return err
+		}
+		if storedToken != "" && previous["digest"] == digest && previous["successor"] == storedToken {
+			token = storedToken
+			return nil
+		}
+
+		used, err := tx.SIsMember(ctx, usedKey, digest).Result()
+		if err != nil {
+			return err
+		}
+		if !used {
+			return errors.New("refresh token was revoked")
+		}
+		reused = true
+		return nil
+	}, key, previousKey, usedKey)
 	if err != nil {
-		return "", "", uuid.Nil, fmt.Errorf("failed to generate new refresh token: %w", err)
+		return "", "", uuid.Nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	if reused {
+		if err := m.RevokeRefreshToken(ctx, userID, sessionID); err != nil {
+			return "", "", uuid.Nil, err
+		}
+		return "", "", uuid.Nil, &RefreshTokenReusedError{UserID: userID, SessionID: sessionID}
 	}
 
-	return newToken, newSessionID, oldClaims.UserID, nil
+	return token, sessionID, userID, nil
 }
@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"poll-app/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// testSecret is the HS256 secret of test managers
+var testSecret = strings.Repeat("s", 32)
+
+// testJWTConfig returns the JWT settings of test managers
+func testJWTConfig() config.JWTConfig {
+	return config.JWTConfig{
+		Secret:          testSecret,
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}
+}
+
+// newTestJWTManager returns a manager signing with the test secret and keeping
+// its state in an in-memory Redis
+func newTestJWTManager(t *testing.T, cfg config.JWTConfig) (*JWTManager, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	m, err := NewJWTManager(rdb, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, mr
+}
+
+// newTestKeyRing returns a key ring holding one ES256 key
+func newTestKeyRing(t *testing.T) *KeyRing {
+	t.Helper()
+	ctx := context.Background()
+	store := NewFileKeyStore(t.TempDir())
+	key, err := GenerateKey(config.AlgorithmES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.AddKey(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := NewKeyRing(ctx, store, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// secretToken signs an access token with the secret and no kid, as tokens were
+// signed before the key ring
+func secretToken(t *testing.T) string {
+	t.Helper()
+	claims := &JWTClaims{
+		UserID:    uuid.New(),
+		TokenType: tokenTypeAccess,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestValidateTokenSecretWithKeyRing(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyRing bool
+		until   time.Time
+		valid   bool
+	}{
+		{"no key ring", false, time.Time{}, true},
+		{"key ring without cutoff", true, time.Time{}, false},
+		{"key ring before cutoff", true, time.Now().Add(time.Hour), true},
+		{"key ring after cutoff", true, time.Now().Add(-time.Second), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testJWTConfig()
+			cfg.LegacySecretUntil = tt.until
+			var keys *KeyRing
+			if tt.keyRing {
+				keys = newTestKeyRing(t)
+			}
+			m, err := NewJWTManager(nil, cfg, keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = m.ValidateToken(secretToken(t))
+			if (err == nil) != tt.valid {
+				t.Fatalf("ValidateToken = %v, want valid %v", err, tt.valid)
+			}
+
+			// Tokens the manager signs itself are always accepted
+			token, err := m.GenerateAccessToken(uuid.New(), "a@example.com", "a", "session")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := m.ValidateToken(token); err != nil {
+				t.Fatalf("ValidateToken of a token it signed = %v", err)
+			}
+		})
+	}
+}
+
+func TestTokenTypes(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestJWTManager(t, testJWTConfig())
+	userID := uuid.New()
+
+	refreshToken, sessionID, err := m.GenerateRefreshToken(ctx, userID, ClientInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	accessToken, err := m.GenerateAccessToken(userID, "a@example.com", "a", sessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An untyped refresh token, as issued before tokens were typed, stored as
+	// the current one of its session
+	legacyUserID := uuid.New()
+	legacyRefreshToken, err := m.sign(&RefreshTokenClaims{
+		UserID:    legacyUserID,
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.redisClient.Set(ctx, refreshTokenKey(legacyUserID, sessionID), legacyRefreshToken, time.Hour).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		token        string
+		validAccess  bool
+		validRefresh bool
+	}{
+		{"access token", accessToken, true, false},
+		{"refresh token", refreshToken, false, true},
+		{"untyped refresh token", legacyRefreshToken, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := m.ValidateToken(tt.token); (err == nil) != tt.validAccess {
+				t.Errorf("ValidateToken = %v, want valid %v", err, tt.validAccess)
+			}
+			if _, err := m.ValidateRefreshToken(ctx, tt.token); (err == nil) != tt.validRefresh {
+				t.Errorf("ValidateRefreshToken = %v, want valid %v", err, tt.validRefresh)
+			}
+		})
+	}
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	cfg := testJWTConfig()
+	cfg.RefreshReuseGrace = 10 * time.Second
+
+	tests := []struct {
+		name string
+		// the first token is presented again after wait, and after the
+		// session is ended if revoke is set
+		wait        time.Duration
+		revoke      bool
+		wantSame    bool
+		wantReused  bool
+		wantSession bool
+	}{
+		{name: "within the grace window", wait: time.Second, wantSame: true, wantSession: true},
+		{name: "after the grace window", wait: time.Minute, wantReused: true},
+		{name: "revoked session", revoke: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mr := newTestJWTManager(t, cfg)
+			userID := uuid.New()
+			first, sessionID, err := m.GenerateRefreshToken(ctx, userID, ClientInfo{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			second, gotSession, gotUser, err := m.RotateRefreshToken(ctx, first, ClientInfo{})
+			if err != nil {
+				t.Fatalf("first rotation: %v", err)
+			}
+			if second == first || gotSession != sessionID || gotUser != userID {
+				t.Fatalf("first rotation returned %q for session %s of %s", second, gotSession, gotUser)
+			}
+			if _, err := m.ValidateRefreshToken(ctx, first); err == nil {
+				t.Fatal("rotated token is still valid")
+			}
+
+			if tt.revoke {
+				if err := m.RevokeRefreshToken(ctx, userID, sessionID); err != nil {
+					t.Fatal(err)
+				}
+			}
+			mr.FastForward(tt.wait)
+
+			replayed, _, _, err := m.RotateRefreshToken(ctx, first, ClientInfo{})
+			var reused *RefreshTokenReusedError
+			if got := errors.As(err, &reused); got != tt.wantReused {
+				t.Fatalf("replay = %v, want reuse detected %v", err, tt.wantReused)
+			}
+			if tt.wantSame && (err != nil || replayed != second) {
+				t.Fatalf("replay = %q, %v, want the successor %q", replayed, err, second)
+			}
+			if !tt.wantSame && err == nil {
+				t.Fatal("replay succeeded")
+			}
+
+			_, err = m.ValidateRefreshToken(ctx, second)
+			if (err == nil) != tt.wantSession {
+				t.Fatalf("successor after replay: %v, want session alive %v", err, tt.wantSession)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"poll-app/config"
+)
+
+const (
+	// keyRefreshInterval is how often servers reload the key ring from its store
+	keyRefreshInterval = time.Minute
+	// KeyPropagationDelay is how long a new key is only published before it
+	// signs tokens, so every server and every JWKS consumer knows it by then
+	KeyPropagationDelay = 2 * keyRefreshInterval
+)
+
+var (
+	// ErrNoSigningKey is returned when the key store holds no key that may sign tokens
+	ErrNoSigningKey = errors.New("no signing key; create one with `poll-app keys generate`")
+	// ErrSigningKeyExists is returned when generating a first key for a store that already has one
+	ErrSigningKeyExists = errors.New("the key store already has a signing key; use `poll-app keys rotate` to replace it")
+)
+
+// Key states, as reported by KeyStatus
+const (
+	KeyStatusPending = "pending"
+	KeyStatusSigning = "signing"
+	KeyStatusActive  = "active"
+	KeyStatusRetired = "retired"
+	KeyStatusExpired = "expired"
+)
+
+// KeyRing holds the signing keys tokens are signed and verified with. The
+// newest key that has been published long enough signs new tokens; older
+// keys keep verifying the tokens they signed until overlap after they retired.
+type KeyRing struct {
+	store   KeyStore
+	overlap time.Duration
+
+	mu   sync.RWMutex
+	keys []*SigningKey
+}
+
+// NewKeyRing loads the key ring from the store. It fails if the store holds no
+// key that may sign tokens.
+func NewKeyRing(ctx context.Context, store KeyStore, overlap time.Duration) (*KeyRing, error) {
+	r := &KeyRing{store: store, overlap: overlap}
+	if err := r.Reload(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := r.SigningKey(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload reads the keys from the store again
+func (r *KeyRing) Reload(ctx context.Context) error {
+	keys, err := r.store.Keys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	r.mu.Lock()
+	r.keys = keys
+	r.mu.Unlock()
+	return nil
+}
+
+// Run reloads the key ring periodically, picking up keys rotated by other
+// processes, until ctx is canceled
+func (r *KeyRing) Run(ctx context.Context) {
+	ticker := time.NewTicker(keyRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reload(ctx); err != nil {
+				// Keep the keys loaded last; they stay usable until rotated
+				log.Printf("Failed to reload signing keys: %v", err)
+			}
+		}
+	}
+}
+
+// SigningKey returns the key new tokens are signed with
+func (r *KeyRing) SigningKey() (*SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if key := signingKey(r.keys, time.Now()); key != nil {
+		return key, nil
+	}
+	return nil, ErrNoSigningKey
+}
+
+// Key returns the key with the given ID if tokens it signed are still accepted
+func (r *KeyRing) Key(id string) (*SigningKey, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	for _, key := range r.keys {
+		if key.ID == id {
+			return key, !key.expired(now, r.overlap)
+		}
+	}
+	return nil, false
+}
+
+// PublicKeys returns the keys whose tokens are accepted, including keys that
+// do not sign yet, newest first
+func (r *KeyRing) PublicKeys() []*SigningKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	keys := make([]*SigningKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		if !key.expired(now, r.overlap) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// signingKey picks the signing key among keys sorted newest first: the newest
+// key that is not retired and has been published for KeyPropagationDelay, or
+// the newest key that is not retired if none has been published that long
+func signingKey(keys []*SigningKey, now time.Time) *SigningKey {
+	var newest *SigningKey
+	for _, key := range keys {
+		if key.RetiredAt != nil && !key.RetiredAt.After(now) {
+			continue
+		}
+		if newest == nil {
+			newest = key
+		}
+		if !key.CreatedAt.Add(KeyPropagationDelay).After(now) {
+			return key
+		}
+	}
+	return newest
+}
+
+// KeyStatus describes the state of each key among keys sorted newest first
+func KeyStatus(keys []*SigningKey, overlap time.Duration, now time.Time) map[string]string {
+	signing := signingKey(keys, now)
+	status := make(map[string]string, len(keys))
+	// Keys newer than the signing key wait to take over
+	newer := signing != nil
+	for _, key := range keys {
+		switch {
+		case key == signing:
+			status[key.ID] = KeyStatusSigning
+			newer = false
+		case key.expired(now, overlap):
+			status[key.ID] = KeyStatusExpired
+		case key.RetiredAt != nil && !key.RetiredAt.After(now):
+			status[key.ID] = KeyStatusRetired
+		case newer:
+			status[key.ID] = KeyStatusPending
+		default:
+			status[key.ID] = KeyStatusActive
+		}
+	}
+	return status
+}
+
+// KeyRotator creates, rotates and prunes the keys of a key store
+type KeyRotator struct {
+	store     KeyStore
+	algorithm string
+	interval  time.Duration
+	overlap   time.Duration
+}
+
+// NewKeyRotator creates a key rotator for the configured algorithm and schedule
+func NewKeyRotator(store KeyStore, cfg config.JWTConfig) *KeyRotator {
+	return &KeyRotator{
+		store:     store,
+		algorithm: cfg.Algorithm,
+		interval:  cfg.KeyRotationInterval,
+		overlap:   cfg.KeyOverlap,
+	}
+}
+
+// Generate creates the first signing key of the store. It returns
+// ErrSigningKeyExists if the store already has a key that is not retired.
+func (k *KeyRotator) Generate(ctx context.Context) (*SigningKey, error) {
+	keys, err := k.store.Keys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if signingKey(keys, time.Now()) != nil {
+		return nil, ErrSigningKeyExists
+	}
+
+	key, err := GenerateKey(k.algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.store.AddKey(ctx, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Rotate creates a new key to replace the current ones. The current keys keep
+// signing while the new key propagates and retire when it takes over.
+func (k *KeyRotator) Rotate(ctx context.Context) (*SigningKey, error) {
+	key, err := GenerateKey(k.algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.store.AddKey(ctx, key); err != nil {
+		return nil, err
+	}
+	if _, err := k.store.RetireKeys(ctx, key.ID, key.CreatedAt.Add(KeyPropagationDelay)); err != nil {
+		return nil, fmt.Errorf("failed to retire signing keys: %w", err)
+	}
+	return key, nil
+}
+
+// Prune deletes the keys whose tokens have all expired
+func (k *KeyRotator) Prune(ctx context.Context) (int, error) {
+	deleted, err := k.store.DeleteKeys(ctx, time.Now().Add(-k.overlap))
+	if err != nil {
+		return deleted, fmt.Errorf("failed to delete expired signing keys: %w", err)
+	}
+	return deleted, nil
+}
+
+// RotateDue rotates the keys if the current key is older than the rotation
+// interval and prunes expired keys. It reports the number of keys created and
+// deleted. Stores without a current key are left for `poll-app keys generate`.
+func (k *KeyRotator) RotateDue(ctx context.Context) (int, error) {
+	affected := 0
+	if k.interval > 0 {
+		keys, err := k.store.Keys(ctx)
+		if err != nil {
+			return 0, err
+		}
+		now := time.Now()
+		// Only the newest key counts; a rotation in progress is not due again
+		if current := signingKey(keys, now.Add(KeyPropagationDelay)); current != nil && !current.CreatedAt.Add(k.interval).After(now) {
+			if _, err := k.Rotate(ctx); err != nil {
+				return 0, err
+			}
+			affected++
+		}
+	}
+
+	deleted, err := k.Prune(ctx)
+	return affected + deleted, err
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestKeyStatus(t *testing.T) {
+	const overlap = time.Hour
+	now := time.Now()
+	at := func(d time.Duration) *time.Time {
+		t := now.Add(d)
+		return &t
+	}
+	key := func(id string, created time.Duration, retired *time.Time) *SigningKey {
+		return &SigningKey{ID: id, CreatedAt: now.Add(created), RetiredAt: retired}
+	}
+	long := -24 * time.Hour
+
+	tests := []struct {
+		name string
+		// keys are sorted newest first
+		keys []*SigningKey
+		want map[string]string
+	}{
+		{
+			name: "first key",
+			keys: []*SigningKey{key("a", 0, nil)},
+			want: map[string]string{"a": KeyStatusSigning},
+		},
+		{
+			name: "new key propagating",
+			keys: []*SigningKey{key("b", 0, nil), key("a", long, at(KeyPropagationDelay))},
+			want: map[string]string{"b": KeyStatusPending, "a": KeyStatusSigning},
+		},
+		{
+			name: "new key took over",
+			keys: []*SigningKey{key("b", -2*KeyPropagationDelay, nil), key("a", long, at(-KeyPropagationDelay))},
+			want: map[string]string{"b": KeyStatusSigning, "a": KeyStatusRetired},
+		},
+		{
+			name: "old key past the overlap",
+			keys: []*SigningKey{key("b", long/2, nil), key("a", long, at(-overlap-time.Second))},
+			want: map[string]string{"b": KeyStatusSigning, "a": KeyStatusExpired},
+		},
+		{
+			name: "older key still signing",
+			keys: []*SigningKey{key("c", 0, nil), key("b", long/2, nil), key("a", long, nil)},
+			want: map[string]string{"c": KeyStatusPending, "b": KeyStatusSigning, "a": KeyStatusActive},
+		},
+		{
+			name: "every key retired",
+			keys: []*SigningKey{key("a", long, at(-time.Minute))},
+			want: map[string]string{"a": KeyStatusRetired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KeyStatus(tt.keys, overlap, now); !maps.Equal(got, tt.want) {
+				t.Fatalf("KeyStatus = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
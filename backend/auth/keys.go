@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"poll-app/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// rsaKeyBits is the size of generated RS256 keys
+const rsaKeyBits = 2048
+
+// SigningKey is an asymmetric key of the key ring. Its public half is
+// published so other services can verify the tokens it signs.
+type SigningKey struct {
+	// ID is the key's JWK thumbprint (RFC 7638), sent in the kid header
+	ID        string
+	Algorithm string
+	Private   crypto.Signer
+	CreatedAt time.Time
+	// RetiredAt is when a newer key took over signing, or nil while the key is current
+	RetiredAt *time.Time
+}
+
+// JWK is the public half of a signing key as a JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Elliptic curve and Edwards curve keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// GenerateKey creates a new signing key for the given algorithm
+func GenerateKey(algorithm string) (*SigningKey, error) {
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case config.AlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case config.AlgorithmES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case config.AlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", algorithm)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s key: %w", algorithm, err)
+	}
+
+	return newSigningKey(private, time.Now().UTC().Truncate(time.Second), nil)
+}
+
+// ParseKey reads a signing key from its PKCS #8 encoding. The algorithm
+// follows from the key type.
+func ParseKey(der []byte, createdAt time.Time, retiredAt *time.Time) (*SigningKey, error) {
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	private, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported signing key type %T", parsed)
+	}
+	return newSigningKey(private, createdAt, retiredAt)
+}
+
+func newSigningKey(private crypto.Signer, createdAt time.Time, retiredAt *time.Time) (*SigningKey, error) {
+	algorithm, err := keyAlgorithm(private)
+	if err != nil {
+		return nil, err
+	}
+
+	k := &SigningKey{
+		Algorithm: algorithm,
+		Private:   private,
+		CreatedAt: createdAt,
+		RetiredAt: retiredAt,
+	}
+	if k.ID, err = k.thumbprint(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// keyAlgorithm returns the signing algorithm a private key is used with
+func keyAlgorithm(private crypto.Signer) (string, error) {
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		return config.AlgorithmRS256, nil
+	case *ecdsa.PrivateKey:
+		if key.Curve != elliptic.P256() {
+			return "", fmt.Errorf("unsupported elliptic curve %s", key.Curve.Params().Name)
+		}
+		return config.AlgorithmES256, nil
+	case ed25519.PrivateKey:
+		return config.AlgorithmEdDSA, nil
+	}
+	return "", fmt.Errorf("unsupported signing key type %T", private)
+}
+
+// Marshal returns the PKCS #8 encoding of the private key
+func (k *SigningKey) Marshal() ([]byte, error) {
+	return x509.MarshalPKCS8PrivateKey(k.Private)
+}
+
+// method returns the JWT signing method of the key
+func (k *SigningKey) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// expired reports whether the key retired longer than overlap ago, so the
+// tokens it signed have all expired
+func (k *SigningKey) expired(now time.Time, overlap time.Duration) bool {
+	return k.RetiredAt != nil && now.After(k.RetiredAt.Add(overlap))
+}
+
+// JWK returns the public half of the key
+func (k *SigningKey) JWK() JWK {
+	jwk := JWK{Use: "sig", Alg: k.Algorithm, Kid: k.ID}
+	switch pub := k.Private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeSegment(pub.N.Bytes())
+		jwk.E = encodeSegment(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		// The uncompressed point is 0x04 followed by X and Y at full length
+		point, _ := pub.Bytes()
+		size := (len(point) - 1) / 2
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = encodeSegment(point[1 : 1+size])
+		jwk.Y = encodeSegment(point[1+size:])
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeSegment(pub)
+	}
+	return jwk
+}
+
+// thumbprint computes the RFC 7638 thumbprint of the key: the hash of its
+// required JWK members in lexicographic order
+func (k *SigningKey) thumbprint() (string, error) {
+	jwk := k.JWK()
+	var members any
+	switch jwk.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{jwk.Crv, jwk.Kty, jwk.X, jwk.Y}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	default:
+		return "", errors.New("cannot compute thumbprint of unknown key type")
+	}
+
+	b, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return encodeSegment(sum[:]), nil
+}
+
+func encodeSegment(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,262 @@
+package auth
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"poll-app/config"
+	"poll-app/ent/signingkey"
+	"poll-app/storage"
+)
+
+// KeyStore keeps the signing keys of the key ring
+type KeyStore interface {
+	// Keys returns every stored key, newest first
+	Keys(ctx context.Context) ([]*SigningKey, error)
+	AddKey(ctx context.Context, key *SigningKey) error
+	// RetireKeys marks every key other than exceptID that is not retired yet as retired at the given time
+	RetireKeys(ctx context.Context, exceptID string, at time.Time) (int, error)
+	// DeleteKeys removes the keys retired before the given time
+	DeleteKeys(ctx context.Context, retiredBefore time.Time) (int, error)
+}
+
+// NewKeyStore returns the key store selected by the configuration. The
+// storage is only used when keys are kept in Postgres.
+func NewKeyStore(cfg config.JWTConfig, store storage.Storage) (KeyStore, error) {
+	switch cfg.KeyStore {
+	case config.KeyStoreFile:
+		return NewFileKeyStore(cfg.KeyDir), nil
+	case config.KeyStorePostgres:
+		return NewDatabaseKeyStore(store), nil
+	}
+	return nil, fmt.Errorf("unknown key store %q", cfg.KeyStore)
+}
+
+// DatabaseKeyStore keeps signing keys in Postgres
+type DatabaseKeyStore struct {
+	store storage.SigningKeyStorage
+}
+
+// NewDatabaseKeyStore creates a key store backed by the database
+func NewDatabaseKeyStore(store storage.SigningKeyStorage) *DatabaseKeyStore {
+	return &DatabaseKeyStore{store: store}
+}
+
+func (s *DatabaseKeyStore) Keys(ctx context.Context) ([]*SigningKey, error) {
+	rows, err := s.store.ListSigningKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	keys := make([]*SigningKey, 0, len(rows))
+	for _, row := range rows {
+		key, err := ParseKey(row.PrivateKey, row.CreatedAt, row.RetiredAt)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", row.ID, err)
+		}
+		if key.ID != row.ID || key.Algorithm != string(row.Algorithm) {
+			return nil, fmt.Errorf("signing key %s does not match its stored ID or algorithm", row.ID)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s *DatabaseKeyStore) AddKey(ctx context.Context, key *SigningKey) error {
+	der, err := key.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode signing key: %w", err)
+	}
+
+	if _, err := s.store.CreateSigningKey(ctx, storage.SigningKeyInput{
+		ID:         key.ID,
+		Algorithm:  signingkey.Algorithm(key.Algorithm),
+		PrivateKey: der,
+		CreatedAt:  key.CreatedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to store signing key: %w", err)
+	}
+	return nil
+}
+
+func (s *DatabaseKeyStore) RetireKeys(ctx context.Context, exceptID string, at time.Time) (int, error) {
+	return s.store.RetireSigningKeys(ctx, exceptID, at)
+}
+
+func (s *DatabaseKeyStore) DeleteKeys(ctx context.Context, retiredBefore time.Time) (int, error) {
+	return s.store.DeleteSigningKeysRetiredBefore(ctx, retiredBefore)
+}
+
+const (
+	// pemBlockType is the type of the PEM blocks keys are written as
+	pemBlockType = "PRIVATE KEY"
+	// Headers of a key file's PEM block holding the key's lifecycle
+	headerCreatedAt = "Created-At"
+	headerRetiredAt = "Retired-At"
+)
+
+// FileKeyStore keeps signing keys in a directory, one PEM file per key named
+// after its ID. Only the key commands and the worker write to it; servers
+// sharing the directory read it.
+type FileKeyStore struct {
+	dir string
+}
+
+// NewFileKeyStore creates a key store backed by a directory
+func NewFileKeyStore(dir string) *FileKeyStore {
+	return &FileKeyStore{dir: dir}
+}
+
+func (s *FileKeyStore) Keys(ctx context.Context) ([]*SigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*SigningKey, 0, len(paths))
+	for _, path := range paths {
+		key, err := readKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b *SigningKey) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	return keys, nil
+}
+
+func (s *FileKeyStore) AddKey(ctx context.Context, key *SigningKey) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+
+	path := s.path(key.ID)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("signing key %s already exists", key.ID)
+	}
+	return writeKeyFile(path, key)
+}
+
+func (s *FileKeyStore) RetireKeys(ctx context.Context, exceptID string, at time.Time) (int, error) {
+	keys, err := s.Keys(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	retired := 0
+	for _, key := range keys {
+		if key.ID == exceptID || key.RetiredAt != nil {
+			continue
+		}
+		key.RetiredAt = &at
+		if err := writeKeyFile(s.path(key.ID), key); err != nil {
+			return retired, err
+		}
+		retired++
+	}
+	return retired, nil
+}
+
+func (s *FileKeyStore) DeleteKeys(ctx context.Context, retiredBefore time.Time) (int, error) {
+	keys, err := s.Keys(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if key.RetiredAt == nil || !key.RetiredAt.Before(retiredBefore) {
+			continue
+		}
+		if err := os.Remove(s.path(key.ID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return deleted, fmt.Errorf("failed to delete signing key %s: %w", key.ID, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+func (s *FileKeyStore) path(id string) string {
+	return filepath.Join(s.dir, id+".pem")
+}
+
+// readKeyFile reads a key written by writeKeyFile
+func readKeyFile(path string) (*SigningKey, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(b)
+	if block == nil || block.Type != pemBlockType {
+		return nil, fmt.Errorf("%s does not contain a PEM encoded private key", path)
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, block.Headers[headerCreatedAt])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid %s header: %w", path, headerCreatedAt, err)
+	}
+	var retiredAt *time.Time
+	if v, ok := block.Headers[headerRetiredAt]; ok {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid %s header: %w", path, headerRetiredAt, err)
+		}
+		retiredAt = &t
+	}
+
+	key, err := ParseKey(block.Bytes, createdAt, retiredAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if filepath.Base(path) != key.ID+".pem" {
+		return nil, fmt.Errorf("%s is not named after its key ID %s", path, key.ID)
+	}
+	return key, nil
+}
+
+// writeKeyFile writes the key through a temporary file, so readers never see
+// a partly written key
+func writeKeyFile(path string, key *SigningKey) error {
+	der, err := key.Marshal()
+	if err != nil {
+		return fmt.Errorf("failed to encode signing key: %w", err)
+	}
+
+	headers := map[string]string{headerCreatedAt: key.CreatedAt.UTC().Format(time.RFC3339)}
+	if key.RetiredAt != nil {
+		headers[headerRetiredAt] = key.RetiredAt.UTC().Format(time.RFC3339)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: pemBlockType, Headers: headers, Bytes: der})
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".key-*")
+	if err != nil {
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+	return nil
+}
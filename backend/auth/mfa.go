@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// maxMFAChallengeAttempts is how many codes can be tried against one challenge
+	maxMFAChallengeAttempts = 5
+	// maxMFAFailures is how many wrong codes a user can enter across challenges
+	// within mfaFailureWindow before no more challenges are accepted
+	maxMFAFailures = 10
+	// mfaFailureWindow is how long wrong codes count against a user
+	mfaFailureWindow = 15 * time.Minute
+)
+
+var (
+	// ErrInvalidMFAChallenge is returned for MFA challenge tokens that do not
+	// exist, were completed, have expired or ran out of attempts
+	ErrInvalidMFAChallenge = errors.New("MFA challenge is invalid or has expired")
+	// ErrTooManyMFAFailures is returned while a user has entered too many wrong codes
+	ErrTooManyMFAFailures = errors.New("too many wrong authentication codes")
+)
+
+// Hash fields of an MFA challenge
+const (
+	mfaChallengeFieldUserID     = "user_id"
+	mfaChallengeFieldDeviceName = "device_name"
+	mfaChallengeFieldAttempts   = "attempts"
+)
+
+// Redis keys of two-factor authentication. The challenge key of a token holds
+// the sign-in it stands for until it expires; the failures key of a user
+// counts their recent wrong codes.
+func mfaChallengeKey(token string) string {
+	return fmt.Sprintf("mfa_challenge:%s", tokenDigest(token))
+}
+
+func mfaFailuresKey(userID uuid.UUID) string {
+	return fmt.Sprintf("mfa_failures:%s", userID.String())
+}
+
+// MFAChallenge is a sign-in that passed the password check and waits for a
+// second factor
+type MFAChallenge struct {
+	UserID uuid.UUID
+	// DeviceName is the device name given with the password, for the session
+	// started once the challenge is completed
+	DeviceName string
+}
+
+// MFAChallenges keeps the MFA challenges of sign-ins in Redis. A challenge
+// token is only good for a few attempts, and a user who enters too many wrong
+// codes is locked out for a while, so codes cannot be guessed.
+type MFAChallenges struct {
+	redisClient *redis.Client
+	ttl         time.Duration
+}
+
+// NewMFAChallenges creates a challenge store whose challenges expire after ttl
+func NewMFAChallenges(redisClient *redis.Client, ttl time.Duration) *MFAChallenges {
+	return &MFAChallenges{redisClient: redisClient, ttl: ttl}
+}
+
+// Create starts a challenge for the user and returns its token, which is not
+// stored, and when it expires
+func (c *MFAChallenges) Create(ctx context.Context, userID uuid.UUID, deviceName string) (string, time.Time, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to generate MFA challenge: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	expiresAt := time.Now().Add(c.ttl)
+
+	key := mfaChallengeKey(token)
+	pipe := c.redisClient.TxPipeline()
+	pipe.HSet(ctx, key, map[string]any{
+		mfaChallengeFieldUserID:     userID.String(),
+		mfaChallengeFieldDeviceName: deviceName,
+		mfaChallengeFieldAttempts:   0,
+	})
+	pipe.Expire(ctx, key, c.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to store MFA challenge: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// Get returns the pending challenge of the token. It returns
+// ErrTooManyMFAFailures while its user is locked out.
+func (c *MFAChallenges) Get(ctx context.Context, token string) (*MFAChallenge, error) {
+	fields, err := c.redisClient.HGetAll(ctx, mfaChallengeKey(token)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MFA challenge: %w", err)
+	}
+	userID, err := uuid.Parse(fields[mfaChallengeFieldUserID])
+	if err != nil {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	failures, err := c.redisClient.Get(ctx, mfaFailuresKey(userID)).Int()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to load MFA failures: %w", err)
+	}
+	if failures >= maxMFAFailures {
+		return nil, ErrTooManyMFAFailures
+	}
+
+	return &MFAChallenge{UserID: userID, DeviceName: fields[mfaChallengeFieldDeviceName]}, nil
+}
+
+// Fail records a wrong code entered for the challenge. The challenge ends
+// once it ran out of attempts.
+func (c *MFAChallenges) Fail(ctx context.Context, token string, userID uuid.UUID) error {
+	key := mfaChallengeKey(token)
+	failuresKey := mfaFailuresKey(userID)
+
+	pipe := c.redisClient.TxPipeline()
+	attempts := pipe.HIncrBy(ctx, key, mfaChallengeFieldAttempts, 1)
+	started := pipe.HExists(ctx, key, mfaChallengeFieldUserID)
+	pipe.Incr(ctx, failuresKey)
+	pipe.Expire(ctx, failuresKey, mfaFailureWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record MFA failure: %w", err)
+	}
+
+	// A challenge that ended meanwhile was just recreated without its expiry
+	if attempts.Val() >= maxMFAChallengeAttempts || !started.Val() {
+		if err := c.redisClient.Del(ctx, key).Err(); err != nil {
+			return fmt.Errorf("failed to end MFA challenge: %w", err)
+		}
+	}
+	return nil
+}
+
+// Complete ends the challenge after its second factor was accepted. It
+// returns ErrInvalidMFAChallenge if the challenge ended meanwhile, so a
+// challenge completes only once.
+func (c *MFAChallenges) Complete(ctx context.Context, token string, userID uuid.UUID) error {
+	deleted, err := c.redisClient.Del(ctx, mfaChallengeKey(token)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to end MFA challenge: %w", err)
+	}
+	if deleted == 0 {
+		return ErrInvalidMFAChallenge
+	}
+
+	// A correct code clears the user's wrong ones
+	if err := c.redisClient.Del(ctx, mfaFailuresKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to reset MFA failures: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestMFAChallenges returns a challenge store in an in-memory Redis
+func newTestMFAChallenges(t *testing.T) (*MFAChallenges, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewMFAChallenges(rdb, 5*time.Minute), mr
+}
+
+func TestMFAChallenges(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		// failures are wrong codes entered on earlier challenges, cooldown
+		// passes after them, and attempts are wrong codes entered on the
+		// challenge itself, wait before it is used
+		failures int
+		cooldown time.Duration
+		attempts int
+		wait     time.Duration
+		wantGet  error
+	}{
+		{name: "fresh"},
+		{name: "some wrong codes", attempts: maxMFAChallengeAttempts - 1},
+		{name: "out of attempts", attempts: maxMFAChallengeAttempts, wantGet: ErrInvalidMFAChallenge},
+		{name: "user locked out", failures: maxMFAFailures, wantGet: ErrTooManyMFAFailures},
+		{name: "lockout over", failures: maxMFAFailures, cooldown: mfaFailureWindow},
+		{name: "expired", wait: 6 * time.Minute, wantGet: ErrInvalidMFAChallenge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, mr := newTestMFAChallenges(t)
+			userID := uuid.New()
+
+			create := func() string {
+				t.Helper()
+				token, _, err := c.Create(ctx, userID, "phone")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return token
+			}
+			// fail enters n wrong codes, starting a new challenge whenever one
+			// runs out of attempts, and returns the last challenge
+			fail := func(n int) string {
+				t.Helper()
+				token := create()
+				for i := 0; i < n; i++ {
+					if i > 0 && i%maxMFAChallengeAttempts == 0 {
+						token = create()
+					}
+					if err := c.Fail(ctx, token, userID); err != nil {
+						t.Fatal(err)
+					}
+				}
+				return token
+			}
+
+			fail(tt.failures)
+			mr.FastForward(tt.cooldown)
+			token := fail(tt.attempts)
+			mr.FastForward(tt.wait)
+
+			challenge, err := c.Get(ctx, token)
+			if !errors.Is(err, tt.wantGet) {
+				t.Fatalf("Get = %v, want %v", err, tt.wantGet)
+			}
+			if err != nil {
+				return
+			}
+			if challenge.UserID != userID || challenge.DeviceName != "phone" {
+				t.Fatalf("Get = %+v", challenge)
+			}
+
+			// A challenge completes once, and clears the user's wrong codes
+			if err := c.Complete(ctx, token, userID); err != nil {
+				t.Fatalf("Complete = %v", err)
+			}
+			if err := c.Complete(ctx, token, userID); !errors.Is(err, ErrInvalidMFAChallenge) {
+				t.Fatalf("second Complete = %v, want ErrInvalidMFAChallenge", err)
+			}
+			if mr.Exists(mfaFailuresKey(userID)) {
+				t.Fatal("wrong codes are still counted after completing a challenge")
+			}
+		})
+	}
+}
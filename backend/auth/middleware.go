@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -9,27 +10,44 @@ import (
 	"github.com/julienschmidt/httprouter"
 )
 
-// AuthMiddleware validates JWT tokens in requests
-func AuthMiddleware(jwtManager *JWTManager) func(httprouter.Handle) httprouter.Handle {
+var (
+	// ErrAuthorizationRequired is passed to the error handler when a request has no Authorization header
+	ErrAuthorizationRequired = errors.New("authorization header required")
+	// ErrInvalidAuthorizationHeader is passed to the error handler when the Authorization header is not a bearer token
+	ErrInvalidAuthorizationHeader = errors.New("invalid authorization header format")
+	// ErrInvalidToken is passed to the error handler when the bearer token is invalid or expired
+	ErrInvalidToken = errors.New("invalid or expired token")
+)
+
+// ErrorHandler writes the response for a request that failed authentication
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// AuthMiddleware validates JWT tokens in requests. Requests that cannot be
+// authenticated are answered by onError.
+func AuthMiddleware(jwtManager *JWTManager, onError ErrorHandler) func(httprouter.Handle) httprouter.Handle {
 	return func(next httprouter.Handle) httprouter.Handle {
 		return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
-				http.Error(w, "Authorization header required", http.StatusUnauthorized)
+				onError(w, r, ErrAuthorizationRequired)
 				return
 			}
 
 			// Extract token from "Bearer <token>"
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
+				onError(w, r, ErrInvalidAuthorizationHeader)
 				return
 			}
 
 			token := parts[1]
 			claims, err := jwtManager.ValidateToken(token)
 			if err != nil {
-				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				onError(w, r, ErrInvalidToken)
+				return
+			}
+			if err := jwtManager.CheckRevoked(r.Context(), claims); err != nil {
+				onError(w, r, err)
 				return
 			}
 
@@ -38,6 +56,38 @@ func AuthMiddleware(jwtManager *JWTManager) func(httprouter.Handle) httprouter.H
 			ctx = contextWithUserID(ctx, claims.UserID)
 			ctx = contextWithEmail(ctx, claims.Email)
 			ctx = contextWithUsername(ctx, claims.Username)
+			ctx = contextWithSessionID(ctx, claims.SessionID)
+			ctx = contextWithClaims(ctx, claims)
+
+			next(w, r.WithContext(ctx), ps)
+		}
+	}
+}
+
+// OptionalAuthMiddleware adds the caller's identity to the request context when a
+// valid bearer token is present. Requests without one, or with an invalid one,
+// continue unauthenticated.
+func OptionalAuthMiddleware(jwtManager *JWTManager) func(httprouter.Handle) httprouter.Handle {
+	return func(next httprouter.Handle) httprouter.Handle {
+		return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+			parts := strings.Split(r.Header.Get("Authorization"), " ")
+			if len(parts) != 2 || parts[0] != "Bearer" {
+				next(w, r, ps)
+				return
+			}
+
+			claims, err := jwtManager.ValidateToken(parts[1])
+			if err != nil || jwtManager.CheckRevoked(r.Context(), claims) != nil {
+				next(w, r, ps)
+				return
+			}
+
+			ctx := r.Context()
+			ctx = contextWithUserID(ctx, claims.UserID)
+			ctx = contextWithEmail(ctx, claims.Email)
+			ctx = contextWithUsername(ctx, claims.Username)
+			ctx = contextWithSessionID(ctx, claims.SessionID)
+			ctx = contextWithClaims(ctx, claims)
 
 			next(w, r.WithContext(ctx), ps)
 		}
@@ -47,9 +97,11 @@ func AuthMiddleware(jwtManager *JWTManager) func(httprouter.Handle) httprouter.H
 type contextKey string
 
 const (
-	userIDKey   contextKey = "user_id"
-	emailKey    contextKey = "email"
-	usernameKey contextKey = "username"
+	userIDKey    contextKey = "user_id"
+	emailKey     contextKey = "email"
+	usernameKey  contextKey = "username"
+	sessionIDKey contextKey = "session_id"
+	claimsKey    contextKey = "claims"
 )
 
 // Context helper functions
@@ -65,6 +117,14 @@ func contextWithUsername(ctx context.Context, username string) context.Context {
 	return context.WithValue(ctx, usernameKey, username)
 }
 
+func contextWithSessionID(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sessionIDKey, sessionID)
+}
+
+func contextWithClaims(ctx context.Context, claims *JWTClaims) context.Context {
+	return context.WithValue(ctx, claimsKey, claims)
+}
+
 // GetUserIDFromContext extracts user ID from context
 func GetUserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
 	userID, ok := ctx.Value(userIDKey).(uuid.UUID)
@@ -82,3 +142,16 @@ func GetUsernameFromContext(ctx context.Context) (string, bool) {
 	username, ok := ctx.Value(usernameKey).(string)
 	return username, ok
 }
+
+// GetSessionIDFromContext extracts the session the access token was issued to.
+// It reports false for tokens issued before sessions were tracked.
+func GetSessionIDFromContext(ctx context.Context) (string, bool) {
+	sessionID, ok := ctx.Value(sessionIDKey).(string)
+	return sessionID, ok && sessionID != ""
+}
+
+// GetClaimsFromContext extracts the claims of the request's access token
+func GetClaimsFromContext(ctx context.Context) (*JWTClaims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*JWTClaims)
+	return claims, ok
+}
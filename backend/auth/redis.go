@@ -3,24 +3,19 @@ package auth
 import (
 	"context"
 	"fmt"
-	"os"
 	"time"
 
+	"poll-app/config"
+
 	"github.com/redis/go-redis/v9"
 )
 
 // NewRedisClient creates a new Redis client
-func NewRedisClient() (*redis.Client, error) {
-	host := getEnv("REDIS_HOST", "localhost")
-	port := getEnv("REDIS_PORT", "6379")
-	password := getEnv("REDIS_PASSWORD", "")
-
-	addr := fmt.Sprintf("%s:%s", host, port)
-
+func NewRedisClient(cfg config.RedisConfig) (*redis.Client, error) {
 	client := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: password,
-		DB:       0,
+		Addr:     cfg.Addr(),
+		Password: cfg.Password,
+		DB:       cfg.DB,
 	})
 
 	// Test connection
@@ -33,10 +28,3 @@ func NewRedisClient() (*redis.Client, error) {
 
 	return client, nil
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
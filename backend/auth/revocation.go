@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// revocationCacheTTL is how long a server trusts its own finding that an
+	// access token is not revoked. Revocations announced on revocationChannel
+	// reach it sooner; the TTL bounds how long a missed announcement goes unnoticed.
+	revocationCacheTTL = 5 * time.Second
+	// revocationChannel announces revocations to every server
+	revocationChannel = "access-token-revocations"
+	// revocationPruneInterval is how often expired cache entries are dropped
+	revocationPruneInterval = time.Minute
+)
+
+// ErrTokenRevoked is passed to the error handler when the bearer token was revoked
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// Redis keys of revoked access tokens. The denied key of a token exists until
+// the token expires, and so does the session key of an ended session until
+// the last access token issued to it expires; the revoked-before key of a
+// user holds the Unix time in microseconds up to which every access token
+// issued to the user is revoked, until the last of them expires.
+func deniedAccessTokenKey(tokenID string) string {
+	return fmt.Sprintf("access_token_denied:%s", tokenID)
+}
+
+func sessionAccessTokensRevokedKey(sessionID string) string {
+	return fmt.Sprintf("access_tokens_session_revoked:%s", sessionID)
+}
+
+func accessTokensRevokedBeforeKey(userID uuid.UUID) string {
+	return fmt.Sprintf("access_tokens_revoked_before:%s", userID.String())
+}
+
+// revocation is announced on revocationChannel. It revokes either one token,
+// every token of a session or every token of a user.
+type revocation struct {
+	TokenID   string    `json:"token_id,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	SessionID string    `json:"session_id,omitempty"`
+	UserID    uuid.UUID `json:"user_id,omitzero"`
+}
+
+// revocationCache remembers what this server learned about access tokens
+// being revoked, so most requests need no Redis round trip
+type revocationCache struct {
+	mu sync.Mutex
+	// allowed holds tokens found not revoked, by ID, until they are checked again
+	allowed map[string]allowedToken
+	// denied holds revoked tokens, by ID, until they expire
+	denied map[string]time.Time
+	// generation changes whenever entries are evicted, so a lookup that raced
+	// with a revocation does not cache its outdated result
+	generation uint64
+}
+
+type allowedToken struct {
+	userID    uuid.UUID
+	sessionID string
+	until     time.Time
+}
+
+func newRevocationCache() *revocationCache {
+	return &revocationCache{
+		allowed: make(map[string]allowedToken),
+		denied:  make(map[string]time.Time),
+	}
+}
+
+// get reports whether the token is revoked, if the cache knows, and the
+// generation to remember a looked up result at
+func (c *revocationCache) get(tokenID string, now time.Time) (revoked, known bool, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.denied[tokenID]; ok {
+		return true, true, c.generation
+	}
+	if token, ok := c.allowed[tokenID]; ok && now.Before(token.until) {
+		return false, true, c.generation
+	}
+	return false, false, c.generation
+}
+
+// remember caches a looked up result unless entries were evicted since generation
+func (c *revocationCache) remember(claims *JWTClaims, revoked bool, generation uint64, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	if revoked {
+		c.denied[claims.ID] = claims.ExpiresAt.Time
+		return
+	}
+	c.allowed[claims.ID] = allowedToken{userID: claims.UserID, sessionID: claims.SessionID, until: now.Add(revocationCacheTTL)}
+}
+
+// apply records an announced revocation
+func (c *revocationCache) apply(r revocation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if r.TokenID != "" {
+		delete(c.allowed, r.TokenID)
+		c.denied[r.TokenID] = r.ExpiresAt
+	}
+	if r.UserID != uuid.Nil || r.SessionID != "" {
+		for id, token := range c.allowed {
+			if (r.UserID != uuid.Nil && token.userID == r.UserID) || (r.SessionID != "" && token.sessionID == r.SessionID) {
+				delete(c.allowed, id)
+			}
+		}
+	}
+}
+
+// prune drops the entries that no longer matter
+func (c *revocationCache) prune(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for id, token := range c.allowed {
+		if !now.Before(token.until) {
+			delete(c.allowed, id)
+		}
+	}
+	for id, expiresAt := range c.denied {
+		if now.After(expiresAt) {
+			delete(c.denied, id)
+		}
+	}
+}
+
+// CheckRevoked returns ErrTokenRevoked if the access token was revoked, on
+// its own, with its session or with every token of its user. Tokens issued
+// before access tokens had IDs can only be revoked with their user's.
+func (m *JWTManager) CheckRevoked(ctx context.Context, claims *JWTClaims) error {
+	now := time.Now()
+	var generation uint64
+	if claims.ID != "" {
+		var revoked, known bool
+		if revoked, known, generation = m.revocations.get(claims.ID, now); known {
+			if revoked {
+				return ErrTokenRevoked
+			}
+			return nil
+		}
+	}
+
+	revoked, err := m.lookupRevocation(ctx, claims)
+	if err != nil {
+		return fmt.Errorf("failed to check access token revocation: %w", err)
+	}
+	if claims.ID != "" && claims.ExpiresAt != nil {
+		m.revocations.remember(claims, revoked, generation, now)
+	}
+	if revoked {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// lookupRevocation reads from Redis whether the access token was revoked
+func (m *JWTManager) lookupRevocation(ctx context.Context, claims *JWTClaims) (bool, error) {
+	pipe := m.redisClient.Pipeline()
+	var denied, sessionRevoked *redis.IntCmd
+	if claims.ID != "" {
+		denied = pipe.Exists(ctx, deniedAccessTokenKey(claims.ID))
+	}
+	if claims.SessionID != "" {
+		sessionRevoked = pipe.Exists(ctx, sessionAccessTokensRevokedKey(claims.SessionID))
+	}
+	revokedBefore := pipe.Get(ctx, accessTokensRevokedBeforeKey(claims.UserID))
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return false, err
+	}
+
+	if denied != nil && denied.Val() > 0 {
+		return true, nil
+	}
+	if sessionRevoked != nil && sessionRevoked.Val() > 0 {
+		return true, nil
+	}
+	before, err := revokedBefore.Int64()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if before < legacyRevokedBeforeLimit {
+		// Marks written before they had microseconds hold seconds
+		before *= int64(time.Second / time.Microsecond)
+	}
+
+	if claims.IssuedAtMicro != 0 {
+		return claims.IssuedAtMicro <= before, nil
+	}
+	// The issue time of older tokens only has seconds, so those issued in the
+	// second of the revocation are revoked too
+	return claims.IssuedAt == nil || claims.IssuedAt.Unix() <= before/int64(time.Second/time.Microsecond), nil
+}
+
+// legacyRevokedBeforeLimit is above any revoked-before mark in seconds and
+// below any in microseconds, which passed it in 1970
+const legacyRevokedBeforeLimit = 1 << 40
+
+// RevokeAccessToken revokes one access token until it expires
+func (m *JWTManager) RevokeAccessToken(ctx context.Context, claims *JWTClaims) error {
+	if claims.ID == "" || claims.ExpiresAt == nil {
+		// Tokens without an ID can only be revoked with the user's other tokens
+		return m.RevokeUserAccessTokens(ctx, claims.UserID)
+	}
+
+	ttl := time.Until(claims.ExpiresAt.Time)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := m.redisClient.Set(ctx, deniedAccessTokenKey(claims.ID), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	m.announce(ctx, revocation{TokenID: claims.ID, ExpiresAt: claims.ExpiresAt.Time})
+	return nil
+}
+
+// RevokeUserAccessTokens revokes every access token issued to the user so far
+func (m *JWTManager) RevokeUserAccessTokens(ctx context.Context, userID uuid.UUID) error {
+	// The mark is kept until the last token it revokes has expired. It has
+	// microseconds, so a token issued right after, such as on signing in again
+	// after a password reset, is not revoked with those before it.
+	now := strconv.FormatInt(time.Now().UnixMicro(), 10)
+	if err := m.redisClient.Set(ctx, accessTokensRevokedBeforeKey(userID), now, m.accessTokenTTL+time.Second).Err(); err != nil {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	m.announce(ctx, revocation{UserID: userID})
+	return nil
+}
+
+// announce tells every server about a revocation, so none of them keeps
+// accepting the tokens from its cache. It is recorded in Redis already, so a
+// failure only delays the revocation until cached results are rechecked.
+func (m *JWTManager) announce(ctx context.Context, r revocation) {
+	m.revocations.apply(r)
+
+	msg, err := json.Marshal(r)
+	if err != nil {
+		log.Printf("Failed to encode access token revocation: %v", err)
+		return
+	}
+	if err := m.redisClient.Publish(ctx, revocationChannel, msg).Err(); err != nil {
+		log.Printf("Failed to announce access token revocation: %v", err)
+	}
+}
+
+// Run applies the revocations announced by other servers to the cache and
+// prunes it, until ctx is canceled. Without it revocations made elsewhere
+// take up to revocationCacheTTL to be noticed.
+func (m *JWTManager) Run(ctx context.Context) {
+	pubsub := m.redisClient.Subscribe(ctx, revocationChannel)
+	defer pubsub.Close()
+
+	ticker := time.NewTicker(revocationPruneInterval)
+	defer ticker.Stop()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			m.revocations.prune(now)
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var r revocation
+			if err := json.Unmarshal([]byte(msg.Payload), &r); err != nil {
+				log.Printf("Dropping malformed access token revocation: %v", err)
+				continue
+			}
+			m.revocations.apply(r)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestCheckRevoked(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name   string
+		revoke func(m *JWTManager, current *JWTClaims) error
+		// want lists whether the current token, another of its session and
+		// one of another session of the user are revoked
+		want [3]bool
+	}{
+		{
+			name:   "nothing",
+			revoke: func(*JWTManager, *JWTClaims) error { return nil },
+		},
+		{
+			name: "token",
+			revoke: func(m *JWTManager, current *JWTClaims) error {
+				return m.RevokeAccessToken(ctx, current)
+			},
+			want: [3]bool{true, false, false},
+		},
+		{
+			name: "session",
+			revoke: func(m *JWTManager, current *JWTClaims) error {
+				return m.RevokeRefreshToken(ctx, userID, current.SessionID)
+			},
+			want: [3]bool{true, true, false},
+		},
+		{
+			name: "user",
+			revoke: func(m *JWTManager, current *JWTClaims) error {
+				return m.RevokeUserAccessTokens(ctx, userID)
+			},
+			want: [3]bool{true, true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mr := newTestJWTManager(t, testJWTConfig())
+
+			// Another server sharing Redis learns of revocations as announced
+			rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			t.Cleanup(func() { rdb.Close() })
+			other, err := NewJWTManager(rdb, testJWTConfig(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			runCtx, cancel := context.WithCancel(ctx)
+			t.Cleanup(cancel)
+			go other.Run(runCtx)
+
+			claims := make([]*JWTClaims, 0, 3)
+			for _, sessionID := range []string{"device", "device", "other-device"} {
+				token, err := m.GenerateAccessToken(userID, "a@example.com", "a", sessionID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				c, err := m.ValidateToken(token)
+				if err != nil {
+					t.Fatal(err)
+				}
+				claims = append(claims, c)
+			}
+
+			// Both servers cache the tokens as not revoked
+			for _, server := range []*JWTManager{m, other} {
+				for _, c := range claims {
+					if err := server.CheckRevoked(ctx, c); err != nil {
+						t.Fatalf("CheckRevoked before revoking = %v", err)
+					}
+				}
+			}
+
+			waitForSubscriber(t, rdb)
+			if err := tt.revoke(m, claims[0]); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, c := range claims {
+				if revoked := errors.Is(m.CheckRevoked(ctx, c), ErrTokenRevoked); revoked != tt.want[i] {
+					t.Errorf("token %d revoked = %v, want %v", i, revoked, tt.want[i])
+				}
+				if !tt.want[i] {
+					continue
+				}
+				deadline := time.Now().Add(time.Second)
+				for !errors.Is(other.CheckRevoked(ctx, c), ErrTokenRevoked) {
+					if time.Now().After(deadline) {
+						t.Fatalf("token %d is still accepted by another server", i)
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}
+		})
+	}
+}
+
+func TestRevokeUserAccessTokensIssueTime(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	tests := []struct {
+		name   string
+		revoke func(m *JWTManager) error
+		// claims returns the claims of the token to check, issued after
+		// revoking; if nil, a token issued before is checked
+		claims func(t *testing.T, m *JWTManager) *JWTClaims
+		want   bool
+	}{
+		{
+			name:   "issued right after",
+			revoke: func(m *JWTManager) error { return m.RevokeUserAccessTokens(ctx, userID) },
+			claims: newUserClaims(userID),
+		},
+		{
+			name:   "issued before",
+			revoke: func(m *JWTManager) error { return m.RevokeUserAccessTokens(ctx, userID) },
+			want:   true,
+		},
+		{
+			name:   "without microseconds in the second of revocation",
+			revoke: func(m *JWTManager) error { return m.RevokeUserAccessTokens(ctx, userID) },
+			claims: func(t *testing.T, m *JWTManager) *JWTClaims {
+				mark, err := m.redisClient.Get(ctx, accessTokensRevokedBeforeKey(userID)).Int64()
+				if err != nil {
+					t.Fatal(err)
+				}
+				issuedAt := time.UnixMicro(mark).Truncate(time.Second).Add(time.Second - time.Microsecond)
+				return &JWTClaims{UserID: userID, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)}}
+			},
+			want: true,
+		},
+		{
+			name: "mark in seconds",
+			revoke: func(m *JWTManager) error {
+				next := strconv.FormatInt(time.Now().Unix()+1, 10)
+				return m.redisClient.Set(ctx, accessTokensRevokedBeforeKey(userID), next, time.Minute).Err()
+			},
+			claims: newUserClaims(userID),
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newTestJWTManager(t, testJWTConfig())
+			before := newUserClaims(userID)(t, m)
+			if err := tt.revoke(m); err != nil {
+				t.Fatal(err)
+			}
+			claims := before
+			if tt.claims != nil {
+				claims = tt.claims(t, m)
+			}
+
+			if revoked := errors.Is(m.CheckRevoked(ctx, claims), ErrTokenRevoked); revoked != tt.want {
+				t.Errorf("revoked = %v, want %v", revoked, tt.want)
+			}
+		})
+	}
+}
+
+// newUserClaims returns a function issuing an access token to the user and returning its claims
+func newUserClaims(userID uuid.UUID) func(t *testing.T, m *JWTManager) *JWTClaims {
+	return func(t *testing.T, m *JWTManager) *JWTClaims {
+		t.Helper()
+		token, err := m.GenerateAccessToken(userID, "a@example.com", "a", "device")
+		if err != nil {
+			t.Fatal(err)
+		}
+		claims, err := m.ValidateToken(token)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return claims
+	}
+}
+
+// waitForSubscriber waits until a server listens for revocations
+func waitForSubscriber(t *testing.T, rdb *redis.Client) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		subs, err := rdb.PubSubNumSub(context.Background(), revocationChannel).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subs[revocationChannel] > 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("no server listens for revocations")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
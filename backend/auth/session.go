@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// MaxSessionNameLength is the maximum length of a session's device name, in characters
+	MaxSessionNameLength = 100
+	// maxUserAgentLength is how much of a client's User-Agent header is kept
+	maxUserAgentLength = 512
+)
+
+// ErrSessionNotFound is returned for a session that does not exist or has ended
+var ErrSessionNotFound = errors.New("session not found")
+
+// Fields of a session's Redis hash
+const (
+	sessionFieldName       = "name"
+	sessionFieldUserAgent  = "user_agent"
+	sessionFieldIP         = "ip"
+	sessionFieldCreatedAt  = "created_at"
+	sessionFieldLastUsedAt = "last_used_at"
+)
+
+// Session is a signed-in device: a chain of refresh tokens and what is known
+// about the client holding them
+type Session struct {
+	ID        string
+	Name      string
+	UserAgent string
+	IP        string
+	// CreatedAt and LastUsedAt are zero for sessions started before sessions were tracked
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// ClientInfo describes the client a session is started or used by
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+	// Name is the device name chosen by the user, if any
+	Name string
+}
+
+// fields returns the hash fields of a new session
+func (c ClientInfo) fields(createdAt, lastUsedAt time.Time) map[string]any {
+	fields := c.usedFields(lastUsedAt)
+	fields[sessionFieldCreatedAt] = createdAt.UTC().Format(time.RFC3339Nano)
+	if c.Name != "" {
+		fields[sessionFieldName] = c.Name
+	}
+	return fields
+}
+
+// usedFields returns the hash fields updated when the session is used
+func (c ClientInfo) usedFields(at time.Time) map[string]any {
+	userAgent := c.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+	return map[string]any{
+		sessionFieldUserAgent:  userAgent,
+		sessionFieldIP:         c.IP,
+		sessionFieldLastUsedAt: at.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func sessionKey(userID uuid.UUID, sessionID string) string {
+	return fmt.Sprintf("session:%s:%s", userID.String(), sessionID)
+}
+
+// ListSessions returns the user's sessions, most recently used first. Sessions
+// whose refresh token expired are dropped from the user's set of sessions.
+func (m *JWTManager) ListSessions(ctx context.Context, userID uuid.UUID) ([]*Session, error) {
+	sessionIDs, err := m.redisClient.SMembers(ctx, userSessionsKey(userID)).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+
+	sessions := make([]*Session, 0, len(sessionIDs))
+	var ended []any
+	for _, sessionID := range sessionIDs {
+		session, err := m.GetSession(ctx, userID, sessionID)
+		if errors.Is(err, ErrSessionNotFound) {
+			ended = append(ended, sessionID)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(ended) > 0 {
+		if err := m.redisClient.SRem(ctx, userSessionsKey(userID), ended...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to remove ended sessions: %w", err)
+		}
+	}
+
+	slices.SortFunc(sessions, func(a, b *Session) int {
+		if c := b.LastUsedAt.Compare(a.LastUsedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return sessions, nil
+}
+
+// GetSession returns one of the user's sessions, or ErrSessionNotFound if it has ended
+func (m *JWTManager) GetSession(ctx context.Context, userID uuid.UUID, sessionID string) (*Session, error) {
+	var exists *redis.IntCmd
+	var fields *redis.MapStringStringCmd
+	if _, err := m.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		exists = pipe.Exists(ctx, refreshTokenKey(userID, sessionID))
+		fields = pipe.HGetAll(ctx, sessionKey(userID, sessionID))
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if exists.Val() == 0 {
+		return nil, ErrSessionNotFound
+	}
+
+	values := fields.Val()
+	session := &Session{
+		ID:        sessionID,
+		Name:      values[sessionFieldName],
+		UserAgent: values[sessionFieldUserAgent],
+		IP:        values[sessionFieldIP],
+	}
+	// Unparsable times are left zero, like those of untracked sessions
+	session.CreatedAt, _ = time.Parse(time.RFC3339Nano, values[sessionFieldCreatedAt])
+	session.LastUsedAt, _ = time.Parse(time.RFC3339Nano, values[sessionFieldLastUsedAt])
+	return session, nil
+}
+
+// RenameSession sets the device name of one of the user's sessions; an empty
+// name removes it
+func (m *JWTManager) RenameSession(ctx context.Context, userID uuid.UUID, sessionID, name string) (*Session, error) {
+	// The session's details expire with its refresh token
+	ttl, err := m.redisClient.PTTL(ctx, refreshTokenKey(userID, sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if ttl <= 0 {
+		return nil, ErrSessionNotFound
+	}
+
+	key := sessionKey(userID, sessionID)
+	if _, err := m.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if name == "" {
+			pipe.HDel(ctx, key, sessionFieldName)
+		} else {
+			pipe.HSet(ctx, key, sessionFieldName, name)
+		}
+		pipe.PExpire(ctx, key, ttl)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to rename session: %w", err)
+	}
+
+	return m.GetSession(ctx, userID, sessionID)
+}
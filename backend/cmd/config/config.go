@@ -0,0 +1,51 @@
+package config
+
+import (
+	"fmt"
+
+	"poll-app/config"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func NewConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the application configuration",
+	}
+
+	cmd.AddCommand(newPrintCommand())
+
+	return cmd
+}
+
+func newPrintCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "print",
+		Short: "Print the effective configuration",
+		Long:  "Print the configuration after applying defaults, the config file, environment variables and flags, with secrets redacted",
+		RunE:  runPrint,
+	}
+}
+
+func runPrint(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	enc := yaml.NewEncoder(cmd.OutOrStdout())
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg.Redacted()); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	if err := cfg.CheckProduction(); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "\n%v\n", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"poll-app/config"
+	"poll-app/events"
+	"poll-app/export"
+	"poll-app/mail"
+	"poll-app/service"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func NewExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export <poll-id>",
+		Short: "Export the results of a poll",
+		Long:  "Export the per-option totals of any poll and, unless it is anonymous, its ballots with voter usernames and timestamps. Unlike the API, no ownership is required.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runExport,
+	}
+
+	cmd.Flags().String("format", string(export.FormatCSV), "Export format: csv, json, ndjson or xlsx")
+	cmd.Flags().StringP("output", "o", "", "File to write the export to (defaults to standard output)")
+
+	return cmd
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	pollID, err := uuid.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid poll ID %q: %w", args[0], err)
+	}
+
+	formatName, _ := cmd.Flags().GetString("format")
+	format, err := export.ParseFormat(formatName)
+	if err != nil {
+		return err
+	}
+	output, _ := cmd.Flags().GetString("output")
+
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dbClient, err := storage.NewClient(cfg.Database, false)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer dbClient.Close()
+
+	// Exporting only reads, so there are no poll changes to announce or emails to send
+	serviceLayer := service.NewService(storage.NewStorage(dbClient), events.Discard, mail.Discard, cfg.Mail.AppURL, cfg.MFA.Issuer)
+
+	pollExport, err := serviceLayer.ExportAnyPoll(cmd.Context(), pollID)
+	if err != nil {
+		return fmt.Errorf("failed to export poll: %w", err)
+	}
+
+	if output == "" {
+		return pollExport.Encode(cmd.Context(), export.NewEncoder(format, cmd.OutOrStdout()))
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := pollExport.Encode(cmd.Context(), export.NewEncoder(format, f)); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to export poll: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Exported poll %s to %s\n", pollID, output)
+	return nil
+}
@@ -0,0 +1,133 @@
+// Package importcmd implements the import command; import is a Go keyword
+package importcmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"poll-app/config"
+	"poll-app/ent"
+	"poll-app/events"
+	"poll-app/importer"
+	"poll-app/mail"
+	"poll-app/service"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func NewImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import polls from a file of definitions",
+		Long:  "Create polls for a user from a JSON, YAML or CSV file of poll definitions, in one transaction. The whole file is validated first. Polls whose external_id the user already imported are skipped, so an import can be re-run.",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runImport,
+	}
+
+	cmd.Flags().String("owner", "", "ID or email of the user who will own the polls")
+	cmd.Flags().String("format", "", "File format: json, yaml or csv (defaults to the file extension)")
+	cmd.Flags().Bool("dry-run", false, "Validate the file and show what would be created without creating anything")
+	cmd.MarkFlagRequired("owner")
+
+	return cmd
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	owner, _ := cmd.Flags().GetString("owner")
+	formatName, _ := cmd.Flags().GetString("format")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	var format importer.Format
+	var err error
+	if formatName != "" {
+		format, err = importer.ParseFormat(formatName)
+	} else {
+		format, err = importer.FormatFromPath(path)
+	}
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer f.Close()
+
+	definitions, err := importer.Parse(format, f)
+	if err != nil {
+		return importError(err)
+	}
+
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dbClient, err := storage.NewClient(cfg.Database, false)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer dbClient.Close()
+
+	storageLayer := storage.NewStorage(dbClient)
+	ownerID, err := resolveOwner(cmd, storageLayer, owner)
+	if err != nil {
+		return err
+	}
+
+	// New polls are announced through webhooks, which the worker delivers
+	serviceLayer := service.NewService(storageLayer, events.Discard, mail.Discard, cfg.Mail.AppURL, cfg.MFA.Issuer)
+	result, err := serviceLayer.ImportPolls(cmd.Context(), ownerID, definitions, dryRun)
+	if err != nil {
+		return importError(err)
+	}
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "EXTERNAL ID\tSTATUS\tPOLL\tTITLE")
+	for i, imported := range result.Polls {
+		pollID := "-"
+		if imported.Poll != nil {
+			pollID = imported.Poll.ID.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", imported.ExternalID, imported.Status, pollID, definitions[i].Title)
+	}
+	return tw.Flush()
+}
+
+// resolveOwner returns the ID of the user given by ID or email
+func resolveOwner(cmd *cobra.Command, store storage.Storage, owner string) (uuid.UUID, error) {
+	if id, err := uuid.Parse(owner); err == nil {
+		return id, nil
+	}
+
+	user, err := store.GetUserByEmail(cmd.Context(), owner)
+	if ent.IsNotFound(err) {
+		return uuid.Nil, fmt.Errorf("no user with email %q", owner)
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to look up owner: %w", err)
+	}
+	return user.ID, nil
+}
+
+// importError describes a failed import, listing every invalid field
+func importError(err error) error {
+	var serviceErr *service.Error
+	if !errors.As(err, &serviceErr) || len(serviceErr.Fields) == 0 {
+		return fmt.Errorf("import failed: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "import failed: %s", serviceErr.Message)
+	for _, field := range serviceErr.Fields {
+		fmt.Fprintf(&b, "\n  %s: %s", field.Field, field.Message)
+	}
+	return errors.New(b.String())
+}
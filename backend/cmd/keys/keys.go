@@ -0,0 +1,169 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"poll-app/auth"
+	"poll-app/config"
+	"poll-app/storage"
+
+	"github.com/spf13/cobra"
+)
+
+func NewKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage token signing keys",
+		Long:  "Manage the asymmetric keys tokens are signed with when jwt.algorithm is RS256, ES256 or EdDSA. Keys are kept in the store selected by jwt.key_store: files in jwt.key_dir, or the database.",
+	}
+
+	cmd.AddCommand(newGenerateCommand())
+	cmd.AddCommand(newRotateCommand())
+	cmd.AddCommand(newListCommand())
+
+	return cmd
+}
+
+func newGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Create the first signing key",
+		Long:  "Create a signing key for a key store that has none, as needed before the server can start",
+		RunE:  runGenerate,
+	}
+
+	cmd.Flags().String("algorithm", "", "Key algorithm: RS256, ES256 or EdDSA (overrides jwt.algorithm)")
+	cmd.Flags().Bool("if-missing", false, "Succeed without changes if the store already has a signing key")
+
+	return cmd
+}
+
+func newRotateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the signing key",
+		Long:  "Create a new signing key and retire the current ones, then delete keys whose tokens have all expired. The new key is published a few minutes before it takes over signing; retired keys are still accepted for jwt.key_overlap.",
+		RunE:  runRotate,
+	}
+
+	cmd.Flags().String("algorithm", "", "Key algorithm: RS256, ES256 or EdDSA (overrides jwt.algorithm)")
+
+	return cmd
+}
+
+func newListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List signing keys",
+		Long:  "List the keys of the key store, newest first, with their state",
+		RunE:  runList,
+	}
+}
+
+// openKeyStore opens the configured key store. The returned function closes
+// the database connection, if one was needed.
+func openKeyStore(cmd *cobra.Command) (*config.Config, auth.KeyStore, func(), error) {
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	if !cfg.JWT.Asymmetric() {
+		return nil, nil, nil, errors.New("jwt.algorithm is HS256; set it to RS256, ES256 or EdDSA to sign tokens with keys")
+	}
+
+	var store storage.Storage
+	closeStore := func() {}
+	if cfg.JWT.KeyStore == config.KeyStorePostgres {
+		dbClient, err := storage.NewClient(cfg.Database, false)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to initialize database: %w", err)
+		}
+		store = storage.NewStorage(dbClient)
+		closeStore = func() { dbClient.Close() }
+	}
+
+	keyStore, err := auth.NewKeyStore(cfg.JWT, store)
+	if err != nil {
+		closeStore()
+		return nil, nil, nil, err
+	}
+	return cfg, keyStore, closeStore, nil
+}
+
+func runGenerate(cmd *cobra.Command, args []string) error {
+	ifMissing, _ := cmd.Flags().GetBool("if-missing")
+
+	cfg, keyStore, closeStore, err := openKeyStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	key, err := auth.NewKeyRotator(keyStore, cfg.JWT).Generate(cmd.Context())
+	if errors.Is(err, auth.ErrSigningKeyExists) && ifMissing {
+		fmt.Fprintln(cmd.ErrOrStderr(), "The key store already has a signing key")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Generated %s signing key %s\n", key.Algorithm, key.ID)
+	return nil
+}
+
+func runRotate(cmd *cobra.Command, args []string) error {
+	cfg, keyStore, closeStore, err := openKeyStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	rotator := auth.NewKeyRotator(keyStore, cfg.JWT)
+	key, err := rotator.Rotate(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to rotate signing keys: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Generated %s signing key %s; it signs tokens from %s\n",
+		key.Algorithm, key.ID, key.CreatedAt.Add(auth.KeyPropagationDelay).Local().Format(time.RFC3339))
+
+	deleted, err := rotator.Prune(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d expired signing keys\n", deleted)
+	}
+	return nil
+}
+
+func runList(cmd *cobra.Command, args []string) error {
+	cfg, keyStore, closeStore, err := openKeyStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	keys, err := keyStore.Keys(cmd.Context())
+	if err != nil {
+		return err
+	}
+	status := auth.KeyStatus(keys, cfg.JWT.KeyOverlap, time.Now())
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KID\tALGORITHM\tSTATUS\tCREATED\tRETIRED\tEXPIRES")
+	for _, key := range keys {
+		retired, expires := "-", "-"
+		if key.RetiredAt != nil {
+			retired = key.RetiredAt.Local().Format(time.RFC3339)
+			expires = key.RetiredAt.Add(cfg.JWT.KeyOverlap).Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.ID, key.Algorithm, status[key.ID], key.CreatedAt.Local().Format(time.RFC3339), retired, expires)
+	}
+
+	return tw.Flush()
+}
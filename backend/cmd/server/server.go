@@ -1,14 +1,23 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"poll-app/auth"
+	"poll-app/config"
 	"poll-app/controller"
+	"poll-app/events"
+	"poll-app/mail"
+	"poll-app/realtime"
 	"poll-app/service"
 	"poll-app/storage"
+	"poll-app/worker"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/spf13/cobra"
@@ -22,46 +31,100 @@ func NewServerCommand() *cobra.Command {
 		RunE:  runServer,
 	}
 
-	cmd.Flags().String("port", "8080", "Port to run the server on")
-	cmd.Flags().String("host", "0.0.0.0", "Host to bind the server to")
+	cmd.Flags().Int("port", 8080, "Port to run the server on (overrides server.port)")
+	cmd.Flags().String("host", "0.0.0.0", "Host to bind the server to (overrides server.host)")
+	cmd.Flags().Bool("embedded-worker", false, "Also run background jobs inside the server process")
+	cmd.Flags().Bool("auto-migrate", false, "Apply pending schema migrations on startup (for development)")
 
 	return cmd
 }
 
 func runServer(cmd *cobra.Command, args []string) error {
-	port, _ := cmd.Flags().GetString("port")
-	host, _ := cmd.Flags().GetString("host")
+	embeddedWorker, _ := cmd.Flags().GetBool("embedded-worker")
+	autoMigrate, _ := cmd.Flags().GetBool("auto-migrate")
+
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.CheckProduction(); err != nil {
+		return err
+	}
 
 	// Initialize database
-	dbClient, err := storage.NewClient()
+	dbClient, err := storage.NewClient(cfg.Database, autoMigrate)
 	if err != nil {
 		return fmt.Errorf("failed to initialize database: %w", err)
 	}
 	defer dbClient.Close()
 
 	// Initialize Redis
-	redisClient, err := auth.NewRedisClient()
+	redisClient, err := auth.NewRedisClient(cfg.Redis)
 	if err != nil {
 		return fmt.Errorf("failed to initialize Redis: %w", err)
 	}
 	defer redisClient.Close()
 
+	// Initialize storage
+	storageLayer := storage.NewStorage(dbClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize the signing key ring, kept up to date with rotations
+	var keyRing *auth.KeyRing
+	var keyRotator *auth.KeyRotator
+	if cfg.JWT.Asymmetric() {
+		keyStore, err := auth.NewKeyStore(cfg.JWT, storageLayer)
+		if err != nil {
+			return fmt.Errorf("failed to initialize key store: %w", err)
+		}
+		if keyRing, err = auth.NewKeyRing(ctx, keyStore, cfg.JWT.KeyOverlap); err != nil {
+			return fmt.Errorf("failed to initialize key ring: %w", err)
+		}
+		go keyRing.Run(ctx)
+		keyRotator = auth.NewKeyRotator(keyStore, cfg.JWT)
+	}
+
 	// Initialize JWT manager
-	jwtManager, err := auth.NewJWTManager(redisClient)
+	jwtManager, err := auth.NewJWTManager(redisClient, cfg.JWT, keyRing)
 	if err != nil {
 		return fmt.Errorf("failed to initialize JWT manager: %w", err)
 	}
+	// Learn of access tokens revoked through other replicas
+	go jwtManager.Run(ctx)
 
-	// Initialize storage
-	storageLayer := storage.NewStorage(dbClient)
+	// Initialize live poll events, fanned out to every replica through Redis
+	broker := events.NewBroker(redisClient)
+	go broker.Run(ctx)
+
+	// Initialize the mailer that sends account emails
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		return fmt.Errorf("failed to initialize mailer: %w", err)
+	}
 
 	// Initialize service
-	serviceLayer := service.NewService(storageLayer)
+	serviceLayer := service.NewService(storageLayer, broker, mailer, cfg.Mail.AppURL, cfg.MFA.Issuer)
+
+	// Run background jobs in-process if requested
+	if embeddedWorker {
+		go worker.NewScheduler(storageLayer, worker.DefaultJobs(serviceLayer, storageLayer, keyRotator)...).Run(ctx)
+	}
 
 	// Initialize controllers
-	userController := controller.NewUserController(serviceLayer, jwtManager)
+	mfaChallenges := auth.NewMFAChallenges(redisClient, cfg.MFA.ChallengeTTL)
+	userController := controller.NewUserController(serviceLayer, serviceLayer, serviceLayer, serviceLayer, jwtManager, mfaChallenges)
+	mfaController := controller.NewMFAController(serviceLayer)
 	pollController := controller.NewPollController(serviceLayer)
 	voteController := controller.NewVoteController(serviceLayer)
+	streamController := controller.NewStreamController(serviceLayer, broker)
+	webhookController := controller.NewWebhookController(serviceLayer)
+	invitationController := controller.NewInvitationController(serviceLayer)
+	keyController := controller.NewKeyController(jwtManager)
+	hub := realtime.NewHub(serviceLayer, broker, redisClient, cfg.WebSocket)
+	go hub.Run(ctx)
+	realtimeController := controller.NewRealtimeController(hub, jwtManager, cfg.CORS.AllowedOrigins)
 
 	// Initialize router
 	router := httprouter.New()
@@ -70,43 +133,104 @@ func runServer(cmd *cobra.Command, args []string) error {
 	router.GET("/health", healthCheck)
 
 	// Auth middleware
-	authMiddleware := auth.AuthMiddleware(jwtManager)
+	authMiddleware := auth.AuthMiddleware(jwtManager, controller.WriteError)
+	optionalAuthMiddleware := auth.OptionalAuthMiddleware(jwtManager)
 
 	// User routes (public)
 	router.POST("/api/users", userController.CreateUser)
 	router.POST("/api/users/login", userController.Login)
+	router.POST("/api/users/login/mfa", userController.CompleteMFALogin)
 	router.POST("/api/users/refresh", userController.RefreshToken)
 	router.POST("/api/users/logout", authMiddleware(userController.Logout)) // Protected
+	router.GET("/.well-known/jwks.json", keyController.JWKS)                // Public
+
+	// Session routes
+	router.GET("/api/users/me/sessions", authMiddleware(userController.ListSessions))         // Protected
+	router.DELETE("/api/users/me/sessions", authMiddleware(userController.DeleteAllSessions)) // Protected
+	router.PATCH("/api/users/me/sessions/:id", authMiddleware(userController.UpdateSession))  // Protected
+	router.DELETE("/api/users/me/sessions/:id", authMiddleware(userController.DeleteSession)) // Protected
+
+	// Security event routes
+	router.GET("/api/users/me/security-events", authMiddleware(userController.ListSecurityEvents)) // Protected
+
+	// Account recovery and email verification routes
+	router.POST("/api/users/password-reset", userController.RequestPasswordReset)                            // Public
+	router.POST("/api/users/password-reset/confirm", userController.ResetPassword)                           // Public
+	router.POST("/api/users/email-verification/confirm", userController.VerifyEmail)                         // Public
+	router.POST("/api/users/me/email-verification", authMiddleware(userController.RequestEmailVerification)) // Protected
+
+	// Two-factor authentication routes
+	router.GET("/api/users/me/mfa", authMiddleware(mfaController.GetMFA))                                  // Protected
+	router.POST("/api/users/me/mfa", authMiddleware(mfaController.EnrollMFA))                              // Protected
+	router.POST("/api/users/me/mfa/confirm", authMiddleware(mfaController.ConfirmMFA))                     // Protected
+	router.POST("/api/users/me/mfa/disable", authMiddleware(mfaController.DisableMFA))                     // Protected
+	router.POST("/api/users/me/mfa/recovery-codes", authMiddleware(mfaController.RegenerateRecoveryCodes)) // Protected
 
 	// Poll routes
-	router.GET("/api/polls", pollController.ListPolls)                         // Public
-	router.GET("/api/polls/:id", pollController.GetPoll)                       // Public
-	router.POST("/api/polls", authMiddleware(pollController.CreatePoll))       // Protected
-	router.PUT("/api/polls/:id", authMiddleware(pollController.UpdatePoll))    // Protected
-	router.DELETE("/api/polls/:id", authMiddleware(pollController.DeletePoll)) // Protected
+	router.GET("/api/polls", optionalAuthMiddleware(pollController.ListPolls))               // Public
+	router.GET("/api/polls/:id", optionalAuthMiddleware(pollController.GetPoll))             // Public
+	router.POST("/api/polls", authMiddleware(pollController.CreatePoll))                     // Protected
+	router.POST("/api/poll-imports", authMiddleware(pollController.ImportPolls))             // Protected
+	router.PUT("/api/polls/:id", authMiddleware(pollController.UpdatePoll))                  // Protected
+	router.PATCH("/api/polls/:id", authMiddleware(pollController.PatchPoll))                 // Protected
+	router.DELETE("/api/polls/:id", authMiddleware(pollController.DeletePoll))               // Protected
+	router.POST("/api/polls/:id/close", authMiddleware(pollController.ClosePoll))            // Protected
+	router.GET("/api/polls/:id/stream", optionalAuthMiddleware(streamController.StreamPoll)) // Public
+	router.GET("/api/polls/:id/export", authMiddleware(pollController.ExportPoll))           // Protected
+
+	// Invitation routes
+	router.GET("/api/polls/:id/invitations", authMiddleware(invitationController.ListPollInvitations))    // Protected
+	router.POST("/api/polls/:id/invitations", authMiddleware(invitationController.CreatePollInvitations)) // Protected
 
 	// Vote routes
-	router.POST("/api/polls/:id/vote", authMiddleware(voteController.VoteOnPoll))   // Protected
-	router.DELETE("/api/polls/:id/vote", authMiddleware(voteController.DeleteVote)) // Protected
-	router.GET("/api/polls/:id/votes", voteController.GetVoteCounts)                // Public
-	router.GET("/api/polls/:id/votes/:option", voteController.GetVotersByOption)    // Public
+	router.POST("/api/polls/:id/vote", authMiddleware(voteController.VoteOnPoll))                           // Protected
+	router.DELETE("/api/polls/:id/vote", authMiddleware(voteController.DeleteVote))                         // Protected
+	router.GET("/api/polls/:id/votes", optionalAuthMiddleware(voteController.GetVoteCounts))                // Public
+	router.GET("/api/polls/:id/results", optionalAuthMiddleware(voteController.GetRankedResults))           // Public
+	router.GET("/api/polls/:id/votes/:option_id", optionalAuthMiddleware(voteController.GetVotersByOption)) // Public
+
+	// Webhook routes
+	router.GET("/api/webhooks", authMiddleware(webhookController.ListWebhookEndpoints))                                    // Protected
+	router.POST("/api/webhooks", authMiddleware(webhookController.CreateWebhookEndpoint))                                  // Protected
+	router.GET("/api/webhooks/:id", authMiddleware(webhookController.GetWebhookEndpoint))                                  // Protected
+	router.PATCH("/api/webhooks/:id", authMiddleware(webhookController.UpdateWebhookEndpoint))                             // Protected
+	router.DELETE("/api/webhooks/:id", authMiddleware(webhookController.DeleteWebhookEndpoint))                            // Protected
+	router.GET("/api/webhooks/:id/deliveries", authMiddleware(webhookController.ListWebhookDeliveries))                    // Protected
+	router.POST("/api/webhooks/:id/deliveries/:delivery_id/redeliver", authMiddleware(webhookController.RedeliverWebhook)) // Protected
+
+	// Real-time routes; the handshake authenticates the connection itself
+	router.GET("/api/ws", realtimeController.Connect)
 
 	// Wrap router with CORS middleware
-	handler := corsMiddleware(router)
+	handler := corsMiddleware(cfg.CORS, router)
 
-	addr := fmt.Sprintf("%s:%s", host, port)
+	addr := cfg.Server.Addr()
 	log.Printf("Starting server on %s", addr)
 
 	return http.ListenAndServe(addr, handler)
 }
 
-func corsMiddleware(next http.Handler) http.Handler {
+func corsMiddleware(cfg config.CORSConfig, next http.Handler) http.Handler {
+	allowAny := slices.Contains(cfg.AllowedOrigins, "*")
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-		w.Header().Set("Access-Control-Max-Age", "3600")
+		origin := r.Header.Get("Origin")
+		switch {
+		case allowAny:
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		case slices.Contains(cfg.AllowedOrigins, origin):
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+		}
+		w.Header().Set("Access-Control-Allow-Methods", methods)
+		w.Header().Set("Access-Control-Allow-Headers", headers)
+		w.Header().Set("Access-Control-Max-Age", maxAge)
+		// Clients read ETag to revalidate polls and, from edits, to send it back in If-Match
+		w.Header().Set("Access-Control-Expose-Headers", "ETag")
 
 		// Handle preflight requests
 		if r.Method == "OPTIONS" {
@@ -0,0 +1,131 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"poll-app/auth"
+	"poll-app/config"
+	"poll-app/events"
+	"poll-app/mail"
+	"poll-app/service"
+	"poll-app/storage"
+	"poll-app/worker"
+
+	"github.com/spf13/cobra"
+)
+
+func NewWorkerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "worker",
+		Short: "Run background jobs",
+		Long:  "Run time-driven background jobs such as finalizing closed polls. Several workers may run at once; each job runs on one of them at a time.",
+		RunE:  runWorker,
+	}
+
+	cmd.AddCommand(newRunsCommand())
+
+	return cmd
+}
+
+func newRunsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "runs",
+		Short: "List recent job runs",
+		Long:  "List the recorded history of background job runs, newest first",
+		RunE:  runRuns,
+	}
+
+	cmd.Flags().String("job", "", "Only show runs of this job")
+	cmd.Flags().Int("limit", 20, "Maximum number of runs to show")
+
+	return cmd
+}
+
+func runWorker(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	if err := cfg.CheckProduction(); err != nil {
+		return err
+	}
+
+	// Initialize database
+	dbClient, err := storage.NewClient(cfg.Database, false)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer dbClient.Close()
+
+	// Initialize Redis, through which closings are announced to live watchers
+	redisClient, err := auth.NewRedisClient(cfg.Redis)
+	if err != nil {
+		return fmt.Errorf("failed to initialize Redis: %w", err)
+	}
+	defer redisClient.Close()
+
+	// Initialize the mailer that sends invitation reminders
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		return fmt.Errorf("failed to initialize mailer: %w", err)
+	}
+
+	storageLayer := storage.NewStorage(dbClient)
+	serviceLayer := service.NewService(storageLayer, events.NewBroker(redisClient), mailer, cfg.Mail.AppURL, cfg.MFA.Issuer)
+
+	// Rotate signing keys when tokens are signed with a key ring
+	var keyRotator *auth.KeyRotator
+	if cfg.JWT.Asymmetric() {
+		keyStore, err := auth.NewKeyStore(cfg.JWT, storageLayer)
+		if err != nil {
+			return fmt.Errorf("failed to initialize key store: %w", err)
+		}
+		keyRotator = auth.NewKeyRotator(keyStore, cfg.JWT)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	worker.NewScheduler(storageLayer, worker.DefaultJobs(serviceLayer, storageLayer, keyRotator)...).Run(ctx)
+	return nil
+}
+
+func runRuns(cmd *cobra.Command, args []string) error {
+	job, _ := cmd.Flags().GetString("job")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	cfg, err := config.Load(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dbClient, err := storage.NewClient(cfg.Database, false)
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer dbClient.Close()
+
+	runs, err := storage.NewStorage(dbClient).ListJobRuns(cmd.Context(), job, limit)
+	if err != nil {
+		return fmt.Errorf("failed to list job runs: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "JOB\tSTATUS\tSTARTED\tDURATION\tAFFECTED\tINSTANCE\tERROR")
+	for _, run := range runs {
+		duration := "-"
+		if run.FinishedAt != nil {
+			duration = run.FinishedAt.Sub(run.StartedAt).Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			run.Job, run.Status, run.StartedAt.Format(time.RFC3339), duration, run.Affected, run.Instance, run.Error)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,500 @@
+// Package config loads the typed configuration of the application. Values are
+// layered: built-in defaults, then a YAML file, then environment variables,
+// then command-line flags, which every field but secrets has. Secrets may be
+// read from files so they never have to appear in the environment or the
+// config file.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Environments the application can run in
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production"
+)
+
+// defaultJWTSecret is only acceptable outside production
+const defaultJWTSecret = "default-secret-key-change-in-production"
+
+// Token signing algorithms
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// Places asymmetric signing keys can be kept
+const (
+	KeyStoreFile     = "file"
+	KeyStorePostgres = "postgres"
+)
+
+// Mail transports
+const (
+	// MailTransportSMTP sends mail through an SMTP server
+	MailTransportSMTP = "smtp"
+	// MailTransportFile writes each message to a file in a directory
+	MailTransportFile = "file"
+	// MailTransportLog keeps messages in memory and logs them, for development
+	MailTransportLog = "log"
+)
+
+// Config holds the whole application configuration
+type Config struct {
+	Environment string          `yaml:"environment" env:"APP_ENV"`
+	Server      ServerConfig    `yaml:"server"`
+	Database    DatabaseConfig  `yaml:"database"`
+	Redis       RedisConfig     `yaml:"redis"`
+	JWT         JWTConfig       `yaml:"jwt"`
+	CORS        CORSConfig      `yaml:"cors"`
+	WebSocket   WebSocketConfig `yaml:"websocket"`
+	Mail        MailConfig      `yaml:"mail"`
+	MFA         MFAConfig       `yaml:"mfa"`
+}
+
+// ServerConfig holds the HTTP listen address
+type ServerConfig struct {
+	Host string `yaml:"host" env:"SERVER_HOST" flag:"host"`
+	Port int    `yaml:"port" env:"SERVER_PORT" flag:"port"`
+}
+
+// Addr returns the address to listen on
+func (c ServerConfig) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// DatabaseConfig holds the PostgreSQL connection and pool settings. When URL
+// is set it is used as the DSN and the individual connection fields are ignored.
+type DatabaseConfig struct {
+	URL             string        `yaml:"url" env:"DATABASE_URL" secret:"true"`
+	Host            string        `yaml:"host" env:"POSTGRES_HOST"`
+	Port            int           `yaml:"port" env:"POSTGRES_PORT"`
+	User            string        `yaml:"user" env:"POSTGRES_USER"`
+	Password        string        `yaml:"password" env:"POSTGRES_PASSWORD" secret:"true"`
+	PasswordFile    string        `yaml:"password_file" env:"POSTGRES_PASSWORD_FILE"`
+	Name            string        `yaml:"name" env:"POSTGRES_DB"`
+	SSLMode         string        `yaml:"sslmode" env:"POSTGRES_SSLMODE"`
+	MaxOpenConns    int           `yaml:"max_open_conns" env:"POSTGRES_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `yaml:"max_idle_conns" env:"POSTGRES_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" env:"POSTGRES_CONN_MAX_LIFETIME"`
+}
+
+// DSN returns the connection string for the database
+func (c DatabaseConfig) DSN() string {
+	if c.URL != "" {
+		return c.URL
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:     "/" + c.Name,
+		RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+// RedisConfig holds the Redis connection settings
+type RedisConfig struct {
+	Host         string `yaml:"host" env:"REDIS_HOST"`
+	Port         int    `yaml:"port" env:"REDIS_PORT"`
+	Password     string `yaml:"password" env:"REDIS_PASSWORD" secret:"true"`
+	PasswordFile string `yaml:"password_file" env:"REDIS_PASSWORD_FILE"`
+	DB           int    `yaml:"db" env:"REDIS_DB"`
+}
+
+// Addr returns the host:port address of the Redis server
+func (c RedisConfig) Addr() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+// JWTConfig holds the token signing keys and token lifetimes. With HS256
+// tokens are signed with Secret; with an asymmetric algorithm they are signed
+// with a ring of keys kept in KeyStore, and Secret, if set, is only used to
+// accept HS256 tokens issued before the switch.
+type JWTConfig struct {
+	Algorithm  string `yaml:"algorithm" env:"JWT_ALGORITHM"`
+	Secret     string `yaml:"secret" env:"JWT_SECRET_KEY" secret:"true"`
+	SecretFile string `yaml:"secret_file" env:"JWT_SECRET_KEY_FILE"`
+	KeyStore   string `yaml:"key_store" env:"JWT_KEY_STORE"`
+	KeyDir     string `yaml:"key_dir" env:"JWT_KEY_DIR"`
+	// KeyRotationInterval is how old the signing key gets before the worker
+	// replaces it; zero disables scheduled rotation
+	KeyRotationInterval time.Duration `yaml:"key_rotation_interval" env:"JWT_KEY_ROTATION_INTERVAL"`
+	// KeyOverlap is how long a replaced key is still accepted, so tokens it
+	// signed stay valid until they expire
+	KeyOverlap time.Duration `yaml:"key_overlap" env:"JWT_KEY_OVERLAP"`
+	// LegacySecretUntil is when HS256 tokens signed with the secret stop being
+	// accepted once tokens are signed with a key ring, so sessions begun before
+	// the switch move over as they refresh. Unset, they are refused outright.
+	LegacySecretUntil time.Time     `yaml:"legacy_secret_until" env:"JWT_LEGACY_SECRET_UNTIL"`
+	AccessTokenTTL    time.Duration `yaml:"access_token_ttl" env:"JWT_ACCESS_TOKEN_TTL"`
+	RefreshTokenTTL   time.Duration `yaml:"refresh_token_ttl" env:"JWT_REFRESH_TOKEN_TTL"`
+	// RefreshReuseGrace is how long a rotated refresh token still gets its
+	// successor, for clients refreshing concurrently; presented later, it is
+	// treated as stolen and ends its session
+	RefreshReuseGrace time.Duration `yaml:"refresh_reuse_grace" env:"JWT_REFRESH_REUSE_GRACE"`
+}
+
+// Asymmetric reports whether tokens are signed with a key ring rather than the secret
+func (c JWTConfig) Asymmetric() bool {
+	return c.Algorithm != AlgorithmHS256
+}
+
+// CORSConfig holds the cross-origin resource sharing policy
+type CORSConfig struct {
+	AllowedOrigins []string      `yaml:"allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods []string      `yaml:"allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	AllowedHeaders []string      `yaml:"allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	MaxAge         time.Duration `yaml:"max_age" env:"CORS_MAX_AGE"`
+}
+
+// WebSocketConfig holds the limits of the real-time WebSocket API
+type WebSocketConfig struct {
+	MaxConnectionsPerUser int `yaml:"max_connections_per_user" env:"WS_MAX_CONNECTIONS_PER_USER"`
+	MaxSubscriptions      int `yaml:"max_subscriptions" env:"WS_MAX_SUBSCRIPTIONS"`
+}
+
+// MailConfig holds how account and invitation emails are sent. AppURL is the
+// address of the web app that links in emails, such as password reset links, point to.
+type MailConfig struct {
+	Transport        string `yaml:"transport" env:"MAIL_TRANSPORT"`
+	From             string `yaml:"from" env:"MAIL_FROM"`
+	AppURL           string `yaml:"app_url" env:"MAIL_APP_URL"`
+	SMTPHost         string `yaml:"smtp_host" env:"SMTP_HOST"`
+	SMTPPort         int    `yaml:"smtp_port" env:"SMTP_PORT"`
+	SMTPUsername     string `yaml:"smtp_username" env:"SMTP_USERNAME"`
+	SMTPPassword     string `yaml:"smtp_password" env:"SMTP_PASSWORD" secret:"true"`
+	SMTPPasswordFile string `yaml:"smtp_password_file" env:"SMTP_PASSWORD_FILE"`
+	// Dir is where the file transport writes messages
+	Dir string `yaml:"dir" env:"MAIL_DIR"`
+}
+
+// MFAConfig holds two-factor authentication settings. Issuer names the app in
+// authenticator apps; ChallengeTTL is how long a user who signed in with their
+// password has to enter their code.
+type MFAConfig struct {
+	Issuer       string        `yaml:"issuer" env:"MFA_ISSUER"`
+	ChallengeTTL time.Duration `yaml:"challenge_ttl" env:"MFA_CHALLENGE_TTL"`
+}
+
+// Default returns the built-in configuration, suitable for local development
+// once the environment is set to development. The environment is left unset so
+// a deployment that forgets to set it is held to production rules.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Host: "0.0.0.0",
+			Port: 8080,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Password:        "postgres",
+			Name:            "postgres",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+		Redis: RedisConfig{
+			Host: "localhost",
+			Port: 6379,
+		},
+		JWT: JWTConfig{
+			Algorithm:           AlgorithmHS256,
+			Secret:              defaultJWTSecret,
+			KeyStore:            KeyStorePostgres,
+			KeyRotationInterval: 30 * 24 * time.Hour,
+			KeyOverlap:          7 * 24 * time.Hour,
+			AccessTokenTTL:      15 * time.Minute,
+			RefreshTokenTTL:     7 * 24 * time.Hour,
+			RefreshReuseGrace:   10 * time.Second,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "If-Match", "If-None-Match"},
+			MaxAge:         time.Hour,
+		},
+		WebSocket: WebSocketConfig{
+			MaxConnectionsPerUser: 5,
+			MaxSubscriptions:      20,
+		},
+		Mail: MailConfig{
+			Transport: MailTransportLog,
+			From:      "Poll App <no-reply@localhost>",
+			AppURL:    "http://localhost:3000",
+			SMTPPort:  587,
+		},
+		MFA: MFAConfig{
+			Issuer:       "Poll App",
+			ChallengeTTL: 5 * time.Minute,
+		},
+	}
+}
+
+// Load builds the configuration for a command. The config file is taken from
+// the --config flag or the POLL_APP_CONFIG environment variable; flags bound
+// to config fields, by BindFlags or the command, override everything else.
+func Load(cmd *cobra.Command) (*Config, error) {
+	cfg := Default()
+
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = os.Getenv("POLL_APP_CONFIG")
+	}
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := apply(cfg, func(field fieldInfo) (string, string, bool) {
+		if field.env == "" {
+			return "", "", false
+		}
+		raw, ok := os.LookupEnv(field.env)
+		return raw, "$" + field.env, ok
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := apply(cfg, func(field fieldInfo) (string, string, bool) {
+		if field.flag == "" {
+			return "", "", false
+		}
+		f := cmd.Flags().Lookup(field.flag)
+		if f == nil || !f.Changed {
+			return "", "", false
+		}
+		return f.Value.String(), "--" + field.flag, true
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.readSecretFiles(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// loadFile overlays the YAML file onto the configuration. Unknown keys are
+// rejected so typos do not silently fall back to defaults.
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// readSecretFiles replaces secrets with the contents of their files, if given
+func (c *Config) readSecretFiles() error {
+	secrets := []struct {
+		path  string
+		value *string
+	}{
+		{c.Database.PasswordFile, &c.Database.Password},
+		{c.Redis.PasswordFile, &c.Redis.Password},
+		{c.JWT.SecretFile, &c.JWT.Secret},
+		{c.Mail.SMTPPasswordFile, &c.Mail.SMTPPassword},
+	}
+
+	for _, secret := range secrets {
+		if secret.path == "" {
+			continue
+		}
+		b, err := os.ReadFile(secret.path)
+		if err != nil {
+			return fmt.Errorf("failed to read secret file: %w", err)
+		}
+		*secret.value = strings.TrimRight(string(b), "\r\n")
+	}
+	return nil
+}
+
+// Validate checks that the configuration is complete and consistent
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Environment != "" && c.Environment != EnvDevelopment && c.Environment != EnvProduction {
+		errs = append(errs, fmt.Errorf("environment must be %q or %q", EnvDevelopment, EnvProduction))
+	}
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, errors.New("server.port must be between 1 and 65535"))
+	}
+
+	if c.Database.URL == "" {
+		if c.Database.Host == "" || c.Database.Name == "" || c.Database.User == "" {
+			errs = append(errs, errors.New("database.host, database.name and database.user are required unless database.url is set"))
+		}
+	} else if _, err := url.Parse(c.Database.URL); err != nil {
+		errs = append(errs, errors.New("database.url is not a valid URL"))
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("database connection pool sizes cannot be negative"))
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, errors.New("database.max_idle_conns cannot exceed database.max_open_conns"))
+	}
+
+	if c.Redis.Host == "" {
+		errs = append(errs, errors.New("redis.host is required"))
+	}
+
+	switch c.JWT.Algorithm {
+	case AlgorithmHS256:
+		if c.JWT.Secret == "" {
+			errs = append(errs, errors.New("jwt.secret is required when jwt.algorithm is HS256"))
+		}
+	case AlgorithmRS256, AlgorithmES256, AlgorithmEdDSA:
+		switch c.JWT.KeyStore {
+		case KeyStorePostgres:
+		case KeyStoreFile:
+			if c.JWT.KeyDir == "" {
+				errs = append(errs, errors.New("jwt.key_dir is required when jwt.key_store is file"))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("jwt.key_store must be %q or %q", KeyStoreFile, KeyStorePostgres))
+		}
+		if c.JWT.KeyRotationInterval < 0 {
+			errs = append(errs, errors.New("jwt.key_rotation_interval cannot be negative"))
+		}
+		if c.JWT.KeyOverlap < c.JWT.RefreshTokenTTL {
+			errs = append(errs, errors.New("jwt.key_overlap cannot be shorter than jwt.refresh_token_ttl"))
+		}
+		// Sessions move over within one refresh token lifetime, so a longer
+		// window only keeps the secret usable for forging tokens
+		if !c.JWT.LegacySecretUntil.IsZero() {
+			if c.JWT.Secret == "" {
+				errs = append(errs, errors.New("jwt.legacy_secret_until requires jwt.secret"))
+			}
+			if time.Until(c.JWT.LegacySecretUntil) > c.JWT.RefreshTokenTTL {
+				errs = append(errs, errors.New("jwt.legacy_secret_until cannot be further away than jwt.refresh_token_ttl"))
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("jwt.algorithm must be one of %s, %s, %s or %s", AlgorithmHS256, AlgorithmRS256, AlgorithmES256, AlgorithmEdDSA))
+	}
+	if c.JWT.AccessTokenTTL <= 0 || c.JWT.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("jwt token TTLs must be positive"))
+	}
+	if c.JWT.RefreshTokenTTL < c.JWT.AccessTokenTTL {
+		errs = append(errs, errors.New("jwt.refresh_token_ttl cannot be shorter than jwt.access_token_ttl"))
+	}
+	if c.JWT.RefreshReuseGrace < 0 || c.JWT.RefreshReuseGrace > time.Minute {
+		errs = append(errs, errors.New("jwt.refresh_reuse_grace must be between 0 and 1m"))
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		errs = append(errs, errors.New("cors.allowed_origins cannot be empty"))
+	}
+
+	if c.WebSocket.MaxConnectionsPerUser < 1 || c.WebSocket.MaxSubscriptions < 1 {
+		errs = append(errs, errors.New("websocket limits must be positive"))
+	}
+
+	switch c.Mail.Transport {
+	case MailTransportSMTP:
+		if c.Mail.SMTPHost == "" {
+			errs = append(errs, errors.New("mail.smtp_host is required when mail.transport is smtp"))
+		}
+		if c.Mail.SMTPPort < 1 || c.Mail.SMTPPort > 65535 {
+			errs = append(errs, errors.New("mail.smtp_port must be between 1 and 65535"))
+		}
+	case MailTransportFile:
+		if c.Mail.Dir == "" {
+			errs = append(errs, errors.New("mail.dir is required when mail.transport is file"))
+		}
+	case MailTransportLog:
+	default:
+		errs = append(errs, fmt.Errorf("mail.transport must be one of %s, %s or %s", MailTransportSMTP, MailTransportFile, MailTransportLog))
+	}
+	if _, err := mail.ParseAddress(c.Mail.From); err != nil {
+		errs = append(errs, errors.New("mail.from must be an email address"))
+	}
+	if u, err := url.Parse(c.Mail.AppURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, errors.New("mail.app_url must be an absolute http or https URL"))
+	}
+
+	// Authenticator apps split the account label on the first colon
+	if c.MFA.Issuer == "" || strings.Contains(c.MFA.Issuer, ":") {
+		errs = append(errs, errors.New("mfa.issuer is required and cannot contain a colon"))
+	}
+	if c.MFA.ChallengeTTL < time.Minute || c.MFA.ChallengeTTL > 15*time.Minute {
+		errs = append(errs, errors.New("mfa.challenge_ttl must be between 1m and 15m"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// CheckProduction returns an error listing every setting that is unsafe for a
+// production deployment. Only an environment explicitly set to development
+// skips the checks, so an unset one fails closed.
+func (c *Config) CheckProduction() error {
+	if c.Environment == EnvDevelopment {
+		return nil
+	}
+
+	var errs []error
+
+	// With an asymmetric algorithm the secret may be left empty, but if set it
+	// still verifies tokens
+	if (!c.JWT.Asymmetric() || c.JWT.Secret != "") && (c.JWT.Secret == defaultJWTSecret || len(c.JWT.Secret) < 32) {
+		errs = append(errs, errors.New("jwt.secret must be set to a random value of at least 32 characters, or left empty with an asymmetric jwt.algorithm"))
+	}
+	if c.Database.URL == "" {
+		if c.Database.Password == "" || c.Database.Password == "postgres" {
+			errs = append(errs, errors.New("database.password must be changed from its default"))
+		}
+		if c.Database.SSLMode == "disable" {
+			errs = append(errs, errors.New("database.sslmode must not be disable"))
+		}
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			errs = append(errs, errors.New("cors.allowed_origins must list explicit origins"))
+		}
+	}
+	// The log transport writes reset links where anyone reading logs can use them
+	if c.Mail.Transport == MailTransportLog {
+		errs = append(errs, errors.New("mail.transport must be smtp or file"))
+	}
+
+	if len(errs) > 0 {
+		if c.Environment == "" {
+			errs = append(errs, fmt.Errorf("environment is not set, so production rules apply; set it to %s for local development", EnvDevelopment))
+		}
+		return fmt.Errorf("insecure production configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// hardened returns the default configuration with every production check met
+func hardened() *Config {
+	cfg := Default()
+	cfg.JWT.Secret = strings.Repeat("s", 32)
+	cfg.Database.Password = "secret"
+	cfg.Database.SSLMode = "require"
+	cfg.CORS.AllowedOrigins = []string{"https://polls.example.com"}
+	cfg.Mail.Transport = MailTransportSMTP
+	return cfg
+}
+
+func TestCheckProduction(t *testing.T) {
+	tests := []struct {
+		name        string
+		environment string
+		cfg         *Config
+		want        string
+	}{
+		{"development defaults", EnvDevelopment, Default(), ""},
+		{"unset environment with defaults", "", Default(), "environment is not set"},
+		{"production defaults", EnvProduction, Default(), "jwt.secret"},
+		{"production hardened", EnvProduction, hardened(), ""},
+		{"unset environment hardened", "", hardened(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Environment = tt.environment
+			err := tt.cfg.CheckProduction()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("CheckProduction = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("CheckProduction = %v, want an error mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadEnvironment(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   string
+		want  string
+		valid bool
+	}{
+		{"unset", "", "", true},
+		{"development", EnvDevelopment, EnvDevelopment, true},
+		{"production", EnvProduction, EnvProduction, true},
+		{"unknown", "staging", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("POLL_APP_CONFIG", "")
+			t.Setenv("APP_ENV", tt.env)
+			cmd := &cobra.Command{}
+			cmd.Flags().String("config", "", "")
+
+			cfg, err := Load(cmd)
+			if (err == nil) != tt.valid {
+				t.Fatalf("Load = %v, want valid %v", err, tt.valid)
+			}
+			if err == nil && cfg.Environment != tt.want {
+				t.Fatalf("environment = %q, want %q", cfg.Environment, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateLegacySecretUntil(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		until  time.Time
+		want   string
+	}{
+		{"unset", "", time.Time{}, ""},
+		{"within the refresh token lifetime", strings.Repeat("s", 32), time.Now().Add(time.Hour), ""},
+		{"passed", strings.Repeat("s", 32), time.Now().Add(-time.Hour), ""},
+		{"without a secret", "", time.Now().Add(time.Hour), "requires jwt.secret"},
+		{"too far away", strings.Repeat("s", 32), time.Now().Add(30 * 24 * time.Hour), "further away"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			cfg.JWT.Algorithm = AlgorithmES256
+			cfg.JWT.Secret = tt.secret
+			cfg.JWT.LegacySecretUntil = tt.until
+			err := cfg.Validate()
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Validate = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Validate = %v, want an error mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLegacySecretUntil(t *testing.T) {
+	t.Setenv("POLL_APP_CONFIG", "")
+	t.Setenv("JWT_SECRET_KEY", strings.Repeat("s", 32))
+	until := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	t.Setenv("JWT_LEGACY_SECRET_UNTIL", until.Format(time.RFC3339))
+	cmd := &cobra.Command{}
+	cmd.Flags().String("config", "", "")
+
+	cfg, err := Load(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.JWT.LegacySecretUntil.Equal(until) {
+		t.Fatalf("legacy_secret_until = %v, want %v", cfg.JWT.LegacySecretUntil, until)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	file := "database:\n  host: file-host\n  name: file-db\n  user: file-user\n"
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("POLL_APP_CONFIG", path)
+	t.Setenv("POSTGRES_HOST", "env-host")
+	t.Setenv("POSTGRES_DB", "env-db")
+
+	root := &cobra.Command{}
+	root.PersistentFlags().String("config", "", "")
+	BindFlags(root)
+	if err := root.ParseFlags([]string{"--database.host=flag-host", "--database.max_open_conns=7"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Database.Host != "flag-host" || cfg.Database.Name != "env-db" || cfg.Database.User != "file-user" || cfg.Database.MaxOpenConns != 7 {
+		t.Errorf("database = %+v, want host from the flag, name from the environment and user from the file", cfg.Database)
+	}
+
+	if root.PersistentFlags().Lookup("database.password") != nil {
+		t.Error("secrets must not have flags")
+	}
+	if err := root.ParseFlags([]string{"--database.port=many"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(root); err == nil || !strings.Contains(err.Error(), "--database.port") {
+		t.Errorf("Load = %v, want an error naming --database.port", err)
+	}
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// fieldInfo describes a configurable leaf field and where its value comes from
+type fieldInfo struct {
+	path string
+	env  string
+	flag string
+	// global is set when the flag is named after the path and bound by
+	// BindFlags rather than by the commands that take it
+	global bool
+	secret bool
+	value  reflect.Value
+}
+
+// fields walks the configuration and returns every leaf field
+func fields(cfg *Config) []fieldInfo {
+	var list []fieldInfo
+	walk(reflect.ValueOf(cfg).Elem(), "", &list)
+	return list
+}
+
+func walk(v reflect.Value, prefix string, list *[]fieldInfo) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, _, _ := strings.Cut(sf.Tag.Get("yaml"), ",")
+		path := prefix + name
+
+		if sf.Type.Kind() == reflect.Struct && sf.Type != reflect.TypeOf(time.Time{}) {
+			walk(v.Field(i), path+".", list)
+			continue
+		}
+
+		field := fieldInfo{
+			path:   path,
+			env:    sf.Tag.Get("env"),
+			flag:   sf.Tag.Get("flag"),
+			secret: sf.Tag.Get("secret") == "true",
+			value:  v.Field(i),
+		}
+		// Command lines can be read by other users of the machine, so
+		// secrets have no flag; their files can be given instead
+		if field.flag == "" && !field.secret {
+			field.flag, field.global = path, true
+		}
+		*list = append(*list, field)
+	}
+}
+
+// BindFlags adds a persistent flag to cmd for every configuration field but
+// secrets, named after its path, such as --database.host. Fields whose flags
+// have other names are bound by the commands that take them.
+func BindFlags(cmd *cobra.Command) {
+	for _, field := range fields(Default()) {
+		if !field.global {
+			continue
+		}
+		usage := "Overrides " + field.path
+		if field.env != "" {
+			usage += " and $" + field.env
+		}
+		cmd.PersistentFlags().String(field.flag, "", usage)
+	}
+}
+
+// apply sets every field for which lookup returns a value, and names where the
+// value came from in errors
+func apply(cfg *Config, lookup func(field fieldInfo) (raw, source string, ok bool)) error {
+	for _, field := range fields(cfg) {
+		raw, source, ok := lookup(field)
+		if !ok {
+			continue
+		}
+		if err := set(field.value, raw); err != nil {
+			return fmt.Errorf("invalid value for %s (%s): %w", field.path, source, err)
+		}
+	}
+	return nil
+}
+
+// set parses raw into v according to its type. Lists are comma-separated and
+// times are in RFC 3339 format.
+func set(v reflect.Value, raw string) error {
+	switch {
+	case v.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+	case v.Type() == reflect.TypeOf(time.Time{}):
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+	case v.Kind() == reflect.String:
+		v.SetString(raw)
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+// Redacted returns a copy of the configuration with every secret that is set
+// replaced by a placeholder, so it can be printed or logged
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redacted.CORS.AllowedOrigins = append([]string(nil), c.CORS.AllowedOrigins...)
+	redacted.CORS.AllowedMethods = append([]string(nil), c.CORS.AllowedMethods...)
+	redacted.CORS.AllowedHeaders = append([]string(nil), c.CORS.AllowedHeaders...)
+
+	for _, field := range fields(&redacted) {
+		if field.secret && field.value.String() != "" {
+			field.value.SetString("REDACTED")
+		}
+	}
+	return &redacted
+}
@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"poll-app/api"
+	"poll-app/auth"
+	"poll-app/converter"
+	"poll-app/service"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+// InvitationController handles poll invitation HTTP requests
+type InvitationController struct {
+	service service.InvitationService
+}
+
+// NewInvitationController creates a new invitation controller
+func NewInvitationController(service service.InvitationService) *InvitationController {
+	return &InvitationController{service: service}
+}
+
+// CreatePollInvitations handles POST /api/polls/:id/invitations
+func (c *InvitationController) CreatePollInvitations(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	var req api.CreateInvitationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	emails := make([]string, 0, len(req.Emails))
+	for _, email := range req.Emails {
+		emails = append(emails, string(email))
+	}
+
+	invitations, err := c.service.InviteToPoll(r.Context(), id, userID, emails)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(converter.InvitationsToResponse(invitations))
+}
+
+// ListPollInvitations handles GET /api/polls/:id/invitations
+func (c *InvitationController) ListPollInvitations(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	invitations, err := c.service.ListPollInvitations(r.Context(), id, userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.InvitationsToResponse(invitations))
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"poll-app/auth"
+	"poll-app/converter"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// KeyController publishes the public keys tokens are signed with
+type KeyController struct {
+	jwtManager *auth.JWTManager
+}
+
+// NewKeyController creates a new key controller
+func NewKeyController(jwtManager *auth.JWTManager) *KeyController {
+	return &KeyController{jwtManager: jwtManager}
+}
+
+// JWKS handles GET /.well-known/jwks.json
+func (c *KeyController) JWKS(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Set("Content-Type", "application/json")
+	// New keys are published minutes before they sign, so a short cache is safe
+	w.Header().Set("Cache-Control", "public, max-age=60")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(converter.JWKSToResponse(c.jwtManager.PublicKeys()))
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"poll-app/api"
+	"poll-app/auth"
+	"poll-app/converter"
+	"poll-app/service"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// MFAController handles the two-factor authentication settings of the current user
+type MFAController struct {
+	service service.MFAService
+}
+
+// NewMFAController creates a new MFA controller
+func NewMFAController(service service.MFAService) *MFAController {
+	return &MFAController{service: service}
+}
+
+// GetMFA handles GET /api/users/me/mfa
+func (c *MFAController) GetMFA(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	status, err := c.service.GetMFAStatus(r.Context(), userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.MFAStatusToResponse(status))
+}
+
+// EnrollMFA handles POST /api/users/me/mfa
+func (c *MFAController) EnrollMFA(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	enrollment, err := c.service.EnrollMFA(r.Context(), userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	// The secret must not linger in caches
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.MFAEnrollmentToResponse(enrollment))
+}
+
+// ConfirmMFA handles POST /api/users/me/mfa/confirm
+func (c *MFAController) ConfirmMFA(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	var req api.MFAConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	codes, err := c.service.ConfirmMFA(r.Context(), userID, req.Code)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	writeRecoveryCodes(w, codes)
+}
+
+// DisableMFA handles POST /api/users/me/mfa/disable
+func (c *MFAController) DisableMFA(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	var req api.MFAReauthenticationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	if err := c.service.DisableMFA(r.Context(), userID, req.Password, req.Code); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes handles POST /api/users/me/mfa/recovery-codes
+func (c *MFAController) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	var req api.MFAReauthenticationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	codes, err := c.service.RegenerateRecoveryCodes(r.Context(), userID, req.Password, req.Code)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	writeRecoveryCodes(w, codes)
+}
+
+// writeRecoveryCodes responds with recovery codes, which are shown only once
+func writeRecoveryCodes(w http.ResponseWriter, codes []string) {
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.RecoveryCodesResponse{RecoveryCodes: &codes})
+}
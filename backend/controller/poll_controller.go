@@ -1,12 +1,25 @@
 package controller
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"poll-app/api"
 	"poll-app/auth"
 	"poll-app/converter"
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/export"
+	"poll-app/importer"
 	"poll-app/service"
 
 	"github.com/google/uuid"
@@ -25,50 +38,92 @@ func NewPollController(service service.PollService) *PollController {
 
 // ListPolls handles GET /api/polls
 func (c *PollController) ListPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	polls, err := c.service.ListPolls(r.Context())
+	query := r.URL.Query()
+	viewerID, ok := auth.GetUserIDFromContext(r.Context())
+	if query.Has("voted") && !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	filter, page, err := converter.PollListQueryFromRequest(query, viewerID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
-	responses := make([]api.PollResponse, 0, len(polls))
-	for _, poll := range polls {
-		responses = append(responses, converter.PollToResponse(poll))
+	list, err := c.service.ListPolls(r.Context(), filter, page)
+	if err != nil {
+		WriteError(w, r, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(responses)
+	json.NewEncoder(w).Encode(converter.PollListToResponse(list, viewerID))
 }
 
 // GetPoll handles GET /api/polls/:id
 func (c *PollController) GetPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := uuid.Parse(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		WriteError(w, r, errInvalidPollID)
 		return
 	}
 
 	poll, err := c.service.GetPollByID(r.Context(), id)
 	if err != nil {
-		http.Error(w, "Poll not found", http.StatusNotFound)
+		WriteError(w, r, err)
+		return
+	}
+
+	viewerID, _ := auth.GetUserIDFromContext(r.Context())
+	resultsVisible, err := c.service.CanViewResults(r.Context(), poll, viewerID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	response := converter.PollToResponse(poll, resultsVisible)
+	if viewerID != uuid.Nil {
+		hasVoted, err := c.service.HasVoted(r.Context(), poll, viewerID)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+		response.HasVoted = &hasVoted
+	}
+
+	body, err := json.Marshal(response)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+	body = append(body, '\n')
+
+	// The response depends on the votes and the viewer, not only on the
+	// version, so its tag is derived from the body. The body varies by caller.
+	etag := representationETag(body)
+	w.Header().Set("ETag", etag)
+	w.Header().Add("Vary", "Authorization")
+	if ifNoneMatch(r, etag) {
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(converter.PollToResponse(poll))
+	w.Write(body)
 }
 
 // CreatePoll handles POST /api/polls
 func (c *PollController) CreatePoll(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	userID, ok := auth.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		WriteError(w, r, errUnauthorized)
 		return
 	}
 
 	var req api.CreatePollRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteError(w, r, errInvalidBody)
 		return
 	}
 
@@ -77,79 +132,351 @@ func (c *PollController) CreatePoll(w http.ResponseWriter, r *http.Request, _ ht
 		description = *req.Description
 	}
 
-	poll, err := c.service.CreatePoll(r.Context(), req.Title, description, req.Options, userID)
+	settings := service.PollSettings{
+		Type:       poll.TypeSingle,
+		MaxChoices: req.MaxChoices,
+		OpensAt:    req.OpensAt,
+		ClosesAt:   req.ClosesAt,
+	}
+	if req.Type != nil {
+		settings.Type = poll.Type(*req.Type)
+	}
+	if req.ResultsVisibility != nil {
+		settings.ResultsVisibility = poll.ResultsVisibility(*req.ResultsVisibility)
+	}
+	if req.MinChoices != nil {
+		settings.MinChoices = *req.MinChoices
+	}
+	if req.Anonymous != nil {
+		settings.Anonymous = *req.Anonymous
+	}
+	if req.RequireVerifiedEmail != nil {
+		settings.RequireVerifiedEmail = *req.RequireVerifiedEmail
+	}
+
+	created, err := c.service.CreatePoll(r.Context(), req.Title, description, converter.OptionInputsFromRequest(req.Options), settings, userID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, r, err)
 		return
 	}
 
+	setETag(w, created)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(converter.PollToResponse(poll))
+	json.NewEncoder(w).Encode(converter.PollToResponse(created, true))
 }
 
 // UpdatePoll handles PUT /api/polls/:id
 func (c *PollController) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	userID, ok := auth.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		WriteError(w, r, errUnauthorized)
 		return
 	}
 
 	id, err := uuid.Parse(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		WriteError(w, r, errInvalidPollID)
 		return
 	}
 
 	var req api.UpdatePollRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteError(w, r, errInvalidBody)
 		return
 	}
 
-	title := ""
-	if req.Title != nil {
-		title = *req.Title
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		WriteError(w, r, err)
+		return
 	}
-	description := ""
-	if req.Description != nil {
-		description = *req.Description
+
+	// Empty fields are left unchanged; PATCH can clear them
+	var update service.PollUpdate
+	if req.Title != nil && *req.Title != "" {
+		update.Title = req.Title
+	}
+	if req.Description != nil && *req.Description != "" {
+		update.Description = req.Description
 	}
-	options := []string{}
-	if req.Options != nil {
-		options = *req.Options
+	if req.Options != nil && len(*req.Options) > 0 {
+		update.Options = converter.OptionInputsFromRequest(*req.Options)
 	}
+	update.MinChoices = req.MinChoices
+	update.MaxChoices = req.MaxChoices
+	update.OpensAt = req.OpensAt
+	update.ClosesAt = req.ClosesAt
+	if req.ResultsVisibility != nil {
+		resultsVisibility := poll.ResultsVisibility(*req.ResultsVisibility)
+		update.ResultsVisibility = &resultsVisibility
+	}
+	update.RequireVerifiedEmail = req.RequireVerifiedEmail
 
-	poll, err := c.service.UpdatePoll(r.Context(), id, userID, title, description, options)
+	poll, err := c.service.UpdatePoll(r.Context(), id, userID, versions, update)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, r, err)
 		return
 	}
 
+	setETag(w, poll)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(converter.PollToResponse(poll))
+	json.NewEncoder(w).Encode(converter.PollToResponse(poll, true))
+}
+
+// PatchPoll handles PATCH /api/polls/:id with a JSON Merge Patch body
+func (c *PollController) PatchPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	update, err := converter.PollUpdateFromMergePatch(body)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	patched, err := c.service.UpdatePoll(r.Context(), id, userID, versions, update)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	setETag(w, patched)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.PollToResponse(patched, true))
 }
 
 // DeletePoll handles DELETE /api/polls/:id
 func (c *PollController) DeletePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	userID, ok := auth.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		WriteError(w, r, errUnauthorized)
 		return
 	}
 
 	id, err := uuid.Parse(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	versions, err := ifMatchVersions(r)
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	if err := c.service.DeletePoll(r.Context(), id, userID); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := c.service.DeletePoll(r.Context(), id, userID, versions); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ClosePoll handles POST /api/polls/:id/close
+func (c *PollController) ClosePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	closed, err := c.service.ClosePoll(r.Context(), id, userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	setETag(w, closed)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.PollToResponse(closed, true))
+}
+
+// maxImportSize bounds the size of poll import files
+const maxImportSize = 5 << 20
+
+// ImportPolls handles POST /api/poll-imports
+func (c *PollController) ImportPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	dryRun := false
+	if query.Has("dry_run") {
+		var err error
+		if dryRun, err = strconv.ParseBool(query.Get("dry_run")); err != nil {
+			WriteError(w, r, service.NewFieldError("dry_run", "invalid_value", "dry_run must be true or false"))
+			return
+		}
+	}
+
+	var format importer.Format
+	var err error
+	if query.Has("format") {
+		format, err = importer.ParseFormat(query.Get("format"))
+	} else {
+		format, err = importer.FormatFromContentType(r.Header.Get("Content-Type"))
+	}
+	if err != nil {
+		WriteError(w, r, service.NewFieldError("format", "unsupported_format", "import files must be JSON, YAML or CSV"))
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxImportSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		WriteError(w, r, service.NewValidationError("file_too_large", fmt.Sprintf("import files must be at most %d bytes", tooLarge.Limit)))
+		return
+	}
+	if err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	definitions, err := importer.Parse(format, bytes.NewReader(body))
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	result, err := c.service.ImportPolls(r.Context(), userID, definitions, dryRun)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.ImportResultToResponse(result))
+}
+
+// ExportPoll handles GET /api/polls/:id/export. The export is streamed, so an
+// error after it has started can only be logged; the client sees a truncated file.
+func (c *PollController) ExportPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	format := export.FormatCSV
+	if r.URL.Query().Has("format") {
+		if format, err = export.ParseFormat(r.URL.Query().Get("format")); err != nil {
+			WriteError(w, r, service.NewFieldError("format", "invalid_value", "format must be one of csv, json, ndjson or xlsx"))
+			return
+		}
+	}
+
+	pollExport, err := c.service.ExportPoll(r.Context(), id, userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="poll-%s.%s"`, id, format))
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := pollExport.Encode(r.Context(), export.NewEncoder(format, w)); err != nil && r.Context().Err() == nil {
+		log.Printf("Failed to export poll %s: %v", id, err)
+	}
+}
+
+// setETag sets the poll's version as the entity tag of a write response,
+// which is what clients send back in If-Match
+func setETag(w http.ResponseWriter, p *ent.Poll) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(p.Version)))
+}
+
+// representationETag returns a weak entity tag of a response body. It is weak
+// because equal bodies may be encoded differently after an upgrade, and as
+// If-Match uses strong comparison it never passes for an edit.
+func representationETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// ifNoneMatch reports whether the If-None-Match header lists etag, using the
+// weak comparison the header calls for
+func ifNoneMatch(r *http.Request, etag string) bool {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return false
+	}
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	opaque := strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersions returns the poll versions listed in the If-Match header, or
+// nil when it is "*". Edits without the header are refused so clients cannot
+// overwrite changes they never saw. Weak tags never match, as If-Match uses
+// strong comparison, so a header listing no usable tag fails outright.
+func ifMatchVersions(r *http.Request) ([]int, error) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil, errIfMatchRequired
+	}
+	if strings.TrimSpace(header) == "*" {
+		return nil, nil
+	}
+
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		unquoted, err := strconv.Unquote(strings.TrimSpace(tag))
+		if err != nil {
+			continue
+		}
+		if version, err := strconv.Atoi(unquoted); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, service.ErrPollModified
+	}
+	return versions, nil
+}
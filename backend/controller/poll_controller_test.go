@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"poll-app/service"
+)
+
+func TestIfMatchVersions(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		versions []int
+		status   int
+	}{
+		{"missing", "", nil, http.StatusPreconditionRequired},
+		{"any version", "*", nil, 0},
+		{"one version", `"3"`, []int{3}, 0},
+		{"several versions", `"3", "4"`, []int{3, 4}, 0},
+		{"weak tag", `W/"3"`, nil, http.StatusPreconditionFailed},
+		{"unquoted", `3`, nil, http.StatusPreconditionFailed},
+		{"not a version", `"abc"`, nil, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/api/polls/x", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+
+			versions, err := ifMatchVersions(r)
+			if !slices.Equal(versions, tt.versions) {
+				t.Errorf("versions = %v, want %v", versions, tt.versions)
+			}
+			if tt.status == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var svcErr *service.Error
+			if !errors.As(err, &svcErr) {
+				t.Fatalf("err = %v, want a service error", err)
+			}
+			w := httptest.NewRecorder()
+			WriteError(w, r, err)
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	etag := representationETag([]byte(`{"id":"x"}`))
+	other := representationETag([]byte(`{"id":"y"}`))
+
+	tests := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{"missing", "", false},
+		{"any", "*", true},
+		{"same tag", etag, true},
+		{"same tag, strong", strings.TrimPrefix(etag, "W/"), true},
+		{"among others", other + ", " + etag, true},
+		{"other tag", other, false},
+		{"version", `"3"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/polls/x", nil)
+			if tt.header != "" {
+				r.Header.Set("If-None-Match", tt.header)
+			}
+			if got := ifNoneMatch(r, etag); got != tt.want {
+				t.Errorf("ifNoneMatch(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepresentationETagNeverMatchesIfMatch(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPut, "/api/polls/x", nil)
+	r.Header.Set("If-Match", representationETag([]byte(`{"version":3}`)))
+	if versions, err := ifMatchVersions(r); !errors.Is(err, service.ErrPollModified) {
+		t.Errorf("ifMatchVersions = %v, %v, want %v", versions, err, service.ErrPollModified)
+	}
+}
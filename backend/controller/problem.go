@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"poll-app/api"
+	"poll-app/auth"
+	"poll-app/service"
+)
+
+// problemContentType is the media type of error responses, as defined by RFC 7807
+const problemContentType = "application/problem+json"
+
+var (
+	errUnauthorized  = service.NewUnauthenticatedError("authorization_required", "Unauthorized")
+	errInvalidBody   = service.NewValidationError("invalid_body", "Invalid request body")
+	errInvalidPollID = service.NewFieldError("id", "invalid_id", "Invalid poll ID")
+	// errIfMatchRequired rejects edits that do not say which version of the poll they are based on
+	errIfMatchRequired = &service.Error{Kind: service.KindPreconditionRequired, Code: "if_match_required", Message: "If-Match header with the poll's ETag is required"}
+)
+
+// authErrorCodes gives the authentication failures of the auth middleware stable codes
+var authErrorCodes = map[error]string{
+	auth.ErrAuthorizationRequired:      "authorization_required",
+	auth.ErrInvalidAuthorizationHeader: "invalid_authorization_header",
+	auth.ErrInvalidToken:               "invalid_token",
+	auth.ErrTokenRevoked:               "token_revoked",
+}
+
+// statusByKind maps service error kinds to HTTP status codes
+var statusByKind = map[service.Kind]int{
+	service.KindInternal:             http.StatusInternalServerError,
+	service.KindValidation:           http.StatusBadRequest,
+	service.KindUnauthenticated:      http.StatusUnauthorized,
+	service.KindForbidden:            http.StatusForbidden,
+	service.KindNotFound:             http.StatusNotFound,
+	service.KindConflict:             http.StatusConflict,
+	service.KindPreconditionFailed:   http.StatusPreconditionFailed,
+	service.KindPreconditionRequired: http.StatusPreconditionRequired,
+	service.KindUnavailable:          http.StatusServiceUnavailable,
+	service.KindTooManyRequests:      http.StatusTooManyRequests,
+}
+
+// WriteError writes err as a problem details response. Service errors keep
+// their code and message; anything else is reported as an internal error
+// without exposing its details, and logged.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	for authErr, code := range authErrorCodes {
+		if errors.Is(err, authErr) {
+			err = service.NewUnauthenticatedError(code, authErr.Error())
+			break
+		}
+	}
+
+	e := service.AsError(err)
+	status, ok := statusByKind[e.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	if status >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	}
+
+	problem := api.Error{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Code:     e.Code,
+		Detail:   &e.Message,
+		Instance: &r.URL.Path,
+	}
+	if len(e.Fields) > 0 {
+		fields := make([]api.FieldError, 0, len(e.Fields))
+		for _, f := range e.Fields {
+			fields = append(fields, api.FieldError{Field: f.Field, Code: f.Code, Message: f.Message})
+		}
+		problem.Errors = &fields
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem)
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"poll-app/api"
+	"poll-app/auth"
+	"poll-app/service"
+)
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		code       string
+		fields     []string
+		hideDetail string
+	}{
+		{"field error", errInvalidPollID, http.StatusBadRequest, "invalid_id", []string{"id"}, ""},
+		{"not found", service.ErrPollNotFound, http.StatusNotFound, "poll_not_found", nil, ""},
+		{"wrapped service error", fmt.Errorf("voting: %w", service.ErrAlreadyVoted), http.StatusConflict, "already_voted", nil, ""},
+		{"precondition required", errIfMatchRequired, http.StatusPreconditionRequired, "if_match_required", nil, ""},
+		{"revoked token", auth.ErrTokenRevoked, http.StatusUnauthorized, "token_revoked", nil, ""},
+		{"wrapped auth error", fmt.Errorf("checking token: %w", auth.ErrInvalidToken), http.StatusUnauthorized, "invalid_token", nil, ""},
+		{"unavailable", service.NewUnavailableError(errors.New("redis down")), http.StatusServiceUnavailable, "service_unavailable", nil, "redis down"},
+		{"unknown error", errors.New("pq: relation does not exist"), http.StatusInternalServerError, "internal_error", nil, "pq:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/polls/x", nil)
+			w := httptest.NewRecorder()
+			WriteError(w, r, tt.err)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != problemContentType {
+				t.Errorf("Content-Type = %q, want %q", ct, problemContentType)
+			}
+			if tt.hideDetail != "" && strings.Contains(w.Body.String(), tt.hideDetail) {
+				t.Errorf("body %s exposes %q", w.Body, tt.hideDetail)
+			}
+
+			var problem api.Error
+			if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+			if problem.Status != tt.status || problem.Code != tt.code {
+				t.Errorf("problem status %d code %q, want %d %q", problem.Status, problem.Code, tt.status, tt.code)
+			}
+			if problem.Instance == nil || *problem.Instance != r.URL.Path {
+				t.Errorf("instance = %v, want %q", problem.Instance, r.URL.Path)
+			}
+
+			var fields []string
+			if problem.Errors != nil {
+				for _, f := range *problem.Errors {
+					fields = append(fields, f.Field)
+				}
+			}
+			if !slices.Equal(fields, tt.fields) {
+				t.Errorf("fields = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
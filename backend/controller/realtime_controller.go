@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"poll-app/auth"
+	"poll-app/realtime"
+	"poll-app/service"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+)
+
+var (
+	errNotWebSocket     = service.NewValidationError("websocket_required", "request is not a WebSocket handshake")
+	errOriginNotAllowed = service.NewForbiddenError("origin_not_allowed", "origin not allowed")
+)
+
+// upgrader completes WebSocket handshakes. Connect checks the origin itself,
+// so it can answer with a problem like other errors.
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(*http.Request) bool { return true },
+	Error: func(w http.ResponseWriter, r *http.Request, status int, reason error) {
+		if status >= http.StatusInternalServerError {
+			WriteError(w, r, reason)
+			return
+		}
+		WriteError(w, r, errNotWebSocket)
+	},
+}
+
+// RealtimeController handles WebSocket connections
+type RealtimeController struct {
+	hub            *realtime.Hub
+	jwtManager     *auth.JWTManager
+	allowedOrigins []string
+}
+
+// NewRealtimeController creates a new realtime controller. Browsers may only
+// connect from allowedOrigins, which may contain "*" to allow any origin.
+func NewRealtimeController(hub *realtime.Hub, jwtManager *auth.JWTManager, allowedOrigins []string) *RealtimeController {
+	return &RealtimeController{hub: hub, jwtManager: jwtManager, allowedOrigins: allowedOrigins}
+}
+
+// Connect handles GET /api/ws. The caller is authenticated before the
+// connection is upgraded, from the Authorization header or, since browsers
+// cannot set headers on WebSocket requests, the access_token query parameter.
+func (c *RealtimeController) Connect(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	token := r.URL.Query().Get("access_token")
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		bearer, ok := strings.CutPrefix(authHeader, "Bearer ")
+		if !ok {
+			WriteError(w, r, auth.ErrInvalidAuthorizationHeader)
+			return
+		}
+		token = bearer
+	}
+	if token == "" {
+		WriteError(w, r, auth.ErrAuthorizationRequired)
+		return
+	}
+
+	claims, err := c.jwtManager.ValidateToken(token)
+	if err != nil {
+		WriteError(w, r, auth.ErrInvalidToken)
+		return
+	}
+	if err := c.jwtManager.CheckRevoked(r.Context(), claims); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	// Browsers send the page's origin; other clients may send none
+	origin := r.Header.Get("Origin")
+	if origin != "" && !slices.Contains(c.allowedOrigins, "*") && !slices.Contains(c.allowedOrigins, origin) {
+		WriteError(w, r, errOriginNotAllowed)
+		return
+	}
+
+	session, err := c.hub.Admit(r.Context(), claims.UserID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	// The upgrader has already answered requests it cannot upgrade
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		session.Release()
+		return
+	}
+
+	session.Serve(conn)
+}
@@ -0,0 +1,294 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"poll-app/api"
+	"poll-app/auth"
+	"poll-app/converter"
+	"poll-app/ent"
+	"poll-app/events"
+	"poll-app/service"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	// heartbeatInterval keeps idle streams from being cut by proxies
+	heartbeatInterval = 15 * time.Second
+	// reconnectDelay is how long clients wait before reconnecting a dropped stream
+	reconnectDelay = 3 * time.Second
+)
+
+// StreamController handles live poll event streams
+type StreamController struct {
+	service service.PollService
+	broker  *events.Broker
+}
+
+// NewStreamController creates a new stream controller
+func NewStreamController(service service.PollService, broker *events.Broker) *StreamController {
+	return &StreamController{service: service, broker: broker}
+}
+
+// StreamPoll handles GET /api/polls/:id/stream. It sends the poll as a
+// Server-Sent Events stream: a snapshot first, then vote counts, edits and
+// status changes as they happen. Clients that reconnect with Last-Event-ID
+// receive the events they missed instead of a new snapshot, as long as
+// those are still retained.
+func (c *StreamController) StreamPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteError(w, r, errors.New("response writer does not support streaming"))
+		return
+	}
+
+	ctx := r.Context()
+	viewerID, _ := auth.GetUserIDFromContext(ctx)
+	p, err := c.service.GetPollByID(ctx, pollID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	// Subscribe before reading the current state so no change falls in between
+	sub := c.broker.Subscribe(pollID)
+	defer sub.Close()
+
+	s := &pollStream{
+		w:        w,
+		flusher:  flusher,
+		service:  c.service,
+		poll:     p,
+		viewerID: viewerID,
+	}
+	if s.resultsVisible, err = c.service.CanViewResults(ctx, p, viewerID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay.Milliseconds())
+
+	if err := c.start(ctx, s, r.Header.Get("Last-Event-ID")); err != nil {
+		s.fail(r, err)
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for !s.done {
+		// Polls open and close on schedule without a write to announce it
+		transition := time.NewTimer(time.Until(nextTransition(s.poll)))
+
+		select {
+		case <-ctx.Done():
+			s.done = true
+		case <-heartbeat.C:
+			err = s.comment("heartbeat")
+		case <-transition.C:
+			err = s.reload(ctx, "", events.TypeStatus)
+		case event, ok := <-sub.C:
+			if !ok {
+				// Dropped for falling behind; the client reconnects and catches up
+				s.done = true
+				break
+			}
+			err = s.handle(ctx, event)
+		}
+		transition.Stop()
+
+		if err != nil {
+			s.fail(r, err)
+			return
+		}
+	}
+}
+
+// start replays the events after lastID, or sends a snapshot of the poll if
+// they are not retained
+func (c *StreamController) start(ctx context.Context, s *pollStream, lastID string) error {
+	if lastID != "" {
+		missed, ok, err := c.broker.Since(ctx, s.poll.ID, lastID)
+		if err != nil {
+			return err
+		}
+		if ok {
+			s.lastID = lastID
+			for _, event := range missed {
+				if err := s.handle(ctx, event); err != nil || s.done {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	return s.sendPoll(ctx, "", events.TypePoll)
+}
+
+// nextTransition returns when the poll next opens or closes, or a time far in
+// the future if it never will
+func nextTransition(p *ent.Poll) time.Time {
+	now := time.Now()
+	if p.OpensAt != nil && p.OpensAt.After(now) {
+		return *p.OpensAt
+	}
+	if p.ClosesAt != nil && p.ClosesAt.After(now) {
+		return *p.ClosesAt
+	}
+	return now.Add(24 * time.Hour)
+}
+
+// pollStream is the state of one client's event stream
+type pollStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	service service.PollService
+
+	poll           *ent.Poll
+	viewerID       uuid.UUID
+	resultsVisible bool
+	// lastID is the ID of the last event sent, so replayed events are not sent twice
+	lastID string
+	done   bool
+}
+
+// handle forwards a poll event to the client, filtered by what it may see
+func (s *pollStream) handle(ctx context.Context, event events.Event) error {
+	if event.ID != "" && s.lastID != "" && events.CompareIDs(event.ID, s.lastID) <= 0 {
+		return nil
+	}
+
+	switch event.Type {
+	case events.TypeVoted:
+		// Only the viewer's own vote changes whether they may see the results
+		var voted events.Voted
+		if err := json.Unmarshal(event.Data, &voted); err != nil {
+			return fmt.Errorf("failed to decode voted event: %w", err)
+		}
+		if s.viewerID == uuid.Nil || voted.UserID != s.viewerID {
+			break
+		}
+		visible, err := s.service.CanViewResults(ctx, s.poll, s.viewerID)
+		if err != nil {
+			return err
+		}
+		s.resultsVisible = visible
+	case events.TypeVotes:
+		if !s.resultsVisible {
+			break
+		}
+
+		var counts events.Counts
+		if err := json.Unmarshal(event.Data, &counts); err != nil {
+			return fmt.Errorf("failed to decode vote counts: %w", err)
+		}
+		pollID := openapi_types.UUID(s.poll.ID)
+		if err := s.send(event.ID, events.TypeVotes, api.VoteCountsResponse{
+			PollId: &pollID,
+			Counts: &counts.Counts,
+			Voters: &counts.Voters,
+		}); err != nil {
+			return err
+		}
+	case events.TypePoll, events.TypeStatus:
+		if err := s.reload(ctx, event.ID, event.Type); err != nil {
+			return err
+		}
+	case events.TypeDeleted:
+		if err := s.send(event.ID, events.TypeDeleted, struct{}{}); err != nil {
+			return err
+		}
+		s.done = true
+	}
+
+	if event.ID != "" {
+		s.lastID = event.ID
+	}
+	return nil
+}
+
+// reload reads the poll again and sends it to the client as the given event
+func (s *pollStream) reload(ctx context.Context, id, eventType string) error {
+	p, err := s.service.GetPollByID(ctx, s.poll.ID)
+	if errors.Is(err, service.ErrPollNotFound) {
+		s.done = true
+		return s.send(id, events.TypeDeleted, struct{}{})
+	}
+	if err != nil {
+		return err
+	}
+
+	s.poll = p
+	if s.resultsVisible, err = s.service.CanViewResults(ctx, p, s.viewerID); err != nil {
+		return err
+	}
+	return s.sendPoll(ctx, id, eventType)
+}
+
+// sendPoll sends the poll as the viewer sees it, like GET /api/polls/:id
+func (s *pollStream) sendPoll(ctx context.Context, id, eventType string) error {
+	response := converter.PollToResponse(s.poll, s.resultsVisible)
+	if s.viewerID != uuid.Nil {
+		hasVoted, err := s.service.HasVoted(ctx, s.poll, s.viewerID)
+		if err != nil {
+			return err
+		}
+		response.HasVoted = &hasVoted
+	}
+	return s.send(id, eventType, response)
+}
+
+// send writes one event to the client
+func (s *pollStream) send(id, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		fmt.Fprintf(s.w, "id: %s\n", id)
+	}
+	if _, err := fmt.Fprintf(s.w, "event: %s\ndata: %s\n\n", eventType, payload); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// comment writes a comment line, which clients ignore
+func (s *pollStream) comment(text string) error {
+	if _, err := fmt.Fprintf(s.w, ": %s\n\n", text); err != nil {
+		return err
+	}
+	s.flusher.Flush()
+	return nil
+}
+
+// fail ends the stream after an error. The response has already started, so
+// the error is only logged; the client reconnects.
+func (s *pollStream) fail(r *http.Request, err error) {
+	if r.Context().Err() != nil {
+		return
+	}
+	log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+}
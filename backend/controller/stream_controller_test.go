@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/events"
+	"poll-app/service"
+
+	"github.com/google/uuid"
+)
+
+// visibilityService answers CanViewResults with whether the viewer has voted
+// and counts how often it is asked
+type visibilityService struct {
+	service.PollService
+	voted  map[uuid.UUID]bool
+	checks int
+}
+
+func (s *visibilityService) CanViewResults(_ context.Context, p *ent.Poll, viewerID uuid.UUID) (bool, error) {
+	s.checks++
+	return service.ResultsVisible(p, viewerID, s.voted[viewerID]), nil
+}
+
+func TestPollStreamResultsVisibility(t *testing.T) {
+	viewer, other := uuid.New(), uuid.New()
+
+	event := func(eventType string, data any) events.Event {
+		b, err := json.Marshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return events.Event{Type: eventType, Data: b}
+	}
+	votes := event(events.TypeVotes, events.Counts{Counts: map[string]int{}, Voters: 1})
+	voted := func(userID uuid.UUID) events.Event {
+		return event(events.TypeVoted, events.Voted{UserID: userID})
+	}
+
+	tests := []struct {
+		name       string
+		viewerID   uuid.UUID
+		visible    bool
+		voted      bool
+		events     []events.Event
+		wantChecks int
+		wantCounts int
+	}{
+		{"others vote", viewer, false, false, []events.Event{voted(other), votes, voted(other), votes}, 0, 0},
+		{"viewer votes", viewer, false, true, []events.Event{voted(other), votes, voted(viewer), votes, votes}, 1, 2},
+		{"viewer retracts", viewer, true, false, []events.Event{votes, voted(viewer), votes}, 1, 1},
+		{"anonymous viewer", uuid.Nil, false, false, []events.Event{voted(other), votes}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &visibilityService{voted: map[uuid.UUID]bool{tt.viewerID: tt.voted}}
+			w := httptest.NewRecorder()
+			s := &pollStream{
+				w:              w,
+				flusher:        w,
+				service:        svc,
+				poll:           &ent.Poll{ID: uuid.New(), OwnerID: uuid.New(), ResultsVisibility: poll.ResultsVisibilityAfterVote},
+				viewerID:       tt.viewerID,
+				resultsVisible: tt.visible,
+			}
+
+			for _, e := range tt.events {
+				if err := s.handle(context.Background(), e); err != nil {
+					t.Fatalf("handle %s: %v", e.Type, err)
+				}
+			}
+
+			if svc.checks != tt.wantChecks {
+				t.Errorf("CanViewResults called %d times, want %d", svc.checks, tt.wantChecks)
+			}
+			if got := strings.Count(w.Body.String(), "event: votes\n"); got != tt.wantCounts {
+				t.Errorf("sent %d vote counts, want %d", got, tt.wantCounts)
+			}
+		})
+	}
+}
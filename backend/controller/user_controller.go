@@ -2,27 +2,60 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
 	"net/http"
+	"strconv"
+	"unicode/utf8"
 
 	"poll-app/api"
 	"poll-app/auth"
+	"poll-app/converter"
+	"poll-app/ent"
+	"poll-app/ent/securityevent"
 	"poll-app/service"
+	"poll-app/storage"
 
 	"github.com/julienschmidt/httprouter"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+var (
+	// errInvalidRefreshToken is returned for refresh tokens that are invalid, expired or revoked
+	errInvalidRefreshToken = service.NewUnauthenticatedError("invalid_refresh_token", "Invalid refresh token")
+	// errRefreshTokenReused is returned for refresh tokens presented again after they were used;
+	// their session has been ended and the user must sign in again
+	errRefreshTokenReused = service.NewUnauthenticatedError("refresh_token_reused", "Refresh token was already used; sign in again")
+	// errSessionNotFound is returned for sessions that do not exist or have ended
+	errSessionNotFound = service.NewNotFoundError("session_not_found", "Session not found")
+	// errInvalidMFAToken is returned for MFA challenge tokens that are invalid, expired or used up;
+	// the user must sign in with their password again
+	errInvalidMFAToken = service.NewUnauthenticatedError("invalid_mfa_token", "MFA token is invalid or has expired; sign in again")
+	// errTooManyMFAFailures is returned while a user is locked out after entering too many wrong codes
+	errTooManyMFAFailures = service.NewTooManyRequestsError("too_many_mfa_failures", "Too many wrong authentication codes; try again later")
+)
+
 // UserController handles user-related HTTP requests
 type UserController struct {
-	service    service.UserService
-	jwtManager *auth.JWTManager
+	service       service.UserService
+	security      service.SecurityService
+	account       service.AccountService
+	mfa           service.MFAService
+	jwtManager    *auth.JWTManager
+	mfaChallenges *auth.MFAChallenges
 }
 
 // NewUserController creates a new user controller
-func NewUserController(service service.UserService, jwtManager *auth.JWTManager) *UserController {
+func NewUserController(service service.UserService, security service.SecurityService, account service.AccountService, mfa service.MFAService, jwtManager *auth.JWTManager, mfaChallenges *auth.MFAChallenges) *UserController {
 	return &UserController{
-		service:    service,
-		jwtManager: jwtManager,
+		service:       service,
+		security:      security,
+		account:       account,
+		mfa:           mfa,
+		jwtManager:    jwtManager,
+		mfaChallenges: mfaChallenges,
 	}
 }
 
@@ -30,139 +63,469 @@ func NewUserController(service service.UserService, jwtManager *auth.JWTManager)
 func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	var req api.CreateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteError(w, r, errInvalidBody)
 		return
 	}
 
-	user, err := c.service.CreateUser(r.Context(), string(req.Email), req.Username, req.Password)
+	client, err := clientInfo(r, "device_name", req.DeviceName)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, r, err)
 		return
 	}
 
-	// Generate tokens
-	accessToken, err := c.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username)
+	user, err := c.service.CreateUser(r.Context(), string(req.Email), req.Username, req.Password)
 	if err != nil {
-		http.Error(w, "Failed to generate access token", http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
-	refreshToken, _, err := c.jwtManager.GenerateRefreshToken(r.Context(), user.ID)
+	response, err := c.startSession(r, user, client)
 	if err != nil {
-		http.Error(w, "Failed to generate refresh token", http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
-	userID := openapi_types.UUID(user.ID)
-	email := openapi_types.Email(user.Email)
-	response := api.AuthResponse{
-		AccessToken:  &accessToken,
-		RefreshToken: &refreshToken,
-		UserId:       &userID,
-		Email:        &email,
-		Username:     &user.Username,
-	}
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
 }
 
-// Login handles POST /api/users/login
+// Login handles POST /api/users/login. Users with two-factor authentication
+// get an MFA challenge instead of tokens, completed with CompleteMFALogin.
 func (c *UserController) Login(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	var req api.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	client, err := clientInfo(r, "device_name", req.DeviceName)
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
 	user, err := c.service.Login(r.Context(), string(req.Email), req.Password)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		WriteError(w, r, err)
+		return
+	}
+
+	if service.MFAEnabled(user) {
+		token, expiresAt, err := c.mfaChallenges.Create(r.Context(), user.ID, client.Name)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(api.MFAChallengeResponse{
+			MfaRequired: true,
+			MfaToken:    token,
+			ExpiresAt:   expiresAt,
+		})
+		return
+	}
+
+	response, err := c.startSession(r, user, client)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// CompleteMFALogin handles POST /api/users/login/mfa. It completes the MFA
+// challenge of a sign-in with a TOTP code or a recovery code.
+func (c *UserController) CompleteMFALogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req api.MFALoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	challenge, err := c.mfaChallenges.Get(r.Context(), req.MfaToken)
+	if errors.Is(err, auth.ErrInvalidMFAChallenge) {
+		WriteError(w, r, errInvalidMFAToken)
+		return
+	}
+	if errors.Is(err, auth.ErrTooManyMFAFailures) {
+		WriteError(w, r, errTooManyMFAFailures)
+		return
+	}
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	// Generate tokens
-	accessToken, err := c.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username)
+	user, err := c.mfa.VerifyMFA(r.Context(), challenge.UserID, req.Code)
+	if errors.Is(err, service.ErrInvalidMFACode) {
+		if err := c.mfaChallenges.Fail(r.Context(), req.MfaToken, challenge.UserID); err != nil {
+			log.Printf("Failed to record wrong MFA code of user %s: %v", challenge.UserID, err)
+		}
+		WriteError(w, r, err)
+		return
+	}
 	if err != nil {
-		http.Error(w, "Failed to generate access token", http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
-	refreshToken, _, err := c.jwtManager.GenerateRefreshToken(r.Context(), user.ID)
+	// Of concurrent requests with correct codes only one completes the challenge
+	err = c.mfaChallenges.Complete(r.Context(), req.MfaToken, user.ID)
+	if errors.Is(err, auth.ErrInvalidMFAChallenge) {
+		WriteError(w, r, errInvalidMFAToken)
+		return
+	}
 	if err != nil {
-		http.Error(w, "Failed to generate refresh token", http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
-	userID := openapi_types.UUID(user.ID)
-	email := openapi_types.Email(user.Email)
-	response := api.AuthResponse{
-		AccessToken:  &accessToken,
-		RefreshToken: &refreshToken,
-		UserId:       &userID,
-		Email:        &email,
-		Username:     &user.Username,
+	client, _ := clientInfo(r, "", nil)
+	client.Name = challenge.DeviceName
+	response, err := c.startSession(r, user, client)
+	if err != nil {
+		WriteError(w, r, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// startSession signs the user in on a new session and returns its first tokens
+func (c *UserController) startSession(r *http.Request, user *ent.User, client auth.ClientInfo) (api.AuthResponse, error) {
+	refreshToken, sessionID, err := c.jwtManager.GenerateRefreshToken(r.Context(), user.ID, client)
+	if err != nil {
+		return api.AuthResponse{}, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	accessToken, err := c.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, sessionID)
+	if err != nil {
+		return api.AuthResponse{}, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	return authResponse(user, accessToken, refreshToken), nil
+}
+
+// authResponse builds the response carrying a user's new tokens
+func authResponse(user *ent.User, accessToken, refreshToken string) api.AuthResponse {
+	userID := openapi_types.UUID(user.ID)
+	email := openapi_types.Email(user.Email)
+	emailVerified := user.EmailVerifiedAt != nil
+	return api.AuthResponse{
+		AccessToken:   &accessToken,
+		RefreshToken:  &refreshToken,
+		UserId:        &userID,
+		Email:         &email,
+		EmailVerified: &emailVerified,
+		Username:      &user.Username,
+	}
+}
+
+// clientInfo describes the client making the request. name is the device
+// name from the given request field, if any.
+func clientInfo(r *http.Request, field string, name *string) (auth.ClientInfo, error) {
+	client := auth.ClientInfo{UserAgent: r.UserAgent(), IP: r.RemoteAddr}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		client.IP = host
+	}
+
+	if name != nil {
+		if utf8.RuneCountInString(*name) > auth.MaxSessionNameLength {
+			return client, service.NewFieldError(field, "invalid_device_name", fmt.Sprintf("Device name cannot exceed %d characters", auth.MaxSessionNameLength))
+		}
+		client.Name = *name
+	}
+	return client, nil
+}
+
 // RefreshToken handles POST /api/users/refresh
 func (c *UserController) RefreshToken(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	var req api.RefreshTokenRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteError(w, r, errInvalidBody)
 		return
 	}
 
-	// Rotate refresh token (validates, revokes old, and generates new)
-	refreshToken, _, userUUID, err := c.jwtManager.RotateRefreshToken(r.Context(), req.RefreshToken)
+	client, _ := clientInfo(r, "", nil)
+
+	// Rotate refresh token (validates, replaces it within its session, and records the use)
+	refreshToken, sessionID, userUUID, err := c.jwtManager.RotateRefreshToken(r.Context(), req.RefreshToken, client)
+	var reused *auth.RefreshTokenReusedError
+	if errors.As(err, &reused) {
+		// The session is already revoked; recording the event only informs the user
+		if _, err := c.security.RecordSecurityEvent(r.Context(), storage.SecurityEventInput{
+			UserID:    reused.UserID,
+			Type:      securityevent.TypeRefreshTokenReused,
+			SessionID: reused.SessionID,
+			IPAddress: client.IP,
+			UserAgent: client.UserAgent,
+		}); err != nil {
+			log.Printf("Failed to record refresh token reuse of user %s: %v", reused.UserID, err)
+		}
+		WriteError(w, r, errRefreshTokenReused)
+		return
+	}
 	if err != nil {
-		http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
+		WriteError(w, r, errInvalidRefreshToken)
 		return
 	}
 
 	// Get user to get email and username
 	user, err := c.service.GetUserByID(r.Context(), userUUID)
 	if err != nil {
-		http.Error(w, "User not found", http.StatusNotFound)
+		WriteError(w, r, err)
 		return
 	}
 
 	// Generate new access token
-	accessToken, err := c.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username)
+	accessToken, err := c.jwtManager.GenerateAccessToken(user.ID, user.Email, user.Username, sessionID)
 	if err != nil {
-		http.Error(w, "Failed to generate access token", http.StatusInternalServerError)
+		WriteError(w, r, fmt.Errorf("failed to generate access token: %w", err))
 		return
 	}
 
-	userID := openapi_types.UUID(user.ID)
-	email := openapi_types.Email(user.Email)
-	response := api.AuthResponse{
-		AccessToken:  &accessToken,
-		RefreshToken: &refreshToken,
-		UserId:       &userID,
-		Email:        &email,
-		Username:     &user.Username,
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(authResponse(user, accessToken, refreshToken))
+}
+
+// Logout handles POST /api/users/logout. It ends the session of the access
+// token, revoking its refresh token and every access token issued to it;
+// tokens issued before sessions were tracked end all sessions and revoke all
+// access tokens.
+func (c *UserController) Logout(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	var err error
+	if sessionID, ok := auth.GetSessionIDFromContext(r.Context()); ok {
+		err = c.jwtManager.RevokeRefreshToken(r.Context(), userID, sessionID)
+	} else {
+		if err = c.jwtManager.RevokeAllUserRefreshTokens(r.Context(), userID); err == nil {
+			err = c.jwtManager.RevokeUserAccessTokens(r.Context(), userID)
+		}
+	}
+	if err != nil {
+		WriteError(w, r, fmt.Errorf("failed to logout: %w", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSessions handles GET /api/users/me/sessions
+func (c *UserController) ListSessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	sessions, err := c.jwtManager.ListSessions(r.Context(), userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	currentID, _ := auth.GetSessionIDFromContext(r.Context())
+	responses := make([]api.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, converter.SessionToResponse(session, session.ID == currentID))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(response)
+	json.NewEncoder(w).Encode(api.SessionListResponse{Sessions: &responses})
 }
 
-// Logout handles POST /api/users/logout
-func (c *UserController) Logout(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+// UpdateSession handles PATCH /api/users/me/sessions/:id
+func (c *UserController) UpdateSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	var req api.UpdateSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+	client, err := clientInfo(r, "name", &req.Name)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	session, err := c.jwtManager.RenameSession(r.Context(), userID, ps.ByName("id"), client.Name)
+	if errors.Is(err, auth.ErrSessionNotFound) {
+		err = errSessionNotFound
+	}
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	currentID, _ := auth.GetSessionIDFromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.SessionToResponse(session, session.ID == currentID))
+}
+
+// DeleteSession handles DELETE /api/users/me/sessions/:id
+func (c *UserController) DeleteSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	sessionID := ps.ByName("id")
+	if _, err := c.jwtManager.GetSession(r.Context(), userID, sessionID); err != nil {
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			err = errSessionNotFound
+		}
+		WriteError(w, r, err)
+		return
+	}
+
+	// The session's access tokens end with it, on this device or another
+	if err := c.jwtManager.RevokeRefreshToken(r.Context(), userID, sessionID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAllSessions handles DELETE /api/users/me/sessions. Besides the
+// sessions, it revokes every access token issued to the user so far.
+func (c *UserController) DeleteAllSessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	userID, ok := auth.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		WriteError(w, r, errUnauthorized)
 		return
 	}
 
-	// Revoke all refresh tokens for this user
 	if err := c.jwtManager.RevokeAllUserRefreshTokens(r.Context(), userID); err != nil {
-		http.Error(w, "Failed to logout", http.StatusInternalServerError)
+		WriteError(w, r, err)
+		return
+	}
+	if err := c.jwtManager.RevokeUserAccessTokens(r.Context(), userID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSecurityEvents handles GET /api/users/me/security-events
+func (c *UserController) ListSecurityEvents(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	limit := 0
+	if query.Has("limit") {
+		var err error
+		if limit, err = strconv.Atoi(query.Get("limit")); err != nil || limit == 0 {
+			WriteError(w, r, service.NewFieldError("limit", "invalid_value", "limit must be a positive integer"))
+			return
+		}
+	}
+
+	events, err := c.security.ListSecurityEvents(r.Context(), userID, limit)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	responses := make([]api.SecurityEventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, converter.SecurityEventToResponse(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.SecurityEventListResponse{Events: &responses})
+}
+
+// RequestPasswordReset handles POST /api/users/password-reset
+func (c *UserController) RequestPasswordReset(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req api.PasswordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	if err := c.account.RequestPasswordReset(r.Context(), string(req.Email)); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword handles POST /api/users/password-reset/confirm. Whoever knew
+// the old password is signed out: every session and access token is revoked.
+func (c *UserController) ResetPassword(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req api.PasswordResetConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	user, err := c.account.ResetPassword(r.Context(), req.Token, req.Password)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	if err := c.jwtManager.RevokeAllUserRefreshTokens(r.Context(), user.ID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+	if err := c.jwtManager.RevokeUserAccessTokens(r.Context(), user.ID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestEmailVerification handles POST /api/users/me/email-verification
+func (c *UserController) RequestEmailVerification(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	if err := c.account.RequestEmailVerification(r.Context(), userID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// VerifyEmail handles POST /api/users/email-verification/confirm
+func (c *UserController) VerifyEmail(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req api.EmailVerificationConfirmRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	if _, err := c.account.VerifyEmail(r.Context(), req.Token); err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
@@ -3,6 +3,7 @@ package controller
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"poll-app/api"
 	"poll-app/auth"
@@ -28,25 +29,35 @@ func NewVoteController(service service.VoteService) *VoteController {
 func (c *VoteController) VoteOnPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	userID, ok := auth.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		WriteError(w, r, errUnauthorized)
 		return
 	}
 
 	pollID, err := uuid.Parse(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		WriteError(w, r, errInvalidPollID)
 		return
 	}
 
 	var req api.VoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		WriteError(w, r, errInvalidBody)
 		return
 	}
 
-	vote, err := c.service.VoteOnPoll(r.Context(), userID, pollID, req.Option)
+	// Ranked ballots arrive as choices, single-choice ballots as option_id
+	var choices []uuid.UUID
+	if req.Choices != nil {
+		for _, choice := range *req.Choices {
+			choices = append(choices, uuid.UUID(choice))
+		}
+	} else if req.OptionId != nil {
+		choices = []uuid.UUID{uuid.UUID(*req.OptionId)}
+	}
+
+	vote, err := c.service.VoteOnPoll(r.Context(), userID, pollID, choices)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -59,56 +70,88 @@ func (c *VoteController) VoteOnPoll(w http.ResponseWriter, r *http.Request, ps h
 func (c *VoteController) GetVoteCounts(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	pollID, err := uuid.Parse(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		WriteError(w, r, errInvalidPollID)
 		return
 	}
 
-	counts, err := c.service.GetVoteCounts(r.Context(), pollID)
+	viewerID, _ := auth.GetUserIDFromContext(r.Context())
+	counts, err := c.service.GetVoteCounts(r.Context(), pollID, viewerID)
 	if err != nil {
-		if err.Error() == "poll not found" {
-			http.Error(w, "Poll not found", http.StatusNotFound)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
 	pollIDUUID := openapi_types.UUID(pollID)
 	response := api.VoteCountsResponse{
 		PollId: &pollIDUUID,
-		Counts: &counts,
+		Counts: &counts.Counts,
+		Voters: &counts.Voters,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
-// GetVotersByOption handles GET /api/polls/:id/votes/:option
-func (c *VoteController) GetVotersByOption(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+// GetRankedResults handles GET /api/polls/:id/results
+func (c *VoteController) GetRankedResults(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	pollID, err := uuid.Parse(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		WriteError(w, r, errInvalidPollID)
+		return
+	}
+
+	viewerID, _ := auth.GetUserIDFromContext(r.Context())
+	results, err := c.service.GetRankedResults(r.Context(), pollID, viewerID)
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	option := ps.ByName("option")
-	if option == "" {
-		http.Error(w, "Option is required", http.StatusBadRequest)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.RankedResultToResponse(pollID, results))
+}
+
+// GetVotersByOption handles GET /api/polls/:id/votes/:option_id
+func (c *VoteController) GetVotersByOption(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	pollID, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidPollID)
 		return
 	}
 
-	voters, err := c.service.GetVotersByOption(r.Context(), pollID, option)
+	optionID, err := uuid.Parse(ps.ByName("option_id"))
 	if err != nil {
-		if err.Error() == "poll not found" || err.Error() == "option not found" {
-			http.Error(w, err.Error(), http.StatusNotFound)
+		WriteError(w, r, service.NewFieldError("option_id", "invalid_id", "Invalid option ID"))
+		return
+	}
+
+	var after *uuid.UUID
+	query := r.URL.Query()
+	if query.Has("cursor") {
+		cursor, err := uuid.Parse(query.Get("cursor"))
+		if err != nil {
+			WriteError(w, r, service.NewFieldError("cursor", "invalid_cursor", "cursor is invalid"))
+			return
+		}
+		after = &cursor
+	}
+	limit := 0
+	if query.Has("limit") {
+		if limit, err = strconv.Atoi(query.Get("limit")); err != nil || limit == 0 {
+			WriteError(w, r, service.NewFieldError("limit", "invalid_value", "limit must be a positive integer"))
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+
+	viewerID, _ := auth.GetUserIDFromContext(r.Context())
+	voters, err := c.service.GetVotersByOption(r.Context(), pollID, optionID, viewerID, after, limit)
+	if err != nil {
+		WriteError(w, r, err)
 		return
 	}
 
-	userInfos := make([]api.UserInfo, 0, len(voters))
-	for _, voter := range voters {
+	userInfos := make([]api.UserInfo, 0, len(voters.Users))
+	for _, voter := range voters.Users {
 		userID := openapi_types.UUID(voter.ID)
 		email := openapi_types.Email(voter.Email)
 		username := voter.Username
@@ -119,11 +162,19 @@ func (c *VoteController) GetVotersByOption(w http.ResponseWriter, r *http.Reques
 		})
 	}
 
+	var nextCursor *string
+	if voters.Next != nil {
+		cursor := voters.Next.String()
+		nextCursor = &cursor
+	}
+
 	pollIDUUID := openapi_types.UUID(pollID)
+	optionIDUUID := openapi_types.UUID(optionID)
 	response := api.VotersResponse{
-		PollId: &pollIDUUID,
-		Option: &option,
-		Voters: &userInfos,
+		PollId:     &pollIDUUID,
+		OptionId:   &optionIDUUID,
+		Voters:     &userInfos,
+		NextCursor: nextCursor,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -134,26 +185,18 @@ func (c *VoteController) GetVotersByOption(w http.ResponseWriter, r *http.Reques
 func (c *VoteController) DeleteVote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	userID, ok := auth.GetUserIDFromContext(r.Context())
 	if !ok {
-		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		WriteError(w, r, errUnauthorized)
 		return
 	}
 
 	pollID, err := uuid.Parse(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		WriteError(w, r, errInvalidPollID)
 		return
 	}
 
 	if err := c.service.DeleteVote(r.Context(), userID, pollID); err != nil {
-		if err.Error() == "poll not found" || err.Error() == "vote not found" {
-			http.Error(w, err.Error(), http.StatusNotFound)
-			return
-		}
-		if err.Error() == "unauthorized: can only delete your own vote" {
-			http.Error(w, err.Error(), http.StatusForbidden)
-			return
-		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,253 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"poll-app/api"
+	"poll-app/auth"
+	"poll-app/converter"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/service"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+	"github.com/julienschmidt/httprouter"
+)
+
+var errInvalidWebhookEndpointID = service.NewFieldError("id", "invalid_id", "Invalid webhook endpoint ID")
+
+// WebhookController handles webhook endpoint HTTP requests
+type WebhookController struct {
+	service service.WebhookService
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(service service.WebhookService) *WebhookController {
+	return &WebhookController{service: service}
+}
+
+// CreateWebhookEndpoint handles POST /api/webhooks
+func (c *WebhookController) CreateWebhookEndpoint(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	var req api.CreateWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	input := storage.WebhookEndpointInput{
+		URL:    req.Url,
+		Events: converter.WebhookEventsFromRequest(req.Events),
+	}
+	if req.Description != nil {
+		input.Description = *req.Description
+	}
+
+	endpoint, err := c.service.CreateWebhookEndpoint(r.Context(), userID, input)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(converter.WebhookEndpointToResponse(endpoint, true))
+}
+
+// ListWebhookEndpoints handles GET /api/webhooks
+func (c *WebhookController) ListWebhookEndpoints(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	endpoints, err := c.service.ListWebhookEndpoints(r.Context(), userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	responses := make([]api.WebhookEndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		responses = append(responses, converter.WebhookEndpointToResponse(endpoint, false))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.WebhookEndpointListResponse{Endpoints: &responses})
+}
+
+// GetWebhookEndpoint handles GET /api/webhooks/:id
+func (c *WebhookController) GetWebhookEndpoint(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidWebhookEndpointID)
+		return
+	}
+
+	endpoint, err := c.service.GetWebhookEndpoint(r.Context(), id, userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.WebhookEndpointToResponse(endpoint, false))
+}
+
+// UpdateWebhookEndpoint handles PATCH /api/webhooks/:id
+func (c *WebhookController) UpdateWebhookEndpoint(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidWebhookEndpointID)
+		return
+	}
+
+	var req api.UpdateWebhookEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, r, errInvalidBody)
+		return
+	}
+
+	endpoint, err := c.service.UpdateWebhookEndpoint(r.Context(), id, userID, converter.WebhookEndpointUpdateFromRequest(req))
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(converter.WebhookEndpointToResponse(endpoint, false))
+}
+
+// DeleteWebhookEndpoint handles DELETE /api/webhooks/:id
+func (c *WebhookController) DeleteWebhookEndpoint(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidWebhookEndpointID)
+		return
+	}
+
+	if err := c.service.DeleteWebhookEndpoint(r.Context(), id, userID); err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries handles GET /api/webhooks/:id/deliveries
+func (c *WebhookController) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidWebhookEndpointID)
+		return
+	}
+
+	query := r.URL.Query()
+	var status *webhookdelivery.Status
+	if query.Has("status") {
+		s := webhookdelivery.Status(query.Get("status"))
+		status = &s
+	}
+	var after *uuid.UUID
+	if query.Has("cursor") {
+		cursor, err := uuid.Parse(query.Get("cursor"))
+		if err != nil {
+			WriteError(w, r, service.NewFieldError("cursor", "invalid_cursor", "cursor is invalid"))
+			return
+		}
+		after = &cursor
+	}
+	limit := 0
+	if query.Has("limit") {
+		if limit, err = strconv.Atoi(query.Get("limit")); err != nil || limit == 0 {
+			WriteError(w, r, service.NewFieldError("limit", "invalid_value", "limit must be a positive integer"))
+			return
+		}
+	}
+
+	page, err := c.service.ListWebhookDeliveries(r.Context(), id, userID, status, after, limit)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	deliveries := make([]api.WebhookDeliveryResponse, 0, len(page.Deliveries))
+	for _, delivery := range page.Deliveries {
+		deliveries = append(deliveries, converter.WebhookDeliveryToResponse(delivery))
+	}
+
+	var nextCursor *string
+	if page.Next != nil {
+		cursor := page.Next.String()
+		nextCursor = &cursor
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(api.WebhookDeliveryListResponse{
+		Deliveries: &deliveries,
+		NextCursor: nextCursor,
+	})
+}
+
+// RedeliverWebhook handles POST /api/webhooks/:id/deliveries/:delivery_id/redeliver
+func (c *WebhookController) RedeliverWebhook(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	userID, ok := auth.GetUserIDFromContext(r.Context())
+	if !ok {
+		WriteError(w, r, errUnauthorized)
+		return
+	}
+
+	id, err := uuid.Parse(ps.ByName("id"))
+	if err != nil {
+		WriteError(w, r, errInvalidWebhookEndpointID)
+		return
+	}
+
+	deliveryID, err := uuid.Parse(ps.ByName("delivery_id"))
+	if err != nil {
+		WriteError(w, r, service.NewFieldError("delivery_id", "invalid_id", "Invalid delivery ID"))
+		return
+	}
+
+	delivery, err := c.service.RedeliverWebhook(r.Context(), id, deliveryID, userID)
+	if err != nil {
+		WriteError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(converter.WebhookDeliveryToResponse(delivery))
+}
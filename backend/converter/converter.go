@@ -1,61 +1,352 @@
 package converter
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
 	"poll-app/api"
+	"poll-app/auth"
 	"poll-app/ent"
+	entpoll "poll-app/ent/poll"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/service"
+	"poll-app/storage"
 
+	"github.com/google/uuid"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-// PollToResponse converts an ent.Poll to api.PollResponse
-func PollToResponse(poll *ent.Poll) api.PollResponse {
+// PollToResponse converts an ent.Poll to api.PollResponse. Counts are only
+// included when resultsVisible is set.
+func PollToResponse(poll *ent.Poll, resultsVisible bool) api.PollResponse {
 	id := openapi_types.UUID(poll.ID)
 	ownerID := openapi_types.UUID(poll.OwnerID)
 	createdAt := poll.CreatedAt
 	updatedAt := poll.UpdatedAt
 	title := poll.Title
 	description := poll.Description
-	options := poll.Options
+	options := make([]api.PollOption, 0, len(poll.Edges.Options))
+	for _, opt := range poll.Edges.Options {
+		options = append(options, OptionToResponse(opt))
+	}
+	pollType := api.PollType(poll.Type)
+	anonymous := poll.Anonymous
+	resultsVisibility := api.ResultsVisibility(poll.ResultsVisibility)
+	requireVerifiedEmail := poll.RequireVerifiedEmail
+	minChoices := poll.MinChoices
+	status := api.PollStatus(service.PollStatus(poll, time.Now()))
+	version := poll.Version
 
 	response := api.PollResponse{
-		Id:          &id,
-		Title:       &title,
-		Description: &description,
-		Options:     &options,
-		OwnerId:     &ownerID,
-		CreatedAt:   &createdAt,
-		UpdatedAt:   &updatedAt,
+		Id:                   &id,
+		Title:                &title,
+		Description:          &description,
+		Options:              &options,
+		Type:                 &pollType,
+		Anonymous:            &anonymous,
+		ResultsVisibility:    &resultsVisibility,
+		ResultsVisible:       &resultsVisible,
+		RequireVerifiedEmail: &requireVerifiedEmail,
+		MinChoices:           &minChoices,
+		MaxChoices:           poll.MaxChoices,
+		Status:               &status,
+		OpensAt:              poll.OpensAt,
+		ClosesAt:             poll.ClosesAt,
+		OwnerId:              &ownerID,
+		ExternalId:           poll.ExternalID,
+		CreatedAt:            &createdAt,
+		UpdatedAt:            &updatedAt,
+		Version:              &version,
+	}
+
+	// Closed polls publish their frozen counts rather than the live ones
+	counts := service.PollVoteCounts(poll)
+	if result := poll.Edges.Result; result != nil {
+		finalizedAt := result.FinalizedAt
+		response.FinalizedAt = &finalizedAt
+	}
+	response.VoteCounts = &counts.Counts
+	response.Voters = &counts.Voters
+
+	// Redact results the caller may not see
+	if !resultsVisible {
+		response.VoteCounts = nil
+		response.Voters = nil
+	}
+
+	return response
+}
+
+// OptionToResponse converts an ent.PollOption to api.PollOption
+func OptionToResponse(opt *ent.PollOption) api.PollOption {
+	id := openapi_types.UUID(opt.ID)
+	label := opt.Label
+	position := opt.Position
+
+	response := api.PollOption{
+		Id:       &id,
+		Label:    &label,
+		Position: &position,
+	}
+	if opt.Description != "" {
+		description := opt.Description
+		response.Description = &description
+	}
+	if opt.ImageURL != "" {
+		imageURL := opt.ImageURL
+		response.ImageUrl = &imageURL
+	}
+
+	return response
+}
+
+// OptionInputsFromRequest converts requested options to service.OptionInput
+func OptionInputsFromRequest(options []api.PollOptionInput) []service.OptionInput {
+	inputs := make([]service.OptionInput, 0, len(options))
+	for _, opt := range options {
+		input := service.OptionInput{Label: opt.Label}
+		if opt.Id != nil {
+			input.ID = uuid.UUID(*opt.Id)
+		}
+		if opt.Description != nil {
+			input.Description = *opt.Description
+		}
+		if opt.ImageUrl != nil {
+			input.ImageURL = *opt.ImageUrl
+		}
+		inputs = append(inputs, input)
 	}
+	return inputs
+}
 
-	// Calculate vote counts and voters by option if votes are loaded
-	votes, err := poll.Edges.VotesOrErr()
-	if err == nil && len(votes) > 0 {
-		voteCounts := make(map[string]int)
-		votersByOption := make(map[string][]api.UserInfo)
-
-		for _, vote := range votes {
-			// Count votes per option
-			voteCounts[vote.Option]++
-
-			// Add user info to voters_by_option if user is loaded
-			if vote.Edges.User != nil {
-				userID := openapi_types.UUID(vote.Edges.User.ID)
-				email := openapi_types.Email(vote.Edges.User.Email)
-				username := vote.Edges.User.Username
-				userInfo := api.UserInfo{
-					Id:       &userID,
-					Email:    &email,
-					Username: &username,
-				}
-				votersByOption[vote.Option] = append(votersByOption[vote.Option], userInfo)
+// PollUpdateFromMergePatch converts a JSON Merge Patch (RFC 7396) of a poll to
+// a service.PollUpdate. Omitted fields are left unchanged and null clears
+// optional fields; fields that cannot be cleared or changed are rejected.
+func PollUpdateFromMergePatch(body []byte) (service.PollUpdate, error) {
+	var update service.PollUpdate
+
+	// The raw members tell omitted fields from null ones, which decode alike
+	var members map[string]json.RawMessage
+	var patch api.PollPatch
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return update, service.NewValidationError("invalid_body", "merge patch must be a JSON object")
+	}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return update, service.NewValidationError("invalid_body", "Invalid request body")
+	}
+
+	var fields []service.FieldError
+	reject := func(field, code, message string) {
+		fields = append(fields, service.FieldError{Field: field, Code: code, Message: message})
+	}
+
+	for field, raw := range members {
+		null := string(raw) == "null"
+		switch field {
+		case "title":
+			if null {
+				reject(field, "required", "title is required")
+			}
+			update.Title = patch.Title
+		case "description":
+			description := ""
+			if !null {
+				description = *patch.Description
+			}
+			update.Description = &description
+		case "options":
+			if null {
+				reject(field, "required", "options cannot be removed")
+				continue
+			}
+			update.Options = OptionInputsFromRequest(*patch.Options)
+		case "results_visibility":
+			if null {
+				reject(field, "required", "results_visibility cannot be removed")
+				continue
+			}
+			resultsVisibility := entpoll.ResultsVisibility(*patch.ResultsVisibility)
+			update.ResultsVisibility = &resultsVisibility
+		case "require_verified_email":
+			if null {
+				reject(field, "required", "require_verified_email cannot be removed")
+				continue
+			}
+			update.RequireVerifiedEmail = patch.RequireVerifiedEmail
+		case "min_choices":
+			if null {
+				reject(field, "required", "min_choices cannot be removed")
 			}
+			update.MinChoices = patch.MinChoices
+		case "max_choices":
+			update.MaxChoices = patch.MaxChoices
+			update.ClearMaxChoices = null
+		case "opens_at":
+			update.OpensAt = patch.OpensAt
+			update.ClearOpensAt = null
+		case "closes_at":
+			update.ClosesAt = patch.ClosesAt
+			update.ClearClosesAt = null
+		case "type", "anonymous":
+			reject(field, "immutable_field", field+" cannot be changed after the poll is created")
+		default:
+			reject(field, "unknown_field", "unknown field "+field)
 		}
+	}
 
-		response.VoteCounts = &voteCounts
-		response.VotersByOption = &votersByOption
+	if len(fields) > 0 {
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Field < fields[j].Field })
+		return update, service.NewValidationError("invalid_patch", "Invalid merge patch", fields...)
 	}
+	return update, nil
+}
 
-	return response
+// PollListToResponse converts a page of polls to api.PollListResponse
+func PollListToResponse(list *storage.PollList, viewerID uuid.UUID) api.PollListResponse {
+	// Whether the caller voted is not looked up for listings, so polls showing
+	// results after a vote only show them here once closed
+	polls := make([]api.PollResponse, 0, len(list.Polls))
+	for _, poll := range list.Polls {
+		polls = append(polls, PollToResponse(poll, service.ResultsVisible(poll, viewerID, false)))
+	}
+
+	var nextCursor *string
+	if list.Next != nil {
+		cursor := EncodePollCursor(list.Next)
+		nextCursor = &cursor
+	}
+
+	return api.PollListResponse{
+		Polls:         polls,
+		NextCursor:    nextCursor,
+		TotalEstimate: list.Total,
+		TotalExact:    list.TotalExact,
+	}
+}
+
+// EncodePollCursor encodes a listing cursor as an opaque URL-safe string
+func EncodePollCursor(cursor *storage.PollCursor) string {
+	b, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodePollCursor decodes a cursor encoded by EncodePollCursor
+func DecodePollCursor(s string) (*storage.PollCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, service.ErrInvalidCursor
+	}
+	var cursor storage.PollCursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, service.ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// PollListQueryFromRequest reads the filters and page of a poll listing from
+// the query string. The voted filter applies to viewerID, which must be set
+// when it is given.
+func PollListQueryFromRequest(query url.Values, viewerID uuid.UUID) (storage.PollFilter, storage.PollPage, error) {
+	var filter storage.PollFilter
+	var page storage.PollPage
+
+	var fields []service.FieldError
+	reject := func(field, code, message string) {
+		fields = append(fields, service.FieldError{Field: field, Code: code, Message: message})
+	}
+	parseTime := func(field string) *time.Time {
+		if !query.Has(field) {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, query.Get(field))
+		if err != nil {
+			reject(field, "invalid_value", field+" must be an RFC 3339 date-time")
+			return nil
+		}
+		return &t
+	}
+
+	filter.Query = query.Get("q")
+	if query.Has("owner") {
+		ownerID, err := uuid.Parse(query.Get("owner"))
+		if err != nil {
+			reject("owner", "invalid_id", "owner must be a user ID")
+		} else {
+			filter.OwnerID = &ownerID
+		}
+	}
+	filter.Status = query.Get("status")
+	filter.CreatedAfter = parseTime("created_after")
+	filter.CreatedBefore = parseTime("created_before")
+	if query.Has("voted") {
+		voted, err := strconv.ParseBool(query.Get("voted"))
+		switch {
+		case err != nil:
+			reject("voted", "invalid_value", "voted must be true or false")
+		case voted:
+			filter.VotedBy = &viewerID
+		default:
+			filter.NotVotedBy = &viewerID
+		}
+	}
+
+	page.Sort = storage.PollSort(query.Get("sort"))
+	if query.Has("limit") {
+		limit, err := strconv.Atoi(query.Get("limit"))
+		if err != nil {
+			reject("limit", "invalid_value", "limit must be an integer")
+		}
+		page.Limit = limit
+		if err == nil && limit == 0 {
+			reject("limit", "out_of_range", "limit must be at least 1")
+		}
+	}
+	if query.Has("cursor") {
+		cursor, err := DecodePollCursor(query.Get("cursor"))
+		if err != nil {
+			reject("cursor", "invalid_cursor", "cursor is invalid")
+		}
+		page.After = cursor
+	}
+
+	if len(fields) > 0 {
+		return filter, page, service.NewValidationError("invalid_query", "Invalid query parameters", fields...)
+	}
+	return filter, page, nil
+}
+
+// ImportResultToResponse converts a service.ImportResult to api.PollImportResponse
+func ImportResultToResponse(result *service.ImportResult) api.PollImportResponse {
+	dryRun := result.DryRun
+	created, existing := 0, 0
+	polls := make([]api.PollImportResult, 0, len(result.Polls))
+	for _, imported := range result.Polls {
+		externalID := imported.ExternalID
+		status := api.PollImportStatus(imported.Status)
+		item := api.PollImportResult{ExternalId: &externalID, Status: &status}
+		if imported.Poll != nil {
+			pollID := openapi_types.UUID(imported.Poll.ID)
+			item.PollId = &pollID
+		}
+		polls = append(polls, item)
+
+		if imported.Status == service.ImportStatusExists {
+			existing++
+		} else {
+			created++
+		}
+	}
+
+	return api.PollImportResponse{
+		DryRun:   &dryRun,
+		Created:  &created,
+		Existing: &existing,
+		Polls:    &polls,
+	}
 }
 
 // VoteToResponse converts an ent.Vote to api.VoteResponse
@@ -63,15 +354,254 @@ func VoteToResponse(vote *ent.Vote) api.VoteResponse {
 	id := openapi_types.UUID(vote.ID)
 	userID := openapi_types.UUID(vote.UserID)
 	pollID := openapi_types.UUID(vote.PollID)
-	option := vote.Option
+	optionID := openapi_types.UUID(vote.OptionID)
+	choices := make([]openapi_types.UUID, 0, len(vote.OptionIds))
+	for _, choice := range vote.OptionIds {
+		choices = append(choices, openapi_types.UUID(choice))
+	}
 	createdAt := vote.CreatedAt
 
 	return api.VoteResponse{
 		Id:        &id,
 		UserId:    &userID,
 		PollId:    &pollID,
-		Option:    &option,
+		OptionId:  &optionID,
+		Choices:   &choices,
 		CreatedAt: &createdAt,
 	}
 }
 
+// RankedResultToResponse converts a service.RankedResult to api.RankedResultsResponse
+func RankedResultToResponse(pollID uuid.UUID, result *service.RankedResult) api.RankedResultsResponse {
+	id := openapi_types.UUID(pollID)
+	totalBallots := result.TotalBallots
+	exhausted := result.Exhausted
+
+	tied := result.Tied
+	if tied == nil {
+		tied = []string{}
+	}
+
+	rounds := make([]api.RankedRound, 0, len(result.Rounds))
+	for _, r := range result.Rounds {
+		round := r.Round
+		counts := r.Counts
+		roundExhausted := r.Exhausted
+		eliminated := r.Eliminated
+		if eliminated == nil {
+			eliminated = []string{}
+		}
+		rounds = append(rounds, api.RankedRound{
+			Round:      &round,
+			Counts:     &counts,
+			Eliminated: &eliminated,
+			Exhausted:  &roundExhausted,
+		})
+	}
+
+	response := api.RankedResultsResponse{
+		PollId:           &id,
+		Tied:             &tied,
+		TotalBallots:     &totalBallots,
+		ExhaustedBallots: &exhausted,
+		Rounds:           &rounds,
+	}
+	if result.Winner != "" {
+		winner := result.Winner
+		response.Winner = &winner
+	}
+
+	return response
+}
+
+// WebhookEndpointToResponse converts an ent.WebhookEndpoint to
+// api.WebhookEndpointResponse. The secret is only included when withSecret is
+// set, which is when the endpoint was just created.
+func WebhookEndpointToResponse(endpoint *ent.WebhookEndpoint, withSecret bool) api.WebhookEndpointResponse {
+	id := openapi_types.UUID(endpoint.ID)
+	url := endpoint.URL
+	description := endpoint.Description
+	active := endpoint.Active
+	createdAt := endpoint.CreatedAt
+	updatedAt := endpoint.UpdatedAt
+	events := make([]api.WebhookEventType, 0, len(endpoint.Events))
+	for _, event := range endpoint.Events {
+		events = append(events, api.WebhookEventType(event))
+	}
+
+	response := api.WebhookEndpointResponse{
+		Id:          &id,
+		Url:         &url,
+		Description: &description,
+		Events:      &events,
+		Active:      &active,
+		CreatedAt:   &createdAt,
+		UpdatedAt:   &updatedAt,
+	}
+	if withSecret {
+		secret := endpoint.Secret
+		response.Secret = &secret
+	}
+	return response
+}
+
+// WebhookDeliveryToResponse converts an ent.WebhookDelivery to api.WebhookDeliveryResponse
+func WebhookDeliveryToResponse(delivery *ent.WebhookDelivery) api.WebhookDeliveryResponse {
+	id := openapi_types.UUID(delivery.ID)
+	eventID := openapi_types.UUID(delivery.EventID)
+	event := api.WebhookEventType(delivery.Event)
+	status := api.WebhookDeliveryStatus(delivery.Status)
+	attempts := delivery.Attempts
+	createdAt := delivery.CreatedAt
+
+	response := api.WebhookDeliveryResponse{
+		Id:             &id,
+		EventId:        &eventID,
+		Event:          &event,
+		Status:         &status,
+		Attempts:       &attempts,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		CreatedAt:      &createdAt,
+	}
+	if delivery.Status == webhookdelivery.StatusPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if delivery.LastError != "" {
+		lastError := delivery.LastError
+		response.LastError = &lastError
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(delivery.Payload, &payload); err == nil {
+		response.Payload = &payload
+	}
+	return response
+}
+
+// WebhookEndpointUpdateFromRequest converts a webhook endpoint update request to storage.WebhookEndpointUpdate
+func WebhookEndpointUpdateFromRequest(req api.UpdateWebhookEndpointRequest) storage.WebhookEndpointUpdate {
+	update := storage.WebhookEndpointUpdate{
+		URL:         req.Url,
+		Description: req.Description,
+		Active:      req.Active,
+	}
+	if req.Events != nil {
+		update.Events = WebhookEventsFromRequest(*req.Events)
+	}
+	return update
+}
+
+// WebhookEventsFromRequest converts requested webhook event types to their names
+func WebhookEventsFromRequest(events []api.WebhookEventType) []string {
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+	return names
+}
+
+// JWKSToResponse converts the public signing keys to api.JSONWebKeySet
+func JWKSToResponse(keys []*auth.SigningKey) api.JSONWebKeySet {
+	response := api.JSONWebKeySet{Keys: make([]api.JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		jwk := key.JWK()
+		response.Keys = append(response.Keys, api.JSONWebKey{
+			Kty: api.JSONWebKeyKty(jwk.Kty),
+			Use: jwk.Use,
+			Alg: api.JSONWebKeyAlg(jwk.Alg),
+			Kid: jwk.Kid,
+			N:   optionalString(jwk.N),
+			E:   optionalString(jwk.E),
+			Crv: (*api.JSONWebKeyCrv)(optionalString(jwk.Crv)),
+			X:   optionalString(jwk.X),
+			Y:   optionalString(jwk.Y),
+		})
+	}
+	return response
+}
+
+// optionalString returns nil for an empty string
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// SessionToResponse converts an auth.Session to api.SessionResponse. current
+// marks the session of the request's access token.
+func SessionToResponse(session *auth.Session, current bool) api.SessionResponse {
+	id := session.ID
+	response := api.SessionResponse{
+		Id:        &id,
+		Name:      optionalString(session.Name),
+		UserAgent: &session.UserAgent,
+		IpAddress: &session.IP,
+		Current:   &current,
+	}
+	if !session.CreatedAt.IsZero() {
+		createdAt := session.CreatedAt
+		response.CreatedAt = &createdAt
+	}
+	if !session.LastUsedAt.IsZero() {
+		lastUsedAt := session.LastUsedAt
+		response.LastUsedAt = &lastUsedAt
+	}
+	return response
+}
+
+// SecurityEventToResponse converts ent.SecurityEvent to api.SecurityEventResponse
+func SecurityEventToResponse(event *ent.SecurityEvent) api.SecurityEventResponse {
+	id := openapi_types.UUID(event.ID)
+	eventType := api.SecurityEventType(event.Type)
+	createdAt := event.CreatedAt
+
+	return api.SecurityEventResponse{
+		Id:        &id,
+		Type:      &eventType,
+		SessionId: optionalString(event.SessionID),
+		IpAddress: optionalString(event.IPAddress),
+		UserAgent: optionalString(event.UserAgent),
+		CreatedAt: &createdAt,
+	}
+}
+
+// MFAStatusToResponse converts service.MFAStatus to api.MFAStatusResponse
+func MFAStatusToResponse(status *service.MFAStatus) api.MFAStatusResponse {
+	return api.MFAStatusResponse{
+		Enabled:                &status.Enabled,
+		EnabledAt:              status.EnabledAt,
+		RecoveryCodesRemaining: &status.RecoveryCodesRemaining,
+	}
+}
+
+// MFAEnrollmentToResponse converts service.MFAEnrollment to api.MFAEnrollmentResponse
+func MFAEnrollmentToResponse(enrollment *service.MFAEnrollment) api.MFAEnrollmentResponse {
+	return api.MFAEnrollmentResponse{
+		Secret:          &enrollment.Secret,
+		ProvisioningUri: &enrollment.ProvisioningURI,
+	}
+}
+
+// InvitationToResponse converts an ent.PollInvitation to api.InvitationResponse
+func InvitationToResponse(inv *ent.PollInvitation) api.InvitationResponse {
+	return api.InvitationResponse{
+		Id:         openapi_types.UUID(inv.ID),
+		Email:      openapi_types.Email(inv.Email),
+		Status:     api.InvitationResponseStatus(inv.Status),
+		ExpiresAt:  inv.ExpiresAt,
+		RemindedAt: inv.RemindedAt,
+		CreatedAt:  inv.CreatedAt,
+	}
+}
+
+// InvitationsToResponse converts invitations to api.InvitationListResponse
+func InvitationsToResponse(invitations []*ent.PollInvitation) api.InvitationListResponse {
+	responses := make([]api.InvitationResponse, 0, len(invitations))
+	for _, inv := range invitations {
+		responses = append(responses, InvitationToResponse(inv))
+	}
+	return api.InvitationListResponse{Invitations: &responses}
+}
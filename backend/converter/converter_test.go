@@ -0,0 +1,241 @@
+package converter
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/service"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
+
+func TestPollToResponseResultsVisible(t *testing.T) {
+	tests := []struct {
+		name    string
+		visible bool
+	}{
+		{"visible", true},
+		{"hidden", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ent.Poll{ID: uuid.New(), Type: poll.TypeSingle, ResultsVisibility: poll.ResultsVisibilityAfterVote, VoterCount: 2}
+			p.Edges.Options = []*ent.PollOption{{ID: uuid.New(), Label: "a", VoteCount: 2}}
+
+			response := PollToResponse(p, tt.visible)
+			if *response.ResultsVisible != tt.visible {
+				t.Errorf("results_visible = %v, want %v", *response.ResultsVisible, tt.visible)
+			}
+			if (response.VoteCounts != nil) != tt.visible || (response.Voters != nil) != tt.visible {
+				t.Fatalf("vote_counts = %v, voters = %v, want present %v", response.VoteCounts, response.Voters, tt.visible)
+			}
+			if tt.visible && ((*response.VoteCounts)[p.Edges.Options[0].ID.String()] != 2 || *response.Voters != 2) {
+				t.Fatalf("vote_counts = %v, voters = %d", *response.VoteCounts, *response.Voters)
+			}
+		})
+	}
+}
+
+func TestPollUpdateFromMergePatch(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		// rejected lists the fields the patch is rejected for, if any
+		rejected []string
+		check    func(update service.PollUpdate) bool
+	}{
+		{
+			name: "omitted fields are left unchanged",
+			body: `{"title":"New"}`,
+			check: func(u service.PollUpdate) bool {
+				return *u.Title == "New" && u.Description == nil && u.Options == nil && !u.ClearMaxChoices
+			},
+		},
+		{
+			name:  "null clears the description",
+			body:  `{"description":null}`,
+			check: func(u service.PollUpdate) bool { return u.Description != nil && *u.Description == "" },
+		},
+		{
+			name: "null clears limits and dates",
+			body: `{"max_choices":null,"opens_at":null,"closes_at":null}`,
+			check: func(u service.PollUpdate) bool {
+				return u.ClearMaxChoices && u.ClearOpensAt && u.ClearClosesAt && u.MaxChoices == nil
+			},
+		},
+		{
+			name: "values are set",
+			body: `{"max_choices":2,"results_visibility":"after_close","options":[{"label":"a"}]}`,
+			check: func(u service.PollUpdate) bool {
+				return *u.MaxChoices == 2 && !u.ClearMaxChoices &&
+					*u.ResultsVisibility == poll.ResultsVisibilityAfterClose && len(u.Options) == 1
+			},
+		},
+		{
+			name:     "required fields cannot be cleared",
+			body:     `{"title":null,"options":null,"min_choices":null}`,
+			rejected: []string{"min_choices", "options", "title"},
+		},
+		{
+			name:     "immutable and unknown fields",
+			body:     `{"type":"multiple","anonymous":true,"colour":"red"}`,
+			rejected: []string{"anonymous", "colour", "type"},
+		},
+		{name: "not an object", body: `[]`},
+		{name: "null document", body: `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update, err := PollUpdateFromMergePatch([]byte(tt.body))
+			if tt.check != nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !tt.check(update) {
+					t.Fatalf("update = %+v", update)
+				}
+				return
+			}
+
+			var svcErr *service.Error
+			if !errors.As(err, &svcErr) || svcErr.Kind != service.KindValidation {
+				t.Fatalf("err = %v, want a validation error", err)
+			}
+			var fields []string
+			for _, f := range svcErr.Fields {
+				fields = append(fields, f.Field)
+			}
+			if !slices.Equal(fields, tt.rejected) {
+				t.Errorf("rejected fields = %v, want %v", fields, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestPollCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	voters := 3
+	cursors := []*storage.PollCursor{
+		{Sort: storage.PollSortNewest, ID: uuid.New(), CreatedAt: &createdAt},
+		{Sort: storage.PollSortMostVotes, ID: uuid.New(), Voters: &voters},
+		{Sort: storage.PollSortClosingSoon, ID: uuid.New()},
+	}
+
+	for _, cursor := range cursors {
+		t.Run(string(cursor.Sort), func(t *testing.T) {
+			got, err := DecodePollCursor(EncodePollCursor(cursor))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, cursor) {
+				t.Fatalf("decoded %+v, want %+v", got, cursor)
+			}
+		})
+	}
+
+	for _, s := range []string{"not base64!", "bm90IGpzb24"} {
+		if _, err := DecodePollCursor(s); !errors.Is(err, service.ErrInvalidCursor) {
+			t.Errorf("DecodePollCursor(%q) = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
+
+func TestPollListQueryFromRequest(t *testing.T) {
+	viewerID := uuid.New()
+	ownerID := uuid.New()
+	cursor := EncodePollCursor(&storage.PollCursor{Sort: storage.PollSortMostVotes, ID: uuid.New()})
+
+	tests := []struct {
+		name     string
+		query    string
+		rejected []string
+		check    func(filter storage.PollFilter, page storage.PollPage) bool
+	}{
+		{
+			name: "no parameters",
+			check: func(f storage.PollFilter, p storage.PollPage) bool {
+				return reflect.DeepEqual(f, storage.PollFilter{}) && reflect.DeepEqual(p, storage.PollPage{})
+			},
+		},
+		{
+			name:  "filters",
+			query: "q=lunch&owner=" + ownerID.String() + "&status=open&created_after=2026-01-01T00:00:00Z",
+			check: func(f storage.PollFilter, _ storage.PollPage) bool {
+				return f.Query == "lunch" && *f.OwnerID == ownerID && f.Status == "open" &&
+					f.CreatedAfter.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) && f.CreatedBefore == nil
+			},
+		},
+		{
+			name:  "voted",
+			query: "voted=true",
+			check: func(f storage.PollFilter, _ storage.PollPage) bool {
+				return *f.VotedBy == viewerID && f.NotVotedBy == nil
+			},
+		},
+		{
+			name:  "not voted",
+			query: "voted=false",
+			check: func(f storage.PollFilter, _ storage.PollPage) bool {
+				return f.VotedBy == nil && *f.NotVotedBy == viewerID
+			},
+		},
+		{
+			name:  "page",
+			query: "sort=most_votes&limit=5&cursor=" + cursor,
+			check: func(_ storage.PollFilter, p storage.PollPage) bool {
+				return p.Sort == storage.PollSortMostVotes && p.Limit == 5 && p.After != nil &&
+					p.After.Sort == storage.PollSortMostVotes
+			},
+		},
+		{
+			name:     "invalid values",
+			query:    "owner=me&created_before=yesterday&voted=maybe&limit=0&cursor=x",
+			rejected: []string{"owner", "created_before", "voted", "limit", "cursor"},
+		},
+		{
+			name:     "limit not a number",
+			query:    "limit=ten",
+			rejected: []string{"limit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filter, page, err := PollListQueryFromRequest(query, viewerID)
+			if tt.check != nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !tt.check(filter, page) {
+					t.Fatalf("filter = %+v, page = %+v", filter, page)
+				}
+				return
+			}
+
+			var svcErr *service.Error
+			if !errors.As(err, &svcErr) || svcErr.Kind != service.KindValidation {
+				t.Fatalf("err = %v, want a validation error", err)
+			}
+			var fields []string
+			for _, f := range svcErr.Fields {
+				fields = append(fields, f.Field)
+			}
+			if !slices.Equal(fields, tt.rejected) {
+				t.Errorf("rejected fields = %v, want %v", fields, tt.rejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"poll-app/ent/ballot"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+	"strings"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// Ballot is the model entity for the Ballot schema.
+type Ballot struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// PollID holds the value of the "poll_id" field.
+	PollID uuid.UUID `json:"poll_id,omitempty"`
+	// OptionID holds the value of the "option_id" field.
+	OptionID uuid.UUID `json:"option_id,omitempty"`
+	// OptionIds holds the value of the "option_ids" field.
+	OptionIds []uuid.UUID `json:"option_ids,omitempty"`
+	// LegacyOption holds the value of the "legacy_option" field.
+	LegacyOption string `json:"legacy_option,omitempty"`
+	// LegacyChoices holds the value of the "legacy_choices" field.
+	LegacyChoices []string `json:"legacy_choices,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the BallotQuery when eager-loading is set.
+	Edges        BallotEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// BallotEdges holds the relations/edges for other nodes in the graph.
+type BallotEdges struct {
+	// Poll holds the value of the poll edge.
+	Poll *Poll `json:"poll,omitempty"`
+	// Option holds the value of the option edge.
+	Option *PollOption `json:"option,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// PollOrErr returns the Poll value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e BallotEdges) PollOrErr() (*Poll, error) {
+	if e.Poll != nil {
+		return e.Poll, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: poll.Label}
+	}
+	return nil, &NotLoadedError{edge: "poll"}
+}
+
+// OptionOrErr returns the Option value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e BallotEdges) OptionOrErr() (*PollOption, error) {
+	if e.Option != nil {
+		return e.Option, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: polloption.Label}
+	}
+	return nil, &NotLoadedError{edge: "option"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Ballot) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case ballot.FieldOptionIds, ballot.FieldLegacyChoices:
+			values[i] = new([]byte)
+		case ballot.FieldLegacyOption:
+			values[i] = new(sql.NullString)
+		case ballot.FieldID, ballot.FieldPollID, ballot.FieldOptionID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Ballot fields.
+func (_m *Ballot) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case ballot.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case ballot.FieldPollID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field poll_id", values[i])
+			} else if value != nil {
+				_m.PollID = *value
+			}
+		case ballot.FieldOptionID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field option_id", values[i])
+			} else if value != nil {
+				_m.OptionID = *value
+			}
+		case ballot.FieldOptionIds:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field option_ids", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.OptionIds); err != nil {
+					return fmt.Errorf("unmarshal field option_ids: %w", err)
+				}
+			}
+		case ballot.FieldLegacyOption:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field legacy_option", values[i])
+			} else if value.Valid {
+				_m.LegacyOption = value.String
+			}
+		case ballot.FieldLegacyChoices:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field legacy_choices", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.LegacyChoices); err != nil {
+					return fmt.Errorf("unmarshal field legacy_choices: %w", err)
+				}
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Ballot.
+// This includes values selected through modifiers, order, etc.
+func (_m *Ballot) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryPoll queries the "poll" edge of the Ballot entity.
+func (_m *Ballot) QueryPoll() *PollQuery {
+	return NewBallotClient(_m.config).QueryPoll(_m)
+}
+
+// QueryOption queries the "option" edge of the Ballot entity.
+func (_m *Ballot) QueryOption() *PollOptionQuery {
+	return NewBallotClient(_m.config).QueryOption(_m)
+}
+
+// Update returns a builder for updating this Ballot.
+// Note that you need to call Ballot.Unwrap() before calling this method if this Ballot
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *Ballot) Update() *BallotUpdateOne {
+	return NewBallotClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the Ballot entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *Ballot) Unwrap() *Ballot {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Ballot is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *Ballot) String() string {
+	var builder strings.Builder
+	builder.WriteString("Ballot(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("poll_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.PollID))
+	builder.WriteString(", ")
+	builder.WriteString("option_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OptionID))
+	builder.WriteString(", ")
+	builder.WriteString("option_ids=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OptionIds))
+	builder.WriteString(", ")
+	builder.WriteString("legacy_option=")
+	builder.WriteString(_m.LegacyOption)
+	builder.WriteString(", ")
+	builder.WriteString("legacy_choices=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LegacyChoices))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Ballots is a parsable slice of Ballot.
+type Ballots []*Ballot
@@ -0,0 +1,277 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ballot
+
+import (
+	"poll-app/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldLTE(FieldID, id))
+}
+
+// PollID applies equality check predicate on the "poll_id" field. It's identical to PollIDEQ.
+func PollID(v uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldPollID, v))
+}
+
+// OptionID applies equality check predicate on the "option_id" field. It's identical to OptionIDEQ.
+func OptionID(v uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldOptionID, v))
+}
+
+// LegacyOption applies equality check predicate on the "legacy_option" field. It's identical to LegacyOptionEQ.
+func LegacyOption(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldLegacyOption, v))
+}
+
+// PollIDEQ applies the EQ predicate on the "poll_id" field.
+func PollIDEQ(v uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldPollID, v))
+}
+
+// PollIDNEQ applies the NEQ predicate on the "poll_id" field.
+func PollIDNEQ(v uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNEQ(FieldPollID, v))
+}
+
+// PollIDIn applies the In predicate on the "poll_id" field.
+func PollIDIn(vs ...uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldIn(FieldPollID, vs...))
+}
+
+// PollIDNotIn applies the NotIn predicate on the "poll_id" field.
+func PollIDNotIn(vs ...uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotIn(FieldPollID, vs...))
+}
+
+// OptionIDEQ applies the EQ predicate on the "option_id" field.
+func OptionIDEQ(v uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldOptionID, v))
+}
+
+// OptionIDNEQ applies the NEQ predicate on the "option_id" field.
+func OptionIDNEQ(v uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNEQ(FieldOptionID, v))
+}
+
+// OptionIDIn applies the In predicate on the "option_id" field.
+func OptionIDIn(vs ...uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldIn(FieldOptionID, vs...))
+}
+
+// OptionIDNotIn applies the NotIn predicate on the "option_id" field.
+func OptionIDNotIn(vs ...uuid.UUID) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotIn(FieldOptionID, vs...))
+}
+
+// OptionIDIsNil applies the IsNil predicate on the "option_id" field.
+func OptionIDIsNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldIsNull(FieldOptionID))
+}
+
+// OptionIDNotNil applies the NotNil predicate on the "option_id" field.
+func OptionIDNotNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotNull(FieldOptionID))
+}
+
+// OptionIdsIsNil applies the IsNil predicate on the "option_ids" field.
+func OptionIdsIsNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldIsNull(FieldOptionIds))
+}
+
+// OptionIdsNotNil applies the NotNil predicate on the "option_ids" field.
+func OptionIdsNotNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotNull(FieldOptionIds))
+}
+
+// LegacyOptionEQ applies the EQ predicate on the "legacy_option" field.
+func LegacyOptionEQ(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEQ(FieldLegacyOption, v))
+}
+
+// LegacyOptionNEQ applies the NEQ predicate on the "legacy_option" field.
+func LegacyOptionNEQ(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNEQ(FieldLegacyOption, v))
+}
+
+// LegacyOptionIn applies the In predicate on the "legacy_option" field.
+func LegacyOptionIn(vs ...string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldIn(FieldLegacyOption, vs...))
+}
+
+// LegacyOptionNotIn applies the NotIn predicate on the "legacy_option" field.
+func LegacyOptionNotIn(vs ...string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotIn(FieldLegacyOption, vs...))
+}
+
+// LegacyOptionGT applies the GT predicate on the "legacy_option" field.
+func LegacyOptionGT(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldGT(FieldLegacyOption, v))
+}
+
+// LegacyOptionGTE applies the GTE predicate on the "legacy_option" field.
+func LegacyOptionGTE(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldGTE(FieldLegacyOption, v))
+}
+
+// LegacyOptionLT applies the LT predicate on the "legacy_option" field.
+func LegacyOptionLT(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldLT(FieldLegacyOption, v))
+}
+
+// LegacyOptionLTE applies the LTE predicate on the "legacy_option" field.
+func LegacyOptionLTE(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldLTE(FieldLegacyOption, v))
+}
+
+// LegacyOptionContains applies the Contains predicate on the "legacy_option" field.
+func LegacyOptionContains(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldContains(FieldLegacyOption, v))
+}
+
+// LegacyOptionHasPrefix applies the HasPrefix predicate on the "legacy_option" field.
+func LegacyOptionHasPrefix(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldHasPrefix(FieldLegacyOption, v))
+}
+
+// LegacyOptionHasSuffix applies the HasSuffix predicate on the "legacy_option" field.
+func LegacyOptionHasSuffix(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldHasSuffix(FieldLegacyOption, v))
+}
+
+// LegacyOptionIsNil applies the IsNil predicate on the "legacy_option" field.
+func LegacyOptionIsNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldIsNull(FieldLegacyOption))
+}
+
+// LegacyOptionNotNil applies the NotNil predicate on the "legacy_option" field.
+func LegacyOptionNotNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotNull(FieldLegacyOption))
+}
+
+// LegacyOptionEqualFold applies the EqualFold predicate on the "legacy_option" field.
+func LegacyOptionEqualFold(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldEqualFold(FieldLegacyOption, v))
+}
+
+// LegacyOptionContainsFold applies the ContainsFold predicate on the "legacy_option" field.
+func LegacyOptionContainsFold(v string) predicate.Ballot {
+	return predicate.Ballot(sql.FieldContainsFold(FieldLegacyOption, v))
+}
+
+// LegacyChoicesIsNil applies the IsNil predicate on the "legacy_choices" field.
+func LegacyChoicesIsNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldIsNull(FieldLegacyChoices))
+}
+
+// LegacyChoicesNotNil applies the NotNil predicate on the "legacy_choices" field.
+func LegacyChoicesNotNil() predicate.Ballot {
+	return predicate.Ballot(sql.FieldNotNull(FieldLegacyChoices))
+}
+
+// HasPoll applies the HasEdge predicate on the "poll" edge.
+func HasPoll() predicate.Ballot {
+	return predicate.Ballot(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, PollTable, PollColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasPollWith applies the HasEdge predicate on the "poll" edge with a given conditions (other predicates).
+func HasPollWith(preds ...predicate.Poll) predicate.Ballot {
+	return predicate.Ballot(func(s *sql.Selector) {
+		step := newPollStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasOption applies the HasEdge predicate on the "option" edge.
+func HasOption() predicate.Ballot {
+	return predicate.Ballot(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, OptionTable, OptionColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOptionWith applies the HasEdge predicate on the "option" edge with a given conditions (other predicates).
+func HasOptionWith(preds ...predicate.PollOption) predicate.Ballot {
+	return predicate.Ballot(func(s *sql.Selector) {
+		step := newOptionStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Ballot) predicate.Ballot {
+	return predicate.Ballot(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Ballot) predicate.Ballot {
+	return predicate.Ballot(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Ballot) predicate.Ballot {
+	return predicate.Ballot(sql.NotPredicates(p))
+}
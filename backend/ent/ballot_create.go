@@ -0,0 +1,310 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll-app/ent/ballot"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// BallotCreate is the builder for creating a Ballot entity.
+type BallotCreate struct {
+	config
+	mutation *BallotMutation
+	hooks    []Hook
+}
+
+// SetPollID sets the "poll_id" field.
+func (_c *BallotCreate) SetPollID(v uuid.UUID) *BallotCreate {
+	_c.mutation.SetPollID(v)
+	return _c
+}
+
+// SetOptionID sets the "option_id" field.
+func (_c *BallotCreate) SetOptionID(v uuid.UUID) *BallotCreate {
+	_c.mutation.SetOptionID(v)
+	return _c
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_c *BallotCreate) SetNillableOptionID(v *uuid.UUID) *BallotCreate {
+	if v != nil {
+		_c.SetOptionID(*v)
+	}
+	return _c
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_c *BallotCreate) SetOptionIds(v []uuid.UUID) *BallotCreate {
+	_c.mutation.SetOptionIds(v)
+	return _c
+}
+
+// SetLegacyOption sets the "legacy_option" field.
+func (_c *BallotCreate) SetLegacyOption(v string) *BallotCreate {
+	_c.mutation.SetLegacyOption(v)
+	return _c
+}
+
+// SetNillableLegacyOption sets the "legacy_option" field if the given value is not nil.
+func (_c *BallotCreate) SetNillableLegacyOption(v *string) *BallotCreate {
+	if v != nil {
+		_c.SetLegacyOption(*v)
+	}
+	return _c
+}
+
+// SetLegacyChoices sets the "legacy_choices" field.
+func (_c *BallotCreate) SetLegacyChoices(v []string) *BallotCreate {
+	_c.mutation.SetLegacyChoices(v)
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *BallotCreate) SetID(v uuid.UUID) *BallotCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *BallotCreate) SetNillableID(v *uuid.UUID) *BallotCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_c *BallotCreate) SetPoll(v *Poll) *BallotCreate {
+	return _c.SetPollID(v.ID)
+}
+
+// SetOption sets the "option" edge to the PollOption entity.
+func (_c *BallotCreate) SetOption(v *PollOption) *BallotCreate {
+	return _c.SetOptionID(v.ID)
+}
+
+// Mutation returns the BallotMutation object of the builder.
+func (_c *BallotCreate) Mutation() *BallotMutation {
+	return _c.mutation
+}
+
+// Save creates the Ballot in the database.
+func (_c *BallotCreate) Save(ctx context.Context) (*Ballot, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *BallotCreate) SaveX(ctx context.Context) *Ballot {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *BallotCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *BallotCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *BallotCreate) defaults() {
+	if _, ok := _c.mutation.ID(); !ok {
+		v := ballot.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *BallotCreate) check() error {
+	if _, ok := _c.mutation.PollID(); !ok {
+		return &ValidationError{Name: "poll_id", err: errors.New(`ent: missing required field "Ballot.poll_id"`)}
+	}
+	if len(_c.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "Ballot.poll"`)}
+	}
+	return nil
+}
+
+func (_c *BallotCreate) sqlSave(ctx context.Context) (*Ballot, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *BallotCreate) createSpec() (*Ballot, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Ballot{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(ballot.Table, sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.OptionIds(); ok {
+		_spec.SetField(ballot.FieldOptionIds, field.TypeJSON, value)
+		_node.OptionIds = value
+	}
+	if value, ok := _c.mutation.LegacyOption(); ok {
+		_spec.SetField(ballot.FieldLegacyOption, field.TypeString, value)
+		_node.LegacyOption = value
+	}
+	if value, ok := _c.mutation.LegacyChoices(); ok {
+		_spec.SetField(ballot.FieldLegacyChoices, field.TypeJSON, value)
+		_node.LegacyChoices = value
+	}
+	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.PollTable,
+			Columns: []string{ballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PollID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.OptionTable,
+			Columns: []string{ballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OptionID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// BallotCreateBulk is the builder for creating many Ballot entities in bulk.
+type BallotCreateBulk struct {
+	config
+	err      error
+	builders []*BallotCreate
+}
+
+// Save creates the Ballot entities in the database.
+func (_c *BallotCreateBulk) Save(ctx context.Context) ([]*Ballot, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*Ballot, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*BallotMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *BallotCreateBulk) SaveX(ctx context.Context) []*Ballot {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *BallotCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *BallotCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,597 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll-app/ent/ballot"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+	"poll-app/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// BallotUpdate is the builder for updating Ballot entities.
+type BallotUpdate struct {
+	config
+	hooks    []Hook
+	mutation *BallotMutation
+}
+
+// Where appends a list predicates to the BallotUpdate builder.
+func (_u *BallotUpdate) Where(ps ...predicate.Ballot) *BallotUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *BallotUpdate) SetPollID(v uuid.UUID) *BallotUpdate {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *BallotUpdate) SetNillablePollID(v *uuid.UUID) *BallotUpdate {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetOptionID sets the "option_id" field.
+func (_u *BallotUpdate) SetOptionID(v uuid.UUID) *BallotUpdate {
+	_u.mutation.SetOptionID(v)
+	return _u
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_u *BallotUpdate) SetNillableOptionID(v *uuid.UUID) *BallotUpdate {
+	if v != nil {
+		_u.SetOptionID(*v)
+	}
+	return _u
+}
+
+// ClearOptionID clears the value of the "option_id" field.
+func (_u *BallotUpdate) ClearOptionID() *BallotUpdate {
+	_u.mutation.ClearOptionID()
+	return _u
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_u *BallotUpdate) SetOptionIds(v []uuid.UUID) *BallotUpdate {
+	_u.mutation.SetOptionIds(v)
+	return _u
+}
+
+// AppendOptionIds appends value to the "option_ids" field.
+func (_u *BallotUpdate) AppendOptionIds(v []uuid.UUID) *BallotUpdate {
+	_u.mutation.AppendOptionIds(v)
+	return _u
+}
+
+// ClearOptionIds clears the value of the "option_ids" field.
+func (_u *BallotUpdate) ClearOptionIds() *BallotUpdate {
+	_u.mutation.ClearOptionIds()
+	return _u
+}
+
+// SetLegacyOption sets the "legacy_option" field.
+func (_u *BallotUpdate) SetLegacyOption(v string) *BallotUpdate {
+	_u.mutation.SetLegacyOption(v)
+	return _u
+}
+
+// SetNillableLegacyOption sets the "legacy_option" field if the given value is not nil.
+func (_u *BallotUpdate) SetNillableLegacyOption(v *string) *BallotUpdate {
+	if v != nil {
+		_u.SetLegacyOption(*v)
+	}
+	return _u
+}
+
+// ClearLegacyOption clears the value of the "legacy_option" field.
+func (_u *BallotUpdate) ClearLegacyOption() *BallotUpdate {
+	_u.mutation.ClearLegacyOption()
+	return _u
+}
+
+// SetLegacyChoices sets the "legacy_choices" field.
+func (_u *BallotUpdate) SetLegacyChoices(v []string) *BallotUpdate {
+	_u.mutation.SetLegacyChoices(v)
+	return _u
+}
+
+// AppendLegacyChoices appends value to the "legacy_choices" field.
+func (_u *BallotUpdate) AppendLegacyChoices(v []string) *BallotUpdate {
+	_u.mutation.AppendLegacyChoices(v)
+	return _u
+}
+
+// ClearLegacyChoices clears the value of the "legacy_choices" field.
+func (_u *BallotUpdate) ClearLegacyChoices() *BallotUpdate {
+	_u.mutation.ClearLegacyChoices()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *BallotUpdate) SetPoll(v *Poll) *BallotUpdate {
+	return _u.SetPollID(v.ID)
+}
+
+// SetOption sets the "option" edge to the PollOption entity.
+func (_u *BallotUpdate) SetOption(v *PollOption) *BallotUpdate {
+	return _u.SetOptionID(v.ID)
+}
+
+// Mutation returns the BallotMutation object of the builder.
+func (_u *BallotUpdate) Mutation() *BallotMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *BallotUpdate) ClearPoll() *BallotUpdate {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// ClearOption clears the "option" edge to the PollOption entity.
+func (_u *BallotUpdate) ClearOption() *BallotUpdate {
+	_u.mutation.ClearOption()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *BallotUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BallotUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *BallotUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BallotUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BallotUpdate) check() error {
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Ballot.poll"`)
+	}
+	return nil
+}
+
+func (_u *BallotUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(ballot.Table, ballot.Columns, sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.OptionIds(); ok {
+		_spec.SetField(ballot.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, ballot.FieldOptionIds, value)
+		})
+	}
+	if _u.mutation.OptionIdsCleared() {
+		_spec.ClearField(ballot.FieldOptionIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.LegacyOption(); ok {
+		_spec.SetField(ballot.FieldLegacyOption, field.TypeString, value)
+	}
+	if _u.mutation.LegacyOptionCleared() {
+		_spec.ClearField(ballot.FieldLegacyOption, field.TypeString)
+	}
+	if value, ok := _u.mutation.LegacyChoices(); ok {
+		_spec.SetField(ballot.FieldLegacyChoices, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedLegacyChoices(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, ballot.FieldLegacyChoices, value)
+		})
+	}
+	if _u.mutation.LegacyChoicesCleared() {
+		_spec.ClearField(ballot.FieldLegacyChoices, field.TypeJSON)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.PollTable,
+			Columns: []string{ballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.PollTable,
+			Columns: []string{ballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OptionCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.OptionTable,
+			Columns: []string{ballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.OptionTable,
+			Columns: []string{ballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ballot.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// BallotUpdateOne is the builder for updating a single Ballot entity.
+type BallotUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *BallotMutation
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *BallotUpdateOne) SetPollID(v uuid.UUID) *BallotUpdateOne {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *BallotUpdateOne) SetNillablePollID(v *uuid.UUID) *BallotUpdateOne {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetOptionID sets the "option_id" field.
+func (_u *BallotUpdateOne) SetOptionID(v uuid.UUID) *BallotUpdateOne {
+	_u.mutation.SetOptionID(v)
+	return _u
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_u *BallotUpdateOne) SetNillableOptionID(v *uuid.UUID) *BallotUpdateOne {
+	if v != nil {
+		_u.SetOptionID(*v)
+	}
+	return _u
+}
+
+// ClearOptionID clears the value of the "option_id" field.
+func (_u *BallotUpdateOne) ClearOptionID() *BallotUpdateOne {
+	_u.mutation.ClearOptionID()
+	return _u
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_u *BallotUpdateOne) SetOptionIds(v []uuid.UUID) *BallotUpdateOne {
+	_u.mutation.SetOptionIds(v)
+	return _u
+}
+
+// AppendOptionIds appends value to the "option_ids" field.
+func (_u *BallotUpdateOne) AppendOptionIds(v []uuid.UUID) *BallotUpdateOne {
+	_u.mutation.AppendOptionIds(v)
+	return _u
+}
+
+// ClearOptionIds clears the value of the "option_ids" field.
+func (_u *BallotUpdateOne) ClearOptionIds() *BallotUpdateOne {
+	_u.mutation.ClearOptionIds()
+	return _u
+}
+
+// SetLegacyOption sets the "legacy_option" field.
+func (_u *BallotUpdateOne) SetLegacyOption(v string) *BallotUpdateOne {
+	_u.mutation.SetLegacyOption(v)
+	return _u
+}
+
+// SetNillableLegacyOption sets the "legacy_option" field if the given value is not nil.
+func (_u *BallotUpdateOne) SetNillableLegacyOption(v *string) *BallotUpdateOne {
+	if v != nil {
+		_u.SetLegacyOption(*v)
+	}
+	return _u
+}
+
+// ClearLegacyOption clears the value of the "legacy_option" field.
+func (_u *BallotUpdateOne) ClearLegacyOption() *BallotUpdateOne {
+	_u.mutation.ClearLegacyOption()
+	return _u
+}
+
+// SetLegacyChoices sets the "legacy_choices" field.
+func (_u *BallotUpdateOne) SetLegacyChoices(v []string) *BallotUpdateOne {
+	_u.mutation.SetLegacyChoices(v)
+	return _u
+}
+
+// AppendLegacyChoices appends value to the "legacy_choices" field.
+func (_u *BallotUpdateOne) AppendLegacyChoices(v []string) *BallotUpdateOne {
+	_u.mutation.AppendLegacyChoices(v)
+	return _u
+}
+
+// ClearLegacyChoices clears the value of the "legacy_choices" field.
+func (_u *BallotUpdateOne) ClearLegacyChoices() *BallotUpdateOne {
+	_u.mutation.ClearLegacyChoices()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *BallotUpdateOne) SetPoll(v *Poll) *BallotUpdateOne {
+	return _u.SetPollID(v.ID)
+}
+
+// SetOption sets the "option" edge to the PollOption entity.
+func (_u *BallotUpdateOne) SetOption(v *PollOption) *BallotUpdateOne {
+	return _u.SetOptionID(v.ID)
+}
+
+// Mutation returns the BallotMutation object of the builder.
+func (_u *BallotUpdateOne) Mutation() *BallotMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *BallotUpdateOne) ClearPoll() *BallotUpdateOne {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// ClearOption clears the "option" edge to the PollOption entity.
+func (_u *BallotUpdateOne) ClearOption() *BallotUpdateOne {
+	_u.mutation.ClearOption()
+	return _u
+}
+
+// Where appends a list predicates to the BallotUpdate builder.
+func (_u *BallotUpdateOne) Where(ps ...predicate.Ballot) *BallotUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *BallotUpdateOne) Select(field string, fields ...string) *BallotUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated Ballot entity.
+func (_u *BallotUpdateOne) Save(ctx context.Context) (*Ballot, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BallotUpdateOne) SaveX(ctx context.Context) *Ballot {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *BallotUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BallotUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BallotUpdateOne) check() error {
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Ballot.poll"`)
+	}
+	return nil
+}
+
+func (_u *BallotUpdateOne) sqlSave(ctx context.Context) (_node *Ballot, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(ballot.Table, ballot.Columns, sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Ballot.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, ballot.FieldID)
+		for _, f := range fields {
+			if !ballot.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != ballot.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.OptionIds(); ok {
+		_spec.SetField(ballot.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, ballot.FieldOptionIds, value)
+		})
+	}
+	if _u.mutation.OptionIdsCleared() {
+		_spec.ClearField(ballot.FieldOptionIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.LegacyOption(); ok {
+		_spec.SetField(ballot.FieldLegacyOption, field.TypeString, value)
+	}
+	if _u.mutation.LegacyOptionCleared() {
+		_spec.ClearField(ballot.FieldLegacyOption, field.TypeString)
+	}
+	if value, ok := _u.mutation.LegacyChoices(); ok {
+		_spec.SetField(ballot.FieldLegacyChoices, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedLegacyChoices(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, ballot.FieldLegacyChoices, value)
+		})
+	}
+	if _u.mutation.LegacyChoicesCleared() {
+		_spec.ClearField(ballot.FieldLegacyChoices, field.TypeJSON)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.PollTable,
+			Columns: []string{ballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.PollTable,
+			Columns: []string{ballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OptionCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.OptionTable,
+			Columns: []string{ballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   ballot.OptionTable,
+			Columns: []string{ballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Ballot{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ballot.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -6,9 +6,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"poll-app/ent/ballot"
+	"poll-app/ent/jobrun"
+	"poll-app/ent/participation"
+	"poll-app/ent/pendingballot"
 	"poll-app/ent/poll"
+	"poll-app/ent/pollinvitation"
+	"poll-app/ent/polloption"
+	"poll-app/ent/pollresult"
+	"poll-app/ent/recoverycode"
+	"poll-app/ent/securityevent"
+	"poll-app/ent/signingkey"
 	"poll-app/ent/user"
+	"poll-app/ent/usertoken"
 	"poll-app/ent/vote"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/ent/webhookendpoint"
 	"reflect"
 	"sync"
 
@@ -75,9 +88,22 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			poll.Table: poll.ValidColumn,
-			user.Table: user.ValidColumn,
-			vote.Table: vote.ValidColumn,
+			ballot.Table:          ballot.ValidColumn,
+			jobrun.Table:          jobrun.ValidColumn,
+			participation.Table:   participation.ValidColumn,
+			pendingballot.Table:   pendingballot.ValidColumn,
+			poll.Table:            poll.ValidColumn,
+			pollinvitation.Table:  pollinvitation.ValidColumn,
+			polloption.Table:      polloption.ValidColumn,
+			pollresult.Table:      pollresult.ValidColumn,
+			recoverycode.Table:    recoverycode.ValidColumn,
+			securityevent.Table:   securityevent.ValidColumn,
+			signingkey.Table:      signingkey.ValidColumn,
+			user.Table:            user.ValidColumn,
+			usertoken.Table:       usertoken.ValidColumn,
+			vote.Table:            vote.ValidColumn,
+			webhookdelivery.Table: webhookdelivery.ValidColumn,
+			webhookendpoint.Table: webhookendpoint.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -3,17 +3,143 @@
 package migrate
 
 import (
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/dialect/sql/schema"
 	"entgo.io/ent/schema/field"
 )
 
 var (
+	// BallotsColumns holds the columns for the "ballots" table.
+	BallotsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "option_ids", Type: field.TypeJSON, Nullable: true},
+		{Name: "option", Type: field.TypeString, Nullable: true},
+		{Name: "choices", Type: field.TypeJSON, Nullable: true},
+		{Name: "poll_id", Type: field.TypeUUID},
+		{Name: "option_id", Type: field.TypeUUID, Nullable: true},
+	}
+	// BallotsTable holds the schema information for the "ballots" table.
+	BallotsTable = &schema.Table{
+		Name:       "ballots",
+		Columns:    BallotsColumns,
+		PrimaryKey: []*schema.Column{BallotsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "ballots_polls_poll",
+				Columns:    []*schema.Column{BallotsColumns[4]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+			{
+				Symbol:     "ballots_poll_options_option",
+				Columns:    []*schema.Column{BallotsColumns[5]},
+				RefColumns: []*schema.Column{PollOptionsColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
+		},
+	}
+	// JobRunsColumns holds the columns for the "job_runs" table.
+	JobRunsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "job", Type: field.TypeString},
+		{Name: "instance", Type: field.TypeString},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"running", "succeeded", "failed"}, Default: "running"},
+		{Name: "affected", Type: field.TypeInt, Default: 0},
+		{Name: "error", Type: field.TypeString, Nullable: true},
+		{Name: "started_at", Type: field.TypeTime},
+		{Name: "finished_at", Type: field.TypeTime, Nullable: true},
+	}
+	// JobRunsTable holds the schema information for the "job_runs" table.
+	JobRunsTable = &schema.Table{
+		Name:       "job_runs",
+		Columns:    JobRunsColumns,
+		PrimaryKey: []*schema.Column{JobRunsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "jobrun_job_started_at",
+				Unique:  false,
+				Columns: []*schema.Column{JobRunsColumns[1], JobRunsColumns[6]},
+			},
+		},
+	}
+	// ParticipationsColumns holds the columns for the "participations" table.
+	ParticipationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "user_id", Type: field.TypeUUID},
+		{Name: "poll_id", Type: field.TypeUUID},
+	}
+	// ParticipationsTable holds the schema information for the "participations" table.
+	ParticipationsTable = &schema.Table{
+		Name:       "participations",
+		Columns:    ParticipationsColumns,
+		PrimaryKey: []*schema.Column{ParticipationsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "participations_users_user",
+				Columns:    []*schema.Column{ParticipationsColumns[1]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+			{
+				Symbol:     "participations_polls_poll",
+				Columns:    []*schema.Column{ParticipationsColumns[2]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "participation_user_id_poll_id",
+				Unique:  true,
+				Columns: []*schema.Column{ParticipationsColumns[1], ParticipationsColumns[2]},
+			},
+		},
+	}
+	// PendingBallotsColumns holds the columns for the "pending_ballots" table.
+	PendingBallotsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "option_ids", Type: field.TypeJSON},
+		{Name: "poll_id", Type: field.TypeUUID},
+		{Name: "option_id", Type: field.TypeUUID},
+	}
+	// PendingBallotsTable holds the schema information for the "pending_ballots" table.
+	PendingBallotsTable = &schema.Table{
+		Name:       "pending_ballots",
+		Columns:    PendingBallotsColumns,
+		PrimaryKey: []*schema.Column{PendingBallotsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "pending_ballots_polls_poll",
+				Columns:    []*schema.Column{PendingBallotsColumns[2]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+			{
+				Symbol:     "pending_ballots_poll_options_option",
+				Columns:    []*schema.Column{PendingBallotsColumns[3]},
+				RefColumns: []*schema.Column{PollOptionsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+	}
 	// PollsColumns holds the columns for the "polls" table.
 	PollsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
 		{Name: "title", Type: field.TypeString},
 		{Name: "description", Type: field.TypeString, Nullable: true},
-		{Name: "options", Type: field.TypeJSON},
+		{Name: "options", Type: field.TypeJSON, Nullable: true},
+		{Name: "type", Type: field.TypeEnum, Enums: []string{"single", "ranked", "approval"}, Default: "single"},
+		{Name: "min_choices", Type: field.TypeInt, Default: 1},
+		{Name: "max_choices", Type: field.TypeInt, Nullable: true},
+		{Name: "anonymous", Type: field.TypeBool, Default: false},
+		{Name: "results_visibility", Type: field.TypeEnum, Enums: []string{"always", "after_vote", "after_close", "owner_only"}, Default: "always"},
+		{Name: "require_verified_email", Type: field.TypeBool, Default: false},
+		{Name: "external_id", Type: field.TypeString, Nullable: true},
+		{Name: "opens_at", Type: field.TypeTime, Nullable: true},
+		{Name: "closes_at", Type: field.TypeTime, Nullable: true},
+		{Name: "version", Type: field.TypeInt, Default: 1},
+		{Name: "search_vector", Type: field.TypeString, Nullable: true, SchemaType: map[string]string{"postgres": "tsvector"}},
+		{Name: "voter_count", Type: field.TypeInt, Default: 0},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 		{Name: "owner_id", Type: field.TypeUUID},
@@ -27,17 +153,212 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "polls_users_owner",
-				Columns:    []*schema.Column{PollsColumns[6]},
+				Columns:    []*schema.Column{PollsColumns[18]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "polls_users_polls",
-				Columns:    []*schema.Column{PollsColumns[7]},
+				Columns:    []*schema.Column{PollsColumns[19]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.SetNull,
 			},
 		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "poll_created_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[16], PollsColumns[0]},
+			},
+			{
+				Name:    "poll_closes_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[12], PollsColumns[0]},
+			},
+			{
+				Name:    "poll_voter_count_id",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[15], PollsColumns[0]},
+			},
+			{
+				Name:    "poll_owner_id_external_id",
+				Unique:  true,
+				Columns: []*schema.Column{PollsColumns[18], PollsColumns[10]},
+			},
+			{
+				Name:    "poll_search_vector",
+				Unique:  false,
+				Columns: []*schema.Column{PollsColumns[14]},
+				Annotation: &entsql.IndexAnnotation{
+					Type: "GIN",
+				},
+			},
+		},
+	}
+	// PollInvitationsColumns holds the columns for the "poll_invitations" table.
+	PollInvitationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "email", Type: field.TypeString},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "expired"}, Default: "pending"},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "reminded_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "poll_id", Type: field.TypeUUID},
+	}
+	// PollInvitationsTable holds the schema information for the "poll_invitations" table.
+	PollInvitationsTable = &schema.Table{
+		Name:       "poll_invitations",
+		Columns:    PollInvitationsColumns,
+		PrimaryKey: []*schema.Column{PollInvitationsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "poll_invitations_polls_poll",
+				Columns:    []*schema.Column{PollInvitationsColumns[6]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "pollinvitation_poll_id_email",
+				Unique:  true,
+				Columns: []*schema.Column{PollInvitationsColumns[6], PollInvitationsColumns[1]},
+			},
+			{
+				Name:    "pollinvitation_status_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{PollInvitationsColumns[2], PollInvitationsColumns[3]},
+			},
+		},
+	}
+	// PollOptionsColumns holds the columns for the "poll_options" table.
+	PollOptionsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "label", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "position", Type: field.TypeInt},
+		{Name: "image_url", Type: field.TypeString, Nullable: true},
+		{Name: "vote_count", Type: field.TypeInt, Default: 0},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "poll_id", Type: field.TypeUUID},
+	}
+	// PollOptionsTable holds the schema information for the "poll_options" table.
+	PollOptionsTable = &schema.Table{
+		Name:       "poll_options",
+		Columns:    PollOptionsColumns,
+		PrimaryKey: []*schema.Column{PollOptionsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "poll_options_polls_poll",
+				Columns:    []*schema.Column{PollOptionsColumns[7]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "polloption_poll_id_position",
+				Unique:  false,
+				Columns: []*schema.Column{PollOptionsColumns[7], PollOptionsColumns[3]},
+			},
+		},
+	}
+	// PollResultsColumns holds the columns for the "poll_results" table.
+	PollResultsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "counts", Type: field.TypeJSON},
+		{Name: "voters", Type: field.TypeInt},
+		{Name: "ranked", Type: field.TypeJSON, Nullable: true},
+		{Name: "finalized_at", Type: field.TypeTime},
+		{Name: "poll_id", Type: field.TypeUUID, Unique: true},
+	}
+	// PollResultsTable holds the schema information for the "poll_results" table.
+	PollResultsTable = &schema.Table{
+		Name:       "poll_results",
+		Columns:    PollResultsColumns,
+		PrimaryKey: []*schema.Column{PollResultsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "poll_results_polls_result",
+				Columns:    []*schema.Column{PollResultsColumns[5]},
+				RefColumns: []*schema.Column{PollsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+	}
+	// RecoveryCodesColumns holds the columns for the "recovery_codes" table.
+	RecoveryCodesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "code_hash", Type: field.TypeString},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// RecoveryCodesTable holds the schema information for the "recovery_codes" table.
+	RecoveryCodesTable = &schema.Table{
+		Name:       "recovery_codes",
+		Columns:    RecoveryCodesColumns,
+		PrimaryKey: []*schema.Column{RecoveryCodesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "recovery_codes_users_user",
+				Columns:    []*schema.Column{RecoveryCodesColumns[4]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "recoverycode_user_id_code_hash",
+				Unique:  true,
+				Columns: []*schema.Column{RecoveryCodesColumns[4], RecoveryCodesColumns[1]},
+			},
+		},
+	}
+	// SecurityEventsColumns holds the columns for the "security_events" table.
+	SecurityEventsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "type", Type: field.TypeEnum, Enums: []string{"refresh_token_reused"}},
+		{Name: "session_id", Type: field.TypeString, Nullable: true},
+		{Name: "ip_address", Type: field.TypeString, Nullable: true},
+		{Name: "user_agent", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// SecurityEventsTable holds the schema information for the "security_events" table.
+	SecurityEventsTable = &schema.Table{
+		Name:       "security_events",
+		Columns:    SecurityEventsColumns,
+		PrimaryKey: []*schema.Column{SecurityEventsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "security_events_users_user",
+				Columns:    []*schema.Column{SecurityEventsColumns[6]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "securityevent_user_id_created_at",
+				Unique:  false,
+				Columns: []*schema.Column{SecurityEventsColumns[6], SecurityEventsColumns[5]},
+			},
+		},
+	}
+	// SigningKeysColumns holds the columns for the "signing_keys" table.
+	SigningKeysColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeString},
+		{Name: "algorithm", Type: field.TypeEnum, Enums: []string{"RS256", "ES256", "EdDSA"}},
+		{Name: "private_key", Type: field.TypeBytes},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "retired_at", Type: field.TypeTime, Nullable: true},
+	}
+	// SigningKeysTable holds the schema information for the "signing_keys" table.
+	SigningKeysTable = &schema.Table{
+		Name:       "signing_keys",
+		Columns:    SigningKeysColumns,
+		PrimaryKey: []*schema.Column{SigningKeysColumns[0]},
 	}
 	// UsersColumns holds the columns for the "users" table.
 	UsersColumns = []*schema.Column{
@@ -45,6 +366,10 @@ var (
 		{Name: "email", Type: field.TypeString, Unique: true},
 		{Name: "username", Type: field.TypeString, Unique: true},
 		{Name: "password", Type: field.TypeString},
+		{Name: "email_verified_at", Type: field.TypeTime, Nullable: true},
+		{Name: "mfa_secret", Type: field.TypeString, Nullable: true},
+		{Name: "mfa_enabled_at", Type: field.TypeTime, Nullable: true},
+		{Name: "mfa_last_step", Type: field.TypeInt64, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "updated_at", Type: field.TypeTime},
 	}
@@ -54,13 +379,53 @@ var (
 		Columns:    UsersColumns,
 		PrimaryKey: []*schema.Column{UsersColumns[0]},
 	}
+	// UserTokensColumns holds the columns for the "user_tokens" table.
+	UserTokensColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "purpose", Type: field.TypeEnum, Enums: []string{"password_reset", "email_verification"}},
+		{Name: "token_hash", Type: field.TypeString, Unique: true},
+		{Name: "email", Type: field.TypeString},
+		{Name: "expires_at", Type: field.TypeTime},
+		{Name: "used_at", Type: field.TypeTime, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "user_id", Type: field.TypeUUID},
+	}
+	// UserTokensTable holds the schema information for the "user_tokens" table.
+	UserTokensTable = &schema.Table{
+		Name:       "user_tokens",
+		Columns:    UserTokensColumns,
+		PrimaryKey: []*schema.Column{UserTokensColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "user_tokens_users_user",
+				Columns:    []*schema.Column{UserTokensColumns[7]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "usertoken_user_id_purpose",
+				Unique:  false,
+				Columns: []*schema.Column{UserTokensColumns[7], UserTokensColumns[1]},
+			},
+			{
+				Name:    "usertoken_expires_at",
+				Unique:  false,
+				Columns: []*schema.Column{UserTokensColumns[4]},
+			},
+		},
+	}
 	// VotesColumns holds the columns for the "votes" table.
 	VotesColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUUID},
-		{Name: "option", Type: field.TypeString},
+		{Name: "option_ids", Type: field.TypeJSON, Nullable: true},
+		{Name: "option", Type: field.TypeString, Nullable: true},
+		{Name: "choices", Type: field.TypeJSON, Nullable: true},
 		{Name: "created_at", Type: field.TypeTime},
 		{Name: "user_id", Type: field.TypeUUID},
 		{Name: "poll_id", Type: field.TypeUUID},
+		{Name: "option_id", Type: field.TypeUUID, Nullable: true},
 	}
 	// VotesTable holds the schema information for the "votes" table.
 	VotesTable = &schema.Table{
@@ -70,36 +435,154 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "votes_users_user",
-				Columns:    []*schema.Column{VotesColumns[3]},
+				Columns:    []*schema.Column{VotesColumns[5]},
 				RefColumns: []*schema.Column{UsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "votes_polls_poll",
-				Columns:    []*schema.Column{VotesColumns[4]},
+				Columns:    []*schema.Column{VotesColumns[6]},
 				RefColumns: []*schema.Column{PollsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
+			{
+				Symbol:     "votes_poll_options_option",
+				Columns:    []*schema.Column{VotesColumns[7]},
+				RefColumns: []*schema.Column{PollOptionsColumns[0]},
+				OnDelete:   schema.SetNull,
+			},
 		},
 		Indexes: []*schema.Index{
 			{
 				Name:    "vote_user_id_poll_id",
 				Unique:  true,
-				Columns: []*schema.Column{VotesColumns[3], VotesColumns[4]},
+				Columns: []*schema.Column{VotesColumns[5], VotesColumns[6]},
+			},
+			{
+				Name:    "vote_poll_id_option_id",
+				Unique:  false,
+				Columns: []*schema.Column{VotesColumns[6], VotesColumns[7]},
+			},
+			{
+				Name:    "vote_poll_id_created_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{VotesColumns[6], VotesColumns[4], VotesColumns[0]},
+			},
+		},
+	}
+	// WebhookDeliveriesColumns holds the columns for the "webhook_deliveries" table.
+	WebhookDeliveriesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "event_id", Type: field.TypeUUID},
+		{Name: "event", Type: field.TypeString},
+		{Name: "payload", Type: field.TypeJSON},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "succeeded", "dead"}, Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "next_attempt_at", Type: field.TypeTime},
+		{Name: "last_attempt_at", Type: field.TypeTime, Nullable: true},
+		{Name: "response_status", Type: field.TypeInt, Nullable: true},
+		{Name: "last_error", Type: field.TypeString, Nullable: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "endpoint_id", Type: field.TypeUUID},
+	}
+	// WebhookDeliveriesTable holds the schema information for the "webhook_deliveries" table.
+	WebhookDeliveriesTable = &schema.Table{
+		Name:       "webhook_deliveries",
+		Columns:    WebhookDeliveriesColumns,
+		PrimaryKey: []*schema.Column{WebhookDeliveriesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "webhook_deliveries_webhook_endpoints_endpoint",
+				Columns:    []*schema.Column{WebhookDeliveriesColumns[11]},
+				RefColumns: []*schema.Column{WebhookEndpointsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "webhookdelivery_status_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{WebhookDeliveriesColumns[4], WebhookDeliveriesColumns[6]},
+			},
+			{
+				Name:    "webhookdelivery_endpoint_id_created_at_id",
+				Unique:  false,
+				Columns: []*schema.Column{WebhookDeliveriesColumns[11], WebhookDeliveriesColumns[10], WebhookDeliveriesColumns[0]},
+			},
+		},
+	}
+	// WebhookEndpointsColumns holds the columns for the "webhook_endpoints" table.
+	WebhookEndpointsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUUID},
+		{Name: "url", Type: field.TypeString},
+		{Name: "description", Type: field.TypeString, Nullable: true},
+		{Name: "secret", Type: field.TypeString},
+		{Name: "events", Type: field.TypeJSON},
+		{Name: "active", Type: field.TypeBool, Default: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "owner_id", Type: field.TypeUUID},
+	}
+	// WebhookEndpointsTable holds the schema information for the "webhook_endpoints" table.
+	WebhookEndpointsTable = &schema.Table{
+		Name:       "webhook_endpoints",
+		Columns:    WebhookEndpointsColumns,
+		PrimaryKey: []*schema.Column{WebhookEndpointsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "webhook_endpoints_users_owner",
+				Columns:    []*schema.Column{WebhookEndpointsColumns[8]},
+				RefColumns: []*schema.Column{UsersColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "webhookendpoint_owner_id",
+				Unique:  false,
+				Columns: []*schema.Column{WebhookEndpointsColumns[8]},
 			},
 		},
 	}
 	// Tables holds all the tables in the schema.
 	Tables = []*schema.Table{
+		BallotsTable,
+		JobRunsTable,
+		ParticipationsTable,
+		PendingBallotsTable,
 		PollsTable,
+		PollInvitationsTable,
+		PollOptionsTable,
+		PollResultsTable,
+		RecoveryCodesTable,
+		SecurityEventsTable,
+		SigningKeysTable,
 		UsersTable,
+		UserTokensTable,
 		VotesTable,
+		WebhookDeliveriesTable,
+		WebhookEndpointsTable,
 	}
 )
 
 func init() {
+	BallotsTable.ForeignKeys[0].RefTable = PollsTable
+	BallotsTable.ForeignKeys[1].RefTable = PollOptionsTable
+	ParticipationsTable.ForeignKeys[0].RefTable = UsersTable
+	ParticipationsTable.ForeignKeys[1].RefTable = PollsTable
+	PendingBallotsTable.ForeignKeys[0].RefTable = PollsTable
+	PendingBallotsTable.ForeignKeys[1].RefTable = PollOptionsTable
 	PollsTable.ForeignKeys[0].RefTable = UsersTable
 	PollsTable.ForeignKeys[1].RefTable = UsersTable
+	PollInvitationsTable.ForeignKeys[0].RefTable = PollsTable
+	PollOptionsTable.ForeignKeys[0].RefTable = PollsTable
+	PollResultsTable.ForeignKeys[0].RefTable = PollsTable
+	RecoveryCodesTable.ForeignKeys[0].RefTable = UsersTable
+	SecurityEventsTable.ForeignKeys[0].RefTable = UsersTable
+	UserTokensTable.ForeignKeys[0].RefTable = UsersTable
 	VotesTable.ForeignKeys[0].RefTable = UsersTable
 	VotesTable.ForeignKeys[1].RefTable = PollsTable
+	VotesTable.ForeignKeys[2].RefTable = PollOptionsTable
+	WebhookDeliveriesTable.ForeignKeys[0].RefTable = WebhookEndpointsTable
+	WebhookEndpointsTable.ForeignKeys[0].RefTable = UsersTable
 }
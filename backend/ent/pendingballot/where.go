@@ -0,0 +1,167 @@
+// Code generated by ent, DO NOT EDIT.
+
+package pendingballot
+
+import (
+	"poll-app/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldLTE(FieldID, id))
+}
+
+// PollID applies equality check predicate on the "poll_id" field. It's identical to PollIDEQ.
+func PollID(v uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldEQ(FieldPollID, v))
+}
+
+// OptionID applies equality check predicate on the "option_id" field. It's identical to OptionIDEQ.
+func OptionID(v uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldEQ(FieldOptionID, v))
+}
+
+// PollIDEQ applies the EQ predicate on the "poll_id" field.
+func PollIDEQ(v uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldEQ(FieldPollID, v))
+}
+
+// PollIDNEQ applies the NEQ predicate on the "poll_id" field.
+func PollIDNEQ(v uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldNEQ(FieldPollID, v))
+}
+
+// PollIDIn applies the In predicate on the "poll_id" field.
+func PollIDIn(vs ...uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldIn(FieldPollID, vs...))
+}
+
+// PollIDNotIn applies the NotIn predicate on the "poll_id" field.
+func PollIDNotIn(vs ...uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldNotIn(FieldPollID, vs...))
+}
+
+// OptionIDEQ applies the EQ predicate on the "option_id" field.
+func OptionIDEQ(v uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldEQ(FieldOptionID, v))
+}
+
+// OptionIDNEQ applies the NEQ predicate on the "option_id" field.
+func OptionIDNEQ(v uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldNEQ(FieldOptionID, v))
+}
+
+// OptionIDIn applies the In predicate on the "option_id" field.
+func OptionIDIn(vs ...uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldIn(FieldOptionID, vs...))
+}
+
+// OptionIDNotIn applies the NotIn predicate on the "option_id" field.
+func OptionIDNotIn(vs ...uuid.UUID) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.FieldNotIn(FieldOptionID, vs...))
+}
+
+// HasPoll applies the HasEdge predicate on the "poll" edge.
+func HasPoll() predicate.PendingBallot {
+	return predicate.PendingBallot(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, PollTable, PollColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasPollWith applies the HasEdge predicate on the "poll" edge with a given conditions (other predicates).
+func HasPollWith(preds ...predicate.Poll) predicate.PendingBallot {
+	return predicate.PendingBallot(func(s *sql.Selector) {
+		step := newPollStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasOption applies the HasEdge predicate on the "option" edge.
+func HasOption() predicate.PendingBallot {
+	return predicate.PendingBallot(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, OptionTable, OptionColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOptionWith applies the HasEdge predicate on the "option" edge with a given conditions (other predicates).
+func HasOptionWith(preds ...predicate.PollOption) predicate.PendingBallot {
+	return predicate.PendingBallot(func(s *sql.Selector) {
+		step := newOptionStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.PendingBallot) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.PendingBallot) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.PendingBallot) predicate.PendingBallot {
+	return predicate.PendingBallot(sql.NotPredicates(p))
+}
@@ -0,0 +1,283 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll-app/ent/pendingballot"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PendingBallotCreate is the builder for creating a PendingBallot entity.
+type PendingBallotCreate struct {
+	config
+	mutation *PendingBallotMutation
+	hooks    []Hook
+}
+
+// SetPollID sets the "poll_id" field.
+func (_c *PendingBallotCreate) SetPollID(v uuid.UUID) *PendingBallotCreate {
+	_c.mutation.SetPollID(v)
+	return _c
+}
+
+// SetOptionID sets the "option_id" field.
+func (_c *PendingBallotCreate) SetOptionID(v uuid.UUID) *PendingBallotCreate {
+	_c.mutation.SetOptionID(v)
+	return _c
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_c *PendingBallotCreate) SetOptionIds(v []uuid.UUID) *PendingBallotCreate {
+	_c.mutation.SetOptionIds(v)
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *PendingBallotCreate) SetID(v uuid.UUID) *PendingBallotCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *PendingBallotCreate) SetNillableID(v *uuid.UUID) *PendingBallotCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_c *PendingBallotCreate) SetPoll(v *Poll) *PendingBallotCreate {
+	return _c.SetPollID(v.ID)
+}
+
+// SetOption sets the "option" edge to the PollOption entity.
+func (_c *PendingBallotCreate) SetOption(v *PollOption) *PendingBallotCreate {
+	return _c.SetOptionID(v.ID)
+}
+
+// Mutation returns the PendingBallotMutation object of the builder.
+func (_c *PendingBallotCreate) Mutation() *PendingBallotMutation {
+	return _c.mutation
+}
+
+// Save creates the PendingBallot in the database.
+func (_c *PendingBallotCreate) Save(ctx context.Context) (*PendingBallot, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *PendingBallotCreate) SaveX(ctx context.Context) *PendingBallot {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PendingBallotCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PendingBallotCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *PendingBallotCreate) defaults() {
+	if _, ok := _c.mutation.ID(); !ok {
+		v := pendingballot.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *PendingBallotCreate) check() error {
+	if _, ok := _c.mutation.PollID(); !ok {
+		return &ValidationError{Name: "poll_id", err: errors.New(`ent: missing required field "PendingBallot.poll_id"`)}
+	}
+	if _, ok := _c.mutation.OptionID(); !ok {
+		return &ValidationError{Name: "option_id", err: errors.New(`ent: missing required field "PendingBallot.option_id"`)}
+	}
+	if _, ok := _c.mutation.OptionIds(); !ok {
+		return &ValidationError{Name: "option_ids", err: errors.New(`ent: missing required field "PendingBallot.option_ids"`)}
+	}
+	if len(_c.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "PendingBallot.poll"`)}
+	}
+	if len(_c.mutation.OptionIDs()) == 0 {
+		return &ValidationError{Name: "option", err: errors.New(`ent: missing required edge "PendingBallot.option"`)}
+	}
+	return nil
+}
+
+func (_c *PendingBallotCreate) sqlSave(ctx context.Context) (*PendingBallot, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *PendingBallotCreate) createSpec() (*PendingBallot, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PendingBallot{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(pendingballot.Table, sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.OptionIds(); ok {
+		_spec.SetField(pendingballot.FieldOptionIds, field.TypeJSON, value)
+		_node.OptionIds = value
+	}
+	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.PollTable,
+			Columns: []string{pendingballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PollID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.OptionTable,
+			Columns: []string{pendingballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OptionID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PendingBallotCreateBulk is the builder for creating many PendingBallot entities in bulk.
+type PendingBallotCreateBulk struct {
+	config
+	err      error
+	builders []*PendingBallotCreate
+}
+
+// Save creates the PendingBallot entities in the database.
+func (_c *PendingBallotCreateBulk) Save(ctx context.Context) ([]*PendingBallot, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*PendingBallot, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PendingBallotMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *PendingBallotCreateBulk) SaveX(ctx context.Context) []*PendingBallot {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PendingBallotCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PendingBallotCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,463 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll-app/ent/pendingballot"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+	"poll-app/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PendingBallotUpdate is the builder for updating PendingBallot entities.
+type PendingBallotUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PendingBallotMutation
+}
+
+// Where appends a list predicates to the PendingBallotUpdate builder.
+func (_u *PendingBallotUpdate) Where(ps ...predicate.PendingBallot) *PendingBallotUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *PendingBallotUpdate) SetPollID(v uuid.UUID) *PendingBallotUpdate {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *PendingBallotUpdate) SetNillablePollID(v *uuid.UUID) *PendingBallotUpdate {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetOptionID sets the "option_id" field.
+func (_u *PendingBallotUpdate) SetOptionID(v uuid.UUID) *PendingBallotUpdate {
+	_u.mutation.SetOptionID(v)
+	return _u
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_u *PendingBallotUpdate) SetNillableOptionID(v *uuid.UUID) *PendingBallotUpdate {
+	if v != nil {
+		_u.SetOptionID(*v)
+	}
+	return _u
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_u *PendingBallotUpdate) SetOptionIds(v []uuid.UUID) *PendingBallotUpdate {
+	_u.mutation.SetOptionIds(v)
+	return _u
+}
+
+// AppendOptionIds appends value to the "option_ids" field.
+func (_u *PendingBallotUpdate) AppendOptionIds(v []uuid.UUID) *PendingBallotUpdate {
+	_u.mutation.AppendOptionIds(v)
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PendingBallotUpdate) SetPoll(v *Poll) *PendingBallotUpdate {
+	return _u.SetPollID(v.ID)
+}
+
+// SetOption sets the "option" edge to the PollOption entity.
+func (_u *PendingBallotUpdate) SetOption(v *PollOption) *PendingBallotUpdate {
+	return _u.SetOptionID(v.ID)
+}
+
+// Mutation returns the PendingBallotMutation object of the builder.
+func (_u *PendingBallotUpdate) Mutation() *PendingBallotMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PendingBallotUpdate) ClearPoll() *PendingBallotUpdate {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// ClearOption clears the "option" edge to the PollOption entity.
+func (_u *PendingBallotUpdate) ClearOption() *PendingBallotUpdate {
+	_u.mutation.ClearOption()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *PendingBallotUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PendingBallotUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *PendingBallotUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PendingBallotUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PendingBallotUpdate) check() error {
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PendingBallot.poll"`)
+	}
+	if _u.mutation.OptionCleared() && len(_u.mutation.OptionIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PendingBallot.option"`)
+	}
+	return nil
+}
+
+func (_u *PendingBallotUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pendingballot.Table, pendingballot.Columns, sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.OptionIds(); ok {
+		_spec.SetField(pendingballot.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, pendingballot.FieldOptionIds, value)
+		})
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.PollTable,
+			Columns: []string{pendingballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.PollTable,
+			Columns: []string{pendingballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OptionCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.OptionTable,
+			Columns: []string{pendingballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.OptionTable,
+			Columns: []string{pendingballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pendingballot.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// PendingBallotUpdateOne is the builder for updating a single PendingBallot entity.
+type PendingBallotUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PendingBallotMutation
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *PendingBallotUpdateOne) SetPollID(v uuid.UUID) *PendingBallotUpdateOne {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *PendingBallotUpdateOne) SetNillablePollID(v *uuid.UUID) *PendingBallotUpdateOne {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetOptionID sets the "option_id" field.
+func (_u *PendingBallotUpdateOne) SetOptionID(v uuid.UUID) *PendingBallotUpdateOne {
+	_u.mutation.SetOptionID(v)
+	return _u
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_u *PendingBallotUpdateOne) SetNillableOptionID(v *uuid.UUID) *PendingBallotUpdateOne {
+	if v != nil {
+		_u.SetOptionID(*v)
+	}
+	return _u
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_u *PendingBallotUpdateOne) SetOptionIds(v []uuid.UUID) *PendingBallotUpdateOne {
+	_u.mutation.SetOptionIds(v)
+	return _u
+}
+
+// AppendOptionIds appends value to the "option_ids" field.
+func (_u *PendingBallotUpdateOne) AppendOptionIds(v []uuid.UUID) *PendingBallotUpdateOne {
+	_u.mutation.AppendOptionIds(v)
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PendingBallotUpdateOne) SetPoll(v *Poll) *PendingBallotUpdateOne {
+	return _u.SetPollID(v.ID)
+}
+
+// SetOption sets the "option" edge to the PollOption entity.
+func (_u *PendingBallotUpdateOne) SetOption(v *PollOption) *PendingBallotUpdateOne {
+	return _u.SetOptionID(v.ID)
+}
+
+// Mutation returns the PendingBallotMutation object of the builder.
+func (_u *PendingBallotUpdateOne) Mutation() *PendingBallotMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PendingBallotUpdateOne) ClearPoll() *PendingBallotUpdateOne {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// ClearOption clears the "option" edge to the PollOption entity.
+func (_u *PendingBallotUpdateOne) ClearOption() *PendingBallotUpdateOne {
+	_u.mutation.ClearOption()
+	return _u
+}
+
+// Where appends a list predicates to the PendingBallotUpdate builder.
+func (_u *PendingBallotUpdateOne) Where(ps ...predicate.PendingBallot) *PendingBallotUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *PendingBallotUpdateOne) Select(field string, fields ...string) *PendingBallotUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated PendingBallot entity.
+func (_u *PendingBallotUpdateOne) Save(ctx context.Context) (*PendingBallot, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PendingBallotUpdateOne) SaveX(ctx context.Context) *PendingBallot {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *PendingBallotUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PendingBallotUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PendingBallotUpdateOne) check() error {
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PendingBallot.poll"`)
+	}
+	if _u.mutation.OptionCleared() && len(_u.mutation.OptionIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PendingBallot.option"`)
+	}
+	return nil
+}
+
+func (_u *PendingBallotUpdateOne) sqlSave(ctx context.Context) (_node *PendingBallot, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pendingballot.Table, pendingballot.Columns, sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PendingBallot.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, pendingballot.FieldID)
+		for _, f := range fields {
+			if !pendingballot.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != pendingballot.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.OptionIds(); ok {
+		_spec.SetField(pendingballot.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, pendingballot.FieldOptionIds, value)
+		})
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.PollTable,
+			Columns: []string{pendingballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.PollTable,
+			Columns: []string{pendingballot.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.OptionCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.OptionTable,
+			Columns: []string{pendingballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   pendingballot.OptionTable,
+			Columns: []string{pendingballot.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &PendingBallot{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pendingballot.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"poll-app/ent/poll"
+	"poll-app/ent/pollresult"
 	"poll-app/ent/user"
 	"strings"
 	"time"
@@ -24,10 +25,34 @@ type Poll struct {
 	Title string `json:"title,omitempty"`
 	// Description holds the value of the "description" field.
 	Description string `json:"description,omitempty"`
-	// Options holds the value of the "options" field.
-	Options []string `json:"options,omitempty"`
+	// LegacyOptions holds the value of the "legacy_options" field.
+	LegacyOptions []string `json:"legacy_options,omitempty"`
+	// Type holds the value of the "type" field.
+	Type poll.Type `json:"type,omitempty"`
+	// MinChoices holds the value of the "min_choices" field.
+	MinChoices int `json:"min_choices,omitempty"`
+	// MaxChoices holds the value of the "max_choices" field.
+	MaxChoices *int `json:"max_choices,omitempty"`
+	// Anonymous holds the value of the "anonymous" field.
+	Anonymous bool `json:"anonymous,omitempty"`
+	// ResultsVisibility holds the value of the "results_visibility" field.
+	ResultsVisibility poll.ResultsVisibility `json:"results_visibility,omitempty"`
+	// RequireVerifiedEmail holds the value of the "require_verified_email" field.
+	RequireVerifiedEmail bool `json:"require_verified_email,omitempty"`
 	// OwnerID holds the value of the "owner_id" field.
 	OwnerID uuid.UUID `json:"owner_id,omitempty"`
+	// ExternalID holds the value of the "external_id" field.
+	ExternalID *string `json:"external_id,omitempty"`
+	// OpensAt holds the value of the "opens_at" field.
+	OpensAt *time.Time `json:"opens_at,omitempty"`
+	// ClosesAt holds the value of the "closes_at" field.
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// SearchVector holds the value of the "search_vector" field.
+	SearchVector string `json:"search_vector,omitempty"`
+	// VoterCount holds the value of the "voter_count" field.
+	VoterCount int `json:"voter_count,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -43,11 +68,23 @@ type Poll struct {
 type PollEdges struct {
 	// Owner holds the value of the owner edge.
 	Owner *User `json:"owner,omitempty"`
+	// Options holds the value of the options edge.
+	Options []*PollOption `json:"options,omitempty"`
 	// Votes holds the value of the votes edge.
 	Votes []*Vote `json:"votes,omitempty"`
+	// Participations holds the value of the participations edge.
+	Participations []*Participation `json:"participations,omitempty"`
+	// Ballots holds the value of the ballots edge.
+	Ballots []*Ballot `json:"ballots,omitempty"`
+	// PendingBallots holds the value of the pending_ballots edge.
+	PendingBallots []*PendingBallot `json:"pending_ballots,omitempty"`
+	// Invitations holds the value of the invitations edge.
+	Invitations []*PollInvitation `json:"invitations,omitempty"`
+	// Result holds the value of the result edge.
+	Result *PollResult `json:"result,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [8]bool
 }
 
 // OwnerOrErr returns the Owner value or an error if the edge
@@ -61,25 +98,85 @@ func (e PollEdges) OwnerOrErr() (*User, error) {
 	return nil, &NotLoadedError{edge: "owner"}
 }
 
+// OptionsOrErr returns the Options value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) OptionsOrErr() ([]*PollOption, error) {
+	if e.loadedTypes[1] {
+		return e.Options, nil
+	}
+	return nil, &NotLoadedError{edge: "options"}
+}
+
 // VotesOrErr returns the Votes value or an error if the edge
 // was not loaded in eager-loading.
 func (e PollEdges) VotesOrErr() ([]*Vote, error) {
-	if e.loadedTypes[1] {
+	if e.loadedTypes[2] {
 		return e.Votes, nil
 	}
 	return nil, &NotLoadedError{edge: "votes"}
 }
 
+// ParticipationsOrErr returns the Participations value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) ParticipationsOrErr() ([]*Participation, error) {
+	if e.loadedTypes[3] {
+		return e.Participations, nil
+	}
+	return nil, &NotLoadedError{edge: "participations"}
+}
+
+// BallotsOrErr returns the Ballots value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) BallotsOrErr() ([]*Ballot, error) {
+	if e.loadedTypes[4] {
+		return e.Ballots, nil
+	}
+	return nil, &NotLoadedError{edge: "ballots"}
+}
+
+// PendingBallotsOrErr returns the PendingBallots value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) PendingBallotsOrErr() ([]*PendingBallot, error) {
+	if e.loadedTypes[5] {
+		return e.PendingBallots, nil
+	}
+	return nil, &NotLoadedError{edge: "pending_ballots"}
+}
+
+// InvitationsOrErr returns the Invitations value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) InvitationsOrErr() ([]*PollInvitation, error) {
+	if e.loadedTypes[6] {
+		return e.Invitations, nil
+	}
+	return nil, &NotLoadedError{edge: "invitations"}
+}
+
+// ResultOrErr returns the Result value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PollEdges) ResultOrErr() (*PollResult, error) {
+	if e.Result != nil {
+		return e.Result, nil
+	} else if e.loadedTypes[7] {
+		return nil, &NotFoundError{label: pollresult.Label}
+	}
+	return nil, &NotLoadedError{edge: "result"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Poll) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case poll.FieldOptions:
+		case poll.FieldLegacyOptions:
 			values[i] = new([]byte)
-		case poll.FieldTitle, poll.FieldDescription:
+		case poll.FieldAnonymous, poll.FieldRequireVerifiedEmail:
+			values[i] = new(sql.NullBool)
+		case poll.FieldMinChoices, poll.FieldMaxChoices, poll.FieldVersion, poll.FieldVoterCount:
+			values[i] = new(sql.NullInt64)
+		case poll.FieldTitle, poll.FieldDescription, poll.FieldType, poll.FieldResultsVisibility, poll.FieldExternalID, poll.FieldSearchVector:
 			values[i] = new(sql.NullString)
-		case poll.FieldCreatedAt, poll.FieldUpdatedAt:
+		case poll.FieldOpensAt, poll.FieldClosesAt, poll.FieldCreatedAt, poll.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		case poll.FieldID, poll.FieldOwnerID:
 			values[i] = new(uuid.UUID)
@@ -118,20 +215,96 @@ func (_m *Poll) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Description = value.String
 			}
-		case poll.FieldOptions:
+		case poll.FieldLegacyOptions:
 			if value, ok := values[i].(*[]byte); !ok {
-				return fmt.Errorf("unexpected type %T for field options", values[i])
+				return fmt.Errorf("unexpected type %T for field legacy_options", values[i])
 			} else if value != nil && len(*value) > 0 {
-				if err := json.Unmarshal(*value, &_m.Options); err != nil {
-					return fmt.Errorf("unmarshal field options: %w", err)
+				if err := json.Unmarshal(*value, &_m.LegacyOptions); err != nil {
+					return fmt.Errorf("unmarshal field legacy_options: %w", err)
 				}
 			}
+		case poll.FieldType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field type", values[i])
+			} else if value.Valid {
+				_m.Type = poll.Type(value.String)
+			}
+		case poll.FieldMinChoices:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field min_choices", values[i])
+			} else if value.Valid {
+				_m.MinChoices = int(value.Int64)
+			}
+		case poll.FieldMaxChoices:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_choices", values[i])
+			} else if value.Valid {
+				_m.MaxChoices = new(int)
+				*_m.MaxChoices = int(value.Int64)
+			}
+		case poll.FieldAnonymous:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field anonymous", values[i])
+			} else if value.Valid {
+				_m.Anonymous = value.Bool
+			}
+		case poll.FieldResultsVisibility:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field results_visibility", values[i])
+			} else if value.Valid {
+				_m.ResultsVisibility = poll.ResultsVisibility(value.String)
+			}
+		case poll.FieldRequireVerifiedEmail:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field require_verified_email", values[i])
+			} else if value.Valid {
+				_m.RequireVerifiedEmail = value.Bool
+			}
 		case poll.FieldOwnerID:
 			if value, ok := values[i].(*uuid.UUID); !ok {
 				return fmt.Errorf("unexpected type %T for field owner_id", values[i])
 			} else if value != nil {
 				_m.OwnerID = *value
 			}
+		case poll.FieldExternalID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field external_id", values[i])
+			} else if value.Valid {
+				_m.ExternalID = new(string)
+				*_m.ExternalID = value.String
+			}
+		case poll.FieldOpensAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field opens_at", values[i])
+			} else if value.Valid {
+				_m.OpensAt = new(time.Time)
+				*_m.OpensAt = value.Time
+			}
+		case poll.FieldClosesAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field closes_at", values[i])
+			} else if value.Valid {
+				_m.ClosesAt = new(time.Time)
+				*_m.ClosesAt = value.Time
+			}
+		case poll.FieldVersion:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[i])
+			} else if value.Valid {
+				_m.Version = int(value.Int64)
+			}
+		case poll.FieldSearchVector:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field search_vector", values[i])
+			} else if value.Valid {
+				_m.SearchVector = value.String
+			}
+		case poll.FieldVoterCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field voter_count", values[i])
+			} else if value.Valid {
+				_m.VoterCount = int(value.Int64)
+			}
 		case poll.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -169,11 +342,41 @@ func (_m *Poll) QueryOwner() *UserQuery {
 	return NewPollClient(_m.config).QueryOwner(_m)
 }
 
+// QueryOptions queries the "options" edge of the Poll entity.
+func (_m *Poll) QueryOptions() *PollOptionQuery {
+	return NewPollClient(_m.config).QueryOptions(_m)
+}
+
 // QueryVotes queries the "votes" edge of the Poll entity.
 func (_m *Poll) QueryVotes() *VoteQuery {
 	return NewPollClient(_m.config).QueryVotes(_m)
 }
 
+// QueryParticipations queries the "participations" edge of the Poll entity.
+func (_m *Poll) QueryParticipations() *ParticipationQuery {
+	return NewPollClient(_m.config).QueryParticipations(_m)
+}
+
+// QueryBallots queries the "ballots" edge of the Poll entity.
+func (_m *Poll) QueryBallots() *BallotQuery {
+	return NewPollClient(_m.config).QueryBallots(_m)
+}
+
+// QueryPendingBallots queries the "pending_ballots" edge of the Poll entity.
+func (_m *Poll) QueryPendingBallots() *PendingBallotQuery {
+	return NewPollClient(_m.config).QueryPendingBallots(_m)
+}
+
+// QueryInvitations queries the "invitations" edge of the Poll entity.
+func (_m *Poll) QueryInvitations() *PollInvitationQuery {
+	return NewPollClient(_m.config).QueryInvitations(_m)
+}
+
+// QueryResult queries the "result" edge of the Poll entity.
+func (_m *Poll) QueryResult() *PollResultQuery {
+	return NewPollClient(_m.config).QueryResult(_m)
+}
+
 // Update returns a builder for updating this Poll.
 // Note that you need to call Poll.Unwrap() before calling this method if this Poll
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -203,12 +406,56 @@ func (_m *Poll) String() string {
 	builder.WriteString("description=")
 	builder.WriteString(_m.Description)
 	builder.WriteString(", ")
-	builder.WriteString("options=")
-	builder.WriteString(fmt.Sprintf("%v", _m.Options))
+	builder.WriteString("legacy_options=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LegacyOptions))
+	builder.WriteString(", ")
+	builder.WriteString("type=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Type))
+	builder.WriteString(", ")
+	builder.WriteString("min_choices=")
+	builder.WriteString(fmt.Sprintf("%v", _m.MinChoices))
+	builder.WriteString(", ")
+	if v := _m.MaxChoices; v != nil {
+		builder.WriteString("max_choices=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("anonymous=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Anonymous))
+	builder.WriteString(", ")
+	builder.WriteString("results_visibility=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResultsVisibility))
+	builder.WriteString(", ")
+	builder.WriteString("require_verified_email=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequireVerifiedEmail))
 	builder.WriteString(", ")
 	builder.WriteString("owner_id=")
 	builder.WriteString(fmt.Sprintf("%v", _m.OwnerID))
 	builder.WriteString(", ")
+	if v := _m.ExternalID; v != nil {
+		builder.WriteString("external_id=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	if v := _m.OpensAt; v != nil {
+		builder.WriteString("opens_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.ClosesAt; v != nil {
+		builder.WriteString("closes_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Version))
+	builder.WriteString(", ")
+	builder.WriteString("search_vector=")
+	builder.WriteString(_m.SearchVector)
+	builder.WriteString(", ")
+	builder.WriteString("voter_count=")
+	builder.WriteString(fmt.Sprintf("%v", _m.VoterCount))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -3,6 +3,7 @@
 package poll
 
 import (
+	"fmt"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -19,18 +20,54 @@ const (
 	FieldTitle = "title"
 	// FieldDescription holds the string denoting the description field in the database.
 	FieldDescription = "description"
-	// FieldOptions holds the string denoting the options field in the database.
-	FieldOptions = "options"
+	// FieldLegacyOptions holds the string denoting the legacy_options field in the database.
+	FieldLegacyOptions = "options"
+	// FieldType holds the string denoting the type field in the database.
+	FieldType = "type"
+	// FieldMinChoices holds the string denoting the min_choices field in the database.
+	FieldMinChoices = "min_choices"
+	// FieldMaxChoices holds the string denoting the max_choices field in the database.
+	FieldMaxChoices = "max_choices"
+	// FieldAnonymous holds the string denoting the anonymous field in the database.
+	FieldAnonymous = "anonymous"
+	// FieldResultsVisibility holds the string denoting the results_visibility field in the database.
+	FieldResultsVisibility = "results_visibility"
+	// FieldRequireVerifiedEmail holds the string denoting the require_verified_email field in the database.
+	FieldRequireVerifiedEmail = "require_verified_email"
 	// FieldOwnerID holds the string denoting the owner_id field in the database.
 	FieldOwnerID = "owner_id"
+	// FieldExternalID holds the string denoting the external_id field in the database.
+	FieldExternalID = "external_id"
+	// FieldOpensAt holds the string denoting the opens_at field in the database.
+	FieldOpensAt = "opens_at"
+	// FieldClosesAt holds the string denoting the closes_at field in the database.
+	FieldClosesAt = "closes_at"
+	// FieldVersion holds the string denoting the version field in the database.
+	FieldVersion = "version"
+	// FieldSearchVector holds the string denoting the search_vector field in the database.
+	FieldSearchVector = "search_vector"
+	// FieldVoterCount holds the string denoting the voter_count field in the database.
+	FieldVoterCount = "voter_count"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
 	FieldUpdatedAt = "updated_at"
 	// EdgeOwner holds the string denoting the owner edge name in mutations.
 	EdgeOwner = "owner"
+	// EdgeOptions holds the string denoting the options edge name in mutations.
+	EdgeOptions = "options"
 	// EdgeVotes holds the string denoting the votes edge name in mutations.
 	EdgeVotes = "votes"
+	// EdgeParticipations holds the string denoting the participations edge name in mutations.
+	EdgeParticipations = "participations"
+	// EdgeBallots holds the string denoting the ballots edge name in mutations.
+	EdgeBallots = "ballots"
+	// EdgePendingBallots holds the string denoting the pending_ballots edge name in mutations.
+	EdgePendingBallots = "pending_ballots"
+	// EdgeInvitations holds the string denoting the invitations edge name in mutations.
+	EdgeInvitations = "invitations"
+	// EdgeResult holds the string denoting the result edge name in mutations.
+	EdgeResult = "result"
 	// Table holds the table name of the poll in the database.
 	Table = "polls"
 	// OwnerTable is the table that holds the owner relation/edge.
@@ -40,6 +77,13 @@ const (
 	OwnerInverseTable = "users"
 	// OwnerColumn is the table column denoting the owner relation/edge.
 	OwnerColumn = "owner_id"
+	// OptionsTable is the table that holds the options relation/edge.
+	OptionsTable = "poll_options"
+	// OptionsInverseTable is the table name for the PollOption entity.
+	// It exists in this package in order to avoid circular dependency with the "polloption" package.
+	OptionsInverseTable = "poll_options"
+	// OptionsColumn is the table column denoting the options relation/edge.
+	OptionsColumn = "poll_id"
 	// VotesTable is the table that holds the votes relation/edge.
 	VotesTable = "votes"
 	// VotesInverseTable is the table name for the Vote entity.
@@ -47,6 +91,41 @@ const (
 	VotesInverseTable = "votes"
 	// VotesColumn is the table column denoting the votes relation/edge.
 	VotesColumn = "poll_id"
+	// ParticipationsTable is the table that holds the participations relation/edge.
+	ParticipationsTable = "participations"
+	// ParticipationsInverseTable is the table name for the Participation entity.
+	// It exists in this package in order to avoid circular dependency with the "participation" package.
+	ParticipationsInverseTable = "participations"
+	// ParticipationsColumn is the table column denoting the participations relation/edge.
+	ParticipationsColumn = "poll_id"
+	// BallotsTable is the table that holds the ballots relation/edge.
+	BallotsTable = "ballots"
+	// BallotsInverseTable is the table name for the Ballot entity.
+	// It exists in this package in order to avoid circular dependency with the "ballot" package.
+	BallotsInverseTable = "ballots"
+	// BallotsColumn is the table column denoting the ballots relation/edge.
+	BallotsColumn = "poll_id"
+	// PendingBallotsTable is the table that holds the pending_ballots relation/edge.
+	PendingBallotsTable = "pending_ballots"
+	// PendingBallotsInverseTable is the table name for the PendingBallot entity.
+	// It exists in this package in order to avoid circular dependency with the "pendingballot" package.
+	PendingBallotsInverseTable = "pending_ballots"
+	// PendingBallotsColumn is the table column denoting the pending_ballots relation/edge.
+	PendingBallotsColumn = "poll_id"
+	// InvitationsTable is the table that holds the invitations relation/edge.
+	InvitationsTable = "poll_invitations"
+	// InvitationsInverseTable is the table name for the PollInvitation entity.
+	// It exists in this package in order to avoid circular dependency with the "pollinvitation" package.
+	InvitationsInverseTable = "poll_invitations"
+	// InvitationsColumn is the table column denoting the invitations relation/edge.
+	InvitationsColumn = "poll_id"
+	// ResultTable is the table that holds the result relation/edge.
+	ResultTable = "poll_results"
+	// ResultInverseTable is the table name for the PollResult entity.
+	// It exists in this package in order to avoid circular dependency with the "pollresult" package.
+	ResultInverseTable = "poll_results"
+	// ResultColumn is the table column denoting the result relation/edge.
+	ResultColumn = "poll_id"
 )
 
 // Columns holds all SQL columns for poll fields.
@@ -54,8 +133,20 @@ var Columns = []string{
 	FieldID,
 	FieldTitle,
 	FieldDescription,
-	FieldOptions,
+	FieldLegacyOptions,
+	FieldType,
+	FieldMinChoices,
+	FieldMaxChoices,
+	FieldAnonymous,
+	FieldResultsVisibility,
+	FieldRequireVerifiedEmail,
 	FieldOwnerID,
+	FieldExternalID,
+	FieldOpensAt,
+	FieldClosesAt,
+	FieldVersion,
+	FieldSearchVector,
+	FieldVoterCount,
 	FieldCreatedAt,
 	FieldUpdatedAt,
 }
@@ -84,8 +175,26 @@ func ValidColumn(column string) bool {
 var (
 	// TitleValidator is a validator for the "title" field. It is called by the builders before save.
 	TitleValidator func(string) error
-	// DefaultOptions holds the default value on creation for the "options" field.
-	DefaultOptions []string
+	// DefaultMinChoices holds the default value on creation for the "min_choices" field.
+	DefaultMinChoices int
+	// MinChoicesValidator is a validator for the "min_choices" field. It is called by the builders before save.
+	MinChoicesValidator func(int) error
+	// MaxChoicesValidator is a validator for the "max_choices" field. It is called by the builders before save.
+	MaxChoicesValidator func(int) error
+	// DefaultAnonymous holds the default value on creation for the "anonymous" field.
+	DefaultAnonymous bool
+	// DefaultRequireVerifiedEmail holds the default value on creation for the "require_verified_email" field.
+	DefaultRequireVerifiedEmail bool
+	// ExternalIDValidator is a validator for the "external_id" field. It is called by the builders before save.
+	ExternalIDValidator func(string) error
+	// DefaultVersion holds the default value on creation for the "version" field.
+	DefaultVersion int
+	// VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	VersionValidator func(int) error
+	// DefaultVoterCount holds the default value on creation for the "voter_count" field.
+	DefaultVoterCount int
+	// VoterCountValidator is a validator for the "voter_count" field. It is called by the builders before save.
+	VoterCountValidator func(int) error
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultUpdatedAt holds the default value on creation for the "updated_at" field.
@@ -96,6 +205,61 @@ var (
 	DefaultID func() uuid.UUID
 )
 
+// Type defines the type for the "type" enum field.
+type Type string
+
+// TypeSingle is the default value of the Type enum.
+const DefaultType = TypeSingle
+
+// Type values.
+const (
+	TypeSingle   Type = "single"
+	TypeRanked   Type = "ranked"
+	TypeApproval Type = "approval"
+)
+
+func (_type Type) String() string {
+	return string(_type)
+}
+
+// TypeValidator is a validator for the "type" field enum values. It is called by the builders before save.
+func TypeValidator(_type Type) error {
+	switch _type {
+	case TypeSingle, TypeRanked, TypeApproval:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for type field: %q", _type)
+	}
+}
+
+// ResultsVisibility defines the type for the "results_visibility" enum field.
+type ResultsVisibility string
+
+// ResultsVisibilityAlways is the default value of the ResultsVisibility enum.
+const DefaultResultsVisibility = ResultsVisibilityAlways
+
+// ResultsVisibility values.
+const (
+	ResultsVisibilityAlways     ResultsVisibility = "always"
+	ResultsVisibilityAfterVote  ResultsVisibility = "after_vote"
+	ResultsVisibilityAfterClose ResultsVisibility = "after_close"
+	ResultsVisibilityOwnerOnly  ResultsVisibility = "owner_only"
+)
+
+func (rv ResultsVisibility) String() string {
+	return string(rv)
+}
+
+// ResultsVisibilityValidator is a validator for the "results_visibility" field enum values. It is called by the builders before save.
+func ResultsVisibilityValidator(rv ResultsVisibility) error {
+	switch rv {
+	case ResultsVisibilityAlways, ResultsVisibilityAfterVote, ResultsVisibilityAfterClose, ResultsVisibilityOwnerOnly:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for results_visibility field: %q", rv)
+	}
+}
+
 // OrderOption defines the ordering options for the Poll queries.
 type OrderOption func(*sql.Selector)
 
@@ -114,11 +278,71 @@ func ByDescription(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldDescription, opts...).ToFunc()
 }
 
+// ByType orders the results by the type field.
+func ByType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldType, opts...).ToFunc()
+}
+
+// ByMinChoices orders the results by the min_choices field.
+func ByMinChoices(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMinChoices, opts...).ToFunc()
+}
+
+// ByMaxChoices orders the results by the max_choices field.
+func ByMaxChoices(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxChoices, opts...).ToFunc()
+}
+
+// ByAnonymous orders the results by the anonymous field.
+func ByAnonymous(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAnonymous, opts...).ToFunc()
+}
+
+// ByResultsVisibility orders the results by the results_visibility field.
+func ByResultsVisibility(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResultsVisibility, opts...).ToFunc()
+}
+
+// ByRequireVerifiedEmail orders the results by the require_verified_email field.
+func ByRequireVerifiedEmail(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRequireVerifiedEmail, opts...).ToFunc()
+}
+
 // ByOwnerID orders the results by the owner_id field.
 func ByOwnerID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldOwnerID, opts...).ToFunc()
 }
 
+// ByExternalID orders the results by the external_id field.
+func ByExternalID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldExternalID, opts...).ToFunc()
+}
+
+// ByOpensAt orders the results by the opens_at field.
+func ByOpensAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOpensAt, opts...).ToFunc()
+}
+
+// ByClosesAt orders the results by the closes_at field.
+func ByClosesAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClosesAt, opts...).ToFunc()
+}
+
+// ByVersion orders the results by the version field.
+func ByVersion(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVersion, opts...).ToFunc()
+}
+
+// BySearchVector orders the results by the search_vector field.
+func BySearchVector(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSearchVector, opts...).ToFunc()
+}
+
+// ByVoterCount orders the results by the voter_count field.
+func ByVoterCount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVoterCount, opts...).ToFunc()
+}
+
 // ByCreatedAt orders the results by the created_at field.
 func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
@@ -136,6 +360,20 @@ func ByOwnerField(field string, opts ...sql.OrderTermOption) OrderOption {
 	}
 }
 
+// ByOptionsCount orders the results by options count.
+func ByOptionsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newOptionsStep(), opts...)
+	}
+}
+
+// ByOptions orders the results by options terms.
+func ByOptions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOptionsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
 // ByVotesCount orders the results by votes count.
 func ByVotesCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -149,6 +387,69 @@ func ByVotes(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newVotesStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByParticipationsCount orders the results by participations count.
+func ByParticipationsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newParticipationsStep(), opts...)
+	}
+}
+
+// ByParticipations orders the results by participations terms.
+func ByParticipations(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newParticipationsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByBallotsCount orders the results by ballots count.
+func ByBallotsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newBallotsStep(), opts...)
+	}
+}
+
+// ByBallots orders the results by ballots terms.
+func ByBallots(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newBallotsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByPendingBallotsCount orders the results by pending_ballots count.
+func ByPendingBallotsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newPendingBallotsStep(), opts...)
+	}
+}
+
+// ByPendingBallots orders the results by pending_ballots terms.
+func ByPendingBallots(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newPendingBallotsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByInvitationsCount orders the results by invitations count.
+func ByInvitationsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newInvitationsStep(), opts...)
+	}
+}
+
+// ByInvitations orders the results by invitations terms.
+func ByInvitations(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newInvitationsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByResultField orders the results by result field.
+func ByResultField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newResultStep(), sql.OrderByField(field, opts...))
+	}
+}
 func newOwnerStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -156,6 +457,13 @@ func newOwnerStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.M2O, false, OwnerTable, OwnerColumn),
 	)
 }
+func newOptionsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OptionsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, true, OptionsTable, OptionsColumn),
+	)
+}
 func newVotesStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -163,3 +471,38 @@ func newVotesStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, true, VotesTable, VotesColumn),
 	)
 }
+func newParticipationsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ParticipationsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, true, ParticipationsTable, ParticipationsColumn),
+	)
+}
+func newBallotsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(BallotsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, true, BallotsTable, BallotsColumn),
+	)
+}
+func newPendingBallotsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(PendingBallotsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, true, PendingBallotsTable, PendingBallotsColumn),
+	)
+}
+func newInvitationsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(InvitationsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, true, InvitationsTable, InvitationsColumn),
+	)
+}
+func newResultStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ResultInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2O, false, ResultTable, ResultColumn),
+	)
+}
@@ -66,11 +66,61 @@ func Description(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldDescription, v))
 }
 
+// MinChoices applies equality check predicate on the "min_choices" field. It's identical to MinChoicesEQ.
+func MinChoices(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMinChoices, v))
+}
+
+// MaxChoices applies equality check predicate on the "max_choices" field. It's identical to MaxChoicesEQ.
+func MaxChoices(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxChoices, v))
+}
+
+// Anonymous applies equality check predicate on the "anonymous" field. It's identical to AnonymousEQ.
+func Anonymous(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAnonymous, v))
+}
+
+// RequireVerifiedEmail applies equality check predicate on the "require_verified_email" field. It's identical to RequireVerifiedEmailEQ.
+func RequireVerifiedEmail(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRequireVerifiedEmail, v))
+}
+
 // OwnerID applies equality check predicate on the "owner_id" field. It's identical to OwnerIDEQ.
 func OwnerID(v uuid.UUID) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldOwnerID, v))
 }
 
+// ExternalID applies equality check predicate on the "external_id" field. It's identical to ExternalIDEQ.
+func ExternalID(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldExternalID, v))
+}
+
+// OpensAt applies equality check predicate on the "opens_at" field. It's identical to OpensAtEQ.
+func OpensAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// ClosesAt applies equality check predicate on the "closes_at" field. It's identical to ClosesAtEQ.
+func ClosesAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// Version applies equality check predicate on the "version" field. It's identical to VersionEQ.
+func Version(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVersion, v))
+}
+
+// SearchVector applies equality check predicate on the "search_vector" field. It's identical to SearchVectorEQ.
+func SearchVector(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldSearchVector, v))
+}
+
+// VoterCount applies equality check predicate on the "voter_count" field. It's identical to VoterCountEQ.
+func VoterCount(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVoterCount, v))
+}
+
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
 func CreatedAt(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldCreatedAt, v))
@@ -221,6 +271,166 @@ func DescriptionContainsFold(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldContainsFold(FieldDescription, v))
 }
 
+// LegacyOptionsIsNil applies the IsNil predicate on the "legacy_options" field.
+func LegacyOptionsIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldLegacyOptions))
+}
+
+// LegacyOptionsNotNil applies the NotNil predicate on the "legacy_options" field.
+func LegacyOptionsNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldLegacyOptions))
+}
+
+// TypeEQ applies the EQ predicate on the "type" field.
+func TypeEQ(v Type) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldType, v))
+}
+
+// TypeNEQ applies the NEQ predicate on the "type" field.
+func TypeNEQ(v Type) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldType, v))
+}
+
+// TypeIn applies the In predicate on the "type" field.
+func TypeIn(vs ...Type) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldType, vs...))
+}
+
+// TypeNotIn applies the NotIn predicate on the "type" field.
+func TypeNotIn(vs ...Type) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldType, vs...))
+}
+
+// MinChoicesEQ applies the EQ predicate on the "min_choices" field.
+func MinChoicesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMinChoices, v))
+}
+
+// MinChoicesNEQ applies the NEQ predicate on the "min_choices" field.
+func MinChoicesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMinChoices, v))
+}
+
+// MinChoicesIn applies the In predicate on the "min_choices" field.
+func MinChoicesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMinChoices, vs...))
+}
+
+// MinChoicesNotIn applies the NotIn predicate on the "min_choices" field.
+func MinChoicesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMinChoices, vs...))
+}
+
+// MinChoicesGT applies the GT predicate on the "min_choices" field.
+func MinChoicesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldMinChoices, v))
+}
+
+// MinChoicesGTE applies the GTE predicate on the "min_choices" field.
+func MinChoicesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldMinChoices, v))
+}
+
+// MinChoicesLT applies the LT predicate on the "min_choices" field.
+func MinChoicesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldMinChoices, v))
+}
+
+// MinChoicesLTE applies the LTE predicate on the "min_choices" field.
+func MinChoicesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldMinChoices, v))
+}
+
+// MaxChoicesEQ applies the EQ predicate on the "max_choices" field.
+func MaxChoicesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxChoices, v))
+}
+
+// MaxChoicesNEQ applies the NEQ predicate on the "max_choices" field.
+func MaxChoicesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMaxChoices, v))
+}
+
+// MaxChoicesIn applies the In predicate on the "max_choices" field.
+func MaxChoicesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMaxChoices, vs...))
+}
+
+// MaxChoicesNotIn applies the NotIn predicate on the "max_choices" field.
+func MaxChoicesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMaxChoices, vs...))
+}
+
+// MaxChoicesGT applies the GT predicate on the "max_choices" field.
+func MaxChoicesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldMaxChoices, v))
+}
+
+// MaxChoicesGTE applies the GTE predicate on the "max_choices" field.
+func MaxChoicesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldMaxChoices, v))
+}
+
+// MaxChoicesLT applies the LT predicate on the "max_choices" field.
+func MaxChoicesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldMaxChoices, v))
+}
+
+// MaxChoicesLTE applies the LTE predicate on the "max_choices" field.
+func MaxChoicesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldMaxChoices, v))
+}
+
+// MaxChoicesIsNil applies the IsNil predicate on the "max_choices" field.
+func MaxChoicesIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldMaxChoices))
+}
+
+// MaxChoicesNotNil applies the NotNil predicate on the "max_choices" field.
+func MaxChoicesNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldMaxChoices))
+}
+
+// AnonymousEQ applies the EQ predicate on the "anonymous" field.
+func AnonymousEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAnonymous, v))
+}
+
+// AnonymousNEQ applies the NEQ predicate on the "anonymous" field.
+func AnonymousNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldAnonymous, v))
+}
+
+// ResultsVisibilityEQ applies the EQ predicate on the "results_visibility" field.
+func ResultsVisibilityEQ(v ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityNEQ applies the NEQ predicate on the "results_visibility" field.
+func ResultsVisibilityNEQ(v ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityIn applies the In predicate on the "results_visibility" field.
+func ResultsVisibilityIn(vs ...ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldResultsVisibility, vs...))
+}
+
+// ResultsVisibilityNotIn applies the NotIn predicate on the "results_visibility" field.
+func ResultsVisibilityNotIn(vs ...ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldResultsVisibility, vs...))
+}
+
+// RequireVerifiedEmailEQ applies the EQ predicate on the "require_verified_email" field.
+func RequireVerifiedEmailEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldRequireVerifiedEmail, v))
+}
+
+// RequireVerifiedEmailNEQ applies the NEQ predicate on the "require_verified_email" field.
+func RequireVerifiedEmailNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldRequireVerifiedEmail, v))
+}
+
 // OwnerIDEQ applies the EQ predicate on the "owner_id" field.
 func OwnerIDEQ(v uuid.UUID) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldOwnerID, v))
@@ -241,6 +451,336 @@ func OwnerIDNotIn(vs ...uuid.UUID) predicate.Poll {
 	return predicate.Poll(sql.FieldNotIn(FieldOwnerID, vs...))
 }
 
+// ExternalIDEQ applies the EQ predicate on the "external_id" field.
+func ExternalIDEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldExternalID, v))
+}
+
+// ExternalIDNEQ applies the NEQ predicate on the "external_id" field.
+func ExternalIDNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldExternalID, v))
+}
+
+// ExternalIDIn applies the In predicate on the "external_id" field.
+func ExternalIDIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldExternalID, vs...))
+}
+
+// ExternalIDNotIn applies the NotIn predicate on the "external_id" field.
+func ExternalIDNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldExternalID, vs...))
+}
+
+// ExternalIDGT applies the GT predicate on the "external_id" field.
+func ExternalIDGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldExternalID, v))
+}
+
+// ExternalIDGTE applies the GTE predicate on the "external_id" field.
+func ExternalIDGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldExternalID, v))
+}
+
+// ExternalIDLT applies the LT predicate on the "external_id" field.
+func ExternalIDLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldExternalID, v))
+}
+
+// ExternalIDLTE applies the LTE predicate on the "external_id" field.
+func ExternalIDLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldExternalID, v))
+}
+
+// ExternalIDContains applies the Contains predicate on the "external_id" field.
+func ExternalIDContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldExternalID, v))
+}
+
+// ExternalIDHasPrefix applies the HasPrefix predicate on the "external_id" field.
+func ExternalIDHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldExternalID, v))
+}
+
+// ExternalIDHasSuffix applies the HasSuffix predicate on the "external_id" field.
+func ExternalIDHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldExternalID, v))
+}
+
+// ExternalIDIsNil applies the IsNil predicate on the "external_id" field.
+func ExternalIDIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldExternalID))
+}
+
+// ExternalIDNotNil applies the NotNil predicate on the "external_id" field.
+func ExternalIDNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldExternalID))
+}
+
+// ExternalIDEqualFold applies the EqualFold predicate on the "external_id" field.
+func ExternalIDEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldExternalID, v))
+}
+
+// ExternalIDContainsFold applies the ContainsFold predicate on the "external_id" field.
+func ExternalIDContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldExternalID, v))
+}
+
+// OpensAtEQ applies the EQ predicate on the "opens_at" field.
+func OpensAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// OpensAtNEQ applies the NEQ predicate on the "opens_at" field.
+func OpensAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldOpensAt, v))
+}
+
+// OpensAtIn applies the In predicate on the "opens_at" field.
+func OpensAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldOpensAt, vs...))
+}
+
+// OpensAtNotIn applies the NotIn predicate on the "opens_at" field.
+func OpensAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldOpensAt, vs...))
+}
+
+// OpensAtGT applies the GT predicate on the "opens_at" field.
+func OpensAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldOpensAt, v))
+}
+
+// OpensAtGTE applies the GTE predicate on the "opens_at" field.
+func OpensAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldOpensAt, v))
+}
+
+// OpensAtLT applies the LT predicate on the "opens_at" field.
+func OpensAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldOpensAt, v))
+}
+
+// OpensAtLTE applies the LTE predicate on the "opens_at" field.
+func OpensAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldOpensAt, v))
+}
+
+// OpensAtIsNil applies the IsNil predicate on the "opens_at" field.
+func OpensAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldOpensAt))
+}
+
+// OpensAtNotNil applies the NotNil predicate on the "opens_at" field.
+func OpensAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldOpensAt))
+}
+
+// ClosesAtEQ applies the EQ predicate on the "closes_at" field.
+func ClosesAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// ClosesAtNEQ applies the NEQ predicate on the "closes_at" field.
+func ClosesAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldClosesAt, v))
+}
+
+// ClosesAtIn applies the In predicate on the "closes_at" field.
+func ClosesAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtNotIn applies the NotIn predicate on the "closes_at" field.
+func ClosesAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtGT applies the GT predicate on the "closes_at" field.
+func ClosesAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldClosesAt, v))
+}
+
+// ClosesAtGTE applies the GTE predicate on the "closes_at" field.
+func ClosesAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldClosesAt, v))
+}
+
+// ClosesAtLT applies the LT predicate on the "closes_at" field.
+func ClosesAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldClosesAt, v))
+}
+
+// ClosesAtLTE applies the LTE predicate on the "closes_at" field.
+func ClosesAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldClosesAt, v))
+}
+
+// ClosesAtIsNil applies the IsNil predicate on the "closes_at" field.
+func ClosesAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldClosesAt))
+}
+
+// ClosesAtNotNil applies the NotNil predicate on the "closes_at" field.
+func ClosesAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldClosesAt))
+}
+
+// VersionEQ applies the EQ predicate on the "version" field.
+func VersionEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVersion, v))
+}
+
+// VersionNEQ applies the NEQ predicate on the "version" field.
+func VersionNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldVersion, v))
+}
+
+// VersionIn applies the In predicate on the "version" field.
+func VersionIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldVersion, vs...))
+}
+
+// VersionNotIn applies the NotIn predicate on the "version" field.
+func VersionNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldVersion, vs...))
+}
+
+// VersionGT applies the GT predicate on the "version" field.
+func VersionGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldVersion, v))
+}
+
+// VersionGTE applies the GTE predicate on the "version" field.
+func VersionGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldVersion, v))
+}
+
+// VersionLT applies the LT predicate on the "version" field.
+func VersionLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldVersion, v))
+}
+
+// VersionLTE applies the LTE predicate on the "version" field.
+func VersionLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldVersion, v))
+}
+
+// SearchVectorEQ applies the EQ predicate on the "search_vector" field.
+func SearchVectorEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldSearchVector, v))
+}
+
+// SearchVectorNEQ applies the NEQ predicate on the "search_vector" field.
+func SearchVectorNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldSearchVector, v))
+}
+
+// SearchVectorIn applies the In predicate on the "search_vector" field.
+func SearchVectorIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldSearchVector, vs...))
+}
+
+// SearchVectorNotIn applies the NotIn predicate on the "search_vector" field.
+func SearchVectorNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldSearchVector, vs...))
+}
+
+// SearchVectorGT applies the GT predicate on the "search_vector" field.
+func SearchVectorGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldSearchVector, v))
+}
+
+// SearchVectorGTE applies the GTE predicate on the "search_vector" field.
+func SearchVectorGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldSearchVector, v))
+}
+
+// SearchVectorLT applies the LT predicate on the "search_vector" field.
+func SearchVectorLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldSearchVector, v))
+}
+
+// SearchVectorLTE applies the LTE predicate on the "search_vector" field.
+func SearchVectorLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldSearchVector, v))
+}
+
+// SearchVectorContains applies the Contains predicate on the "search_vector" field.
+func SearchVectorContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldSearchVector, v))
+}
+
+// SearchVectorHasPrefix applies the HasPrefix predicate on the "search_vector" field.
+func SearchVectorHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldSearchVector, v))
+}
+
+// SearchVectorHasSuffix applies the HasSuffix predicate on the "search_vector" field.
+func SearchVectorHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldSearchVector, v))
+}
+
+// SearchVectorIsNil applies the IsNil predicate on the "search_vector" field.
+func SearchVectorIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldSearchVector))
+}
+
+// SearchVectorNotNil applies the NotNil predicate on the "search_vector" field.
+func SearchVectorNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldSearchVector))
+}
+
+// SearchVectorEqualFold applies the EqualFold predicate on the "search_vector" field.
+func SearchVectorEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldSearchVector, v))
+}
+
+// SearchVectorContainsFold applies the ContainsFold predicate on the "search_vector" field.
+func SearchVectorContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldSearchVector, v))
+}
+
+// VoterCountEQ applies the EQ predicate on the "voter_count" field.
+func VoterCountEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVoterCount, v))
+}
+
+// VoterCountNEQ applies the NEQ predicate on the "voter_count" field.
+func VoterCountNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldVoterCount, v))
+}
+
+// VoterCountIn applies the In predicate on the "voter_count" field.
+func VoterCountIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldVoterCount, vs...))
+}
+
+// VoterCountNotIn applies the NotIn predicate on the "voter_count" field.
+func VoterCountNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldVoterCount, vs...))
+}
+
+// VoterCountGT applies the GT predicate on the "voter_count" field.
+func VoterCountGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldVoterCount, v))
+}
+
+// VoterCountGTE applies the GTE predicate on the "voter_count" field.
+func VoterCountGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldVoterCount, v))
+}
+
+// VoterCountLT applies the LT predicate on the "voter_count" field.
+func VoterCountLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldVoterCount, v))
+}
+
+// VoterCountLTE applies the LTE predicate on the "voter_count" field.
+func VoterCountLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldVoterCount, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldCreatedAt, v))
@@ -344,6 +884,29 @@ func HasOwnerWith(preds ...predicate.User) predicate.Poll {
 	})
 }
 
+// HasOptions applies the HasEdge predicate on the "options" edge.
+func HasOptions() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, OptionsTable, OptionsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOptionsWith applies the HasEdge predicate on the "options" edge with a given conditions (other predicates).
+func HasOptionsWith(preds ...predicate.PollOption) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newOptionsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // HasVotes applies the HasEdge predicate on the "votes" edge.
 func HasVotes() predicate.Poll {
 	return predicate.Poll(func(s *sql.Selector) {
@@ -367,6 +930,121 @@ func HasVotesWith(preds ...predicate.Vote) predicate.Poll {
 	})
 }
 
+// HasParticipations applies the HasEdge predicate on the "participations" edge.
+func HasParticipations() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, ParticipationsTable, ParticipationsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasParticipationsWith applies the HasEdge predicate on the "participations" edge with a given conditions (other predicates).
+func HasParticipationsWith(preds ...predicate.Participation) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newParticipationsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasBallots applies the HasEdge predicate on the "ballots" edge.
+func HasBallots() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, BallotsTable, BallotsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasBallotsWith applies the HasEdge predicate on the "ballots" edge with a given conditions (other predicates).
+func HasBallotsWith(preds ...predicate.Ballot) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newBallotsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasPendingBallots applies the HasEdge predicate on the "pending_ballots" edge.
+func HasPendingBallots() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, PendingBallotsTable, PendingBallotsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasPendingBallotsWith applies the HasEdge predicate on the "pending_ballots" edge with a given conditions (other predicates).
+func HasPendingBallotsWith(preds ...predicate.PendingBallot) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newPendingBallotsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasInvitations applies the HasEdge predicate on the "invitations" edge.
+func HasInvitations() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, InvitationsTable, InvitationsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasInvitationsWith applies the HasEdge predicate on the "invitations" edge with a given conditions (other predicates).
+func HasInvitationsWith(preds ...predicate.PollInvitation) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newInvitationsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasResult applies the HasEdge predicate on the "result" edge.
+func HasResult() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2O, false, ResultTable, ResultColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasResultWith applies the HasEdge predicate on the "result" edge with a given conditions (other predicates).
+func HasResultWith(preds ...predicate.PollResult) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newResultStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Poll) predicate.Poll {
 	return predicate.Poll(sql.AndPredicates(predicates...))
@@ -6,7 +6,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"poll-app/ent/ballot"
+	"poll-app/ent/participation"
+	"poll-app/ent/pendingballot"
 	"poll-app/ent/poll"
+	"poll-app/ent/pollinvitation"
+	"poll-app/ent/polloption"
+	"poll-app/ent/pollresult"
 	"poll-app/ent/user"
 	"poll-app/ent/vote"
 	"time"
@@ -43,9 +49,93 @@ func (_c *PollCreate) SetNillableDescription(v *string) *PollCreate {
 	return _c
 }
 
-// SetOptions sets the "options" field.
-func (_c *PollCreate) SetOptions(v []string) *PollCreate {
-	_c.mutation.SetOptions(v)
+// SetLegacyOptions sets the "legacy_options" field.
+func (_c *PollCreate) SetLegacyOptions(v []string) *PollCreate {
+	_c.mutation.SetLegacyOptions(v)
+	return _c
+}
+
+// SetType sets the "type" field.
+func (_c *PollCreate) SetType(v poll.Type) *PollCreate {
+	_c.mutation.SetType(v)
+	return _c
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_c *PollCreate) SetNillableType(v *poll.Type) *PollCreate {
+	if v != nil {
+		_c.SetType(*v)
+	}
+	return _c
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (_c *PollCreate) SetMinChoices(v int) *PollCreate {
+	_c.mutation.SetMinChoices(v)
+	return _c
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (_c *PollCreate) SetNillableMinChoices(v *int) *PollCreate {
+	if v != nil {
+		_c.SetMinChoices(*v)
+	}
+	return _c
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (_c *PollCreate) SetMaxChoices(v int) *PollCreate {
+	_c.mutation.SetMaxChoices(v)
+	return _c
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (_c *PollCreate) SetNillableMaxChoices(v *int) *PollCreate {
+	if v != nil {
+		_c.SetMaxChoices(*v)
+	}
+	return _c
+}
+
+// SetAnonymous sets the "anonymous" field.
+func (_c *PollCreate) SetAnonymous(v bool) *PollCreate {
+	_c.mutation.SetAnonymous(v)
+	return _c
+}
+
+// SetNillableAnonymous sets the "anonymous" field if the given value is not nil.
+func (_c *PollCreate) SetNillableAnonymous(v *bool) *PollCreate {
+	if v != nil {
+		_c.SetAnonymous(*v)
+	}
+	return _c
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_c *PollCreate) SetResultsVisibility(v poll.ResultsVisibility) *PollCreate {
+	_c.mutation.SetResultsVisibility(v)
+	return _c
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_c *PollCreate) SetNillableResultsVisibility(v *poll.ResultsVisibility) *PollCreate {
+	if v != nil {
+		_c.SetResultsVisibility(*v)
+	}
+	return _c
+}
+
+// SetRequireVerifiedEmail sets the "require_verified_email" field.
+func (_c *PollCreate) SetRequireVerifiedEmail(v bool) *PollCreate {
+	_c.mutation.SetRequireVerifiedEmail(v)
+	return _c
+}
+
+// SetNillableRequireVerifiedEmail sets the "require_verified_email" field if the given value is not nil.
+func (_c *PollCreate) SetNillableRequireVerifiedEmail(v *bool) *PollCreate {
+	if v != nil {
+		_c.SetRequireVerifiedEmail(*v)
+	}
 	return _c
 }
 
@@ -55,6 +145,90 @@ func (_c *PollCreate) SetOwnerID(v uuid.UUID) *PollCreate {
 	return _c
 }
 
+// SetExternalID sets the "external_id" field.
+func (_c *PollCreate) SetExternalID(v string) *PollCreate {
+	_c.mutation.SetExternalID(v)
+	return _c
+}
+
+// SetNillableExternalID sets the "external_id" field if the given value is not nil.
+func (_c *PollCreate) SetNillableExternalID(v *string) *PollCreate {
+	if v != nil {
+		_c.SetExternalID(*v)
+	}
+	return _c
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (_c *PollCreate) SetOpensAt(v time.Time) *PollCreate {
+	_c.mutation.SetOpensAt(v)
+	return _c
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableOpensAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetOpensAt(*v)
+	}
+	return _c
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (_c *PollCreate) SetClosesAt(v time.Time) *PollCreate {
+	_c.mutation.SetClosesAt(v)
+	return _c
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (_c *PollCreate) SetNillableClosesAt(v *time.Time) *PollCreate {
+	if v != nil {
+		_c.SetClosesAt(*v)
+	}
+	return _c
+}
+
+// SetVersion sets the "version" field.
+func (_c *PollCreate) SetVersion(v int) *PollCreate {
+	_c.mutation.SetVersion(v)
+	return _c
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_c *PollCreate) SetNillableVersion(v *int) *PollCreate {
+	if v != nil {
+		_c.SetVersion(*v)
+	}
+	return _c
+}
+
+// SetSearchVector sets the "search_vector" field.
+func (_c *PollCreate) SetSearchVector(v string) *PollCreate {
+	_c.mutation.SetSearchVector(v)
+	return _c
+}
+
+// SetNillableSearchVector sets the "search_vector" field if the given value is not nil.
+func (_c *PollCreate) SetNillableSearchVector(v *string) *PollCreate {
+	if v != nil {
+		_c.SetSearchVector(*v)
+	}
+	return _c
+}
+
+// SetVoterCount sets the "voter_count" field.
+func (_c *PollCreate) SetVoterCount(v int) *PollCreate {
+	_c.mutation.SetVoterCount(v)
+	return _c
+}
+
+// SetNillableVoterCount sets the "voter_count" field if the given value is not nil.
+func (_c *PollCreate) SetNillableVoterCount(v *int) *PollCreate {
+	if v != nil {
+		_c.SetVoterCount(*v)
+	}
+	return _c
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_c *PollCreate) SetCreatedAt(v time.Time) *PollCreate {
 	_c.mutation.SetCreatedAt(v)
@@ -102,6 +276,21 @@ func (_c *PollCreate) SetOwner(v *User) *PollCreate {
 	return _c.SetOwnerID(v.ID)
 }
 
+// AddOptionIDs adds the "options" edge to the PollOption entity by IDs.
+func (_c *PollCreate) AddOptionIDs(ids ...uuid.UUID) *PollCreate {
+	_c.mutation.AddOptionIDs(ids...)
+	return _c
+}
+
+// AddOptions adds the "options" edges to the PollOption entity.
+func (_c *PollCreate) AddOptions(v ...*PollOption) *PollCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddOptionIDs(ids...)
+}
+
 // AddVoteIDs adds the "votes" edge to the Vote entity by IDs.
 func (_c *PollCreate) AddVoteIDs(ids ...uuid.UUID) *PollCreate {
 	_c.mutation.AddVoteIDs(ids...)
@@ -117,6 +306,85 @@ func (_c *PollCreate) AddVotes(v ...*Vote) *PollCreate {
 	return _c.AddVoteIDs(ids...)
 }
 
+// AddParticipationIDs adds the "participations" edge to the Participation entity by IDs.
+func (_c *PollCreate) AddParticipationIDs(ids ...uuid.UUID) *PollCreate {
+	_c.mutation.AddParticipationIDs(ids...)
+	return _c
+}
+
+// AddParticipations adds the "participations" edges to the Participation entity.
+func (_c *PollCreate) AddParticipations(v ...*Participation) *PollCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddParticipationIDs(ids...)
+}
+
+// AddBallotIDs adds the "ballots" edge to the Ballot entity by IDs.
+func (_c *PollCreate) AddBallotIDs(ids ...uuid.UUID) *PollCreate {
+	_c.mutation.AddBallotIDs(ids...)
+	return _c
+}
+
+// AddBallots adds the "ballots" edges to the Ballot entity.
+func (_c *PollCreate) AddBallots(v ...*Ballot) *PollCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddBallotIDs(ids...)
+}
+
+// AddPendingBallotIDs adds the "pending_ballots" edge to the PendingBallot entity by IDs.
+func (_c *PollCreate) AddPendingBallotIDs(ids ...uuid.UUID) *PollCreate {
+	_c.mutation.AddPendingBallotIDs(ids...)
+	return _c
+}
+
+// AddPendingBallots adds the "pending_ballots" edges to the PendingBallot entity.
+func (_c *PollCreate) AddPendingBallots(v ...*PendingBallot) *PollCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddPendingBallotIDs(ids...)
+}
+
+// AddInvitationIDs adds the "invitations" edge to the PollInvitation entity by IDs.
+func (_c *PollCreate) AddInvitationIDs(ids ...uuid.UUID) *PollCreate {
+	_c.mutation.AddInvitationIDs(ids...)
+	return _c
+}
+
+// AddInvitations adds the "invitations" edges to the PollInvitation entity.
+func (_c *PollCreate) AddInvitations(v ...*PollInvitation) *PollCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddInvitationIDs(ids...)
+}
+
+// SetResultID sets the "result" edge to the PollResult entity by ID.
+func (_c *PollCreate) SetResultID(id uuid.UUID) *PollCreate {
+	_c.mutation.SetResultID(id)
+	return _c
+}
+
+// SetNillableResultID sets the "result" edge to the PollResult entity by ID if the given value is not nil.
+func (_c *PollCreate) SetNillableResultID(id *uuid.UUID) *PollCreate {
+	if id != nil {
+		_c = _c.SetResultID(*id)
+	}
+	return _c
+}
+
+// SetResult sets the "result" edge to the PollResult entity.
+func (_c *PollCreate) SetResult(v *PollResult) *PollCreate {
+	return _c.SetResultID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_c *PollCreate) Mutation() *PollMutation {
 	return _c.mutation
@@ -152,9 +420,33 @@ func (_c *PollCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (_c *PollCreate) defaults() {
-	if _, ok := _c.mutation.Options(); !ok {
-		v := poll.DefaultOptions
-		_c.mutation.SetOptions(v)
+	if _, ok := _c.mutation.GetType(); !ok {
+		v := poll.DefaultType
+		_c.mutation.SetType(v)
+	}
+	if _, ok := _c.mutation.MinChoices(); !ok {
+		v := poll.DefaultMinChoices
+		_c.mutation.SetMinChoices(v)
+	}
+	if _, ok := _c.mutation.Anonymous(); !ok {
+		v := poll.DefaultAnonymous
+		_c.mutation.SetAnonymous(v)
+	}
+	if _, ok := _c.mutation.ResultsVisibility(); !ok {
+		v := poll.DefaultResultsVisibility
+		_c.mutation.SetResultsVisibility(v)
+	}
+	if _, ok := _c.mutation.RequireVerifiedEmail(); !ok {
+		v := poll.DefaultRequireVerifiedEmail
+		_c.mutation.SetRequireVerifiedEmail(v)
+	}
+	if _, ok := _c.mutation.Version(); !ok {
+		v := poll.DefaultVersion
+		_c.mutation.SetVersion(v)
+	}
+	if _, ok := _c.mutation.VoterCount(); !ok {
+		v := poll.DefaultVoterCount
+		_c.mutation.SetVoterCount(v)
 	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		v := poll.DefaultCreatedAt()
@@ -180,12 +472,65 @@ func (_c *PollCreate) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
-	if _, ok := _c.mutation.Options(); !ok {
-		return &ValidationError{Name: "options", err: errors.New(`ent: missing required field "Poll.options"`)}
+	if _, ok := _c.mutation.GetType(); !ok {
+		return &ValidationError{Name: "type", err: errors.New(`ent: missing required field "Poll.type"`)}
+	}
+	if v, ok := _c.mutation.GetType(); ok {
+		if err := poll.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Poll.type": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.MinChoices(); !ok {
+		return &ValidationError{Name: "min_choices", err: errors.New(`ent: missing required field "Poll.min_choices"`)}
+	}
+	if v, ok := _c.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Anonymous(); !ok {
+		return &ValidationError{Name: "anonymous", err: errors.New(`ent: missing required field "Poll.anonymous"`)}
+	}
+	if _, ok := _c.mutation.ResultsVisibility(); !ok {
+		return &ValidationError{Name: "results_visibility", err: errors.New(`ent: missing required field "Poll.results_visibility"`)}
+	}
+	if v, ok := _c.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.RequireVerifiedEmail(); !ok {
+		return &ValidationError{Name: "require_verified_email", err: errors.New(`ent: missing required field "Poll.require_verified_email"`)}
 	}
 	if _, ok := _c.mutation.OwnerID(); !ok {
 		return &ValidationError{Name: "owner_id", err: errors.New(`ent: missing required field "Poll.owner_id"`)}
 	}
+	if v, ok := _c.mutation.ExternalID(); ok {
+		if err := poll.ExternalIDValidator(v); err != nil {
+			return &ValidationError{Name: "external_id", err: fmt.Errorf(`ent: validator failed for field "Poll.external_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Poll.version"`)}
+	}
+	if v, ok := _c.mutation.Version(); ok {
+		if err := poll.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Poll.version": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.VoterCount(); !ok {
+		return &ValidationError{Name: "voter_count", err: errors.New(`ent: missing required field "Poll.voter_count"`)}
+	}
+	if v, ok := _c.mutation.VoterCount(); ok {
+		if err := poll.VoterCountValidator(v); err != nil {
+			return &ValidationError{Name: "voter_count", err: fmt.Errorf(`ent: validator failed for field "Poll.voter_count": %w`, err)}
+		}
+	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Poll.created_at"`)}
 	}
@@ -238,9 +583,57 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		_spec.SetField(poll.FieldDescription, field.TypeString, value)
 		_node.Description = value
 	}
-	if value, ok := _c.mutation.Options(); ok {
-		_spec.SetField(poll.FieldOptions, field.TypeJSON, value)
-		_node.Options = value
+	if value, ok := _c.mutation.LegacyOptions(); ok {
+		_spec.SetField(poll.FieldLegacyOptions, field.TypeJSON, value)
+		_node.LegacyOptions = value
+	}
+	if value, ok := _c.mutation.GetType(); ok {
+		_spec.SetField(poll.FieldType, field.TypeEnum, value)
+		_node.Type = value
+	}
+	if value, ok := _c.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+		_node.MinChoices = value
+	}
+	if value, ok := _c.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+		_node.MaxChoices = &value
+	}
+	if value, ok := _c.mutation.Anonymous(); ok {
+		_spec.SetField(poll.FieldAnonymous, field.TypeBool, value)
+		_node.Anonymous = value
+	}
+	if value, ok := _c.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+		_node.ResultsVisibility = value
+	}
+	if value, ok := _c.mutation.RequireVerifiedEmail(); ok {
+		_spec.SetField(poll.FieldRequireVerifiedEmail, field.TypeBool, value)
+		_node.RequireVerifiedEmail = value
+	}
+	if value, ok := _c.mutation.ExternalID(); ok {
+		_spec.SetField(poll.FieldExternalID, field.TypeString, value)
+		_node.ExternalID = &value
+	}
+	if value, ok := _c.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+		_node.OpensAt = &value
+	}
+	if value, ok := _c.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+		_node.ClosesAt = &value
+	}
+	if value, ok := _c.mutation.Version(); ok {
+		_spec.SetField(poll.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := _c.mutation.SearchVector(); ok {
+		_spec.SetField(poll.FieldSearchVector, field.TypeString, value)
+		_node.SearchVector = value
+	}
+	if value, ok := _c.mutation.VoterCount(); ok {
+		_spec.SetField(poll.FieldVoterCount, field.TypeInt, value)
+		_node.VoterCount = value
 	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
@@ -267,6 +660,22 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		_node.OwnerID = nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.OptionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	if nodes := _c.mutation.VotesIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -283,6 +692,86 @@ func (_c *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.ParticipationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.ParticipationsTable,
+			Columns: []string{poll.ParticipationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(participation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.BallotsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.BallotsTable,
+			Columns: []string{poll.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.PendingBallotsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.PendingBallotsTable,
+			Columns: []string{poll.PendingBallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.InvitationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.InvitationsTable,
+			Columns: []string{poll.InvitationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvitation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.ResultIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   poll.ResultTable,
+			Columns: []string{poll.ResultColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -7,7 +7,13 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"math"
+	"poll-app/ent/ballot"
+	"poll-app/ent/participation"
+	"poll-app/ent/pendingballot"
 	"poll-app/ent/poll"
+	"poll-app/ent/pollinvitation"
+	"poll-app/ent/polloption"
+	"poll-app/ent/pollresult"
 	"poll-app/ent/predicate"
 	"poll-app/ent/user"
 	"poll-app/ent/vote"
@@ -22,13 +28,19 @@ import (
 // PollQuery is the builder for querying Poll entities.
 type PollQuery struct {
 	config
-	ctx        *QueryContext
-	order      []poll.OrderOption
-	inters     []Interceptor
-	predicates []predicate.Poll
-	withOwner  *UserQuery
-	withVotes  *VoteQuery
-	withFKs    bool
+	ctx                *QueryContext
+	order              []poll.OrderOption
+	inters             []Interceptor
+	predicates         []predicate.Poll
+	withOwner          *UserQuery
+	withOptions        *PollOptionQuery
+	withVotes          *VoteQuery
+	withParticipations *ParticipationQuery
+	withBallots        *BallotQuery
+	withPendingBallots *PendingBallotQuery
+	withInvitations    *PollInvitationQuery
+	withResult         *PollResultQuery
+	withFKs            bool
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -87,6 +99,28 @@ func (_q *PollQuery) QueryOwner() *UserQuery {
 	return query
 }
 
+// QueryOptions chains the current query on the "options" edge.
+func (_q *PollQuery) QueryOptions() *PollOptionQuery {
+	query := (&PollOptionClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(polloption.Table, polloption.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, poll.OptionsTable, poll.OptionsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // QueryVotes chains the current query on the "votes" edge.
 func (_q *PollQuery) QueryVotes() *VoteQuery {
 	query := (&VoteClient{config: _q.config}).Query()
@@ -109,6 +143,116 @@ func (_q *PollQuery) QueryVotes() *VoteQuery {
 	return query
 }
 
+// QueryParticipations chains the current query on the "participations" edge.
+func (_q *PollQuery) QueryParticipations() *ParticipationQuery {
+	query := (&ParticipationClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(participation.Table, participation.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, poll.ParticipationsTable, poll.ParticipationsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryBallots chains the current query on the "ballots" edge.
+func (_q *PollQuery) QueryBallots() *BallotQuery {
+	query := (&BallotClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(ballot.Table, ballot.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, poll.BallotsTable, poll.BallotsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryPendingBallots chains the current query on the "pending_ballots" edge.
+func (_q *PollQuery) QueryPendingBallots() *PendingBallotQuery {
+	query := (&PendingBallotClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(pendingballot.Table, pendingballot.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, poll.PendingBallotsTable, poll.PendingBallotsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryInvitations chains the current query on the "invitations" edge.
+func (_q *PollQuery) QueryInvitations() *PollInvitationQuery {
+	query := (&PollInvitationClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(pollinvitation.Table, pollinvitation.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, true, poll.InvitationsTable, poll.InvitationsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryResult chains the current query on the "result" edge.
+func (_q *PollQuery) QueryResult() *PollResultQuery {
+	query := (&PollResultClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(pollresult.Table, pollresult.FieldID),
+			sqlgraph.Edge(sqlgraph.O2O, false, poll.ResultTable, poll.ResultColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Poll entity from the query.
 // Returns a *NotFoundError when no Poll was found.
 func (_q *PollQuery) First(ctx context.Context) (*Poll, error) {
@@ -296,13 +440,19 @@ func (_q *PollQuery) Clone() *PollQuery {
 		return nil
 	}
 	return &PollQuery{
-		config:     _q.config,
-		ctx:        _q.ctx.Clone(),
-		order:      append([]poll.OrderOption{}, _q.order...),
-		inters:     append([]Interceptor{}, _q.inters...),
-		predicates: append([]predicate.Poll{}, _q.predicates...),
-		withOwner:  _q.withOwner.Clone(),
-		withVotes:  _q.withVotes.Clone(),
+		config:             _q.config,
+		ctx:                _q.ctx.Clone(),
+		order:              append([]poll.OrderOption{}, _q.order...),
+		inters:             append([]Interceptor{}, _q.inters...),
+		predicates:         append([]predicate.Poll{}, _q.predicates...),
+		withOwner:          _q.withOwner.Clone(),
+		withOptions:        _q.withOptions.Clone(),
+		withVotes:          _q.withVotes.Clone(),
+		withParticipations: _q.withParticipations.Clone(),
+		withBallots:        _q.withBallots.Clone(),
+		withPendingBallots: _q.withPendingBallots.Clone(),
+		withInvitations:    _q.withInvitations.Clone(),
+		withResult:         _q.withResult.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -320,6 +470,17 @@ func (_q *PollQuery) WithOwner(opts ...func(*UserQuery)) *PollQuery {
 	return _q
 }
 
+// WithOptions tells the query-builder to eager-load the nodes that are connected to
+// the "options" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithOptions(opts ...func(*PollOptionQuery)) *PollQuery {
+	query := (&PollOptionClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withOptions = query
+	return _q
+}
+
 // WithVotes tells the query-builder to eager-load the nodes that are connected to
 // the "votes" edge. The optional arguments are used to configure the query builder of the edge.
 func (_q *PollQuery) WithVotes(opts ...func(*VoteQuery)) *PollQuery {
@@ -331,6 +492,61 @@ func (_q *PollQuery) WithVotes(opts ...func(*VoteQuery)) *PollQuery {
 	return _q
 }
 
+// WithParticipations tells the query-builder to eager-load the nodes that are connected to
+// the "participations" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithParticipations(opts ...func(*ParticipationQuery)) *PollQuery {
+	query := (&ParticipationClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withParticipations = query
+	return _q
+}
+
+// WithBallots tells the query-builder to eager-load the nodes that are connected to
+// the "ballots" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithBallots(opts ...func(*BallotQuery)) *PollQuery {
+	query := (&BallotClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withBallots = query
+	return _q
+}
+
+// WithPendingBallots tells the query-builder to eager-load the nodes that are connected to
+// the "pending_ballots" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithPendingBallots(opts ...func(*PendingBallotQuery)) *PollQuery {
+	query := (&PendingBallotClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withPendingBallots = query
+	return _q
+}
+
+// WithInvitations tells the query-builder to eager-load the nodes that are connected to
+// the "invitations" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithInvitations(opts ...func(*PollInvitationQuery)) *PollQuery {
+	query := (&PollInvitationClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withInvitations = query
+	return _q
+}
+
+// WithResult tells the query-builder to eager-load the nodes that are connected to
+// the "result" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *PollQuery) WithResult(opts ...func(*PollResultQuery)) *PollQuery {
+	query := (&PollResultClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withResult = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -410,9 +626,15 @@ func (_q *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 		nodes       = []*Poll{}
 		withFKs     = _q.withFKs
 		_spec       = _q.querySpec()
-		loadedTypes = [2]bool{
+		loadedTypes = [8]bool{
 			_q.withOwner != nil,
+			_q.withOptions != nil,
 			_q.withVotes != nil,
+			_q.withParticipations != nil,
+			_q.withBallots != nil,
+			_q.withPendingBallots != nil,
+			_q.withInvitations != nil,
+			_q.withResult != nil,
 		}
 	)
 	if withFKs {
@@ -442,6 +664,13 @@ func (_q *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 			return nil, err
 		}
 	}
+	if query := _q.withOptions; query != nil {
+		if err := _q.loadOptions(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Options = []*PollOption{} },
+			func(n *Poll, e *PollOption) { n.Edges.Options = append(n.Edges.Options, e) }); err != nil {
+			return nil, err
+		}
+	}
 	if query := _q.withVotes; query != nil {
 		if err := _q.loadVotes(ctx, query, nodes,
 			func(n *Poll) { n.Edges.Votes = []*Vote{} },
@@ -449,6 +678,40 @@ func (_q *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 			return nil, err
 		}
 	}
+	if query := _q.withParticipations; query != nil {
+		if err := _q.loadParticipations(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Participations = []*Participation{} },
+			func(n *Poll, e *Participation) { n.Edges.Participations = append(n.Edges.Participations, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withBallots; query != nil {
+		if err := _q.loadBallots(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Ballots = []*Ballot{} },
+			func(n *Poll, e *Ballot) { n.Edges.Ballots = append(n.Edges.Ballots, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withPendingBallots; query != nil {
+		if err := _q.loadPendingBallots(ctx, query, nodes,
+			func(n *Poll) { n.Edges.PendingBallots = []*PendingBallot{} },
+			func(n *Poll, e *PendingBallot) { n.Edges.PendingBallots = append(n.Edges.PendingBallots, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withInvitations; query != nil {
+		if err := _q.loadInvitations(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Invitations = []*PollInvitation{} },
+			func(n *Poll, e *PollInvitation) { n.Edges.Invitations = append(n.Edges.Invitations, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withResult; query != nil {
+		if err := _q.loadResult(ctx, query, nodes, nil,
+			func(n *Poll, e *PollResult) { n.Edges.Result = e }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -481,6 +744,36 @@ func (_q *PollQuery) loadOwner(ctx context.Context, query *UserQuery, nodes []*P
 	}
 	return nil
 }
+func (_q *PollQuery) loadOptions(ctx context.Context, query *PollOptionQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *PollOption)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(polloption.FieldPollID)
+	}
+	query.Where(predicate.PollOption(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.OptionsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.PollID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 func (_q *PollQuery) loadVotes(ctx context.Context, query *VoteQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Vote)) error {
 	fks := make([]driver.Value, 0, len(nodes))
 	nodeids := make(map[uuid.UUID]*Poll)
@@ -511,6 +804,153 @@ func (_q *PollQuery) loadVotes(ctx context.Context, query *VoteQuery, nodes []*P
 	}
 	return nil
 }
+func (_q *PollQuery) loadParticipations(ctx context.Context, query *ParticipationQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Participation)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(participation.FieldPollID)
+	}
+	query.Where(predicate.Participation(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.ParticipationsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.PollID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *PollQuery) loadBallots(ctx context.Context, query *BallotQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Ballot)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(ballot.FieldPollID)
+	}
+	query.Where(predicate.Ballot(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.BallotsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.PollID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *PollQuery) loadPendingBallots(ctx context.Context, query *PendingBallotQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *PendingBallot)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(pendingballot.FieldPollID)
+	}
+	query.Where(predicate.PendingBallot(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.PendingBallotsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.PollID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *PollQuery) loadInvitations(ctx context.Context, query *PollInvitationQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *PollInvitation)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(pollinvitation.FieldPollID)
+	}
+	query.Where(predicate.PollInvitation(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.InvitationsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.PollID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *PollQuery) loadResult(ctx context.Context, query *PollResultQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *PollResult)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uuid.UUID]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(pollresult.FieldPollID)
+	}
+	query.Where(predicate.PollResult(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.ResultColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.PollID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (_q *PollQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
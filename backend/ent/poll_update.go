@@ -6,7 +6,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"poll-app/ent/ballot"
+	"poll-app/ent/participation"
+	"poll-app/ent/pendingballot"
 	"poll-app/ent/poll"
+	"poll-app/ent/pollinvitation"
+	"poll-app/ent/polloption"
+	"poll-app/ent/pollresult"
 	"poll-app/ent/predicate"
 	"poll-app/ent/user"
 	"poll-app/ent/vote"
@@ -66,15 +72,111 @@ func (_u *PollUpdate) ClearDescription() *PollUpdate {
 	return _u
 }
 
-// SetOptions sets the "options" field.
-func (_u *PollUpdate) SetOptions(v []string) *PollUpdate {
-	_u.mutation.SetOptions(v)
+// SetLegacyOptions sets the "legacy_options" field.
+func (_u *PollUpdate) SetLegacyOptions(v []string) *PollUpdate {
+	_u.mutation.SetLegacyOptions(v)
 	return _u
 }
 
-// AppendOptions appends value to the "options" field.
-func (_u *PollUpdate) AppendOptions(v []string) *PollUpdate {
-	_u.mutation.AppendOptions(v)
+// AppendLegacyOptions appends value to the "legacy_options" field.
+func (_u *PollUpdate) AppendLegacyOptions(v []string) *PollUpdate {
+	_u.mutation.AppendLegacyOptions(v)
+	return _u
+}
+
+// ClearLegacyOptions clears the value of the "legacy_options" field.
+func (_u *PollUpdate) ClearLegacyOptions() *PollUpdate {
+	_u.mutation.ClearLegacyOptions()
+	return _u
+}
+
+// SetType sets the "type" field.
+func (_u *PollUpdate) SetType(v poll.Type) *PollUpdate {
+	_u.mutation.SetType(v)
+	return _u
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableType(v *poll.Type) *PollUpdate {
+	if v != nil {
+		_u.SetType(*v)
+	}
+	return _u
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (_u *PollUpdate) SetMinChoices(v int) *PollUpdate {
+	_u.mutation.ResetMinChoices()
+	_u.mutation.SetMinChoices(v)
+	return _u
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableMinChoices(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetMinChoices(*v)
+	}
+	return _u
+}
+
+// AddMinChoices adds value to the "min_choices" field.
+func (_u *PollUpdate) AddMinChoices(v int) *PollUpdate {
+	_u.mutation.AddMinChoices(v)
+	return _u
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (_u *PollUpdate) SetMaxChoices(v int) *PollUpdate {
+	_u.mutation.ResetMaxChoices()
+	_u.mutation.SetMaxChoices(v)
+	return _u
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableMaxChoices(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetMaxChoices(*v)
+	}
+	return _u
+}
+
+// AddMaxChoices adds value to the "max_choices" field.
+func (_u *PollUpdate) AddMaxChoices(v int) *PollUpdate {
+	_u.mutation.AddMaxChoices(v)
+	return _u
+}
+
+// ClearMaxChoices clears the value of the "max_choices" field.
+func (_u *PollUpdate) ClearMaxChoices() *PollUpdate {
+	_u.mutation.ClearMaxChoices()
+	return _u
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (_u *PollUpdate) SetResultsVisibility(v poll.ResultsVisibility) *PollUpdate {
+	_u.mutation.SetResultsVisibility(v)
+	return _u
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableResultsVisibility(v *poll.ResultsVisibility) *PollUpdate {
+	if v != nil {
+		_u.SetResultsVisibility(*v)
+	}
+	return _u
+}
+
+// SetRequireVerifiedEmail sets the "require_verified_email" field.
+func (_u *PollUpdate) SetRequireVerifiedEmail(v bool) *PollUpdate {
+	_u.mutation.SetRequireVerifiedEmail(v)
+	return _u
+}
+
+// SetNillableRequireVerifiedEmail sets the "require_verified_email" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableRequireVerifiedEmail(v *bool) *PollUpdate {
+	if v != nil {
+		_u.SetRequireVerifiedEmail(*v)
+	}
 	return _u
 }
 
@@ -92,6 +194,108 @@ func (_u *PollUpdate) SetNillableOwnerID(v *uuid.UUID) *PollUpdate {
 	return _u
 }
 
+// SetOpensAt sets the "opens_at" field.
+func (_u *PollUpdate) SetOpensAt(v time.Time) *PollUpdate {
+	_u.mutation.SetOpensAt(v)
+	return _u
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableOpensAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetOpensAt(*v)
+	}
+	return _u
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (_u *PollUpdate) ClearOpensAt() *PollUpdate {
+	_u.mutation.ClearOpensAt()
+	return _u
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (_u *PollUpdate) SetClosesAt(v time.Time) *PollUpdate {
+	_u.mutation.SetClosesAt(v)
+	return _u
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableClosesAt(v *time.Time) *PollUpdate {
+	if v != nil {
+		_u.SetClosesAt(*v)
+	}
+	return _u
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (_u *PollUpdate) ClearClosesAt() *PollUpdate {
+	_u.mutation.ClearClosesAt()
+	return _u
+}
+
+// SetVersion sets the "version" field.
+func (_u *PollUpdate) SetVersion(v int) *PollUpdate {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableVersion(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *PollUpdate) AddVersion(v int) *PollUpdate {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetSearchVector sets the "search_vector" field.
+func (_u *PollUpdate) SetSearchVector(v string) *PollUpdate {
+	_u.mutation.SetSearchVector(v)
+	return _u
+}
+
+// SetNillableSearchVector sets the "search_vector" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableSearchVector(v *string) *PollUpdate {
+	if v != nil {
+		_u.SetSearchVector(*v)
+	}
+	return _u
+}
+
+// ClearSearchVector clears the value of the "search_vector" field.
+func (_u *PollUpdate) ClearSearchVector() *PollUpdate {
+	_u.mutation.ClearSearchVector()
+	return _u
+}
+
+// SetVoterCount sets the "voter_count" field.
+func (_u *PollUpdate) SetVoterCount(v int) *PollUpdate {
+	_u.mutation.ResetVoterCount()
+	_u.mutation.SetVoterCount(v)
+	return _u
+}
+
+// SetNillableVoterCount sets the "voter_count" field if the given value is not nil.
+func (_u *PollUpdate) SetNillableVoterCount(v *int) *PollUpdate {
+	if v != nil {
+		_u.SetVoterCount(*v)
+	}
+	return _u
+}
+
+// AddVoterCount adds value to the "voter_count" field.
+func (_u *PollUpdate) AddVoterCount(v int) *PollUpdate {
+	_u.mutation.AddVoterCount(v)
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *PollUpdate) SetCreatedAt(v time.Time) *PollUpdate {
 	_u.mutation.SetCreatedAt(v)
@@ -117,6 +321,21 @@ func (_u *PollUpdate) SetOwner(v *User) *PollUpdate {
 	return _u.SetOwnerID(v.ID)
 }
 
+// AddOptionIDs adds the "options" edge to the PollOption entity by IDs.
+func (_u *PollUpdate) AddOptionIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.AddOptionIDs(ids...)
+	return _u
+}
+
+// AddOptions adds the "options" edges to the PollOption entity.
+func (_u *PollUpdate) AddOptions(v ...*PollOption) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOptionIDs(ids...)
+}
+
 // AddVoteIDs adds the "votes" edge to the Vote entity by IDs.
 func (_u *PollUpdate) AddVoteIDs(ids ...uuid.UUID) *PollUpdate {
 	_u.mutation.AddVoteIDs(ids...)
@@ -132,6 +351,85 @@ func (_u *PollUpdate) AddVotes(v ...*Vote) *PollUpdate {
 	return _u.AddVoteIDs(ids...)
 }
 
+// AddParticipationIDs adds the "participations" edge to the Participation entity by IDs.
+func (_u *PollUpdate) AddParticipationIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.AddParticipationIDs(ids...)
+	return _u
+}
+
+// AddParticipations adds the "participations" edges to the Participation entity.
+func (_u *PollUpdate) AddParticipations(v ...*Participation) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddParticipationIDs(ids...)
+}
+
+// AddBallotIDs adds the "ballots" edge to the Ballot entity by IDs.
+func (_u *PollUpdate) AddBallotIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.AddBallotIDs(ids...)
+	return _u
+}
+
+// AddBallots adds the "ballots" edges to the Ballot entity.
+func (_u *PollUpdate) AddBallots(v ...*Ballot) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBallotIDs(ids...)
+}
+
+// AddPendingBallotIDs adds the "pending_ballots" edge to the PendingBallot entity by IDs.
+func (_u *PollUpdate) AddPendingBallotIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.AddPendingBallotIDs(ids...)
+	return _u
+}
+
+// AddPendingBallots adds the "pending_ballots" edges to the PendingBallot entity.
+func (_u *PollUpdate) AddPendingBallots(v ...*PendingBallot) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddPendingBallotIDs(ids...)
+}
+
+// AddInvitationIDs adds the "invitations" edge to the PollInvitation entity by IDs.
+func (_u *PollUpdate) AddInvitationIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.AddInvitationIDs(ids...)
+	return _u
+}
+
+// AddInvitations adds the "invitations" edges to the PollInvitation entity.
+func (_u *PollUpdate) AddInvitations(v ...*PollInvitation) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddInvitationIDs(ids...)
+}
+
+// SetResultID sets the "result" edge to the PollResult entity by ID.
+func (_u *PollUpdate) SetResultID(id uuid.UUID) *PollUpdate {
+	_u.mutation.SetResultID(id)
+	return _u
+}
+
+// SetNillableResultID sets the "result" edge to the PollResult entity by ID if the given value is not nil.
+func (_u *PollUpdate) SetNillableResultID(id *uuid.UUID) *PollUpdate {
+	if id != nil {
+		_u = _u.SetResultID(*id)
+	}
+	return _u
+}
+
+// SetResult sets the "result" edge to the PollResult entity.
+func (_u *PollUpdate) SetResult(v *PollResult) *PollUpdate {
+	return _u.SetResultID(v.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (_u *PollUpdate) Mutation() *PollMutation {
 	return _u.mutation
@@ -143,6 +441,27 @@ func (_u *PollUpdate) ClearOwner() *PollUpdate {
 	return _u
 }
 
+// ClearOptions clears all "options" edges to the PollOption entity.
+func (_u *PollUpdate) ClearOptions() *PollUpdate {
+	_u.mutation.ClearOptions()
+	return _u
+}
+
+// RemoveOptionIDs removes the "options" edge to PollOption entities by IDs.
+func (_u *PollUpdate) RemoveOptionIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.RemoveOptionIDs(ids...)
+	return _u
+}
+
+// RemoveOptions removes "options" edges to PollOption entities.
+func (_u *PollUpdate) RemoveOptions(v ...*PollOption) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOptionIDs(ids...)
+}
+
 // ClearVotes clears all "votes" edges to the Vote entity.
 func (_u *PollUpdate) ClearVotes() *PollUpdate {
 	_u.mutation.ClearVotes()
@@ -164,6 +483,96 @@ func (_u *PollUpdate) RemoveVotes(v ...*Vote) *PollUpdate {
 	return _u.RemoveVoteIDs(ids...)
 }
 
+// ClearParticipations clears all "participations" edges to the Participation entity.
+func (_u *PollUpdate) ClearParticipations() *PollUpdate {
+	_u.mutation.ClearParticipations()
+	return _u
+}
+
+// RemoveParticipationIDs removes the "participations" edge to Participation entities by IDs.
+func (_u *PollUpdate) RemoveParticipationIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.RemoveParticipationIDs(ids...)
+	return _u
+}
+
+// RemoveParticipations removes "participations" edges to Participation entities.
+func (_u *PollUpdate) RemoveParticipations(v ...*Participation) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveParticipationIDs(ids...)
+}
+
+// ClearBallots clears all "ballots" edges to the Ballot entity.
+func (_u *PollUpdate) ClearBallots() *PollUpdate {
+	_u.mutation.ClearBallots()
+	return _u
+}
+
+// RemoveBallotIDs removes the "ballots" edge to Ballot entities by IDs.
+func (_u *PollUpdate) RemoveBallotIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.RemoveBallotIDs(ids...)
+	return _u
+}
+
+// RemoveBallots removes "ballots" edges to Ballot entities.
+func (_u *PollUpdate) RemoveBallots(v ...*Ballot) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveBallotIDs(ids...)
+}
+
+// ClearPendingBallots clears all "pending_ballots" edges to the PendingBallot entity.
+func (_u *PollUpdate) ClearPendingBallots() *PollUpdate {
+	_u.mutation.ClearPendingBallots()
+	return _u
+}
+
+// RemovePendingBallotIDs removes the "pending_ballots" edge to PendingBallot entities by IDs.
+func (_u *PollUpdate) RemovePendingBallotIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.RemovePendingBallotIDs(ids...)
+	return _u
+}
+
+// RemovePendingBallots removes "pending_ballots" edges to PendingBallot entities.
+func (_u *PollUpdate) RemovePendingBallots(v ...*PendingBallot) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemovePendingBallotIDs(ids...)
+}
+
+// ClearInvitations clears all "invitations" edges to the PollInvitation entity.
+func (_u *PollUpdate) ClearInvitations() *PollUpdate {
+	_u.mutation.ClearInvitations()
+	return _u
+}
+
+// RemoveInvitationIDs removes the "invitations" edge to PollInvitation entities by IDs.
+func (_u *PollUpdate) RemoveInvitationIDs(ids ...uuid.UUID) *PollUpdate {
+	_u.mutation.RemoveInvitationIDs(ids...)
+	return _u
+}
+
+// RemoveInvitations removes "invitations" edges to PollInvitation entities.
+func (_u *PollUpdate) RemoveInvitations(v ...*PollInvitation) *PollUpdate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveInvitationIDs(ids...)
+}
+
+// ClearResult clears the "result" edge to the PollResult entity.
+func (_u *PollUpdate) ClearResult() *PollUpdate {
+	_u.mutation.ClearResult()
+	return _u
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *PollUpdate) Save(ctx context.Context) (int, error) {
 	_u.defaults()
@@ -207,6 +616,36 @@ func (_u *PollUpdate) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.GetType(); ok {
+		if err := poll.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Poll.type": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Version(); ok {
+		if err := poll.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Poll.version": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.VoterCount(); ok {
+		if err := poll.VoterCountValidator(v); err != nil {
+			return &ValidationError{Name: "voter_count", err: fmt.Errorf(`ent: validator failed for field "Poll.voter_count": %w`, err)}
+		}
+	}
 	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Poll.owner"`)
 	}
@@ -234,14 +673,74 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(poll.FieldDescription, field.TypeString)
 	}
-	if value, ok := _u.mutation.Options(); ok {
-		_spec.SetField(poll.FieldOptions, field.TypeJSON, value)
+	if value, ok := _u.mutation.LegacyOptions(); ok {
+		_spec.SetField(poll.FieldLegacyOptions, field.TypeJSON, value)
 	}
-	if value, ok := _u.mutation.AppendedOptions(); ok {
+	if value, ok := _u.mutation.AppendedLegacyOptions(); ok {
 		_spec.AddModifier(func(u *sql.UpdateBuilder) {
-			sqljson.Append(u, poll.FieldOptions, value)
+			sqljson.Append(u, poll.FieldLegacyOptions, value)
 		})
 	}
+	if _u.mutation.LegacyOptionsCleared() {
+		_spec.ClearField(poll.FieldLegacyOptions, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.GetType(); ok {
+		_spec.SetField(poll.FieldType, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinChoices(); ok {
+		_spec.AddField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxChoices(); ok {
+		_spec.AddField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if _u.mutation.MaxChoicesCleared() {
+		_spec.ClearField(poll.FieldMaxChoices, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.RequireVerifiedEmail(); ok {
+		_spec.SetField(poll.FieldRequireVerifiedEmail, field.TypeBool, value)
+	}
+	if _u.mutation.ExternalIDCleared() {
+		_spec.ClearField(poll.FieldExternalID, field.TypeString)
+	}
+	if value, ok := _u.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if _u.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if _u.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(poll.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(poll.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.SearchVector(); ok {
+		_spec.SetField(poll.FieldSearchVector, field.TypeString, value)
+	}
+	if _u.mutation.SearchVectorCleared() {
+		_spec.ClearField(poll.FieldSearchVector, field.TypeString)
+	}
+	if value, ok := _u.mutation.VoterCount(); ok {
+		_spec.SetField(poll.FieldVoterCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVoterCount(); ok {
+		_spec.AddField(poll.FieldVoterCount, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -277,6 +776,51 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OptionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOptionsIDs(); len(nodes) > 0 && !_u.mutation.OptionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _u.mutation.VotesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -322,6 +866,215 @@ func (_u *PollUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.ParticipationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.ParticipationsTable,
+			Columns: []string{poll.ParticipationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(participation.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedParticipationsIDs(); len(nodes) > 0 && !_u.mutation.ParticipationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.ParticipationsTable,
+			Columns: []string{poll.ParticipationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(participation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ParticipationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.ParticipationsTable,
+			Columns: []string{poll.ParticipationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(participation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.BallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.BallotsTable,
+			Columns: []string{poll.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedBallotsIDs(); len(nodes) > 0 && !_u.mutation.BallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.BallotsTable,
+			Columns: []string{poll.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.BallotsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.BallotsTable,
+			Columns: []string{poll.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.PendingBallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.PendingBallotsTable,
+			Columns: []string{poll.PendingBallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedPendingBallotsIDs(); len(nodes) > 0 && !_u.mutation.PendingBallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.PendingBallotsTable,
+			Columns: []string{poll.PendingBallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PendingBallotsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.PendingBallotsTable,
+			Columns: []string{poll.PendingBallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.InvitationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.InvitationsTable,
+			Columns: []string{poll.InvitationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvitation.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedInvitationsIDs(); len(nodes) > 0 && !_u.mutation.InvitationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.InvitationsTable,
+			Columns: []string{poll.InvitationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvitation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.InvitationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.InvitationsTable,
+			Columns: []string{poll.InvitationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvitation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ResultCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   poll.ResultTable,
+			Columns: []string{poll.ResultColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ResultIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   poll.ResultTable,
+			Columns: []string{poll.ResultColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{poll.Label}
@@ -376,15 +1129,111 @@ func (_u *PollUpdateOne) ClearDescription() *PollUpdateOne {
 	return _u
 }
 
-// SetOptions sets the "options" field.
-func (_u *PollUpdateOne) SetOptions(v []string) *PollUpdateOne {
-	_u.mutation.SetOptions(v)
+// SetLegacyOptions sets the "legacy_options" field.
+func (_u *PollUpdateOne) SetLegacyOptions(v []string) *PollUpdateOne {
+	_u.mutation.SetLegacyOptions(v)
+	return _u
+}
+
+// AppendLegacyOptions appends value to the "legacy_options" field.
+func (_u *PollUpdateOne) AppendLegacyOptions(v []string) *PollUpdateOne {
+	_u.mutation.AppendLegacyOptions(v)
+	return _u
+}
+
+// ClearLegacyOptions clears the value of the "legacy_options" field.
+func (_u *PollUpdateOne) ClearLegacyOptions() *PollUpdateOne {
+	_u.mutation.ClearLegacyOptions()
+	return _u
+}
+
+// SetType sets the "type" field.
+func (_u *PollUpdateOne) SetType(v poll.Type) *PollUpdateOne {
+	_u.mutation.SetType(v)
+	return _u
+}
+
+// SetNillableType sets the "type" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableType(v *poll.Type) *PollUpdateOne {
+	if v != nil {
+		_u.SetType(*v)
+	}
+	return _u
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (_u *PollUpdateOne) SetMinChoices(v int) *PollUpdateOne {
+	_u.mutation.ResetMinChoices()
+	_u.mutation.SetMinChoices(v)
+	return _u
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableMinChoices(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetMinChoices(*v)
+	}
+	return _u
+}
+
+// AddMinChoices adds value to the "min_choices" field.
+func (_u *PollUpdateOne) AddMinChoices(v int) *PollUpdateOne {
+	_u.mutation.AddMinChoices(v)
+	return _u
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (_u *PollUpdateOne) SetMaxChoices(v int) *PollUpdateOne {
+	_u.mutation.ResetMaxChoices()
+	_u.mutation.SetMaxChoices(v)
+	return _u
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableMaxChoices(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetMaxChoices(*v)
+	}
+	return _u
+}
+
+// AddMaxChoices adds value to the "max_choices" field.
+func (_u *PollUpdateOne) AddMaxChoices(v int) *PollUpdateOne {
+	_u.mutation.AddMaxChoices(v)
+	return _u
+}
+
+// ClearMaxChoices clears the value of the "max_choices" field.
+func (_u *PollUpdateOne) ClearMaxChoices() *PollUpdateOne {
+	_u.mutation.ClearMaxChoices()
 	return _u
 }
 
-// AppendOptions appends value to the "options" field.
-func (_u *PollUpdateOne) AppendOptions(v []string) *PollUpdateOne {
-	_u.mutation.AppendOptions(v)
+// SetResultsVisibility sets the "results_visibility" field.
+func (_u *PollUpdateOne) SetResultsVisibility(v poll.ResultsVisibility) *PollUpdateOne {
+	_u.mutation.SetResultsVisibility(v)
+	return _u
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableResultsVisibility(v *poll.ResultsVisibility) *PollUpdateOne {
+	if v != nil {
+		_u.SetResultsVisibility(*v)
+	}
+	return _u
+}
+
+// SetRequireVerifiedEmail sets the "require_verified_email" field.
+func (_u *PollUpdateOne) SetRequireVerifiedEmail(v bool) *PollUpdateOne {
+	_u.mutation.SetRequireVerifiedEmail(v)
+	return _u
+}
+
+// SetNillableRequireVerifiedEmail sets the "require_verified_email" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableRequireVerifiedEmail(v *bool) *PollUpdateOne {
+	if v != nil {
+		_u.SetRequireVerifiedEmail(*v)
+	}
 	return _u
 }
 
@@ -402,6 +1251,108 @@ func (_u *PollUpdateOne) SetNillableOwnerID(v *uuid.UUID) *PollUpdateOne {
 	return _u
 }
 
+// SetOpensAt sets the "opens_at" field.
+func (_u *PollUpdateOne) SetOpensAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetOpensAt(v)
+	return _u
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableOpensAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetOpensAt(*v)
+	}
+	return _u
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (_u *PollUpdateOne) ClearOpensAt() *PollUpdateOne {
+	_u.mutation.ClearOpensAt()
+	return _u
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (_u *PollUpdateOne) SetClosesAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetClosesAt(v)
+	return _u
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableClosesAt(v *time.Time) *PollUpdateOne {
+	if v != nil {
+		_u.SetClosesAt(*v)
+	}
+	return _u
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (_u *PollUpdateOne) ClearClosesAt() *PollUpdateOne {
+	_u.mutation.ClearClosesAt()
+	return _u
+}
+
+// SetVersion sets the "version" field.
+func (_u *PollUpdateOne) SetVersion(v int) *PollUpdateOne {
+	_u.mutation.ResetVersion()
+	_u.mutation.SetVersion(v)
+	return _u
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableVersion(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetVersion(*v)
+	}
+	return _u
+}
+
+// AddVersion adds value to the "version" field.
+func (_u *PollUpdateOne) AddVersion(v int) *PollUpdateOne {
+	_u.mutation.AddVersion(v)
+	return _u
+}
+
+// SetSearchVector sets the "search_vector" field.
+func (_u *PollUpdateOne) SetSearchVector(v string) *PollUpdateOne {
+	_u.mutation.SetSearchVector(v)
+	return _u
+}
+
+// SetNillableSearchVector sets the "search_vector" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableSearchVector(v *string) *PollUpdateOne {
+	if v != nil {
+		_u.SetSearchVector(*v)
+	}
+	return _u
+}
+
+// ClearSearchVector clears the value of the "search_vector" field.
+func (_u *PollUpdateOne) ClearSearchVector() *PollUpdateOne {
+	_u.mutation.ClearSearchVector()
+	return _u
+}
+
+// SetVoterCount sets the "voter_count" field.
+func (_u *PollUpdateOne) SetVoterCount(v int) *PollUpdateOne {
+	_u.mutation.ResetVoterCount()
+	_u.mutation.SetVoterCount(v)
+	return _u
+}
+
+// SetNillableVoterCount sets the "voter_count" field if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableVoterCount(v *int) *PollUpdateOne {
+	if v != nil {
+		_u.SetVoterCount(*v)
+	}
+	return _u
+}
+
+// AddVoterCount adds value to the "voter_count" field.
+func (_u *PollUpdateOne) AddVoterCount(v int) *PollUpdateOne {
+	_u.mutation.AddVoterCount(v)
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *PollUpdateOne) SetCreatedAt(v time.Time) *PollUpdateOne {
 	_u.mutation.SetCreatedAt(v)
@@ -413,65 +1364,270 @@ func (_u *PollUpdateOne) SetNillableCreatedAt(v *time.Time) *PollUpdateOne {
 	if v != nil {
 		_u.SetCreatedAt(*v)
 	}
-	return _u
+	return _u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_u *PollUpdateOne) SetUpdatedAt(v time.Time) *PollUpdateOne {
+	_u.mutation.SetUpdatedAt(v)
+	return _u
+}
+
+// SetOwner sets the "owner" edge to the User entity.
+func (_u *PollUpdateOne) SetOwner(v *User) *PollUpdateOne {
+	return _u.SetOwnerID(v.ID)
+}
+
+// AddOptionIDs adds the "options" edge to the PollOption entity by IDs.
+func (_u *PollUpdateOne) AddOptionIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.AddOptionIDs(ids...)
+	return _u
+}
+
+// AddOptions adds the "options" edges to the PollOption entity.
+func (_u *PollUpdateOne) AddOptions(v ...*PollOption) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddOptionIDs(ids...)
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by IDs.
+func (_u *PollUpdateOne) AddVoteIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.AddVoteIDs(ids...)
+	return _u
+}
+
+// AddVotes adds the "votes" edges to the Vote entity.
+func (_u *PollUpdateOne) AddVotes(v ...*Vote) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddVoteIDs(ids...)
+}
+
+// AddParticipationIDs adds the "participations" edge to the Participation entity by IDs.
+func (_u *PollUpdateOne) AddParticipationIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.AddParticipationIDs(ids...)
+	return _u
+}
+
+// AddParticipations adds the "participations" edges to the Participation entity.
+func (_u *PollUpdateOne) AddParticipations(v ...*Participation) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddParticipationIDs(ids...)
+}
+
+// AddBallotIDs adds the "ballots" edge to the Ballot entity by IDs.
+func (_u *PollUpdateOne) AddBallotIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.AddBallotIDs(ids...)
+	return _u
+}
+
+// AddBallots adds the "ballots" edges to the Ballot entity.
+func (_u *PollUpdateOne) AddBallots(v ...*Ballot) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddBallotIDs(ids...)
+}
+
+// AddPendingBallotIDs adds the "pending_ballots" edge to the PendingBallot entity by IDs.
+func (_u *PollUpdateOne) AddPendingBallotIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.AddPendingBallotIDs(ids...)
+	return _u
+}
+
+// AddPendingBallots adds the "pending_ballots" edges to the PendingBallot entity.
+func (_u *PollUpdateOne) AddPendingBallots(v ...*PendingBallot) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddPendingBallotIDs(ids...)
+}
+
+// AddInvitationIDs adds the "invitations" edge to the PollInvitation entity by IDs.
+func (_u *PollUpdateOne) AddInvitationIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.AddInvitationIDs(ids...)
+	return _u
+}
+
+// AddInvitations adds the "invitations" edges to the PollInvitation entity.
+func (_u *PollUpdateOne) AddInvitations(v ...*PollInvitation) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddInvitationIDs(ids...)
+}
+
+// SetResultID sets the "result" edge to the PollResult entity by ID.
+func (_u *PollUpdateOne) SetResultID(id uuid.UUID) *PollUpdateOne {
+	_u.mutation.SetResultID(id)
+	return _u
+}
+
+// SetNillableResultID sets the "result" edge to the PollResult entity by ID if the given value is not nil.
+func (_u *PollUpdateOne) SetNillableResultID(id *uuid.UUID) *PollUpdateOne {
+	if id != nil {
+		_u = _u.SetResultID(*id)
+	}
+	return _u
+}
+
+// SetResult sets the "result" edge to the PollResult entity.
+func (_u *PollUpdateOne) SetResult(v *PollResult) *PollUpdateOne {
+	return _u.SetResultID(v.ID)
+}
+
+// Mutation returns the PollMutation object of the builder.
+func (_u *PollUpdateOne) Mutation() *PollMutation {
+	return _u.mutation
+}
+
+// ClearOwner clears the "owner" edge to the User entity.
+func (_u *PollUpdateOne) ClearOwner() *PollUpdateOne {
+	_u.mutation.ClearOwner()
+	return _u
+}
+
+// ClearOptions clears all "options" edges to the PollOption entity.
+func (_u *PollUpdateOne) ClearOptions() *PollUpdateOne {
+	_u.mutation.ClearOptions()
+	return _u
+}
+
+// RemoveOptionIDs removes the "options" edge to PollOption entities by IDs.
+func (_u *PollUpdateOne) RemoveOptionIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.RemoveOptionIDs(ids...)
+	return _u
+}
+
+// RemoveOptions removes "options" edges to PollOption entities.
+func (_u *PollUpdateOne) RemoveOptions(v ...*PollOption) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveOptionIDs(ids...)
+}
+
+// ClearVotes clears all "votes" edges to the Vote entity.
+func (_u *PollUpdateOne) ClearVotes() *PollUpdateOne {
+	_u.mutation.ClearVotes()
+	return _u
+}
+
+// RemoveVoteIDs removes the "votes" edge to Vote entities by IDs.
+func (_u *PollUpdateOne) RemoveVoteIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.RemoveVoteIDs(ids...)
+	return _u
+}
+
+// RemoveVotes removes "votes" edges to Vote entities.
+func (_u *PollUpdateOne) RemoveVotes(v ...*Vote) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveVoteIDs(ids...)
+}
+
+// ClearParticipations clears all "participations" edges to the Participation entity.
+func (_u *PollUpdateOne) ClearParticipations() *PollUpdateOne {
+	_u.mutation.ClearParticipations()
+	return _u
+}
+
+// RemoveParticipationIDs removes the "participations" edge to Participation entities by IDs.
+func (_u *PollUpdateOne) RemoveParticipationIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.RemoveParticipationIDs(ids...)
+	return _u
+}
+
+// RemoveParticipations removes "participations" edges to Participation entities.
+func (_u *PollUpdateOne) RemoveParticipations(v ...*Participation) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveParticipationIDs(ids...)
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (_u *PollUpdateOne) SetUpdatedAt(v time.Time) *PollUpdateOne {
-	_u.mutation.SetUpdatedAt(v)
+// ClearBallots clears all "ballots" edges to the Ballot entity.
+func (_u *PollUpdateOne) ClearBallots() *PollUpdateOne {
+	_u.mutation.ClearBallots()
 	return _u
 }
 
-// SetOwner sets the "owner" edge to the User entity.
-func (_u *PollUpdateOne) SetOwner(v *User) *PollUpdateOne {
-	return _u.SetOwnerID(v.ID)
-}
-
-// AddVoteIDs adds the "votes" edge to the Vote entity by IDs.
-func (_u *PollUpdateOne) AddVoteIDs(ids ...uuid.UUID) *PollUpdateOne {
-	_u.mutation.AddVoteIDs(ids...)
+// RemoveBallotIDs removes the "ballots" edge to Ballot entities by IDs.
+func (_u *PollUpdateOne) RemoveBallotIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.RemoveBallotIDs(ids...)
 	return _u
 }
 
-// AddVotes adds the "votes" edges to the Vote entity.
-func (_u *PollUpdateOne) AddVotes(v ...*Vote) *PollUpdateOne {
+// RemoveBallots removes "ballots" edges to Ballot entities.
+func (_u *PollUpdateOne) RemoveBallots(v ...*Ballot) *PollUpdateOne {
 	ids := make([]uuid.UUID, len(v))
 	for i := range v {
 		ids[i] = v[i].ID
 	}
-	return _u.AddVoteIDs(ids...)
+	return _u.RemoveBallotIDs(ids...)
 }
 
-// Mutation returns the PollMutation object of the builder.
-func (_u *PollUpdateOne) Mutation() *PollMutation {
-	return _u.mutation
+// ClearPendingBallots clears all "pending_ballots" edges to the PendingBallot entity.
+func (_u *PollUpdateOne) ClearPendingBallots() *PollUpdateOne {
+	_u.mutation.ClearPendingBallots()
+	return _u
 }
 
-// ClearOwner clears the "owner" edge to the User entity.
-func (_u *PollUpdateOne) ClearOwner() *PollUpdateOne {
-	_u.mutation.ClearOwner()
+// RemovePendingBallotIDs removes the "pending_ballots" edge to PendingBallot entities by IDs.
+func (_u *PollUpdateOne) RemovePendingBallotIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.RemovePendingBallotIDs(ids...)
 	return _u
 }
 
-// ClearVotes clears all "votes" edges to the Vote entity.
-func (_u *PollUpdateOne) ClearVotes() *PollUpdateOne {
-	_u.mutation.ClearVotes()
+// RemovePendingBallots removes "pending_ballots" edges to PendingBallot entities.
+func (_u *PollUpdateOne) RemovePendingBallots(v ...*PendingBallot) *PollUpdateOne {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemovePendingBallotIDs(ids...)
+}
+
+// ClearInvitations clears all "invitations" edges to the PollInvitation entity.
+func (_u *PollUpdateOne) ClearInvitations() *PollUpdateOne {
+	_u.mutation.ClearInvitations()
 	return _u
 }
 
-// RemoveVoteIDs removes the "votes" edge to Vote entities by IDs.
-func (_u *PollUpdateOne) RemoveVoteIDs(ids ...uuid.UUID) *PollUpdateOne {
-	_u.mutation.RemoveVoteIDs(ids...)
+// RemoveInvitationIDs removes the "invitations" edge to PollInvitation entities by IDs.
+func (_u *PollUpdateOne) RemoveInvitationIDs(ids ...uuid.UUID) *PollUpdateOne {
+	_u.mutation.RemoveInvitationIDs(ids...)
 	return _u
 }
 
-// RemoveVotes removes "votes" edges to Vote entities.
-func (_u *PollUpdateOne) RemoveVotes(v ...*Vote) *PollUpdateOne {
+// RemoveInvitations removes "invitations" edges to PollInvitation entities.
+func (_u *PollUpdateOne) RemoveInvitations(v ...*PollInvitation) *PollUpdateOne {
 	ids := make([]uuid.UUID, len(v))
 	for i := range v {
 		ids[i] = v[i].ID
 	}
-	return _u.RemoveVoteIDs(ids...)
+	return _u.RemoveInvitationIDs(ids...)
+}
+
+// ClearResult clears the "result" edge to the PollResult entity.
+func (_u *PollUpdateOne) ClearResult() *PollUpdateOne {
+	_u.mutation.ClearResult()
+	return _u
 }
 
 // Where appends a list predicates to the PollUpdate builder.
@@ -530,6 +1686,36 @@ func (_u *PollUpdateOne) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.GetType(); ok {
+		if err := poll.TypeValidator(v); err != nil {
+			return &ValidationError{Name: "type", err: fmt.Errorf(`ent: validator failed for field "Poll.type": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Version(); ok {
+		if err := poll.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Poll.version": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.VoterCount(); ok {
+		if err := poll.VoterCountValidator(v); err != nil {
+			return &ValidationError{Name: "voter_count", err: fmt.Errorf(`ent: validator failed for field "Poll.voter_count": %w`, err)}
+		}
+	}
 	if _u.mutation.OwnerCleared() && len(_u.mutation.OwnerIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Poll.owner"`)
 	}
@@ -574,14 +1760,74 @@ func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
 	if _u.mutation.DescriptionCleared() {
 		_spec.ClearField(poll.FieldDescription, field.TypeString)
 	}
-	if value, ok := _u.mutation.Options(); ok {
-		_spec.SetField(poll.FieldOptions, field.TypeJSON, value)
+	if value, ok := _u.mutation.LegacyOptions(); ok {
+		_spec.SetField(poll.FieldLegacyOptions, field.TypeJSON, value)
 	}
-	if value, ok := _u.mutation.AppendedOptions(); ok {
+	if value, ok := _u.mutation.AppendedLegacyOptions(); ok {
 		_spec.AddModifier(func(u *sql.UpdateBuilder) {
-			sqljson.Append(u, poll.FieldOptions, value)
+			sqljson.Append(u, poll.FieldLegacyOptions, value)
 		})
 	}
+	if _u.mutation.LegacyOptionsCleared() {
+		_spec.ClearField(poll.FieldLegacyOptions, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.GetType(); ok {
+		_spec.SetField(poll.FieldType, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMinChoices(); ok {
+		_spec.AddField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedMaxChoices(); ok {
+		_spec.AddField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if _u.mutation.MaxChoicesCleared() {
+		_spec.ClearField(poll.FieldMaxChoices, field.TypeInt)
+	}
+	if value, ok := _u.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+	}
+	if value, ok := _u.mutation.RequireVerifiedEmail(); ok {
+		_spec.SetField(poll.FieldRequireVerifiedEmail, field.TypeBool, value)
+	}
+	if _u.mutation.ExternalIDCleared() {
+		_spec.ClearField(poll.FieldExternalID, field.TypeString)
+	}
+	if value, ok := _u.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if _u.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if _u.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.Version(); ok {
+		_spec.SetField(poll.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVersion(); ok {
+		_spec.AddField(poll.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.SearchVector(); ok {
+		_spec.SetField(poll.FieldSearchVector, field.TypeString, value)
+	}
+	if _u.mutation.SearchVectorCleared() {
+		_spec.ClearField(poll.FieldSearchVector, field.TypeString)
+	}
+	if value, ok := _u.mutation.VoterCount(); ok {
+		_spec.SetField(poll.FieldVoterCount, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVoterCount(); ok {
+		_spec.AddField(poll.FieldVoterCount, field.TypeInt, value)
+	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(poll.FieldCreatedAt, field.TypeTime, value)
 	}
@@ -617,6 +1863,51 @@ func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OptionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedOptionsIDs(); len(nodes) > 0 && !_u.mutation.OptionsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.OptionsTable,
+			Columns: []string{poll.OptionsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _u.mutation.VotesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -662,6 +1953,215 @@ func (_u *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.ParticipationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.ParticipationsTable,
+			Columns: []string{poll.ParticipationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(participation.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedParticipationsIDs(); len(nodes) > 0 && !_u.mutation.ParticipationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.ParticipationsTable,
+			Columns: []string{poll.ParticipationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(participation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ParticipationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.ParticipationsTable,
+			Columns: []string{poll.ParticipationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(participation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.BallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.BallotsTable,
+			Columns: []string{poll.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedBallotsIDs(); len(nodes) > 0 && !_u.mutation.BallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.BallotsTable,
+			Columns: []string{poll.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.BallotsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.BallotsTable,
+			Columns: []string{poll.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.PendingBallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.PendingBallotsTable,
+			Columns: []string{poll.PendingBallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedPendingBallotsIDs(); len(nodes) > 0 && !_u.mutation.PendingBallotsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.PendingBallotsTable,
+			Columns: []string{poll.PendingBallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PendingBallotsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.PendingBallotsTable,
+			Columns: []string{poll.PendingBallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pendingballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.InvitationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.InvitationsTable,
+			Columns: []string{poll.InvitationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvitation.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedInvitationsIDs(); len(nodes) > 0 && !_u.mutation.InvitationsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.InvitationsTable,
+			Columns: []string{poll.InvitationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvitation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.InvitationsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   poll.InvitationsTable,
+			Columns: []string{poll.InvitationsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollinvitation.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.ResultCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   poll.ResultTable,
+			Columns: []string{poll.ResultColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ResultIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: false,
+			Table:   poll.ResultTable,
+			Columns: []string{poll.ResultColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Poll{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
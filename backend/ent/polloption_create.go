@@ -0,0 +1,427 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll-app/ent/ballot"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+	"poll-app/ent/vote"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PollOptionCreate is the builder for creating a PollOption entity.
+type PollOptionCreate struct {
+	config
+	mutation *PollOptionMutation
+	hooks    []Hook
+}
+
+// SetPollID sets the "poll_id" field.
+func (_c *PollOptionCreate) SetPollID(v uuid.UUID) *PollOptionCreate {
+	_c.mutation.SetPollID(v)
+	return _c
+}
+
+// SetLabel sets the "label" field.
+func (_c *PollOptionCreate) SetLabel(v string) *PollOptionCreate {
+	_c.mutation.SetLabel(v)
+	return _c
+}
+
+// SetDescription sets the "description" field.
+func (_c *PollOptionCreate) SetDescription(v string) *PollOptionCreate {
+	_c.mutation.SetDescription(v)
+	return _c
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (_c *PollOptionCreate) SetNillableDescription(v *string) *PollOptionCreate {
+	if v != nil {
+		_c.SetDescription(*v)
+	}
+	return _c
+}
+
+// SetPosition sets the "position" field.
+func (_c *PollOptionCreate) SetPosition(v int) *PollOptionCreate {
+	_c.mutation.SetPosition(v)
+	return _c
+}
+
+// SetImageURL sets the "image_url" field.
+func (_c *PollOptionCreate) SetImageURL(v string) *PollOptionCreate {
+	_c.mutation.SetImageURL(v)
+	return _c
+}
+
+// SetNillableImageURL sets the "image_url" field if the given value is not nil.
+func (_c *PollOptionCreate) SetNillableImageURL(v *string) *PollOptionCreate {
+	if v != nil {
+		_c.SetImageURL(*v)
+	}
+	return _c
+}
+
+// SetVoteCount sets the "vote_count" field.
+func (_c *PollOptionCreate) SetVoteCount(v int) *PollOptionCreate {
+	_c.mutation.SetVoteCount(v)
+	return _c
+}
+
+// SetNillableVoteCount sets the "vote_count" field if the given value is not nil.
+func (_c *PollOptionCreate) SetNillableVoteCount(v *int) *PollOptionCreate {
+	if v != nil {
+		_c.SetVoteCount(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *PollOptionCreate) SetCreatedAt(v time.Time) *PollOptionCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *PollOptionCreate) SetNillableCreatedAt(v *time.Time) *PollOptionCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *PollOptionCreate) SetID(v uuid.UUID) *PollOptionCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *PollOptionCreate) SetNillableID(v *uuid.UUID) *PollOptionCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_c *PollOptionCreate) SetPoll(v *Poll) *PollOptionCreate {
+	return _c.SetPollID(v.ID)
+}
+
+// AddVoteIDs adds the "votes" edge to the Vote entity by IDs.
+func (_c *PollOptionCreate) AddVoteIDs(ids ...uuid.UUID) *PollOptionCreate {
+	_c.mutation.AddVoteIDs(ids...)
+	return _c
+}
+
+// AddVotes adds the "votes" edges to the Vote entity.
+func (_c *PollOptionCreate) AddVotes(v ...*Vote) *PollOptionCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddVoteIDs(ids...)
+}
+
+// AddBallotIDs adds the "ballots" edge to the Ballot entity by IDs.
+func (_c *PollOptionCreate) AddBallotIDs(ids ...uuid.UUID) *PollOptionCreate {
+	_c.mutation.AddBallotIDs(ids...)
+	return _c
+}
+
+// AddBallots adds the "ballots" edges to the Ballot entity.
+func (_c *PollOptionCreate) AddBallots(v ...*Ballot) *PollOptionCreate {
+	ids := make([]uuid.UUID, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddBallotIDs(ids...)
+}
+
+// Mutation returns the PollOptionMutation object of the builder.
+func (_c *PollOptionCreate) Mutation() *PollOptionMutation {
+	return _c.mutation
+}
+
+// Save creates the PollOption in the database.
+func (_c *PollOptionCreate) Save(ctx context.Context) (*PollOption, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *PollOptionCreate) SaveX(ctx context.Context) *PollOption {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollOptionCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollOptionCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *PollOptionCreate) defaults() {
+	if _, ok := _c.mutation.VoteCount(); !ok {
+		v := polloption.DefaultVoteCount
+		_c.mutation.SetVoteCount(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := polloption.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := polloption.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *PollOptionCreate) check() error {
+	if _, ok := _c.mutation.PollID(); !ok {
+		return &ValidationError{Name: "poll_id", err: errors.New(`ent: missing required field "PollOption.poll_id"`)}
+	}
+	if _, ok := _c.mutation.Label(); !ok {
+		return &ValidationError{Name: "label", err: errors.New(`ent: missing required field "PollOption.label"`)}
+	}
+	if v, ok := _c.mutation.Label(); ok {
+		if err := polloption.LabelValidator(v); err != nil {
+			return &ValidationError{Name: "label", err: fmt.Errorf(`ent: validator failed for field "PollOption.label": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Position(); !ok {
+		return &ValidationError{Name: "position", err: errors.New(`ent: missing required field "PollOption.position"`)}
+	}
+	if v, ok := _c.mutation.Position(); ok {
+		if err := polloption.PositionValidator(v); err != nil {
+			return &ValidationError{Name: "position", err: fmt.Errorf(`ent: validator failed for field "PollOption.position": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.VoteCount(); !ok {
+		return &ValidationError{Name: "vote_count", err: errors.New(`ent: missing required field "PollOption.vote_count"`)}
+	}
+	if v, ok := _c.mutation.VoteCount(); ok {
+		if err := polloption.VoteCountValidator(v); err != nil {
+			return &ValidationError{Name: "vote_count", err: fmt.Errorf(`ent: validator failed for field "PollOption.vote_count": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PollOption.created_at"`)}
+	}
+	if len(_c.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "PollOption.poll"`)}
+	}
+	return nil
+}
+
+func (_c *PollOptionCreate) sqlSave(ctx context.Context) (*PollOption, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *PollOptionCreate) createSpec() (*PollOption, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PollOption{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(polloption.Table, sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Label(); ok {
+		_spec.SetField(polloption.FieldLabel, field.TypeString, value)
+		_node.Label = value
+	}
+	if value, ok := _c.mutation.Description(); ok {
+		_spec.SetField(polloption.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if value, ok := _c.mutation.Position(); ok {
+		_spec.SetField(polloption.FieldPosition, field.TypeInt, value)
+		_node.Position = value
+	}
+	if value, ok := _c.mutation.ImageURL(); ok {
+		_spec.SetField(polloption.FieldImageURL, field.TypeString, value)
+		_node.ImageURL = value
+	}
+	if value, ok := _c.mutation.VoteCount(); ok {
+		_spec.SetField(polloption.FieldVoteCount, field.TypeInt, value)
+		_node.VoteCount = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(polloption.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   polloption.PollTable,
+			Columns: []string{polloption.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PollID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.VotesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   polloption.VotesTable,
+			Columns: []string{polloption.VotesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(vote.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.BallotsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: true,
+			Table:   polloption.BallotsTable,
+			Columns: []string{polloption.BallotsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(ballot.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PollOptionCreateBulk is the builder for creating many PollOption entities in bulk.
+type PollOptionCreateBulk struct {
+	config
+	err      error
+	builders []*PollOptionCreate
+}
+
+// Save creates the PollOption entities in the database.
+func (_c *PollOptionCreateBulk) Save(ctx context.Context) ([]*PollOption, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*PollOption, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PollOptionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *PollOptionCreateBulk) SaveX(ctx context.Context) []*PollOption {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollOptionCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollOptionCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,187 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"poll-app/ent/poll"
+	"poll-app/ent/pollresult"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// PollResult is the model entity for the PollResult schema.
+type PollResult struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID uuid.UUID `json:"id,omitempty"`
+	// PollID holds the value of the "poll_id" field.
+	PollID uuid.UUID `json:"poll_id,omitempty"`
+	// Counts holds the value of the "counts" field.
+	Counts map[string]int `json:"counts,omitempty"`
+	// Voters holds the value of the "voters" field.
+	Voters int `json:"voters,omitempty"`
+	// Ranked holds the value of the "ranked" field.
+	Ranked json.RawMessage `json:"ranked,omitempty"`
+	// FinalizedAt holds the value of the "finalized_at" field.
+	FinalizedAt time.Time `json:"finalized_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PollResultQuery when eager-loading is set.
+	Edges        PollResultEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// PollResultEdges holds the relations/edges for other nodes in the graph.
+type PollResultEdges struct {
+	// Poll holds the value of the poll edge.
+	Poll *Poll `json:"poll,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// PollOrErr returns the Poll value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PollResultEdges) PollOrErr() (*Poll, error) {
+	if e.Poll != nil {
+		return e.Poll, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: poll.Label}
+	}
+	return nil, &NotLoadedError{edge: "poll"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*PollResult) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case pollresult.FieldCounts, pollresult.FieldRanked:
+			values[i] = new([]byte)
+		case pollresult.FieldVoters:
+			values[i] = new(sql.NullInt64)
+		case pollresult.FieldFinalizedAt:
+			values[i] = new(sql.NullTime)
+		case pollresult.FieldID, pollresult.FieldPollID:
+			values[i] = new(uuid.UUID)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the PollResult fields.
+func (_m *PollResult) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case pollresult.FieldID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value != nil {
+				_m.ID = *value
+			}
+		case pollresult.FieldPollID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field poll_id", values[i])
+			} else if value != nil {
+				_m.PollID = *value
+			}
+		case pollresult.FieldCounts:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field counts", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Counts); err != nil {
+					return fmt.Errorf("unmarshal field counts: %w", err)
+				}
+			}
+		case pollresult.FieldVoters:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field voters", values[i])
+			} else if value.Valid {
+				_m.Voters = int(value.Int64)
+			}
+		case pollresult.FieldRanked:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field ranked", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Ranked); err != nil {
+					return fmt.Errorf("unmarshal field ranked: %w", err)
+				}
+			}
+		case pollresult.FieldFinalizedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field finalized_at", values[i])
+			} else if value.Valid {
+				_m.FinalizedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the PollResult.
+// This includes values selected through modifiers, order, etc.
+func (_m *PollResult) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryPoll queries the "poll" edge of the PollResult entity.
+func (_m *PollResult) QueryPoll() *PollQuery {
+	return NewPollResultClient(_m.config).QueryPoll(_m)
+}
+
+// Update returns a builder for updating this PollResult.
+// Note that you need to call PollResult.Unwrap() before calling this method if this PollResult
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *PollResult) Update() *PollResultUpdateOne {
+	return NewPollResultClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the PollResult entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *PollResult) Unwrap() *PollResult {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: PollResult is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *PollResult) String() string {
+	var builder strings.Builder
+	builder.WriteString("PollResult(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("poll_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.PollID))
+	builder.WriteString(", ")
+	builder.WriteString("counts=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Counts))
+	builder.WriteString(", ")
+	builder.WriteString("voters=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Voters))
+	builder.WriteString(", ")
+	builder.WriteString("ranked=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Ranked))
+	builder.WriteString(", ")
+	builder.WriteString("finalized_at=")
+	builder.WriteString(_m.FinalizedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// PollResults is a parsable slice of PollResult.
+type PollResults []*PollResult
@@ -0,0 +1,105 @@
+// Code generated by ent, DO NOT EDIT.
+
+package pollresult
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+const (
+	// Label holds the string label denoting the pollresult type in the database.
+	Label = "poll_result"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldPollID holds the string denoting the poll_id field in the database.
+	FieldPollID = "poll_id"
+	// FieldCounts holds the string denoting the counts field in the database.
+	FieldCounts = "counts"
+	// FieldVoters holds the string denoting the voters field in the database.
+	FieldVoters = "voters"
+	// FieldRanked holds the string denoting the ranked field in the database.
+	FieldRanked = "ranked"
+	// FieldFinalizedAt holds the string denoting the finalized_at field in the database.
+	FieldFinalizedAt = "finalized_at"
+	// EdgePoll holds the string denoting the poll edge name in mutations.
+	EdgePoll = "poll"
+	// Table holds the table name of the pollresult in the database.
+	Table = "poll_results"
+	// PollTable is the table that holds the poll relation/edge.
+	PollTable = "poll_results"
+	// PollInverseTable is the table name for the Poll entity.
+	// It exists in this package in order to avoid circular dependency with the "poll" package.
+	PollInverseTable = "polls"
+	// PollColumn is the table column denoting the poll relation/edge.
+	PollColumn = "poll_id"
+)
+
+// Columns holds all SQL columns for pollresult fields.
+var Columns = []string{
+	FieldID,
+	FieldPollID,
+	FieldCounts,
+	FieldVoters,
+	FieldRanked,
+	FieldFinalizedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// VotersValidator is a validator for the "voters" field. It is called by the builders before save.
+	VotersValidator func(int) error
+	// DefaultFinalizedAt holds the default value on creation for the "finalized_at" field.
+	DefaultFinalizedAt func() time.Time
+	// DefaultID holds the default value on creation for the "id" field.
+	DefaultID func() uuid.UUID
+)
+
+// OrderOption defines the ordering options for the PollResult queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByPollID orders the results by the poll_id field.
+func ByPollID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPollID, opts...).ToFunc()
+}
+
+// ByVoters orders the results by the voters field.
+func ByVoters(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVoters, opts...).ToFunc()
+}
+
+// ByFinalizedAt orders the results by the finalized_at field.
+func ByFinalizedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFinalizedAt, opts...).ToFunc()
+}
+
+// ByPollField orders the results by poll field.
+func ByPollField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newPollStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newPollStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(PollInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2O, true, PollTable, PollColumn),
+	)
+}
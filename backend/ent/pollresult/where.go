@@ -0,0 +1,220 @@
+// Code generated by ent, DO NOT EDIT.
+
+package pollresult
+
+import (
+	"poll-app/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/google/uuid"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldLTE(FieldID, id))
+}
+
+// PollID applies equality check predicate on the "poll_id" field. It's identical to PollIDEQ.
+func PollID(v uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldPollID, v))
+}
+
+// Voters applies equality check predicate on the "voters" field. It's identical to VotersEQ.
+func Voters(v int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldVoters, v))
+}
+
+// FinalizedAt applies equality check predicate on the "finalized_at" field. It's identical to FinalizedAtEQ.
+func FinalizedAt(v time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldFinalizedAt, v))
+}
+
+// PollIDEQ applies the EQ predicate on the "poll_id" field.
+func PollIDEQ(v uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldPollID, v))
+}
+
+// PollIDNEQ applies the NEQ predicate on the "poll_id" field.
+func PollIDNEQ(v uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNEQ(FieldPollID, v))
+}
+
+// PollIDIn applies the In predicate on the "poll_id" field.
+func PollIDIn(vs ...uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldIn(FieldPollID, vs...))
+}
+
+// PollIDNotIn applies the NotIn predicate on the "poll_id" field.
+func PollIDNotIn(vs ...uuid.UUID) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNotIn(FieldPollID, vs...))
+}
+
+// VotersEQ applies the EQ predicate on the "voters" field.
+func VotersEQ(v int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldVoters, v))
+}
+
+// VotersNEQ applies the NEQ predicate on the "voters" field.
+func VotersNEQ(v int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNEQ(FieldVoters, v))
+}
+
+// VotersIn applies the In predicate on the "voters" field.
+func VotersIn(vs ...int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldIn(FieldVoters, vs...))
+}
+
+// VotersNotIn applies the NotIn predicate on the "voters" field.
+func VotersNotIn(vs ...int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNotIn(FieldVoters, vs...))
+}
+
+// VotersGT applies the GT predicate on the "voters" field.
+func VotersGT(v int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldGT(FieldVoters, v))
+}
+
+// VotersGTE applies the GTE predicate on the "voters" field.
+func VotersGTE(v int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldGTE(FieldVoters, v))
+}
+
+// VotersLT applies the LT predicate on the "voters" field.
+func VotersLT(v int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldLT(FieldVoters, v))
+}
+
+// VotersLTE applies the LTE predicate on the "voters" field.
+func VotersLTE(v int) predicate.PollResult {
+	return predicate.PollResult(sql.FieldLTE(FieldVoters, v))
+}
+
+// RankedIsNil applies the IsNil predicate on the "ranked" field.
+func RankedIsNil() predicate.PollResult {
+	return predicate.PollResult(sql.FieldIsNull(FieldRanked))
+}
+
+// RankedNotNil applies the NotNil predicate on the "ranked" field.
+func RankedNotNil() predicate.PollResult {
+	return predicate.PollResult(sql.FieldNotNull(FieldRanked))
+}
+
+// FinalizedAtEQ applies the EQ predicate on the "finalized_at" field.
+func FinalizedAtEQ(v time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldEQ(FieldFinalizedAt, v))
+}
+
+// FinalizedAtNEQ applies the NEQ predicate on the "finalized_at" field.
+func FinalizedAtNEQ(v time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNEQ(FieldFinalizedAt, v))
+}
+
+// FinalizedAtIn applies the In predicate on the "finalized_at" field.
+func FinalizedAtIn(vs ...time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldIn(FieldFinalizedAt, vs...))
+}
+
+// FinalizedAtNotIn applies the NotIn predicate on the "finalized_at" field.
+func FinalizedAtNotIn(vs ...time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldNotIn(FieldFinalizedAt, vs...))
+}
+
+// FinalizedAtGT applies the GT predicate on the "finalized_at" field.
+func FinalizedAtGT(v time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldGT(FieldFinalizedAt, v))
+}
+
+// FinalizedAtGTE applies the GTE predicate on the "finalized_at" field.
+func FinalizedAtGTE(v time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldGTE(FieldFinalizedAt, v))
+}
+
+// FinalizedAtLT applies the LT predicate on the "finalized_at" field.
+func FinalizedAtLT(v time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldLT(FieldFinalizedAt, v))
+}
+
+// FinalizedAtLTE applies the LTE predicate on the "finalized_at" field.
+func FinalizedAtLTE(v time.Time) predicate.PollResult {
+	return predicate.PollResult(sql.FieldLTE(FieldFinalizedAt, v))
+}
+
+// HasPoll applies the HasEdge predicate on the "poll" edge.
+func HasPoll() predicate.PollResult {
+	return predicate.PollResult(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2O, true, PollTable, PollColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasPollWith applies the HasEdge predicate on the "poll" edge with a given conditions (other predicates).
+func HasPollWith(preds ...predicate.Poll) predicate.PollResult {
+	return predicate.PollResult(func(s *sql.Selector) {
+		step := newPollStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.PollResult) predicate.PollResult {
+	return predicate.PollResult(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.PollResult) predicate.PollResult {
+	return predicate.PollResult(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.PollResult) predicate.PollResult {
+	return predicate.PollResult(sql.NotPredicates(p))
+}
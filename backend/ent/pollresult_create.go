@@ -0,0 +1,303 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"poll-app/ent/poll"
+	"poll-app/ent/pollresult"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PollResultCreate is the builder for creating a PollResult entity.
+type PollResultCreate struct {
+	config
+	mutation *PollResultMutation
+	hooks    []Hook
+}
+
+// SetPollID sets the "poll_id" field.
+func (_c *PollResultCreate) SetPollID(v uuid.UUID) *PollResultCreate {
+	_c.mutation.SetPollID(v)
+	return _c
+}
+
+// SetCounts sets the "counts" field.
+func (_c *PollResultCreate) SetCounts(v map[string]int) *PollResultCreate {
+	_c.mutation.SetCounts(v)
+	return _c
+}
+
+// SetVoters sets the "voters" field.
+func (_c *PollResultCreate) SetVoters(v int) *PollResultCreate {
+	_c.mutation.SetVoters(v)
+	return _c
+}
+
+// SetRanked sets the "ranked" field.
+func (_c *PollResultCreate) SetRanked(v json.RawMessage) *PollResultCreate {
+	_c.mutation.SetRanked(v)
+	return _c
+}
+
+// SetFinalizedAt sets the "finalized_at" field.
+func (_c *PollResultCreate) SetFinalizedAt(v time.Time) *PollResultCreate {
+	_c.mutation.SetFinalizedAt(v)
+	return _c
+}
+
+// SetNillableFinalizedAt sets the "finalized_at" field if the given value is not nil.
+func (_c *PollResultCreate) SetNillableFinalizedAt(v *time.Time) *PollResultCreate {
+	if v != nil {
+		_c.SetFinalizedAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *PollResultCreate) SetID(v uuid.UUID) *PollResultCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (_c *PollResultCreate) SetNillableID(v *uuid.UUID) *PollResultCreate {
+	if v != nil {
+		_c.SetID(*v)
+	}
+	return _c
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_c *PollResultCreate) SetPoll(v *Poll) *PollResultCreate {
+	return _c.SetPollID(v.ID)
+}
+
+// Mutation returns the PollResultMutation object of the builder.
+func (_c *PollResultCreate) Mutation() *PollResultMutation {
+	return _c.mutation
+}
+
+// Save creates the PollResult in the database.
+func (_c *PollResultCreate) Save(ctx context.Context) (*PollResult, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *PollResultCreate) SaveX(ctx context.Context) *PollResult {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollResultCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollResultCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *PollResultCreate) defaults() {
+	if _, ok := _c.mutation.FinalizedAt(); !ok {
+		v := pollresult.DefaultFinalizedAt()
+		_c.mutation.SetFinalizedAt(v)
+	}
+	if _, ok := _c.mutation.ID(); !ok {
+		v := pollresult.DefaultID()
+		_c.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *PollResultCreate) check() error {
+	if _, ok := _c.mutation.PollID(); !ok {
+		return &ValidationError{Name: "poll_id", err: errors.New(`ent: missing required field "PollResult.poll_id"`)}
+	}
+	if _, ok := _c.mutation.Counts(); !ok {
+		return &ValidationError{Name: "counts", err: errors.New(`ent: missing required field "PollResult.counts"`)}
+	}
+	if _, ok := _c.mutation.Voters(); !ok {
+		return &ValidationError{Name: "voters", err: errors.New(`ent: missing required field "PollResult.voters"`)}
+	}
+	if v, ok := _c.mutation.Voters(); ok {
+		if err := pollresult.VotersValidator(v); err != nil {
+			return &ValidationError{Name: "voters", err: fmt.Errorf(`ent: validator failed for field "PollResult.voters": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.FinalizedAt(); !ok {
+		return &ValidationError{Name: "finalized_at", err: errors.New(`ent: missing required field "PollResult.finalized_at"`)}
+	}
+	if len(_c.mutation.PollIDs()) == 0 {
+		return &ValidationError{Name: "poll", err: errors.New(`ent: missing required edge "PollResult.poll"`)}
+	}
+	return nil
+}
+
+func (_c *PollResultCreate) sqlSave(ctx context.Context) (*PollResult, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*uuid.UUID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *PollResultCreate) createSpec() (*PollResult, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PollResult{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(pollresult.Table, sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := _c.mutation.Counts(); ok {
+		_spec.SetField(pollresult.FieldCounts, field.TypeJSON, value)
+		_node.Counts = value
+	}
+	if value, ok := _c.mutation.Voters(); ok {
+		_spec.SetField(pollresult.FieldVoters, field.TypeInt, value)
+		_node.Voters = value
+	}
+	if value, ok := _c.mutation.Ranked(); ok {
+		_spec.SetField(pollresult.FieldRanked, field.TypeJSON, value)
+		_node.Ranked = value
+	}
+	if value, ok := _c.mutation.FinalizedAt(); ok {
+		_spec.SetField(pollresult.FieldFinalizedAt, field.TypeTime, value)
+		_node.FinalizedAt = value
+	}
+	if nodes := _c.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   pollresult.PollTable,
+			Columns: []string{pollresult.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.PollID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PollResultCreateBulk is the builder for creating many PollResult entities in bulk.
+type PollResultCreateBulk struct {
+	config
+	err      error
+	builders []*PollResultCreate
+}
+
+// Save creates the PollResult entities in the database.
+func (_c *PollResultCreateBulk) Save(ctx context.Context) ([]*PollResult, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*PollResult, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PollResultMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *PollResultCreateBulk) SaveX(ctx context.Context) []*PollResult {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *PollResultCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *PollResultCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,449 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"poll-app/ent/poll"
+	"poll-app/ent/pollresult"
+	"poll-app/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PollResultUpdate is the builder for updating PollResult entities.
+type PollResultUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PollResultMutation
+}
+
+// Where appends a list predicates to the PollResultUpdate builder.
+func (_u *PollResultUpdate) Where(ps ...predicate.PollResult) *PollResultUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *PollResultUpdate) SetPollID(v uuid.UUID) *PollResultUpdate {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *PollResultUpdate) SetNillablePollID(v *uuid.UUID) *PollResultUpdate {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetCounts sets the "counts" field.
+func (_u *PollResultUpdate) SetCounts(v map[string]int) *PollResultUpdate {
+	_u.mutation.SetCounts(v)
+	return _u
+}
+
+// SetVoters sets the "voters" field.
+func (_u *PollResultUpdate) SetVoters(v int) *PollResultUpdate {
+	_u.mutation.ResetVoters()
+	_u.mutation.SetVoters(v)
+	return _u
+}
+
+// SetNillableVoters sets the "voters" field if the given value is not nil.
+func (_u *PollResultUpdate) SetNillableVoters(v *int) *PollResultUpdate {
+	if v != nil {
+		_u.SetVoters(*v)
+	}
+	return _u
+}
+
+// AddVoters adds value to the "voters" field.
+func (_u *PollResultUpdate) AddVoters(v int) *PollResultUpdate {
+	_u.mutation.AddVoters(v)
+	return _u
+}
+
+// SetRanked sets the "ranked" field.
+func (_u *PollResultUpdate) SetRanked(v json.RawMessage) *PollResultUpdate {
+	_u.mutation.SetRanked(v)
+	return _u
+}
+
+// AppendRanked appends value to the "ranked" field.
+func (_u *PollResultUpdate) AppendRanked(v json.RawMessage) *PollResultUpdate {
+	_u.mutation.AppendRanked(v)
+	return _u
+}
+
+// ClearRanked clears the value of the "ranked" field.
+func (_u *PollResultUpdate) ClearRanked() *PollResultUpdate {
+	_u.mutation.ClearRanked()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PollResultUpdate) SetPoll(v *Poll) *PollResultUpdate {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the PollResultMutation object of the builder.
+func (_u *PollResultUpdate) Mutation() *PollResultMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PollResultUpdate) ClearPoll() *PollResultUpdate {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *PollResultUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PollResultUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *PollResultUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PollResultUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollResultUpdate) check() error {
+	if v, ok := _u.mutation.Voters(); ok {
+		if err := pollresult.VotersValidator(v); err != nil {
+			return &ValidationError{Name: "voters", err: fmt.Errorf(`ent: validator failed for field "PollResult.voters": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PollResult.poll"`)
+	}
+	return nil
+}
+
+func (_u *PollResultUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pollresult.Table, pollresult.Columns, sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Counts(); ok {
+		_spec.SetField(pollresult.FieldCounts, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.Voters(); ok {
+		_spec.SetField(pollresult.FieldVoters, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVoters(); ok {
+		_spec.AddField(pollresult.FieldVoters, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Ranked(); ok {
+		_spec.SetField(pollresult.FieldRanked, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRanked(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, pollresult.FieldRanked, value)
+		})
+	}
+	if _u.mutation.RankedCleared() {
+		_spec.ClearField(pollresult.FieldRanked, field.TypeJSON)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   pollresult.PollTable,
+			Columns: []string{pollresult.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   pollresult.PollTable,
+			Columns: []string{pollresult.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pollresult.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// PollResultUpdateOne is the builder for updating a single PollResult entity.
+type PollResultUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PollResultMutation
+}
+
+// SetPollID sets the "poll_id" field.
+func (_u *PollResultUpdateOne) SetPollID(v uuid.UUID) *PollResultUpdateOne {
+	_u.mutation.SetPollID(v)
+	return _u
+}
+
+// SetNillablePollID sets the "poll_id" field if the given value is not nil.
+func (_u *PollResultUpdateOne) SetNillablePollID(v *uuid.UUID) *PollResultUpdateOne {
+	if v != nil {
+		_u.SetPollID(*v)
+	}
+	return _u
+}
+
+// SetCounts sets the "counts" field.
+func (_u *PollResultUpdateOne) SetCounts(v map[string]int) *PollResultUpdateOne {
+	_u.mutation.SetCounts(v)
+	return _u
+}
+
+// SetVoters sets the "voters" field.
+func (_u *PollResultUpdateOne) SetVoters(v int) *PollResultUpdateOne {
+	_u.mutation.ResetVoters()
+	_u.mutation.SetVoters(v)
+	return _u
+}
+
+// SetNillableVoters sets the "voters" field if the given value is not nil.
+func (_u *PollResultUpdateOne) SetNillableVoters(v *int) *PollResultUpdateOne {
+	if v != nil {
+		_u.SetVoters(*v)
+	}
+	return _u
+}
+
+// AddVoters adds value to the "voters" field.
+func (_u *PollResultUpdateOne) AddVoters(v int) *PollResultUpdateOne {
+	_u.mutation.AddVoters(v)
+	return _u
+}
+
+// SetRanked sets the "ranked" field.
+func (_u *PollResultUpdateOne) SetRanked(v json.RawMessage) *PollResultUpdateOne {
+	_u.mutation.SetRanked(v)
+	return _u
+}
+
+// AppendRanked appends value to the "ranked" field.
+func (_u *PollResultUpdateOne) AppendRanked(v json.RawMessage) *PollResultUpdateOne {
+	_u.mutation.AppendRanked(v)
+	return _u
+}
+
+// ClearRanked clears the value of the "ranked" field.
+func (_u *PollResultUpdateOne) ClearRanked() *PollResultUpdateOne {
+	_u.mutation.ClearRanked()
+	return _u
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (_u *PollResultUpdateOne) SetPoll(v *Poll) *PollResultUpdateOne {
+	return _u.SetPollID(v.ID)
+}
+
+// Mutation returns the PollResultMutation object of the builder.
+func (_u *PollResultUpdateOne) Mutation() *PollResultMutation {
+	return _u.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (_u *PollResultUpdateOne) ClearPoll() *PollResultUpdateOne {
+	_u.mutation.ClearPoll()
+	return _u
+}
+
+// Where appends a list predicates to the PollResultUpdate builder.
+func (_u *PollResultUpdateOne) Where(ps ...predicate.PollResult) *PollResultUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *PollResultUpdateOne) Select(field string, fields ...string) *PollResultUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated PollResult entity.
+func (_u *PollResultUpdateOne) Save(ctx context.Context) (*PollResult, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *PollResultUpdateOne) SaveX(ctx context.Context) *PollResult {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *PollResultUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *PollResultUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *PollResultUpdateOne) check() error {
+	if v, ok := _u.mutation.Voters(); ok {
+		if err := pollresult.VotersValidator(v); err != nil {
+			return &ValidationError{Name: "voters", err: fmt.Errorf(`ent: validator failed for field "PollResult.voters": %w`, err)}
+		}
+	}
+	if _u.mutation.PollCleared() && len(_u.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "PollResult.poll"`)
+	}
+	return nil
+}
+
+func (_u *PollResultUpdateOne) sqlSave(ctx context.Context) (_node *PollResult, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(pollresult.Table, pollresult.Columns, sqlgraph.NewFieldSpec(pollresult.FieldID, field.TypeUUID))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PollResult.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, pollresult.FieldID)
+		for _, f := range fields {
+			if !pollresult.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != pollresult.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.Counts(); ok {
+		_spec.SetField(pollresult.FieldCounts, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.Voters(); ok {
+		_spec.SetField(pollresult.FieldVoters, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedVoters(); ok {
+		_spec.AddField(pollresult.FieldVoters, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.Ranked(); ok {
+		_spec.SetField(pollresult.FieldRanked, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedRanked(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, pollresult.FieldRanked, value)
+		})
+	}
+	if _u.mutation.RankedCleared() {
+		_spec.ClearField(pollresult.FieldRanked, field.TypeJSON)
+	}
+	if _u.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   pollresult.PollTable,
+			Columns: []string{pollresult.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2O,
+			Inverse: true,
+			Table:   pollresult.PollTable,
+			Columns: []string{pollresult.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &PollResult{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{pollresult.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
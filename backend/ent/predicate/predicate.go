@@ -6,11 +6,50 @@ import (
 	"entgo.io/ent/dialect/sql"
 )
 
+// Ballot is the predicate function for ballot builders.
+type Ballot func(*sql.Selector)
+
+// JobRun is the predicate function for jobrun builders.
+type JobRun func(*sql.Selector)
+
+// Participation is the predicate function for participation builders.
+type Participation func(*sql.Selector)
+
+// PendingBallot is the predicate function for pendingballot builders.
+type PendingBallot func(*sql.Selector)
+
 // Poll is the predicate function for poll builders.
 type Poll func(*sql.Selector)
 
+// PollInvitation is the predicate function for pollinvitation builders.
+type PollInvitation func(*sql.Selector)
+
+// PollOption is the predicate function for polloption builders.
+type PollOption func(*sql.Selector)
+
+// PollResult is the predicate function for pollresult builders.
+type PollResult func(*sql.Selector)
+
+// RecoveryCode is the predicate function for recoverycode builders.
+type RecoveryCode func(*sql.Selector)
+
+// SecurityEvent is the predicate function for securityevent builders.
+type SecurityEvent func(*sql.Selector)
+
+// SigningKey is the predicate function for signingkey builders.
+type SigningKey func(*sql.Selector)
+
 // User is the predicate function for user builders.
 type User func(*sql.Selector)
 
+// UserToken is the predicate function for usertoken builders.
+type UserToken func(*sql.Selector)
+
 // Vote is the predicate function for vote builders.
 type Vote func(*sql.Selector)
+
+// WebhookDelivery is the predicate function for webhookdelivery builders.
+type WebhookDelivery func(*sql.Selector)
+
+// WebhookEndpoint is the predicate function for webhookendpoint builders.
+type WebhookEndpoint func(*sql.Selector)
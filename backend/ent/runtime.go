@@ -3,10 +3,23 @@
 package ent
 
 import (
+	"poll-app/ent/ballot"
+	"poll-app/ent/jobrun"
+	"poll-app/ent/participation"
+	"poll-app/ent/pendingballot"
 	"poll-app/ent/poll"
+	"poll-app/ent/pollinvitation"
+	"poll-app/ent/polloption"
+	"poll-app/ent/pollresult"
+	"poll-app/ent/recoverycode"
 	"poll-app/ent/schema"
+	"poll-app/ent/securityevent"
+	"poll-app/ent/signingkey"
 	"poll-app/ent/user"
+	"poll-app/ent/usertoken"
 	"poll-app/ent/vote"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/ent/webhookendpoint"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,22 +29,92 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	ballotFields := schema.Ballot{}.Fields()
+	_ = ballotFields
+	// ballotDescID is the schema descriptor for id field.
+	ballotDescID := ballotFields[0].Descriptor()
+	// ballot.DefaultID holds the default value on creation for the id field.
+	ballot.DefaultID = ballotDescID.Default.(func() uuid.UUID)
+	jobrunFields := schema.JobRun{}.Fields()
+	_ = jobrunFields
+	// jobrunDescJob is the schema descriptor for job field.
+	jobrunDescJob := jobrunFields[1].Descriptor()
+	// jobrun.JobValidator is a validator for the "job" field. It is called by the builders before save.
+	jobrun.JobValidator = jobrunDescJob.Validators[0].(func(string) error)
+	// jobrunDescInstance is the schema descriptor for instance field.
+	jobrunDescInstance := jobrunFields[2].Descriptor()
+	// jobrun.InstanceValidator is a validator for the "instance" field. It is called by the builders before save.
+	jobrun.InstanceValidator = jobrunDescInstance.Validators[0].(func(string) error)
+	// jobrunDescAffected is the schema descriptor for affected field.
+	jobrunDescAffected := jobrunFields[4].Descriptor()
+	// jobrun.DefaultAffected holds the default value on creation for the affected field.
+	jobrun.DefaultAffected = jobrunDescAffected.Default.(int)
+	// jobrunDescStartedAt is the schema descriptor for started_at field.
+	jobrunDescStartedAt := jobrunFields[6].Descriptor()
+	// jobrun.DefaultStartedAt holds the default value on creation for the started_at field.
+	jobrun.DefaultStartedAt = jobrunDescStartedAt.Default.(func() time.Time)
+	// jobrunDescID is the schema descriptor for id field.
+	jobrunDescID := jobrunFields[0].Descriptor()
+	// jobrun.DefaultID holds the default value on creation for the id field.
+	jobrun.DefaultID = jobrunDescID.Default.(func() uuid.UUID)
+	participationFields := schema.Participation{}.Fields()
+	_ = participationFields
+	// participationDescID is the schema descriptor for id field.
+	participationDescID := participationFields[0].Descriptor()
+	// participation.DefaultID holds the default value on creation for the id field.
+	participation.DefaultID = participationDescID.Default.(func() uuid.UUID)
+	pendingballotFields := schema.PendingBallot{}.Fields()
+	_ = pendingballotFields
+	// pendingballotDescID is the schema descriptor for id field.
+	pendingballotDescID := pendingballotFields[0].Descriptor()
+	// pendingballot.DefaultID holds the default value on creation for the id field.
+	pendingballot.DefaultID = pendingballotDescID.Default.(func() uuid.UUID)
 	pollFields := schema.Poll{}.Fields()
 	_ = pollFields
 	// pollDescTitle is the schema descriptor for title field.
 	pollDescTitle := pollFields[1].Descriptor()
 	// poll.TitleValidator is a validator for the "title" field. It is called by the builders before save.
 	poll.TitleValidator = pollDescTitle.Validators[0].(func(string) error)
-	// pollDescOptions is the schema descriptor for options field.
-	pollDescOptions := pollFields[3].Descriptor()
-	// poll.DefaultOptions holds the default value on creation for the options field.
-	poll.DefaultOptions = pollDescOptions.Default.([]string)
+	// pollDescMinChoices is the schema descriptor for min_choices field.
+	pollDescMinChoices := pollFields[5].Descriptor()
+	// poll.DefaultMinChoices holds the default value on creation for the min_choices field.
+	poll.DefaultMinChoices = pollDescMinChoices.Default.(int)
+	// poll.MinChoicesValidator is a validator for the "min_choices" field. It is called by the builders before save.
+	poll.MinChoicesValidator = pollDescMinChoices.Validators[0].(func(int) error)
+	// pollDescMaxChoices is the schema descriptor for max_choices field.
+	pollDescMaxChoices := pollFields[6].Descriptor()
+	// poll.MaxChoicesValidator is a validator for the "max_choices" field. It is called by the builders before save.
+	poll.MaxChoicesValidator = pollDescMaxChoices.Validators[0].(func(int) error)
+	// pollDescAnonymous is the schema descriptor for anonymous field.
+	pollDescAnonymous := pollFields[7].Descriptor()
+	// poll.DefaultAnonymous holds the default value on creation for the anonymous field.
+	poll.DefaultAnonymous = pollDescAnonymous.Default.(bool)
+	// pollDescRequireVerifiedEmail is the schema descriptor for require_verified_email field.
+	pollDescRequireVerifiedEmail := pollFields[9].Descriptor()
+	// poll.DefaultRequireVerifiedEmail holds the default value on creation for the require_verified_email field.
+	poll.DefaultRequireVerifiedEmail = pollDescRequireVerifiedEmail.Default.(bool)
+	// pollDescExternalID is the schema descriptor for external_id field.
+	pollDescExternalID := pollFields[11].Descriptor()
+	// poll.ExternalIDValidator is a validator for the "external_id" field. It is called by the builders before save.
+	poll.ExternalIDValidator = pollDescExternalID.Validators[0].(func(string) error)
+	// pollDescVersion is the schema descriptor for version field.
+	pollDescVersion := pollFields[14].Descriptor()
+	// poll.DefaultVersion holds the default value on creation for the version field.
+	poll.DefaultVersion = pollDescVersion.Default.(int)
+	// poll.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	poll.VersionValidator = pollDescVersion.Validators[0].(func(int) error)
+	// pollDescVoterCount is the schema descriptor for voter_count field.
+	pollDescVoterCount := pollFields[16].Descriptor()
+	// poll.DefaultVoterCount holds the default value on creation for the voter_count field.
+	poll.DefaultVoterCount = pollDescVoterCount.Default.(int)
+	// poll.VoterCountValidator is a validator for the "voter_count" field. It is called by the builders before save.
+	poll.VoterCountValidator = pollDescVoterCount.Validators[0].(func(int) error)
 	// pollDescCreatedAt is the schema descriptor for created_at field.
-	pollDescCreatedAt := pollFields[5].Descriptor()
+	pollDescCreatedAt := pollFields[17].Descriptor()
 	// poll.DefaultCreatedAt holds the default value on creation for the created_at field.
 	poll.DefaultCreatedAt = pollDescCreatedAt.Default.(func() time.Time)
 	// pollDescUpdatedAt is the schema descriptor for updated_at field.
-	pollDescUpdatedAt := pollFields[6].Descriptor()
+	pollDescUpdatedAt := pollFields[18].Descriptor()
 	// poll.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	poll.DefaultUpdatedAt = pollDescUpdatedAt.Default.(func() time.Time)
 	// poll.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -40,6 +123,96 @@ func init() {
 	pollDescID := pollFields[0].Descriptor()
 	// poll.DefaultID holds the default value on creation for the id field.
 	poll.DefaultID = pollDescID.Default.(func() uuid.UUID)
+	pollinvitationFields := schema.PollInvitation{}.Fields()
+	_ = pollinvitationFields
+	// pollinvitationDescEmail is the schema descriptor for email field.
+	pollinvitationDescEmail := pollinvitationFields[2].Descriptor()
+	// pollinvitation.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	pollinvitation.EmailValidator = pollinvitationDescEmail.Validators[0].(func(string) error)
+	// pollinvitationDescCreatedAt is the schema descriptor for created_at field.
+	pollinvitationDescCreatedAt := pollinvitationFields[6].Descriptor()
+	// pollinvitation.DefaultCreatedAt holds the default value on creation for the created_at field.
+	pollinvitation.DefaultCreatedAt = pollinvitationDescCreatedAt.Default.(func() time.Time)
+	// pollinvitationDescID is the schema descriptor for id field.
+	pollinvitationDescID := pollinvitationFields[0].Descriptor()
+	// pollinvitation.DefaultID holds the default value on creation for the id field.
+	pollinvitation.DefaultID = pollinvitationDescID.Default.(func() uuid.UUID)
+	polloptionFields := schema.PollOption{}.Fields()
+	_ = polloptionFields
+	// polloptionDescLabel is the schema descriptor for label field.
+	polloptionDescLabel := polloptionFields[2].Descriptor()
+	// polloption.LabelValidator is a validator for the "label" field. It is called by the builders before save.
+	polloption.LabelValidator = polloptionDescLabel.Validators[0].(func(string) error)
+	// polloptionDescPosition is the schema descriptor for position field.
+	polloptionDescPosition := polloptionFields[4].Descriptor()
+	// polloption.PositionValidator is a validator for the "position" field. It is called by the builders before save.
+	polloption.PositionValidator = polloptionDescPosition.Validators[0].(func(int) error)
+	// polloptionDescVoteCount is the schema descriptor for vote_count field.
+	polloptionDescVoteCount := polloptionFields[6].Descriptor()
+	// polloption.DefaultVoteCount holds the default value on creation for the vote_count field.
+	polloption.DefaultVoteCount = polloptionDescVoteCount.Default.(int)
+	// polloption.VoteCountValidator is a validator for the "vote_count" field. It is called by the builders before save.
+	polloption.VoteCountValidator = polloptionDescVoteCount.Validators[0].(func(int) error)
+	// polloptionDescCreatedAt is the schema descriptor for created_at field.
+	polloptionDescCreatedAt := polloptionFields[7].Descriptor()
+	// polloption.DefaultCreatedAt holds the default value on creation for the created_at field.
+	polloption.DefaultCreatedAt = polloptionDescCreatedAt.Default.(func() time.Time)
+	// polloptionDescID is the schema descriptor for id field.
+	polloptionDescID := polloptionFields[0].Descriptor()
+	// polloption.DefaultID holds the default value on creation for the id field.
+	polloption.DefaultID = polloptionDescID.Default.(func() uuid.UUID)
+	pollresultFields := schema.PollResult{}.Fields()
+	_ = pollresultFields
+	// pollresultDescVoters is the schema descriptor for voters field.
+	pollresultDescVoters := pollresultFields[3].Descriptor()
+	// pollresult.VotersValidator is a validator for the "voters" field. It is called by the builders before save.
+	pollresult.VotersValidator = pollresultDescVoters.Validators[0].(func(int) error)
+	// pollresultDescFinalizedAt is the schema descriptor for finalized_at field.
+	pollresultDescFinalizedAt := pollresultFields[5].Descriptor()
+	// pollresult.DefaultFinalizedAt holds the default value on creation for the finalized_at field.
+	pollresult.DefaultFinalizedAt = pollresultDescFinalizedAt.Default.(func() time.Time)
+	// pollresultDescID is the schema descriptor for id field.
+	pollresultDescID := pollresultFields[0].Descriptor()
+	// pollresult.DefaultID holds the default value on creation for the id field.
+	pollresult.DefaultID = pollresultDescID.Default.(func() uuid.UUID)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescCodeHash is the schema descriptor for code_hash field.
+	recoverycodeDescCodeHash := recoverycodeFields[2].Descriptor()
+	// recoverycode.CodeHashValidator is a validator for the "code_hash" field. It is called by the builders before save.
+	recoverycode.CodeHashValidator = recoverycodeDescCodeHash.Validators[0].(func(string) error)
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[4].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
+	// recoverycodeDescID is the schema descriptor for id field.
+	recoverycodeDescID := recoverycodeFields[0].Descriptor()
+	// recoverycode.DefaultID holds the default value on creation for the id field.
+	recoverycode.DefaultID = recoverycodeDescID.Default.(func() uuid.UUID)
+	securityeventFields := schema.SecurityEvent{}.Fields()
+	_ = securityeventFields
+	// securityeventDescCreatedAt is the schema descriptor for created_at field.
+	securityeventDescCreatedAt := securityeventFields[6].Descriptor()
+	// securityevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	securityevent.DefaultCreatedAt = securityeventDescCreatedAt.Default.(func() time.Time)
+	// securityeventDescID is the schema descriptor for id field.
+	securityeventDescID := securityeventFields[0].Descriptor()
+	// securityevent.DefaultID holds the default value on creation for the id field.
+	securityevent.DefaultID = securityeventDescID.Default.(func() uuid.UUID)
+	signingkeyFields := schema.SigningKey{}.Fields()
+	_ = signingkeyFields
+	// signingkeyDescPrivateKey is the schema descriptor for private_key field.
+	signingkeyDescPrivateKey := signingkeyFields[2].Descriptor()
+	// signingkey.PrivateKeyValidator is a validator for the "private_key" field. It is called by the builders before save.
+	signingkey.PrivateKeyValidator = signingkeyDescPrivateKey.Validators[0].(func([]byte) error)
+	// signingkeyDescCreatedAt is the schema descriptor for created_at field.
+	signingkeyDescCreatedAt := signingkeyFields[3].Descriptor()
+	// signingkey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	signingkey.DefaultCreatedAt = signingkeyDescCreatedAt.Default.(func() time.Time)
+	// signingkeyDescID is the schema descriptor for id field.
+	signingkeyDescID := signingkeyFields[0].Descriptor()
+	// signingkey.IDValidator is a validator for the "id" field. It is called by the builders before save.
+	signingkey.IDValidator = signingkeyDescID.Validators[0].(func(string) error)
 	userFields := schema.User{}.Fields()
 	_ = userFields
 	// userDescEmail is the schema descriptor for email field.
@@ -55,11 +228,11 @@ func init() {
 	// user.PasswordValidator is a validator for the "password" field. It is called by the builders before save.
 	user.PasswordValidator = userDescPassword.Validators[0].(func(string) error)
 	// userDescCreatedAt is the schema descriptor for created_at field.
-	userDescCreatedAt := userFields[4].Descriptor()
+	userDescCreatedAt := userFields[8].Descriptor()
 	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
 	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
 	// userDescUpdatedAt is the schema descriptor for updated_at field.
-	userDescUpdatedAt := userFields[5].Descriptor()
+	userDescUpdatedAt := userFields[9].Descriptor()
 	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
 	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -68,18 +241,84 @@ func init() {
 	userDescID := userFields[0].Descriptor()
 	// user.DefaultID holds the default value on creation for the id field.
 	user.DefaultID = userDescID.Default.(func() uuid.UUID)
+	usertokenFields := schema.UserToken{}.Fields()
+	_ = usertokenFields
+	// usertokenDescTokenHash is the schema descriptor for token_hash field.
+	usertokenDescTokenHash := usertokenFields[3].Descriptor()
+	// usertoken.TokenHashValidator is a validator for the "token_hash" field. It is called by the builders before save.
+	usertoken.TokenHashValidator = usertokenDescTokenHash.Validators[0].(func(string) error)
+	// usertokenDescEmail is the schema descriptor for email field.
+	usertokenDescEmail := usertokenFields[4].Descriptor()
+	// usertoken.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	usertoken.EmailValidator = usertokenDescEmail.Validators[0].(func(string) error)
+	// usertokenDescCreatedAt is the schema descriptor for created_at field.
+	usertokenDescCreatedAt := usertokenFields[7].Descriptor()
+	// usertoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	usertoken.DefaultCreatedAt = usertokenDescCreatedAt.Default.(func() time.Time)
+	// usertokenDescID is the schema descriptor for id field.
+	usertokenDescID := usertokenFields[0].Descriptor()
+	// usertoken.DefaultID holds the default value on creation for the id field.
+	usertoken.DefaultID = usertokenDescID.Default.(func() uuid.UUID)
 	voteFields := schema.Vote{}.Fields()
 	_ = voteFields
-	// voteDescOption is the schema descriptor for option field.
-	voteDescOption := voteFields[3].Descriptor()
-	// vote.OptionValidator is a validator for the "option" field. It is called by the builders before save.
-	vote.OptionValidator = voteDescOption.Validators[0].(func(string) error)
 	// voteDescCreatedAt is the schema descriptor for created_at field.
-	voteDescCreatedAt := voteFields[4].Descriptor()
+	voteDescCreatedAt := voteFields[7].Descriptor()
 	// vote.DefaultCreatedAt holds the default value on creation for the created_at field.
 	vote.DefaultCreatedAt = voteDescCreatedAt.Default.(func() time.Time)
 	// voteDescID is the schema descriptor for id field.
 	voteDescID := voteFields[0].Descriptor()
 	// vote.DefaultID holds the default value on creation for the id field.
 	vote.DefaultID = voteDescID.Default.(func() uuid.UUID)
+	webhookdeliveryFields := schema.WebhookDelivery{}.Fields()
+	_ = webhookdeliveryFields
+	// webhookdeliveryDescEvent is the schema descriptor for event field.
+	webhookdeliveryDescEvent := webhookdeliveryFields[3].Descriptor()
+	// webhookdelivery.EventValidator is a validator for the "event" field. It is called by the builders before save.
+	webhookdelivery.EventValidator = webhookdeliveryDescEvent.Validators[0].(func(string) error)
+	// webhookdeliveryDescAttempts is the schema descriptor for attempts field.
+	webhookdeliveryDescAttempts := webhookdeliveryFields[6].Descriptor()
+	// webhookdelivery.DefaultAttempts holds the default value on creation for the attempts field.
+	webhookdelivery.DefaultAttempts = webhookdeliveryDescAttempts.Default.(int)
+	// webhookdelivery.AttemptsValidator is a validator for the "attempts" field. It is called by the builders before save.
+	webhookdelivery.AttemptsValidator = webhookdeliveryDescAttempts.Validators[0].(func(int) error)
+	// webhookdeliveryDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	webhookdeliveryDescNextAttemptAt := webhookdeliveryFields[7].Descriptor()
+	// webhookdelivery.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	webhookdelivery.DefaultNextAttemptAt = webhookdeliveryDescNextAttemptAt.Default.(func() time.Time)
+	// webhookdeliveryDescCreatedAt is the schema descriptor for created_at field.
+	webhookdeliveryDescCreatedAt := webhookdeliveryFields[11].Descriptor()
+	// webhookdelivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookdelivery.DefaultCreatedAt = webhookdeliveryDescCreatedAt.Default.(func() time.Time)
+	// webhookdeliveryDescID is the schema descriptor for id field.
+	webhookdeliveryDescID := webhookdeliveryFields[0].Descriptor()
+	// webhookdelivery.DefaultID holds the default value on creation for the id field.
+	webhookdelivery.DefaultID = webhookdeliveryDescID.Default.(func() uuid.UUID)
+	webhookendpointFields := schema.WebhookEndpoint{}.Fields()
+	_ = webhookendpointFields
+	// webhookendpointDescURL is the schema descriptor for url field.
+	webhookendpointDescURL := webhookendpointFields[2].Descriptor()
+	// webhookendpoint.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	webhookendpoint.URLValidator = webhookendpointDescURL.Validators[0].(func(string) error)
+	// webhookendpointDescSecret is the schema descriptor for secret field.
+	webhookendpointDescSecret := webhookendpointFields[4].Descriptor()
+	// webhookendpoint.SecretValidator is a validator for the "secret" field. It is called by the builders before save.
+	webhookendpoint.SecretValidator = webhookendpointDescSecret.Validators[0].(func(string) error)
+	// webhookendpointDescActive is the schema descriptor for active field.
+	webhookendpointDescActive := webhookendpointFields[6].Descriptor()
+	// webhookendpoint.DefaultActive holds the default value on creation for the active field.
+	webhookendpoint.DefaultActive = webhookendpointDescActive.Default.(bool)
+	// webhookendpointDescCreatedAt is the schema descriptor for created_at field.
+	webhookendpointDescCreatedAt := webhookendpointFields[7].Descriptor()
+	// webhookendpoint.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookendpoint.DefaultCreatedAt = webhookendpointDescCreatedAt.Default.(func() time.Time)
+	// webhookendpointDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookendpointDescUpdatedAt := webhookendpointFields[8].Descriptor()
+	// webhookendpoint.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhookendpoint.DefaultUpdatedAt = webhookendpointDescUpdatedAt.Default.(func() time.Time)
+	// webhookendpoint.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhookendpoint.UpdateDefaultUpdatedAt = webhookendpointDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// webhookendpointDescID is the schema descriptor for id field.
+	webhookendpointDescID := webhookendpointFields[0].Descriptor()
+	// webhookendpoint.DefaultID holds the default value on creation for the id field.
+	webhookendpoint.DefaultID = webhookendpointDescID.Default.(func() uuid.UUID)
 }
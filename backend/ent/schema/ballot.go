@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Ballot holds the schema definition for the Ballot entity.
+// Ballots are the choices cast on anonymous polls. They deliberately carry no
+// user reference and no timestamp. Nor are they written with their
+// Participation: ballots wait as a PendingBallot and are moved here in
+// batches, in shuffled order, so neither the transaction that wrote a ballot
+// nor its place in the table points to a single voter.
+type Ballot struct {
+	ent.Schema
+}
+
+// Fields of the Ballot.
+func (Ballot) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("poll_id", uuid.UUID{}),
+		// First choice; only nil on ballots not yet backfilled from their legacy option
+		field.UUID("option_id", uuid.UUID{}).Optional(),
+		// Full ballot of option IDs, ordered by preference for ranked polls. OptionID holds the first entry.
+		field.JSON("option_ids", []uuid.UUID{}).Optional(),
+		// Option labels recorded before options had IDs; cleared by the backfill
+		field.String("legacy_option").StorageKey("option").Optional(),
+		field.JSON("legacy_choices", []string{}).StorageKey("choices").Optional(),
+	}
+}
+
+// Edges of the Ballot.
+func (Ballot) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("poll", Poll.Type).
+			Field("poll_id").
+			Required().
+			Unique(),
+		edge.To("option", PollOption.Type).
+			Field("option_id").
+			Unique(),
+	}
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// JobRun holds the schema definition for the JobRun entity.
+// Every execution of a background job is recorded as one row.
+type JobRun struct {
+	ent.Schema
+}
+
+// Fields of the JobRun.
+func (JobRun) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.String("job").NotEmpty(),
+		// Instance that ran the job (hostname and process ID)
+		field.String("instance").NotEmpty(),
+		field.Enum("status").Values("running", "succeeded", "failed").Default("running"),
+		field.Int("affected").Default(0),
+		field.String("error").Optional(),
+		field.Time("started_at").Default(time.Now).Immutable(),
+		field.Time("finished_at").Optional().Nillable(),
+	}
+}
+
+// Indexes of the JobRun.
+func (JobRun) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("job", "started_at"),
+	}
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Participation holds the schema definition for the Participation entity.
+// It records that a user voted on an anonymous poll, but not how; the choices
+// are stored in an unlinked Ballot. It keeps no time either, which could be
+// matched to the moment the poll's counts changed.
+type Participation struct {
+	ent.Schema
+}
+
+// Fields of the Participation.
+func (Participation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("user_id", uuid.UUID{}),
+		field.UUID("poll_id", uuid.UUID{}),
+	}
+}
+
+// Edges of the Participation.
+func (Participation) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).
+			Field("user_id").
+			Required().
+			Unique(),
+		edge.To("poll", Poll.Type).
+			Field("poll_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the Participation.
+func (Participation) Indexes() []ent.Index {
+	return []ent.Index{
+		// Ensure one vote per user per poll
+		index.Fields("user_id", "poll_id").Unique(),
+	}
+}
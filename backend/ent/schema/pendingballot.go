@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PendingBallot holds the schema definition for the PendingBallot entity.
+// Secret ballots wait here until enough of them have been cast on their poll
+// to be moved to Ballot in a shuffled batch. A pending ballot is written in
+// the same transaction as its Participation, so it is only kept until then.
+type PendingBallot struct {
+	ent.Schema
+}
+
+// Fields of the PendingBallot.
+func (PendingBallot) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("poll_id", uuid.UUID{}),
+		// First choice
+		field.UUID("option_id", uuid.UUID{}),
+		// Full ballot of option IDs, ordered by preference for ranked polls. OptionID holds the first entry.
+		field.JSON("option_ids", []uuid.UUID{}),
+	}
+}
+
+// Edges of the PendingBallot.
+func (PendingBallot) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("poll", Poll.Type).
+			Field("poll_id").
+			Required().
+			Unique(),
+		edge.To("option", PollOption.Type).
+			Field("option_id").
+			Required().
+			Unique(),
+	}
+}
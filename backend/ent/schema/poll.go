@@ -4,8 +4,11 @@ import (
 	"time"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 	"github.com/google/uuid"
 )
 
@@ -20,8 +23,37 @@ func (Poll) Fields() []ent.Field {
 		field.UUID("id", uuid.UUID{}).Default(uuid.New),
 		field.String("title").NotEmpty(),
 		field.String("description").Optional(),
-		field.JSON("options", []string{}).Default([]string{}),
+		// Option labels from before options had their own table; cleared once
+		// the poll's options, votes and results are backfilled
+		field.JSON("legacy_options", []string{}).StorageKey("options").Optional(),
+		field.Enum("type").Values("single", "ranked", "approval").Default("single"),
+		field.Int("min_choices").Default(1).Positive(),
+		// Nil means a ballot may include every option
+		field.Int("max_choices").Optional().Nillable().Positive(),
+		// Anonymous polls store secret ballots; fixed at creation
+		field.Bool("anonymous").Default(false).Immutable(),
+		// Who may see counts and voters: everyone, voters, everyone once closed, or only the owner
+		field.Enum("results_visibility").Values("always", "after_vote", "after_close", "owner_only").Default("always"),
+		// Only users who verified their email address may vote
+		field.Bool("require_verified_email").Default(false),
 		field.UUID("owner_id", uuid.UUID{}),
+		// Identifier given by the owner's import file, so re-running an
+		// import does not create the poll again; unique per owner
+		field.String("external_id").Optional().Nillable().Immutable().NotEmpty(),
+		// Voting window; nil means open immediately / never closes
+		field.Time("opens_at").Optional().Nillable(),
+		field.Time("closes_at").Optional().Nillable(),
+		// Incremented on every edit; clients send it back in If-Match to detect
+		// concurrent edits
+		field.Int("version").Default(1).Positive(),
+		// Full-text document of the title, description and option labels;
+		// maintained by the storage layer whenever any of them changes
+		field.String("search_vector").
+			SchemaType(map[string]string{dialect.Postgres: "tsvector"}).
+			Optional(),
+		// Votes and tallied secret ballots; maintained in the vote transaction and
+		// when a batch of secret ballots is flushed
+		field.Int("voter_count").Default(0).NonNegative(),
 		field.Time("created_at").Default(time.Now),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
@@ -34,6 +66,25 @@ func (Poll) Edges() []ent.Edge {
 			Field("owner_id").
 			Required().
 			Unique(),
+		edge.From("options", PollOption.Type).Ref("poll"),
 		edge.From("votes", Vote.Type).Ref("poll"),
+		edge.From("participations", Participation.Type).Ref("poll"),
+		edge.From("ballots", Ballot.Type).Ref("poll"),
+		edge.From("pending_ballots", PendingBallot.Type).Ref("poll"),
+		edge.From("invitations", PollInvitation.Type).Ref("poll"),
+		edge.To("result", PollResult.Type).Unique(),
+	}
+}
+
+// Indexes of the Poll.
+func (Poll) Indexes() []ent.Index {
+	return []ent.Index{
+		// Keyset pagination of listings, newest first, closing soonest or most voted
+		index.Fields("created_at", "id"),
+		index.Fields("closes_at", "id"),
+		index.Fields("voter_count", "id"),
+		index.Fields("owner_id", "external_id").Unique(),
+		index.Fields("search_vector").
+			Annotations(entsql.IndexType("GIN")),
 	}
 }
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// PollInvitation holds the schema definition for the PollInvitation entity.
+// It is an email address the owner invited to vote on a poll. Invitees are
+// reminded once before the invitation expires, unless they have voted.
+type PollInvitation struct {
+	ent.Schema
+}
+
+// Fields of the PollInvitation.
+func (PollInvitation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("poll_id", uuid.UUID{}),
+		field.String("email").NotEmpty(),
+		// Expired once expires_at passes or the poll closes
+		field.Enum("status").Values("pending", "expired").Default("pending"),
+		field.Time("expires_at"),
+		// Nil until the reminder is sent
+		field.Time("reminded_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the PollInvitation.
+func (PollInvitation) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("poll", Poll.Type).
+			Field("poll_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the PollInvitation.
+func (PollInvitation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("poll_id", "email").Unique(),
+		// Expiry and reminder jobs scan pending invitations by expiry
+		index.Fields("status", "expires_at"),
+	}
+}
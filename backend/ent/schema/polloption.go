@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// PollOption holds the schema definition for the PollOption entity.
+// Votes reference options by ID, so labels can be renamed and options reordered
+// without touching the votes cast for them.
+type PollOption struct {
+	ent.Schema
+}
+
+// Fields of the PollOption.
+func (PollOption) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("poll_id", uuid.UUID{}),
+		field.String("label").NotEmpty(),
+		field.String("description").Optional(),
+		// Zero-based display order within the poll
+		field.Int("position").NonNegative(),
+		field.String("image_url").Optional(),
+		// Ballots counting for the option: every approval on approval polls,
+		// first choices otherwise. Maintained in the vote transaction.
+		field.Int("vote_count").Default(0).NonNegative(),
+		field.Time("created_at").Default(time.Now),
+	}
+}
+
+// Edges of the PollOption.
+func (PollOption) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("poll", Poll.Type).
+			Field("poll_id").
+			Required().
+			Unique(),
+		edge.From("votes", Vote.Type).Ref("option"),
+		edge.From("ballots", Ballot.Type).Ref("option"),
+	}
+}
+
+// Indexes of the PollOption.
+func (PollOption) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("poll_id", "position"),
+	}
+}
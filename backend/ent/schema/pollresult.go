@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// PollResult holds the schema definition for the PollResult entity.
+// It is the frozen outcome of a poll, recorded once when the poll closes.
+type PollResult struct {
+	ent.Schema
+}
+
+// Fields of the PollResult.
+func (PollResult) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("poll_id", uuid.UUID{}),
+		// Tallies keyed by option ID
+		field.JSON("counts", map[string]int{}),
+		field.Int("voters").NonNegative(),
+		// Instant-runoff tabulation, only recorded for ranked polls
+		field.JSON("ranked", json.RawMessage{}).Optional(),
+		field.Time("finalized_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the PollResult.
+func (PollResult) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Unique, so a poll is only finalized once
+		edge.From("poll", Poll.Type).
+			Ref("result").
+			Field("poll_id").
+			Required().
+			Unique(),
+	}
+}
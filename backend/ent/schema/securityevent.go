@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// SecurityEvent holds the schema definition for the SecurityEvent entity.
+// It records something that happened to a user's account that suggests it
+// may be compromised, such as a stolen refresh token being replayed.
+type SecurityEvent struct {
+	ent.Schema
+}
+
+// Fields of the SecurityEvent.
+func (SecurityEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("user_id", uuid.UUID{}),
+		field.Enum("type").Values("refresh_token_reused").Immutable(),
+		// Session the event concerns, if any
+		field.String("session_id").Optional().Immutable(),
+		// Client that caused the event
+		field.String("ip_address").Optional().Immutable(),
+		field.String("user_agent").Optional().Immutable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the SecurityEvent.
+func (SecurityEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).
+			Field("user_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the SecurityEvent.
+func (SecurityEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		// Events of a user, newest first
+		index.Fields("user_id", "created_at"),
+	}
+}
@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// SigningKey holds the schema definition for the SigningKey entity.
+// It is an asymmetric key tokens are signed with, when keys are kept in the database.
+type SigningKey struct {
+	ent.Schema
+}
+
+// Fields of the SigningKey.
+func (SigningKey) Fields() []ent.Field {
+	return []ent.Field{
+		// Key ID sent in the kid header of the tokens the key signs
+		field.String("id").NotEmpty().Immutable(),
+		field.Enum("algorithm").Values("RS256", "ES256", "EdDSA").Immutable(),
+		// PKCS #8 encoded private key
+		field.Bytes("private_key").NotEmpty().Sensitive().Immutable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		// When a newer key took over signing; the key is still accepted for a while after
+		field.Time("retired_at").Optional().Nillable(),
+	}
+}
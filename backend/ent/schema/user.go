@@ -21,6 +21,15 @@ func (User) Fields() []ent.Field {
 		field.String("email").Unique().NotEmpty(),
 		field.String("username").Unique().NotEmpty(),
 		field.String("password").NotEmpty().Sensitive(),
+		// When the user proved they own the email address; cleared if it changes
+		field.Time("email_verified_at").Optional().Nillable(),
+		// Base32 TOTP secret; set while enrolling in two-factor authentication
+		// and kept while it is enabled
+		field.String("mfa_secret").Optional().Nillable().Sensitive(),
+		// When two-factor authentication was confirmed; nil while enrolling or off
+		field.Time("mfa_enabled_at").Optional().Nillable(),
+		// Time step of the last TOTP code accepted, so no code is accepted twice
+		field.Int64("mfa_last_step").Optional().Nillable(),
 		field.Time("created_at").Default(time.Now),
 		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
 	}
@@ -31,5 +40,10 @@ func (User) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("polls", Poll.Type),
 		edge.From("votes", Vote.Type).Ref("user"),
+		edge.From("participations", Participation.Type).Ref("user"),
+		edge.From("webhook_endpoints", WebhookEndpoint.Type).Ref("owner"),
+		edge.From("security_events", SecurityEvent.Type).Ref("user"),
+		edge.From("tokens", UserToken.Type).Ref("user"),
+		edge.From("recovery_codes", RecoveryCode.Type).Ref("user"),
 	}
 }
@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// UserToken holds the schema definition for the UserToken entity.
+// It is a single-use token mailed to a user to reset their password or
+// verify their email address. Only a hash of the token is stored.
+type UserToken struct {
+	ent.Schema
+}
+
+// Fields of the UserToken.
+func (UserToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("user_id", uuid.UUID{}),
+		field.Enum("purpose").Values("password_reset", "email_verification").Immutable(),
+		// SHA-256 of the token, hex encoded
+		field.String("token_hash").NotEmpty().Unique().Sensitive().Immutable(),
+		// Address the token was sent to; a token only verifies the address it was sent to
+		field.String("email").NotEmpty().Immutable(),
+		field.Time("expires_at").Immutable(),
+		// Set once the token is used, or when a newer token of the same purpose replaces it
+		field.Time("used_at").Optional().Nillable(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the UserToken.
+func (UserToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("user", User.Type).
+			Field("user_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the UserToken.
+func (UserToken) Indexes() []ent.Index {
+	return []ent.Index{
+		// Outstanding tokens of a user
+		index.Fields("user_id", "purpose"),
+		// Tokens to purge
+		index.Fields("expires_at"),
+	}
+}
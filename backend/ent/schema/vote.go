@@ -21,7 +21,14 @@ func (Vote) Fields() []ent.Field {
 		field.UUID("id", uuid.UUID{}).Default(uuid.New),
 		field.UUID("user_id", uuid.UUID{}),
 		field.UUID("poll_id", uuid.UUID{}),
-		field.String("option").NotEmpty(),
+		// First choice; only nil on votes not yet backfilled from their legacy option
+		field.UUID("option_id", uuid.UUID{}).Optional(),
+		// Full ballot: the chosen option IDs, ordered by preference for ranked polls.
+		// OptionID always holds the first entry.
+		field.JSON("option_ids", []uuid.UUID{}).Optional(),
+		// Option labels recorded before options had IDs; cleared by the backfill
+		field.String("legacy_option").StorageKey("option").Optional(),
+		field.JSON("legacy_choices", []string{}).StorageKey("choices").Optional(),
 		field.Time("created_at").Default(time.Now),
 	}
 }
@@ -37,6 +44,9 @@ func (Vote) Edges() []ent.Edge {
 			Field("poll_id").
 			Required().
 			Unique(),
+		edge.To("option", PollOption.Type).
+			Field("option_id").
+			Unique(),
 	}
 }
 
@@ -45,5 +55,9 @@ func (Vote) Indexes() []ent.Index {
 	return []ent.Index{
 		// Ensure one vote per user per poll
 		index.Fields("user_id", "poll_id").Unique(),
+		// Voters of an option
+		index.Fields("poll_id", "option_id"),
+		// Ballots of a poll in the order they were cast, for exports
+		index.Fields("poll_id", "created_at", "id"),
 	}
 }
@@ -0,0 +1,61 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// WebhookDelivery holds the schema definition for the WebhookDelivery entity.
+// It is one event to be posted to one endpoint. Deliveries are written in the
+// transaction of the change they report, so they form an outbox that is
+// delivered asynchronously, and are kept afterwards as the delivery log.
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+// Fields of the WebhookDelivery.
+func (WebhookDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("endpoint_id", uuid.UUID{}),
+		// Shared by the deliveries of one event to every endpoint and by redeliveries
+		field.UUID("event_id", uuid.UUID{}),
+		field.String("event").NotEmpty(),
+		field.JSON("payload", json.RawMessage{}),
+		// Pending deliveries are retried until they succeed or are dead
+		field.Enum("status").Values("pending", "succeeded", "dead").Default("pending"),
+		field.Int("attempts").Default(0).NonNegative(),
+		field.Time("next_attempt_at").Default(time.Now),
+		field.Time("last_attempt_at").Optional().Nillable(),
+		// HTTP status of the last attempt, unset if it got no response
+		field.Int("response_status").Optional().Nillable(),
+		field.String("last_error").Optional(),
+		field.Time("created_at").Default(time.Now).Immutable(),
+	}
+}
+
+// Edges of the WebhookDelivery.
+func (WebhookDelivery) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("endpoint", WebhookEndpoint.Type).
+			Field("endpoint_id").
+			Required().
+			Unique(),
+	}
+}
+
+// Indexes of the WebhookDelivery.
+func (WebhookDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		// Deliveries that are due
+		index.Fields("status", "next_attempt_at"),
+		// Delivery log of an endpoint, newest first
+		index.Fields("endpoint_id", "created_at", "id"),
+	}
+}
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// WebhookEndpoint holds the schema definition for the WebhookEndpoint entity.
+// It is a URL to which the events of its owner's polls are posted.
+type WebhookEndpoint struct {
+	ent.Schema
+}
+
+// Fields of the WebhookEndpoint.
+func (WebhookEndpoint) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).Default(uuid.New),
+		field.UUID("owner_id", uuid.UUID{}),
+		field.String("url").NotEmpty(),
+		field.String("description").Optional(),
+		// Key the payloads are signed with; shown to the owner only on creation
+		field.String("secret").NotEmpty().Sensitive(),
+		// Event types delivered to the endpoint
+		field.JSON("events", []string{}),
+		field.Bool("active").Default(true),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the WebhookEndpoint.
+func (WebhookEndpoint) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("owner", User.Type).
+			Field("owner_id").
+			Required().
+			Unique(),
+		edge.From("deliveries", WebhookDelivery.Type).Ref("endpoint"),
+	}
+}
+
+// Indexes of the WebhookEndpoint.
+func (WebhookEndpoint) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("owner_id"),
+	}
+}
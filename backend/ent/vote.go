@@ -3,8 +3,10 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
 	"poll-app/ent/user"
 	"poll-app/ent/vote"
 	"strings"
@@ -24,8 +26,14 @@ type Vote struct {
 	UserID uuid.UUID `json:"user_id,omitempty"`
 	// PollID holds the value of the "poll_id" field.
 	PollID uuid.UUID `json:"poll_id,omitempty"`
-	// Option holds the value of the "option" field.
-	Option string `json:"option,omitempty"`
+	// OptionID holds the value of the "option_id" field.
+	OptionID uuid.UUID `json:"option_id,omitempty"`
+	// OptionIds holds the value of the "option_ids" field.
+	OptionIds []uuid.UUID `json:"option_ids,omitempty"`
+	// LegacyOption holds the value of the "legacy_option" field.
+	LegacyOption string `json:"legacy_option,omitempty"`
+	// LegacyChoices holds the value of the "legacy_choices" field.
+	LegacyChoices []string `json:"legacy_choices,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -40,9 +48,11 @@ type VoteEdges struct {
 	User *User `json:"user,omitempty"`
 	// Poll holds the value of the poll edge.
 	Poll *Poll `json:"poll,omitempty"`
+	// Option holds the value of the option edge.
+	Option *PollOption `json:"option,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [3]bool
 }
 
 // UserOrErr returns the User value or an error if the edge
@@ -67,16 +77,29 @@ func (e VoteEdges) PollOrErr() (*Poll, error) {
 	return nil, &NotLoadedError{edge: "poll"}
 }
 
+// OptionOrErr returns the Option value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e VoteEdges) OptionOrErr() (*PollOption, error) {
+	if e.Option != nil {
+		return e.Option, nil
+	} else if e.loadedTypes[2] {
+		return nil, &NotFoundError{label: polloption.Label}
+	}
+	return nil, &NotLoadedError{edge: "option"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Vote) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case vote.FieldOption:
+		case vote.FieldOptionIds, vote.FieldLegacyChoices:
+			values[i] = new([]byte)
+		case vote.FieldLegacyOption:
 			values[i] = new(sql.NullString)
 		case vote.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
-		case vote.FieldID, vote.FieldUserID, vote.FieldPollID:
+		case vote.FieldID, vote.FieldUserID, vote.FieldPollID, vote.FieldOptionID:
 			values[i] = new(uuid.UUID)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -111,11 +134,33 @@ func (_m *Vote) assignValues(columns []string, values []any) error {
 			} else if value != nil {
 				_m.PollID = *value
 			}
-		case vote.FieldOption:
+		case vote.FieldOptionID:
+			if value, ok := values[i].(*uuid.UUID); !ok {
+				return fmt.Errorf("unexpected type %T for field option_id", values[i])
+			} else if value != nil {
+				_m.OptionID = *value
+			}
+		case vote.FieldOptionIds:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field option_ids", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.OptionIds); err != nil {
+					return fmt.Errorf("unmarshal field option_ids: %w", err)
+				}
+			}
+		case vote.FieldLegacyOption:
 			if value, ok := values[i].(*sql.NullString); !ok {
-				return fmt.Errorf("unexpected type %T for field option", values[i])
+				return fmt.Errorf("unexpected type %T for field legacy_option", values[i])
 			} else if value.Valid {
-				_m.Option = value.String
+				_m.LegacyOption = value.String
+			}
+		case vote.FieldLegacyChoices:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field legacy_choices", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.LegacyChoices); err != nil {
+					return fmt.Errorf("unmarshal field legacy_choices: %w", err)
+				}
 			}
 		case vote.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
@@ -146,6 +191,11 @@ func (_m *Vote) QueryPoll() *PollQuery {
 	return NewVoteClient(_m.config).QueryPoll(_m)
 }
 
+// QueryOption queries the "option" edge of the Vote entity.
+func (_m *Vote) QueryOption() *PollOptionQuery {
+	return NewVoteClient(_m.config).QueryOption(_m)
+}
+
 // Update returns a builder for updating this Vote.
 // Note that you need to call Vote.Unwrap() before calling this method if this Vote
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -175,8 +225,17 @@ func (_m *Vote) String() string {
 	builder.WriteString("poll_id=")
 	builder.WriteString(fmt.Sprintf("%v", _m.PollID))
 	builder.WriteString(", ")
-	builder.WriteString("option=")
-	builder.WriteString(_m.Option)
+	builder.WriteString("option_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OptionID))
+	builder.WriteString(", ")
+	builder.WriteString("option_ids=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OptionIds))
+	builder.WriteString(", ")
+	builder.WriteString("legacy_option=")
+	builder.WriteString(_m.LegacyOption)
+	builder.WriteString(", ")
+	builder.WriteString("legacy_choices=")
+	builder.WriteString(fmt.Sprintf("%v", _m.LegacyChoices))
 	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
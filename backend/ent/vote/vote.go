@@ -19,14 +19,22 @@ const (
 	FieldUserID = "user_id"
 	// FieldPollID holds the string denoting the poll_id field in the database.
 	FieldPollID = "poll_id"
-	// FieldOption holds the string denoting the option field in the database.
-	FieldOption = "option"
+	// FieldOptionID holds the string denoting the option_id field in the database.
+	FieldOptionID = "option_id"
+	// FieldOptionIds holds the string denoting the option_ids field in the database.
+	FieldOptionIds = "option_ids"
+	// FieldLegacyOption holds the string denoting the legacy_option field in the database.
+	FieldLegacyOption = "option"
+	// FieldLegacyChoices holds the string denoting the legacy_choices field in the database.
+	FieldLegacyChoices = "choices"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
 	// EdgePoll holds the string denoting the poll edge name in mutations.
 	EdgePoll = "poll"
+	// EdgeOption holds the string denoting the option edge name in mutations.
+	EdgeOption = "option"
 	// Table holds the table name of the vote in the database.
 	Table = "votes"
 	// UserTable is the table that holds the user relation/edge.
@@ -43,6 +51,13 @@ const (
 	PollInverseTable = "polls"
 	// PollColumn is the table column denoting the poll relation/edge.
 	PollColumn = "poll_id"
+	// OptionTable is the table that holds the option relation/edge.
+	OptionTable = "votes"
+	// OptionInverseTable is the table name for the PollOption entity.
+	// It exists in this package in order to avoid circular dependency with the "polloption" package.
+	OptionInverseTable = "poll_options"
+	// OptionColumn is the table column denoting the option relation/edge.
+	OptionColumn = "option_id"
 )
 
 // Columns holds all SQL columns for vote fields.
@@ -50,7 +65,10 @@ var Columns = []string{
 	FieldID,
 	FieldUserID,
 	FieldPollID,
-	FieldOption,
+	FieldOptionID,
+	FieldOptionIds,
+	FieldLegacyOption,
+	FieldLegacyChoices,
 	FieldCreatedAt,
 }
 
@@ -65,8 +83,6 @@ func ValidColumn(column string) bool {
 }
 
 var (
-	// OptionValidator is a validator for the "option" field. It is called by the builders before save.
-	OptionValidator func(string) error
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 	// DefaultID holds the default value on creation for the "id" field.
@@ -91,9 +107,14 @@ func ByPollID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldPollID, opts...).ToFunc()
 }
 
-// ByOption orders the results by the option field.
-func ByOption(opts ...sql.OrderTermOption) OrderOption {
-	return sql.OrderByField(FieldOption, opts...).ToFunc()
+// ByOptionID orders the results by the option_id field.
+func ByOptionID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOptionID, opts...).ToFunc()
+}
+
+// ByLegacyOption orders the results by the legacy_option field.
+func ByLegacyOption(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLegacyOption, opts...).ToFunc()
 }
 
 // ByCreatedAt orders the results by the created_at field.
@@ -114,6 +135,13 @@ func ByPollField(field string, opts ...sql.OrderTermOption) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newPollStep(), sql.OrderByField(field, opts...))
 	}
 }
+
+// ByOptionField orders the results by option field.
+func ByOptionField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newOptionStep(), sql.OrderByField(field, opts...))
+	}
+}
 func newUserStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -128,3 +156,10 @@ func newPollStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.M2O, false, PollTable, PollColumn),
 	)
 }
+func newOptionStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(OptionInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, false, OptionTable, OptionColumn),
+	)
+}
@@ -66,9 +66,14 @@ func PollID(v uuid.UUID) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldPollID, v))
 }
 
-// Option applies equality check predicate on the "option" field. It's identical to OptionEQ.
-func Option(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldEQ(FieldOption, v))
+// OptionID applies equality check predicate on the "option_id" field. It's identical to OptionIDEQ.
+func OptionID(v uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldOptionID, v))
+}
+
+// LegacyOption applies equality check predicate on the "legacy_option" field. It's identical to LegacyOptionEQ.
+func LegacyOption(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldLegacyOption, v))
 }
 
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
@@ -116,69 +121,129 @@ func PollIDNotIn(vs ...uuid.UUID) predicate.Vote {
 	return predicate.Vote(sql.FieldNotIn(FieldPollID, vs...))
 }
 
-// OptionEQ applies the EQ predicate on the "option" field.
-func OptionEQ(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldEQ(FieldOption, v))
+// OptionIDEQ applies the EQ predicate on the "option_id" field.
+func OptionIDEQ(v uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldOptionID, v))
+}
+
+// OptionIDNEQ applies the NEQ predicate on the "option_id" field.
+func OptionIDNEQ(v uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldOptionID, v))
+}
+
+// OptionIDIn applies the In predicate on the "option_id" field.
+func OptionIDIn(vs ...uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldOptionID, vs...))
+}
+
+// OptionIDNotIn applies the NotIn predicate on the "option_id" field.
+func OptionIDNotIn(vs ...uuid.UUID) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldOptionID, vs...))
+}
+
+// OptionIDIsNil applies the IsNil predicate on the "option_id" field.
+func OptionIDIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldOptionID))
+}
+
+// OptionIDNotNil applies the NotNil predicate on the "option_id" field.
+func OptionIDNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldOptionID))
+}
+
+// OptionIdsIsNil applies the IsNil predicate on the "option_ids" field.
+func OptionIdsIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldOptionIds))
+}
+
+// OptionIdsNotNil applies the NotNil predicate on the "option_ids" field.
+func OptionIdsNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldOptionIds))
+}
+
+// LegacyOptionEQ applies the EQ predicate on the "legacy_option" field.
+func LegacyOptionEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldLegacyOption, v))
+}
+
+// LegacyOptionNEQ applies the NEQ predicate on the "legacy_option" field.
+func LegacyOptionNEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldLegacyOption, v))
+}
+
+// LegacyOptionIn applies the In predicate on the "legacy_option" field.
+func LegacyOptionIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldLegacyOption, vs...))
 }
 
-// OptionNEQ applies the NEQ predicate on the "option" field.
-func OptionNEQ(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldNEQ(FieldOption, v))
+// LegacyOptionNotIn applies the NotIn predicate on the "legacy_option" field.
+func LegacyOptionNotIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldLegacyOption, vs...))
 }
 
-// OptionIn applies the In predicate on the "option" field.
-func OptionIn(vs ...string) predicate.Vote {
-	return predicate.Vote(sql.FieldIn(FieldOption, vs...))
+// LegacyOptionGT applies the GT predicate on the "legacy_option" field.
+func LegacyOptionGT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldLegacyOption, v))
 }
 
-// OptionNotIn applies the NotIn predicate on the "option" field.
-func OptionNotIn(vs ...string) predicate.Vote {
-	return predicate.Vote(sql.FieldNotIn(FieldOption, vs...))
+// LegacyOptionGTE applies the GTE predicate on the "legacy_option" field.
+func LegacyOptionGTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldLegacyOption, v))
 }
 
-// OptionGT applies the GT predicate on the "option" field.
-func OptionGT(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldGT(FieldOption, v))
+// LegacyOptionLT applies the LT predicate on the "legacy_option" field.
+func LegacyOptionLT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldLegacyOption, v))
 }
 
-// OptionGTE applies the GTE predicate on the "option" field.
-func OptionGTE(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldGTE(FieldOption, v))
+// LegacyOptionLTE applies the LTE predicate on the "legacy_option" field.
+func LegacyOptionLTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldLegacyOption, v))
 }
 
-// OptionLT applies the LT predicate on the "option" field.
-func OptionLT(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldLT(FieldOption, v))
+// LegacyOptionContains applies the Contains predicate on the "legacy_option" field.
+func LegacyOptionContains(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContains(FieldLegacyOption, v))
 }
 
-// OptionLTE applies the LTE predicate on the "option" field.
-func OptionLTE(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldLTE(FieldOption, v))
+// LegacyOptionHasPrefix applies the HasPrefix predicate on the "legacy_option" field.
+func LegacyOptionHasPrefix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasPrefix(FieldLegacyOption, v))
 }
 
-// OptionContains applies the Contains predicate on the "option" field.
-func OptionContains(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldContains(FieldOption, v))
+// LegacyOptionHasSuffix applies the HasSuffix predicate on the "legacy_option" field.
+func LegacyOptionHasSuffix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasSuffix(FieldLegacyOption, v))
 }
 
-// OptionHasPrefix applies the HasPrefix predicate on the "option" field.
-func OptionHasPrefix(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldHasPrefix(FieldOption, v))
+// LegacyOptionIsNil applies the IsNil predicate on the "legacy_option" field.
+func LegacyOptionIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldLegacyOption))
 }
 
-// OptionHasSuffix applies the HasSuffix predicate on the "option" field.
-func OptionHasSuffix(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldHasSuffix(FieldOption, v))
+// LegacyOptionNotNil applies the NotNil predicate on the "legacy_option" field.
+func LegacyOptionNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldLegacyOption))
 }
 
-// OptionEqualFold applies the EqualFold predicate on the "option" field.
-func OptionEqualFold(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldEqualFold(FieldOption, v))
+// LegacyOptionEqualFold applies the EqualFold predicate on the "legacy_option" field.
+func LegacyOptionEqualFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEqualFold(FieldLegacyOption, v))
 }
 
-// OptionContainsFold applies the ContainsFold predicate on the "option" field.
-func OptionContainsFold(v string) predicate.Vote {
-	return predicate.Vote(sql.FieldContainsFold(FieldOption, v))
+// LegacyOptionContainsFold applies the ContainsFold predicate on the "legacy_option" field.
+func LegacyOptionContainsFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContainsFold(FieldLegacyOption, v))
+}
+
+// LegacyChoicesIsNil applies the IsNil predicate on the "legacy_choices" field.
+func LegacyChoicesIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldLegacyChoices))
+}
+
+// LegacyChoicesNotNil applies the NotNil predicate on the "legacy_choices" field.
+func LegacyChoicesNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldLegacyChoices))
 }
 
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
@@ -267,6 +332,29 @@ func HasPollWith(preds ...predicate.Poll) predicate.Vote {
 	})
 }
 
+// HasOption applies the HasEdge predicate on the "option" edge.
+func HasOption() predicate.Vote {
+	return predicate.Vote(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, OptionTable, OptionColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasOptionWith applies the HasEdge predicate on the "option" edge with a given conditions (other predicates).
+func HasOptionWith(preds ...predicate.PollOption) predicate.Vote {
+	return predicate.Vote(func(s *sql.Selector) {
+		step := newOptionStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Vote) predicate.Vote {
 	return predicate.Vote(sql.AndPredicates(predicates...))
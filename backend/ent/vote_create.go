@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
 	"poll-app/ent/user"
 	"poll-app/ent/vote"
 	"time"
@@ -35,9 +36,43 @@ func (_c *VoteCreate) SetPollID(v uuid.UUID) *VoteCreate {
 	return _c
 }
 
-// SetOption sets the "option" field.
-func (_c *VoteCreate) SetOption(v string) *VoteCreate {
-	_c.mutation.SetOption(v)
+// SetOptionID sets the "option_id" field.
+func (_c *VoteCreate) SetOptionID(v uuid.UUID) *VoteCreate {
+	_c.mutation.SetOptionID(v)
+	return _c
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_c *VoteCreate) SetNillableOptionID(v *uuid.UUID) *VoteCreate {
+	if v != nil {
+		_c.SetOptionID(*v)
+	}
+	return _c
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_c *VoteCreate) SetOptionIds(v []uuid.UUID) *VoteCreate {
+	_c.mutation.SetOptionIds(v)
+	return _c
+}
+
+// SetLegacyOption sets the "legacy_option" field.
+func (_c *VoteCreate) SetLegacyOption(v string) *VoteCreate {
+	_c.mutation.SetLegacyOption(v)
+	return _c
+}
+
+// SetNillableLegacyOption sets the "legacy_option" field if the given value is not nil.
+func (_c *VoteCreate) SetNillableLegacyOption(v *string) *VoteCreate {
+	if v != nil {
+		_c.SetLegacyOption(*v)
+	}
+	return _c
+}
+
+// SetLegacyChoices sets the "legacy_choices" field.
+func (_c *VoteCreate) SetLegacyChoices(v []string) *VoteCreate {
+	_c.mutation.SetLegacyChoices(v)
 	return _c
 }
 
@@ -79,6 +114,11 @@ func (_c *VoteCreate) SetPoll(v *Poll) *VoteCreate {
 	return _c.SetPollID(v.ID)
 }
 
+// SetOption sets the "option" edge to the PollOption entity.
+func (_c *VoteCreate) SetOption(v *PollOption) *VoteCreate {
+	return _c.SetOptionID(v.ID)
+}
+
 // Mutation returns the VoteMutation object of the builder.
 func (_c *VoteCreate) Mutation() *VoteMutation {
 	return _c.mutation
@@ -132,14 +172,6 @@ func (_c *VoteCreate) check() error {
 	if _, ok := _c.mutation.PollID(); !ok {
 		return &ValidationError{Name: "poll_id", err: errors.New(`ent: missing required field "Vote.poll_id"`)}
 	}
-	if _, ok := _c.mutation.Option(); !ok {
-		return &ValidationError{Name: "option", err: errors.New(`ent: missing required field "Vote.option"`)}
-	}
-	if v, ok := _c.mutation.Option(); ok {
-		if err := vote.OptionValidator(v); err != nil {
-			return &ValidationError{Name: "option", err: fmt.Errorf(`ent: validator failed for field "Vote.option": %w`, err)}
-		}
-	}
 	if _, ok := _c.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Vote.created_at"`)}
 	}
@@ -184,9 +216,17 @@ func (_c *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		_node.ID = id
 		_spec.ID.Value = &id
 	}
-	if value, ok := _c.mutation.Option(); ok {
-		_spec.SetField(vote.FieldOption, field.TypeString, value)
-		_node.Option = value
+	if value, ok := _c.mutation.OptionIds(); ok {
+		_spec.SetField(vote.FieldOptionIds, field.TypeJSON, value)
+		_node.OptionIds = value
+	}
+	if value, ok := _c.mutation.LegacyOption(); ok {
+		_spec.SetField(vote.FieldLegacyOption, field.TypeString, value)
+		_node.LegacyOption = value
+	}
+	if value, ok := _c.mutation.LegacyChoices(); ok {
+		_spec.SetField(vote.FieldLegacyChoices, field.TypeJSON, value)
+		_node.LegacyChoices = value
 	}
 	if value, ok := _c.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
@@ -226,6 +266,23 @@ func (_c *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		_node.PollID = nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   vote.OptionTable,
+			Columns: []string{vote.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.OptionID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
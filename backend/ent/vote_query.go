@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math"
 	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
 	"poll-app/ent/predicate"
 	"poll-app/ent/user"
 	"poll-app/ent/vote"
@@ -27,6 +28,7 @@ type VoteQuery struct {
 	predicates []predicate.Vote
 	withUser   *UserQuery
 	withPoll   *PollQuery
+	withOption *PollOptionQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -107,6 +109,28 @@ func (_q *VoteQuery) QueryPoll() *PollQuery {
 	return query
 }
 
+// QueryOption chains the current query on the "option" edge.
+func (_q *VoteQuery) QueryOption() *PollOptionQuery {
+	query := (&PollOptionClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(vote.Table, vote.FieldID, selector),
+			sqlgraph.To(polloption.Table, polloption.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, false, vote.OptionTable, vote.OptionColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Vote entity from the query.
 // Returns a *NotFoundError when no Vote was found.
 func (_q *VoteQuery) First(ctx context.Context) (*Vote, error) {
@@ -301,6 +325,7 @@ func (_q *VoteQuery) Clone() *VoteQuery {
 		predicates: append([]predicate.Vote{}, _q.predicates...),
 		withUser:   _q.withUser.Clone(),
 		withPoll:   _q.withPoll.Clone(),
+		withOption: _q.withOption.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -329,6 +354,17 @@ func (_q *VoteQuery) WithPoll(opts ...func(*PollQuery)) *VoteQuery {
 	return _q
 }
 
+// WithOption tells the query-builder to eager-load the nodes that are connected to
+// the "option" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *VoteQuery) WithOption(opts ...func(*PollOptionQuery)) *VoteQuery {
+	query := (&PollOptionClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withOption = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -407,9 +443,10 @@ func (_q *VoteQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Vote, e
 	var (
 		nodes       = []*Vote{}
 		_spec       = _q.querySpec()
-		loadedTypes = [2]bool{
+		loadedTypes = [3]bool{
 			_q.withUser != nil,
 			_q.withPoll != nil,
+			_q.withOption != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -442,6 +479,12 @@ func (_q *VoteQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Vote, e
 			return nil, err
 		}
 	}
+	if query := _q.withOption; query != nil {
+		if err := _q.loadOption(ctx, query, nodes, nil,
+			func(n *Vote, e *PollOption) { n.Edges.Option = e }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -503,6 +546,35 @@ func (_q *VoteQuery) loadPoll(ctx context.Context, query *PollQuery, nodes []*Vo
 	}
 	return nil
 }
+func (_q *VoteQuery) loadOption(ctx context.Context, query *PollOptionQuery, nodes []*Vote, init func(*Vote), assign func(*Vote, *PollOption)) error {
+	ids := make([]uuid.UUID, 0, len(nodes))
+	nodeids := make(map[uuid.UUID][]*Vote)
+	for i := range nodes {
+		fk := nodes[i].OptionID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(polloption.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "option_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
 
 func (_q *VoteQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
@@ -535,6 +607,9 @@ func (_q *VoteQuery) querySpec() *sqlgraph.QuerySpec {
 		if _q.withPoll != nil {
 			_spec.Node.AddColumnOnce(vote.FieldPollID)
 		}
+		if _q.withOption != nil {
+			_spec.Node.AddColumnOnce(vote.FieldOptionID)
+		}
 	}
 	if ps := _q.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
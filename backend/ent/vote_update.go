@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
 	"poll-app/ent/predicate"
 	"poll-app/ent/user"
 	"poll-app/ent/vote"
@@ -14,6 +15,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 	"github.com/google/uuid"
 )
@@ -59,20 +61,82 @@ func (_u *VoteUpdate) SetNillablePollID(v *uuid.UUID) *VoteUpdate {
 	return _u
 }
 
-// SetOption sets the "option" field.
-func (_u *VoteUpdate) SetOption(v string) *VoteUpdate {
-	_u.mutation.SetOption(v)
+// SetOptionID sets the "option_id" field.
+func (_u *VoteUpdate) SetOptionID(v uuid.UUID) *VoteUpdate {
+	_u.mutation.SetOptionID(v)
 	return _u
 }
 
-// SetNillableOption sets the "option" field if the given value is not nil.
-func (_u *VoteUpdate) SetNillableOption(v *string) *VoteUpdate {
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_u *VoteUpdate) SetNillableOptionID(v *uuid.UUID) *VoteUpdate {
 	if v != nil {
-		_u.SetOption(*v)
+		_u.SetOptionID(*v)
 	}
 	return _u
 }
 
+// ClearOptionID clears the value of the "option_id" field.
+func (_u *VoteUpdate) ClearOptionID() *VoteUpdate {
+	_u.mutation.ClearOptionID()
+	return _u
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_u *VoteUpdate) SetOptionIds(v []uuid.UUID) *VoteUpdate {
+	_u.mutation.SetOptionIds(v)
+	return _u
+}
+
+// AppendOptionIds appends value to the "option_ids" field.
+func (_u *VoteUpdate) AppendOptionIds(v []uuid.UUID) *VoteUpdate {
+	_u.mutation.AppendOptionIds(v)
+	return _u
+}
+
+// ClearOptionIds clears the value of the "option_ids" field.
+func (_u *VoteUpdate) ClearOptionIds() *VoteUpdate {
+	_u.mutation.ClearOptionIds()
+	return _u
+}
+
+// SetLegacyOption sets the "legacy_option" field.
+func (_u *VoteUpdate) SetLegacyOption(v string) *VoteUpdate {
+	_u.mutation.SetLegacyOption(v)
+	return _u
+}
+
+// SetNillableLegacyOption sets the "legacy_option" field if the given value is not nil.
+func (_u *VoteUpdate) SetNillableLegacyOption(v *string) *VoteUpdate {
+	if v != nil {
+		_u.SetLegacyOption(*v)
+	}
+	return _u
+}
+
+// ClearLegacyOption clears the value of the "legacy_option" field.
+func (_u *VoteUpdate) ClearLegacyOption() *VoteUpdate {
+	_u.mutation.ClearLegacyOption()
+	return _u
+}
+
+// SetLegacyChoices sets the "legacy_choices" field.
+func (_u *VoteUpdate) SetLegacyChoices(v []string) *VoteUpdate {
+	_u.mutation.SetLegacyChoices(v)
+	return _u
+}
+
+// AppendLegacyChoices appends value to the "legacy_choices" field.
+func (_u *VoteUpdate) AppendLegacyChoices(v []string) *VoteUpdate {
+	_u.mutation.AppendLegacyChoices(v)
+	return _u
+}
+
+// ClearLegacyChoices clears the value of the "legacy_choices" field.
+func (_u *VoteUpdate) ClearLegacyChoices() *VoteUpdate {
+	_u.mutation.ClearLegacyChoices()
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *VoteUpdate) SetCreatedAt(v time.Time) *VoteUpdate {
 	_u.mutation.SetCreatedAt(v)
@@ -97,6 +161,11 @@ func (_u *VoteUpdate) SetPoll(v *Poll) *VoteUpdate {
 	return _u.SetPollID(v.ID)
 }
 
+// SetOption sets the "option" edge to the PollOption entity.
+func (_u *VoteUpdate) SetOption(v *PollOption) *VoteUpdate {
+	return _u.SetOptionID(v.ID)
+}
+
 // Mutation returns the VoteMutation object of the builder.
 func (_u *VoteUpdate) Mutation() *VoteMutation {
 	return _u.mutation
@@ -114,6 +183,12 @@ func (_u *VoteUpdate) ClearPoll() *VoteUpdate {
 	return _u
 }
 
+// ClearOption clears the "option" edge to the PollOption entity.
+func (_u *VoteUpdate) ClearOption() *VoteUpdate {
+	_u.mutation.ClearOption()
+	return _u
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *VoteUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
@@ -143,11 +218,6 @@ func (_u *VoteUpdate) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *VoteUpdate) check() error {
-	if v, ok := _u.mutation.Option(); ok {
-		if err := vote.OptionValidator(v); err != nil {
-			return &ValidationError{Name: "option", err: fmt.Errorf(`ent: validator failed for field "Vote.option": %w`, err)}
-		}
-	}
 	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.user"`)
 	}
@@ -169,8 +239,33 @@ func (_u *VoteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 			}
 		}
 	}
-	if value, ok := _u.mutation.Option(); ok {
-		_spec.SetField(vote.FieldOption, field.TypeString, value)
+	if value, ok := _u.mutation.OptionIds(); ok {
+		_spec.SetField(vote.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, vote.FieldOptionIds, value)
+		})
+	}
+	if _u.mutation.OptionIdsCleared() {
+		_spec.ClearField(vote.FieldOptionIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.LegacyOption(); ok {
+		_spec.SetField(vote.FieldLegacyOption, field.TypeString, value)
+	}
+	if _u.mutation.LegacyOptionCleared() {
+		_spec.ClearField(vote.FieldLegacyOption, field.TypeString)
+	}
+	if value, ok := _u.mutation.LegacyChoices(); ok {
+		_spec.SetField(vote.FieldLegacyChoices, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedLegacyChoices(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, vote.FieldLegacyChoices, value)
+		})
+	}
+	if _u.mutation.LegacyChoicesCleared() {
+		_spec.ClearField(vote.FieldLegacyChoices, field.TypeJSON)
 	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
@@ -233,6 +328,35 @@ func (_u *VoteUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OptionCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   vote.OptionTable,
+			Columns: []string{vote.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   vote.OptionTable,
+			Columns: []string{vote.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{vote.Label}
@@ -281,20 +405,82 @@ func (_u *VoteUpdateOne) SetNillablePollID(v *uuid.UUID) *VoteUpdateOne {
 	return _u
 }
 
-// SetOption sets the "option" field.
-func (_u *VoteUpdateOne) SetOption(v string) *VoteUpdateOne {
-	_u.mutation.SetOption(v)
+// SetOptionID sets the "option_id" field.
+func (_u *VoteUpdateOne) SetOptionID(v uuid.UUID) *VoteUpdateOne {
+	_u.mutation.SetOptionID(v)
 	return _u
 }
 
-// SetNillableOption sets the "option" field if the given value is not nil.
-func (_u *VoteUpdateOne) SetNillableOption(v *string) *VoteUpdateOne {
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableOptionID(v *uuid.UUID) *VoteUpdateOne {
 	if v != nil {
-		_u.SetOption(*v)
+		_u.SetOptionID(*v)
 	}
 	return _u
 }
 
+// ClearOptionID clears the value of the "option_id" field.
+func (_u *VoteUpdateOne) ClearOptionID() *VoteUpdateOne {
+	_u.mutation.ClearOptionID()
+	return _u
+}
+
+// SetOptionIds sets the "option_ids" field.
+func (_u *VoteUpdateOne) SetOptionIds(v []uuid.UUID) *VoteUpdateOne {
+	_u.mutation.SetOptionIds(v)
+	return _u
+}
+
+// AppendOptionIds appends value to the "option_ids" field.
+func (_u *VoteUpdateOne) AppendOptionIds(v []uuid.UUID) *VoteUpdateOne {
+	_u.mutation.AppendOptionIds(v)
+	return _u
+}
+
+// ClearOptionIds clears the value of the "option_ids" field.
+func (_u *VoteUpdateOne) ClearOptionIds() *VoteUpdateOne {
+	_u.mutation.ClearOptionIds()
+	return _u
+}
+
+// SetLegacyOption sets the "legacy_option" field.
+func (_u *VoteUpdateOne) SetLegacyOption(v string) *VoteUpdateOne {
+	_u.mutation.SetLegacyOption(v)
+	return _u
+}
+
+// SetNillableLegacyOption sets the "legacy_option" field if the given value is not nil.
+func (_u *VoteUpdateOne) SetNillableLegacyOption(v *string) *VoteUpdateOne {
+	if v != nil {
+		_u.SetLegacyOption(*v)
+	}
+	return _u
+}
+
+// ClearLegacyOption clears the value of the "legacy_option" field.
+func (_u *VoteUpdateOne) ClearLegacyOption() *VoteUpdateOne {
+	_u.mutation.ClearLegacyOption()
+	return _u
+}
+
+// SetLegacyChoices sets the "legacy_choices" field.
+func (_u *VoteUpdateOne) SetLegacyChoices(v []string) *VoteUpdateOne {
+	_u.mutation.SetLegacyChoices(v)
+	return _u
+}
+
+// AppendLegacyChoices appends value to the "legacy_choices" field.
+func (_u *VoteUpdateOne) AppendLegacyChoices(v []string) *VoteUpdateOne {
+	_u.mutation.AppendLegacyChoices(v)
+	return _u
+}
+
+// ClearLegacyChoices clears the value of the "legacy_choices" field.
+func (_u *VoteUpdateOne) ClearLegacyChoices() *VoteUpdateOne {
+	_u.mutation.ClearLegacyChoices()
+	return _u
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (_u *VoteUpdateOne) SetCreatedAt(v time.Time) *VoteUpdateOne {
 	_u.mutation.SetCreatedAt(v)
@@ -319,6 +505,11 @@ func (_u *VoteUpdateOne) SetPoll(v *Poll) *VoteUpdateOne {
 	return _u.SetPollID(v.ID)
 }
 
+// SetOption sets the "option" edge to the PollOption entity.
+func (_u *VoteUpdateOne) SetOption(v *PollOption) *VoteUpdateOne {
+	return _u.SetOptionID(v.ID)
+}
+
 // Mutation returns the VoteMutation object of the builder.
 func (_u *VoteUpdateOne) Mutation() *VoteMutation {
 	return _u.mutation
@@ -336,6 +527,12 @@ func (_u *VoteUpdateOne) ClearPoll() *VoteUpdateOne {
 	return _u
 }
 
+// ClearOption clears the "option" edge to the PollOption entity.
+func (_u *VoteUpdateOne) ClearOption() *VoteUpdateOne {
+	_u.mutation.ClearOption()
+	return _u
+}
+
 // Where appends a list predicates to the VoteUpdate builder.
 func (_u *VoteUpdateOne) Where(ps ...predicate.Vote) *VoteUpdateOne {
 	_u.mutation.Where(ps...)
@@ -378,11 +575,6 @@ func (_u *VoteUpdateOne) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (_u *VoteUpdateOne) check() error {
-	if v, ok := _u.mutation.Option(); ok {
-		if err := vote.OptionValidator(v); err != nil {
-			return &ValidationError{Name: "option", err: fmt.Errorf(`ent: validator failed for field "Vote.option": %w`, err)}
-		}
-	}
 	if _u.mutation.UserCleared() && len(_u.mutation.UserIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.user"`)
 	}
@@ -421,8 +613,33 @@ func (_u *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error) {
 			}
 		}
 	}
-	if value, ok := _u.mutation.Option(); ok {
-		_spec.SetField(vote.FieldOption, field.TypeString, value)
+	if value, ok := _u.mutation.OptionIds(); ok {
+		_spec.SetField(vote.FieldOptionIds, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedOptionIds(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, vote.FieldOptionIds, value)
+		})
+	}
+	if _u.mutation.OptionIdsCleared() {
+		_spec.ClearField(vote.FieldOptionIds, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.LegacyOption(); ok {
+		_spec.SetField(vote.FieldLegacyOption, field.TypeString, value)
+	}
+	if _u.mutation.LegacyOptionCleared() {
+		_spec.ClearField(vote.FieldLegacyOption, field.TypeString)
+	}
+	if value, ok := _u.mutation.LegacyChoices(); ok {
+		_spec.SetField(vote.FieldLegacyChoices, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedLegacyChoices(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, vote.FieldLegacyChoices, value)
+		})
+	}
+	if _u.mutation.LegacyChoicesCleared() {
+		_spec.ClearField(vote.FieldLegacyChoices, field.TypeJSON)
 	}
 	if value, ok := _u.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
@@ -485,6 +702,35 @@ func (_u *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.OptionCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   vote.OptionTable,
+			Columns: []string{vote.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.OptionIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: false,
+			Table:   vote.OptionTable,
+			Columns: []string{vote.OptionColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(polloption.FieldID, field.TypeUUID),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Vote{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
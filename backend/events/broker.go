@@ -0,0 +1,208 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// keyPrefix names both the event stream and the pub/sub channel of a poll
+	keyPrefix = "poll-events:"
+	// streamMaxLen is roughly how many past events of a poll are kept for replay
+	streamMaxLen = 1000
+	// streamTTL is how long the events of a quiet poll are kept for replay
+	streamTTL = time.Hour
+	// subscriptionBuffer is how many events a slow subscriber may fall behind
+	// before it is dropped
+	subscriptionBuffer = 64
+)
+
+// Broker publishes poll events through Redis and delivers them to the
+// subscribers of this process. Each event is appended to a capped per-poll
+// stream, which assigns its ID and lets reconnecting clients catch up, and
+// announced on a pub/sub channel that every replica listens to.
+type Broker struct {
+	rdb *redis.Client
+
+	mu   sync.Mutex
+	subs map[uuid.UUID]map[*Subscription]struct{}
+}
+
+// NewBroker creates a broker. Run must be running for subscribers to receive events.
+func NewBroker(rdb *redis.Client) *Broker {
+	return &Broker{
+		rdb:  rdb,
+		subs: make(map[uuid.UUID]map[*Subscription]struct{}),
+	}
+}
+
+// Publish records the event and announces it to every replica
+func (b *Broker) Publish(ctx context.Context, pollID uuid.UUID, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	key := keyPrefix + pollID.String()
+	pipe := b.rdb.TxPipeline()
+	add := pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: streamMaxLen,
+		Approx: true,
+		Values: map[string]any{"type": eventType, "data": payload},
+	})
+	pipe.Expire(ctx, key, streamTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record %s event: %w", eventType, err)
+	}
+
+	msg, err := json.Marshal(Event{ID: add.Val(), Type: eventType, PollID: pollID, Data: payload})
+	if err != nil {
+		return err
+	}
+	return b.rdb.Publish(ctx, key, msg).Err()
+}
+
+// Announce delivers a transient event to the current subscribers of every
+// replica. Unlike published events it is not recorded, has no ID and is not
+// replayed to reconnecting clients.
+func (b *Broker) Announce(ctx context.Context, pollID uuid.UUID, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+
+	msg, err := json.Marshal(Event{Type: eventType, PollID: pollID, Data: payload})
+	if err != nil {
+		return err
+	}
+	return b.rdb.Publish(ctx, keyPrefix+pollID.String(), msg).Err()
+}
+
+// Since returns the poll's events after lastID, oldest first. ok is false if
+// lastID is not a retained event of the poll, so the events since then cannot
+// be replayed.
+func (b *Broker) Since(ctx context.Context, pollID uuid.UUID, lastID string) (events []Event, ok bool, err error) {
+	if _, _, valid := parseID(lastID); !valid {
+		return nil, false, nil
+	}
+
+	key := keyPrefix + pollID.String()
+	last, err := b.rdb.XRange(ctx, key, lastID, lastID).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(last) == 0 {
+		return nil, false, nil
+	}
+
+	msgs, err := b.rdb.XRange(ctx, key, "("+lastID, "+").Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	events = make([]Event, 0, len(msgs))
+	for _, msg := range msgs {
+		eventType, _ := msg.Values["type"].(string)
+		data, _ := msg.Values["data"].(string)
+		events = append(events, Event{ID: msg.ID, Type: eventType, PollID: pollID, Data: json.RawMessage(data)})
+	}
+	return events, true, nil
+}
+
+// Run receives the events of every poll from Redis and delivers them to the
+// local subscribers until ctx is done
+func (b *Broker) Run(ctx context.Context) {
+	pubsub := b.rdb.PSubscribe(ctx, keyPrefix+"*")
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Dropping malformed event on %s: %v", msg.Channel, err)
+				continue
+			}
+			b.deliver(event)
+		}
+	}
+}
+
+// deliver hands the event to the poll's subscribers. Subscribers that are too
+// far behind are dropped; their clients reconnect and catch up by event ID.
+func (b *Broker) deliver(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs[event.PollID] {
+		select {
+		case sub.ch <- event:
+		default:
+			b.remove(sub)
+		}
+	}
+}
+
+// Subscription receives the events of one poll
+type Subscription struct {
+	// C delivers the events; it is closed when the subscription is closed or
+	// dropped for falling behind
+	C <-chan Event
+
+	ch     chan Event
+	broker *Broker
+	pollID uuid.UUID
+}
+
+// Subscribe starts receiving the events of a poll
+func (b *Broker) Subscribe(pollID uuid.UUID) *Subscription {
+	ch := make(chan Event, subscriptionBuffer)
+	sub := &Subscription{C: ch, ch: ch, broker: b, pollID: pollID}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs[pollID] == nil {
+		b.subs[pollID] = make(map[*Subscription]struct{})
+	}
+	b.subs[pollID][sub] = struct{}{}
+
+	return sub
+}
+
+// Close stops the subscription
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.remove(s)
+}
+
+// remove unregisters the subscription and closes its channel; b.mu must be held
+func (b *Broker) remove(sub *Subscription) {
+	subs, ok := b.subs[sub.pollID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subs, sub.pollID)
+	}
+	close(sub.ch)
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestBroker returns a broker backed by an in-memory Redis
+func newTestBroker(t *testing.T) (*Broker, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewBroker(rdb), rdb
+}
+
+func TestBrokerSince(t *testing.T) {
+	ctx := context.Background()
+	b, _ := newTestBroker(t)
+	pollID := uuid.New()
+
+	for _, eventType := range []string{TypeVotes, TypePoll, TypeStatus} {
+		if err := b.Publish(ctx, pollID, eventType, struct{}{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Neither announced events nor those of other polls are replayed
+	if err := b.Announce(ctx, pollID, TypePresence, Presence{Viewers: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Publish(ctx, uuid.New(), TypeVotes, struct{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := b.rdb.XRange(ctx, keyPrefix+pollID.String(), "-", "+").Result()
+	if err != nil || len(first) != 3 {
+		t.Fatalf("stream = %v, %v, want 3 events", first, err)
+	}
+
+	tests := []struct {
+		name   string
+		lastID string
+		ok     bool
+		want   []string
+	}{
+		{"first event", first[0].ID, true, []string{TypePoll, TypeStatus}},
+		{"last event", first[2].ID, true, []string{}},
+		{"expired event", "1-0", false, nil},
+		{"zero ID", "0-0", false, nil},
+		{"malformed ID", "latest", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, ok, err := b.Since(ctx, pollID, tt.lastID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if len(events) != len(tt.want) {
+				t.Fatalf("events = %v, want types %v", events, tt.want)
+			}
+			for i, e := range events {
+				if e.Type != tt.want[i] || e.PollID != pollID || CompareIDs(e.ID, tt.lastID) <= 0 {
+					t.Errorf("event %d = %+v, want a %s event after %s", i, e, tt.want[i], tt.lastID)
+				}
+			}
+		})
+	}
+}
+
+func TestBrokerDeliver(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// A second broker on the same Redis stands for another replica
+	b, rdb := newTestBroker(t)
+	other := NewBroker(rdb)
+	go other.Run(ctx)
+
+	pollID := uuid.New()
+	sub := other.Subscribe(pollID)
+	defer sub.Close()
+	unrelated := other.Subscribe(uuid.New())
+	defer unrelated.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		n, err := rdb.PubSubNumPat(ctx).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the other broker does not listen for events")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := b.Publish(ctx, pollID, TypeVotes, Counts{Voters: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Announce(ctx, pollID, TypePresence, Presence{Viewers: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []struct {
+		eventType string
+		hasID     bool
+	}{{TypeVotes, true}, {TypePresence, false}} {
+		select {
+		case e := <-sub.C:
+			if e.Type != want.eventType || (e.ID != "") != want.hasID {
+				t.Fatalf("received %+v, want a %s event with ID %v", e, want.eventType, want.hasID)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("no %s event delivered", want.eventType)
+		}
+	}
+
+	select {
+	case e := <-unrelated.C:
+		t.Fatalf("subscriber of another poll received %+v", e)
+	default:
+	}
+
+	sub.Close()
+	if _, open := <-sub.C; open {
+		t.Fatal("closed subscription still delivers")
+	}
+}
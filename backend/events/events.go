@@ -0,0 +1,122 @@
+// Package events carries live poll updates from the replica that handled a
+// write to every client watching the poll, whichever replica it is connected to.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Event types published for a poll
+const (
+	// TypeVotes is published when the vote counts change; Data holds Counts
+	TypeVotes = "votes"
+	// TypePoll is published when the poll is edited; Data holds PollChange
+	TypePoll = "poll"
+	// TypeStatus is published when the poll closes; Data holds StatusChange
+	TypeStatus = "status"
+	// TypeDeleted is published when the poll is deleted; Data is empty
+	TypeDeleted = "deleted"
+	// TypePresence is announced when viewers come and go; Data holds Presence
+	TypePresence = "presence"
+	// TypeVoted is announced when a user casts or retracts their vote on a
+	// poll whose results are shown after voting; Data holds Voted
+	TypeVoted = "voted"
+)
+
+// Event is a change to a poll. ID orders the events of a poll and is empty
+// for events that were not published through a Broker.
+type Event struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	PollID uuid.UUID       `json:"poll_id"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Counts holds the live tallies of a poll, keyed by option ID
+type Counts struct {
+	Counts map[string]int `json:"counts"`
+	Voters int            `json:"voters"`
+}
+
+// PollChange identifies the edited poll's new version
+type PollChange struct {
+	Version int `json:"version"`
+}
+
+// StatusChange holds the poll's new lifecycle status
+type StatusChange struct {
+	Status string `json:"status"`
+}
+
+// Presence holds how many users are viewing a poll
+type Presence struct {
+	Viewers int `json:"viewers"`
+}
+
+// Voted identifies the user whose vote changed, so their own streams can
+// check again whether they may see the results
+type Voted struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// Publisher publishes poll events
+type Publisher interface {
+	Publish(ctx context.Context, pollID uuid.UUID, eventType string, data any) error
+	Announce(ctx context.Context, pollID uuid.UUID, eventType string, data any) error
+}
+
+// Discard is a Publisher that drops every event, for processes nobody watches
+var Discard Publisher = discard{}
+
+type discard struct{}
+
+func (discard) Publish(context.Context, uuid.UUID, string, any) error {
+	return nil
+}
+
+func (discard) Announce(context.Context, uuid.UUID, string, any) error {
+	return nil
+}
+
+// CompareIDs orders two event IDs of the same poll like strings.Compare.
+// IDs are Redis stream IDs of the form <milliseconds>-<sequence>.
+func CompareIDs(a, b string) int {
+	aMillis, aSeq, _ := parseID(a)
+	bMillis, bSeq, _ := parseID(b)
+	if aMillis != bMillis {
+		return compare(aMillis, bMillis)
+	}
+	return compare(aSeq, bSeq)
+}
+
+// parseID splits an event ID into its time and sequence parts
+func parseID(id string) (millis, seq uint64, ok bool) {
+	m, s, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	millis, err := strconv.ParseUint(m, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err = strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return millis, seq, true
+}
+
+func compare(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
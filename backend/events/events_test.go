@@ -0,0 +1,23 @@
+package events
+
+import "testing"
+
+func TestCompareIDs(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"5-0", "5-0", 0},
+		{"5-1", "5-0", 1},
+		{"5-9", "5-10", -1},
+		{"9-0", "10-0", -1},
+		{"10-0", "9-99", 1},
+		{"", "1-0", -1},
+	}
+
+	for _, tt := range tests {
+		if got := CompareIDs(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareIDs(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
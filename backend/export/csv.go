@@ -0,0 +1,56 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvHeader names the columns of CSV exports. Every row is either a total or
+// a ballot, told apart by the record column, so the file stays one table.
+var csvHeader = []string{"record", "option_id", "option", "votes", "username", "choices", "voted_at"}
+
+// csvEncoder writes exports as CSV
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) *csvEncoder {
+	return &csvEncoder{w: csv.NewWriter(w)}
+}
+
+func (e *csvEncoder) WritePoll(p Poll) error {
+	if err := e.w.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, opt := range p.Options {
+		if err := e.w.Write([]string{"total", opt.ID.String(), csvText(opt.Label), strconv.Itoa(opt.Votes), "", "", ""}); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) WriteBallot(b Ballot) error {
+	choices := make([]string, 0, len(b.Choices))
+	for _, choice := range b.Choices {
+		choices = append(choices, csvText(choice))
+	}
+	return e.w.Write([]string{"ballot", "", "", "", csvText(b.Username), strings.Join(choices, "; "), b.VotedAt.UTC().Format(timeFormat)})
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvText neutralizes user-provided text that spreadsheets would evaluate as
+// a formula when the CSV is opened
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+// Package export encodes poll results for download in several formats. The
+// encoders write as they go, so ballots can be streamed one at a time without
+// holding a whole poll in memory.
+package export
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatXLSX   Format = "xlsx"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatCSV, FormatJSON, FormatNDJSON, FormatXLSX}
+
+// ParseFormat returns the format named s
+func ParseFormat(s string) (Format, error) {
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown export format %q", s)
+}
+
+// ContentType returns the media type of files in the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "application/json"
+}
+
+// Poll summarizes the exported poll and its per-option totals
+type Poll struct {
+	ID        uuid.UUID
+	Title     string
+	Type      string
+	Status    string
+	Anonymous bool
+	// Voters is the number of users who voted
+	Voters     int
+	ExportedAt time.Time
+	Options    []Option
+}
+
+// Option is the total of one option. For approval polls Votes counts
+// approvals, otherwise first choices.
+type Option struct {
+	ID    uuid.UUID
+	Label string
+	Votes int
+}
+
+// Ballot is one voter's ballot. Ballots of anonymous polls are never exported.
+type Ballot struct {
+	Username string
+	// Choices are the labels of the chosen options, in ballot order
+	Choices []string
+	VotedAt time.Time
+}
+
+// Encoder writes a poll export. WritePoll is called once, followed by
+// WriteBallot for every ballot and finally Close.
+type Encoder interface {
+	WritePoll(p Poll) error
+	WriteBallot(b Ballot) error
+	// Close completes the export; it does not close the underlying writer
+	Close() error
+}
+
+// NewEncoder returns an encoder writing the format to w
+func NewEncoder(format Format, w io.Writer) Encoder {
+	switch format {
+	case FormatCSV:
+		return newCSVEncoder(w)
+	case FormatNDJSON:
+		return newNDJSONEncoder(w)
+	case FormatXLSX:
+		return newXLSXEncoder(w)
+	}
+	return newJSONEncoder(w)
+}
+
+// timeFormat is how timestamps are written in text formats
+const timeFormat = time.RFC3339
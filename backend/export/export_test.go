@@ -0,0 +1,198 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// testPoll returns a poll with two options whose labels need escaping
+func testPoll(anonymous bool) Poll {
+	return Poll{
+		ID:         uuid.New(),
+		Title:      "Lunch",
+		Type:       "single",
+		Status:     "closed",
+		Anonymous:  anonymous,
+		Voters:     2,
+		ExportedAt: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC),
+		Options: []Option{
+			{ID: uuid.New(), Label: "=HYPERLINK(\"x\")", Votes: 1},
+			{ID: uuid.New(), Label: "Fish & <chips>", Votes: 1},
+		},
+	}
+}
+
+// testBallots returns a ballot for each option of testPoll
+func testBallots() []Ballot {
+	votedAt := time.Date(2026, 5, 1, 11, 0, 0, 0, time.UTC)
+	return []Ballot{
+		{Username: "ann", Choices: []string{"=HYPERLINK(\"x\")"}, VotedAt: votedAt},
+		{Username: "bob", Choices: []string{"Fish & <chips>", "=HYPERLINK(\"x\")"}, VotedAt: votedAt},
+	}
+}
+
+// encode writes the poll and, unless it is anonymous, its ballots
+func encode(t *testing.T, format Format, p Poll) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := NewEncoder(format, &buf)
+	if err := enc.WritePoll(p); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Anonymous {
+		for _, b := range testBallots() {
+			if err := enc.WriteBallot(b); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := enc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		if got, err := ParseFormat(string(f)); err != nil || got != f {
+			t.Errorf("ParseFormat(%q) = %q, %v", f, got, err)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("ParseFormat accepted pdf")
+	}
+}
+
+func TestEncoders(t *testing.T) {
+	tests := []struct {
+		format Format
+		// records counts the totals and ballots of an export
+		records func(t *testing.T, data []byte) (totals, ballots int)
+	}{
+		{FormatCSV, csvRecords},
+		{FormatJSON, jsonRecords},
+		{FormatNDJSON, ndjsonRecords},
+		{FormatXLSX, xlsxRecords},
+	}
+
+	for _, tt := range tests {
+		for _, anonymous := range []bool{false, true} {
+			name := string(tt.format)
+			if anonymous {
+				name += " anonymous"
+			}
+			t.Run(name, func(t *testing.T) {
+				totals, ballots := tt.records(t, encode(t, tt.format, testPoll(anonymous)))
+				wantBallots := len(testBallots())
+				if anonymous {
+					wantBallots = 0
+				}
+				if totals != 2 || ballots != wantBallots {
+					t.Fatalf("export has %d totals and %d ballots, want 2 and %d", totals, ballots, wantBallots)
+				}
+			})
+		}
+	}
+}
+
+func csvRecords(t *testing.T, data []byte) (totals, ballots int) {
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range rows[1:] {
+		switch row[0] {
+		case "total":
+			totals++
+			if row[2] == "=HYPERLINK(\"x\")" {
+				t.Errorf("formula label %q is not neutralized", row[2])
+			}
+		case "ballot":
+			ballots++
+		}
+	}
+	return totals, ballots
+}
+
+func jsonRecords(t *testing.T, data []byte) (totals, ballots int) {
+	var doc struct {
+		Poll    jsonPoll     `json:"poll"`
+		Totals  []jsonTotal  `json:"totals"`
+		Ballots []jsonBallot `json:"ballots"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	if doc.Poll.Title != "Lunch" || doc.Totals[1].Option != "Fish & <chips>" {
+		t.Errorf("document = %+v", doc)
+	}
+	return len(doc.Totals), len(doc.Ballots)
+}
+
+func ndjsonRecords(t *testing.T, data []byte) (totals, ballots int) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for i := 0; scanner.Scan(); i++ {
+		var record struct {
+			Record string `json:"record"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid line %s: %v", scanner.Bytes(), err)
+		}
+		switch {
+		case i == 0 && record.Record != "poll":
+			t.Fatalf("first record is a %s", record.Record)
+		case record.Record == "total":
+			totals++
+		case record.Record == "ballot":
+			ballots++
+		}
+	}
+	return totals, ballots
+}
+
+func xlsxRecords(t *testing.T, data []byte) (totals, ballots int) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := make(map[string]int)
+	for _, f := range zr.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Every part must be well-formed XML
+		dec := xml.NewDecoder(r)
+		for {
+			tok, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", f.Name, err)
+			}
+			if el, ok := tok.(xml.StartElement); ok && el.Name.Local == "row" {
+				rows[f.Name]++
+			}
+		}
+		r.Close()
+	}
+
+	// Totals has a header, the options, a blank row and the voters; Ballots a
+	// header and the ballots
+	totals = rows["xl/worksheets/sheet1.xml"] - 3
+	if n, ok := rows["xl/worksheets/sheet2.xml"]; ok {
+		ballots = n - 1
+	}
+	return totals, ballots
+}
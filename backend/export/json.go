@@ -0,0 +1,158 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// jsonPoll is the poll as written in JSON and NDJSON exports
+type jsonPoll struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	Type       string    `json:"type"`
+	Status     string    `json:"status"`
+	Anonymous  bool      `json:"anonymous"`
+	Voters     int       `json:"voters"`
+	ExportedAt time.Time `json:"exported_at"`
+}
+
+// jsonTotal is an option total as written in JSON and NDJSON exports
+type jsonTotal struct {
+	OptionID uuid.UUID `json:"option_id"`
+	Option   string    `json:"option"`
+	Votes    int       `json:"votes"`
+}
+
+// jsonBallot is a ballot as written in JSON and NDJSON exports
+type jsonBallot struct {
+	Username string    `json:"username"`
+	Choices  []string  `json:"choices"`
+	VotedAt  time.Time `json:"voted_at"`
+}
+
+func newJSONPoll(p Poll) jsonPoll {
+	return jsonPoll{
+		ID:         p.ID,
+		Title:      p.Title,
+		Type:       p.Type,
+		Status:     p.Status,
+		Anonymous:  p.Anonymous,
+		Voters:     p.Voters,
+		ExportedAt: p.ExportedAt.UTC(),
+	}
+}
+
+func newJSONTotals(p Poll) []jsonTotal {
+	totals := make([]jsonTotal, 0, len(p.Options))
+	for _, opt := range p.Options {
+		totals = append(totals, jsonTotal{OptionID: opt.ID, Option: opt.Label, Votes: opt.Votes})
+	}
+	return totals
+}
+
+func newJSONBallot(b Ballot) jsonBallot {
+	return jsonBallot{Username: b.Username, Choices: b.Choices, VotedAt: b.VotedAt.UTC()}
+}
+
+// jsonEncoder writes exports as one JSON document with poll, totals and,
+// unless the poll is anonymous, ballots members. The ballots array is written
+// element by element.
+type jsonEncoder struct {
+	w         *bufio.Writer
+	anonymous bool
+	ballots   int
+}
+
+func newJSONEncoder(w io.Writer) *jsonEncoder {
+	return &jsonEncoder{w: bufio.NewWriter(w)}
+}
+
+func (e *jsonEncoder) WritePoll(p Poll) error {
+	e.anonymous = p.Anonymous
+
+	poll, err := json.Marshal(newJSONPoll(p))
+	if err != nil {
+		return err
+	}
+	totals, err := json.Marshal(newJSONTotals(p))
+	if err != nil {
+		return err
+	}
+
+	e.w.WriteString(`{"poll":`)
+	e.w.Write(poll)
+	e.w.WriteString(`,"totals":`)
+	e.w.Write(totals)
+	if !e.anonymous {
+		e.w.WriteString(`,"ballots":[`)
+	}
+	return e.w.Flush()
+}
+
+func (e *jsonEncoder) WriteBallot(b Ballot) error {
+	ballot, err := json.Marshal(newJSONBallot(b))
+	if err != nil {
+		return err
+	}
+
+	if e.ballots > 0 {
+		e.w.WriteByte(',')
+	}
+	e.ballots++
+	_, err = e.w.Write(ballot)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if !e.anonymous {
+		e.w.WriteByte(']')
+	}
+	e.w.WriteString("}\n")
+	return e.w.Flush()
+}
+
+// ndjsonEncoder writes exports as newline-delimited JSON: one poll record,
+// one total record per option, then one ballot record per ballot
+type ndjsonEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	bw := bufio.NewWriter(w)
+	return &ndjsonEncoder{w: bw, enc: json.NewEncoder(bw)}
+}
+
+func (e *ndjsonEncoder) WritePoll(p Poll) error {
+	if err := e.enc.Encode(struct {
+		Record string `json:"record"`
+		jsonPoll
+	}{"poll", newJSONPoll(p)}); err != nil {
+		return err
+	}
+
+	for _, total := range newJSONTotals(p) {
+		if err := e.enc.Encode(struct {
+			Record string `json:"record"`
+			jsonTotal
+		}{"total", total}); err != nil {
+			return err
+		}
+	}
+	return e.w.Flush()
+}
+
+func (e *ndjsonEncoder) WriteBallot(b Ballot) error {
+	return e.enc.Encode(struct {
+		Record string `json:"record"`
+		jsonBallot
+	}{"ballot", newJSONBallot(b)})
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return e.w.Flush()
+}
@@ -0,0 +1,158 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// XLSX exports are Office Open XML workbooks with a Totals sheet and, unless
+// the poll is anonymous, a Ballots sheet. Cells hold inline strings and plain
+// numbers, so no shared string table or styles are needed and rows can be
+// written as they arrive.
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>%s</Types>`
+	xlsxSheetContentType = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>%s</sheets></workbook>`
+	xlsxWorkbookSheet = `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">%s</Relationships>`
+	xlsxWorkbookRel = `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`
+
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxEncoder writes exports as XLSX workbooks
+type xlsxEncoder struct {
+	zw *zip.Writer
+	// sheet is the worksheet being written, nil between sheets
+	sheet  *bufio.Writer
+	sheets []string
+}
+
+func newXLSXEncoder(w io.Writer) *xlsxEncoder {
+	return &xlsxEncoder{zw: zip.NewWriter(w)}
+}
+
+func (e *xlsxEncoder) WritePoll(p Poll) error {
+	if err := e.startSheet("Totals"); err != nil {
+		return err
+	}
+	e.row(xlsxString("Option ID"), xlsxString("Option"), xlsxString("Votes"))
+	for _, opt := range p.Options {
+		e.row(xlsxString(opt.ID.String()), xlsxString(opt.Label), xlsxNumber(opt.Votes))
+	}
+	e.row()
+	e.row(xlsxString("Voters"), xlsxString(""), xlsxNumber(p.Voters))
+	if err := e.endSheet(); err != nil {
+		return err
+	}
+
+	if p.Anonymous {
+		return nil
+	}
+	if err := e.startSheet("Ballots"); err != nil {
+		return err
+	}
+	e.row(xlsxString("Username"), xlsxString("Choices"), xlsxString("Voted at"))
+	return e.sheet.Flush()
+}
+
+func (e *xlsxEncoder) WriteBallot(b Ballot) error {
+	if e.sheet == nil {
+		return fmt.Errorf("no ballots sheet to write to")
+	}
+	e.row(xlsxString(b.Username), xlsxString(strings.Join(b.Choices, "; ")), xlsxString(b.VotedAt.UTC().Format(timeFormat)))
+	return nil
+}
+
+func (e *xlsxEncoder) Close() error {
+	if e.sheet != nil {
+		if err := e.endSheet(); err != nil {
+			return err
+		}
+	}
+
+	var contentTypes, sheets, rels strings.Builder
+	for i, name := range e.sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, xlsxSheetContentType, n)
+		fmt.Fprintf(&sheets, xlsxWorkbookSheet, name, n, n)
+		fmt.Fprintf(&rels, xlsxWorkbookRel, n, n)
+	}
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", fmt.Sprintf(xlsxContentTypes, contentTypes.String())},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, sheets.String())},
+		{"xl/_rels/workbook.xml.rels", fmt.Sprintf(xlsxWorkbookRels, rels.String())},
+	}
+	for _, part := range parts {
+		w, err := e.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return err
+		}
+	}
+	return e.zw.Close()
+}
+
+// startSheet begins the next worksheet of the workbook
+func (e *xlsxEncoder) startSheet(name string) error {
+	e.sheets = append(e.sheets, name)
+	w, err := e.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(e.sheets)))
+	if err != nil {
+		return err
+	}
+	e.sheet = bufio.NewWriter(w)
+	e.sheet.WriteString(xlsxSheetStart)
+	return nil
+}
+
+// endSheet completes the current worksheet
+func (e *xlsxEncoder) endSheet() error {
+	e.sheet.WriteString(xlsxSheetEnd)
+	err := e.sheet.Flush()
+	e.sheet = nil
+	return err
+}
+
+// row writes a row of cells to the current worksheet. Write errors surface
+// when the sheet is flushed.
+func (e *xlsxEncoder) row(cells ...string) {
+	e.sheet.WriteString("<row>")
+	for _, cell := range cells {
+		e.sheet.WriteString(cell)
+	}
+	e.sheet.WriteString("</row>")
+}
+
+// xlsxString returns a cell holding text
+func xlsxString(s string) string {
+	var b strings.Builder
+	b.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	xml.EscapeText(&b, []byte(s))
+	b.WriteString(`</t></is></c>`)
+	return b.String()
+}
+
+// xlsxNumber returns a cell holding a number
+func xlsxNumber(n int) string {
+	return "<c><v>" + strconv.Itoa(n) + "</v></c>"
+}
@@ -0,0 +1,126 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"poll-app/service"
+)
+
+// CSV import files have a header row naming their columns, in any order.
+// Each further row defines one poll; its options are the non-empty cells of
+// the option columns, of which there may be as many as needed.
+const csvOptionColumn = "option"
+
+// csvColumns are the columns CSV import files may have besides options
+var csvColumns = []string{
+	"external_id", "title", "description", "type", "anonymous",
+	"results_visibility", "require_verified_email", "min_choices", "max_choices",
+	"opens_at", "closes_at",
+}
+
+// readCSV reads the polls of a CSV import file
+func readCSV(r io.Reader) ([]pollDefinition, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, invalidFile(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	var optionColumns []int
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case name == csvOptionColumn:
+			optionColumns = append(optionColumns, i)
+		case !isCSVColumn(name):
+			return nil, service.NewValidationError("invalid_body", fmt.Sprintf("Invalid import file: unknown column %q", name))
+		default:
+			if _, ok := columns[name]; ok {
+				return nil, service.NewValidationError("invalid_body", fmt.Sprintf("Invalid import file: column %q appears more than once", name))
+			}
+			columns[name] = i
+		}
+	}
+
+	var polls []pollDefinition
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidFile(err)
+		}
+
+		prefix := fmt.Sprintf("polls[%d]", len(polls))
+		cell := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		p := pollDefinition{
+			ExternalID:        cell("external_id"),
+			Title:             cell("title"),
+			Description:       cell("description"),
+			Type:              cell("type"),
+			ResultsVisibility: cell("results_visibility"),
+			OpensAt:           cell("opens_at"),
+			ClosesAt:          cell("closes_at"),
+		}
+		for _, i := range optionColumns {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				p.Options = append(p.Options, optionDefinition{Label: strings.TrimSpace(record[i])})
+			}
+		}
+
+		if v := cell("anonymous"); v != "" {
+			if p.Anonymous, err = strconv.ParseBool(v); err != nil {
+				p.errs = append(p.errs, service.FieldError{Field: prefix + ".anonymous", Code: "invalid_value", Message: "anonymous must be true or false"})
+			}
+		}
+		if v := cell("require_verified_email"); v != "" {
+			if p.RequireVerifiedEmail, err = strconv.ParseBool(v); err != nil {
+				p.errs = append(p.errs, service.FieldError{Field: prefix + ".require_verified_email", Code: "invalid_value", Message: "require_verified_email must be true or false"})
+			}
+		}
+		if v := cell("min_choices"); v != "" {
+			if p.MinChoices, err = strconv.Atoi(v); err != nil {
+				p.errs = append(p.errs, service.FieldError{Field: prefix + ".min_choices", Code: "invalid_value", Message: "min_choices must be an integer"})
+			}
+		}
+		if v := cell("max_choices"); v != "" {
+			maxChoices, err := strconv.Atoi(v)
+			if err != nil {
+				p.errs = append(p.errs, service.FieldError{Field: prefix + ".max_choices", Code: "invalid_value", Message: "max_choices must be an integer"})
+			}
+			p.MaxChoices = &maxChoices
+		}
+
+		polls = append(polls, p)
+	}
+
+	return polls, nil
+}
+
+// isCSVColumn reports whether name is a known column other than option
+func isCSVColumn(name string) bool {
+	for _, column := range csvColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
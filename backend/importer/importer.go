@@ -0,0 +1,206 @@
+// Package importer reads poll definitions from JSON, YAML and CSV import
+// files. Problems are reported per poll, with field names such as
+// polls[2].opens_at, so a whole file can be fixed in one pass.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"poll-app/ent/poll"
+	"poll-app/service"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an import file format
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+// ParseFormat returns the format named s
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "csv":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("unknown import format %q", s)
+}
+
+// FormatFromContentType returns the format of a request body by its media type
+func FormatFromContentType(contentType string) (Format, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("invalid content type %q", contentType)
+	}
+
+	switch mediaType {
+	case "application/json":
+		return FormatJSON, nil
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return FormatYAML, nil
+	case "text/csv":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("unsupported content type %q", mediaType)
+}
+
+// FormatFromPath returns the format of a file by its extension
+func FormatFromPath(path string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(path), "."))
+}
+
+// Parse reads the poll definitions of an import file. A file that cannot be
+// read at all fails with an invalid_body validation error; definitions with
+// invalid values fail with an invalid_import error listing every one of them.
+func Parse(format Format, r io.Reader) ([]service.PollDefinition, error) {
+	var file importFile
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(r)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&file); err != nil {
+			return nil, invalidFile(err)
+		}
+	case FormatYAML:
+		dec := yaml.NewDecoder(r)
+		dec.KnownFields(true)
+		if err := dec.Decode(&file); err != nil {
+			return nil, invalidFile(err)
+		}
+	case FormatCSV:
+		polls, err := readCSV(r)
+		if err != nil {
+			return nil, err
+		}
+		file.Polls = polls
+	default:
+		return nil, fmt.Errorf("unknown import format %q", format)
+	}
+
+	definitions := make([]service.PollDefinition, 0, len(file.Polls))
+	var fields []service.FieldError
+	for i, p := range file.Polls {
+		def, errs := p.definition(fmt.Sprintf("polls[%d]", i))
+		fields = append(fields, errs...)
+		definitions = append(definitions, def)
+	}
+	if len(fields) > 0 {
+		return nil, service.NewValidationError("invalid_import", "some polls are invalid", fields...)
+	}
+	return definitions, nil
+}
+
+// invalidFile reports an import file that could not be decoded
+func invalidFile(err error) error {
+	return service.NewValidationError("invalid_body", "Invalid import file: "+err.Error())
+}
+
+// importFile is the structure of JSON and YAML import files
+type importFile struct {
+	Polls []pollDefinition `json:"polls" yaml:"polls"`
+}
+
+// pollDefinition is a poll as written in an import file
+type pollDefinition struct {
+	ExternalID           string             `json:"external_id" yaml:"external_id"`
+	Title                string             `json:"title" yaml:"title"`
+	Description          string             `json:"description" yaml:"description"`
+	Type                 string             `json:"type" yaml:"type"`
+	Options              []optionDefinition `json:"options" yaml:"options"`
+	Anonymous            bool               `json:"anonymous" yaml:"anonymous"`
+	ResultsVisibility    string             `json:"results_visibility" yaml:"results_visibility"`
+	RequireVerifiedEmail bool               `json:"require_verified_email" yaml:"require_verified_email"`
+	MinChoices           int                `json:"min_choices" yaml:"min_choices"`
+	MaxChoices           *int               `json:"max_choices" yaml:"max_choices"`
+	OpensAt              string             `json:"opens_at" yaml:"opens_at"`
+	ClosesAt             string             `json:"closes_at" yaml:"closes_at"`
+
+	// errs are problems found while reading the poll from a CSV row
+	errs []service.FieldError
+}
+
+// optionDefinition is a poll option as written in an import file: either a
+// label or an object with a label and details
+type optionDefinition struct {
+	Label       string `json:"label" yaml:"label"`
+	Description string `json:"description" yaml:"description"`
+	ImageURL    string `json:"image_url" yaml:"image_url"`
+}
+
+func (o *optionDefinition) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &o.Label); err == nil {
+		return nil
+	}
+	type plain optionDefinition
+	return json.Unmarshal(data, (*plain)(o))
+}
+
+func (o *optionDefinition) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&o.Label)
+	}
+	type plain optionDefinition
+	return node.Decode((*plain)(o))
+}
+
+// definition converts the poll to a service.PollDefinition, reporting values
+// that cannot be converted on fields under prefix. Everything else is left to
+// the service to validate.
+func (p pollDefinition) definition(prefix string) (service.PollDefinition, []service.FieldError) {
+	def := service.PollDefinition{
+		ExternalID:  p.ExternalID,
+		Title:       p.Title,
+		Description: p.Description,
+		Settings: service.PollSettings{
+			Type:                 poll.Type(p.Type),
+			Anonymous:            p.Anonymous,
+			ResultsVisibility:    poll.ResultsVisibility(p.ResultsVisibility),
+			RequireVerifiedEmail: p.RequireVerifiedEmail,
+			MinChoices:           p.MinChoices,
+			MaxChoices:           p.MaxChoices,
+		},
+	}
+	for _, opt := range p.Options {
+		def.Options = append(def.Options, service.OptionInput{
+			Label:       opt.Label,
+			Description: opt.Description,
+			ImageURL:    opt.ImageURL,
+		})
+	}
+
+	fields := p.errs
+	var err error
+	if def.Settings.OpensAt, err = parseTime(p.OpensAt); err != nil {
+		fields = append(fields, service.FieldError{Field: prefix + ".opens_at", Code: "invalid_value", Message: "opens_at must be an RFC 3339 timestamp"})
+	}
+	if def.Settings.ClosesAt, err = parseTime(p.ClosesAt); err != nil {
+		fields = append(fields, service.FieldError{Field: prefix + ".closes_at", Code: "invalid_value", Message: "closes_at must be an RFC 3339 timestamp"})
+	}
+	return def, fields
+}
+
+// parseTime parses an optional RFC 3339 timestamp
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
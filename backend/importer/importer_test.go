@@ -0,0 +1,127 @@
+package importer
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"poll-app/ent/poll"
+	"poll-app/service"
+)
+
+func TestFormatFromContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        Format
+		valid       bool
+	}{
+		{"application/json", FormatJSON, true},
+		{"application/yaml", FormatYAML, true},
+		{"text/csv; charset=utf-8", FormatCSV, true},
+		{"text/plain", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, err := FormatFromContentType(tt.contentType)
+		if got != tt.want || (err == nil) != tt.valid {
+			t.Errorf("FormatFromContentType(%q) = %q, %v", tt.contentType, got, err)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		body   string
+		// code is the code of the validation error, if the file is rejected
+		code     string
+		rejected []string
+		check    func(defs []service.PollDefinition) bool
+	}{
+		{
+			name:   "json",
+			format: FormatJSON,
+			body:   `{"polls":[{"external_id":"a","title":"Lunch","type":"approval","options":["Pizza",{"label":"Sushi","description":"raw"}],"max_choices":2,"closes_at":"2026-06-01T12:00:00Z"}]}`,
+			check: func(defs []service.PollDefinition) bool {
+				d := defs[0]
+				return len(defs) == 1 && d.ExternalID == "a" && d.Settings.Type == poll.TypeApproval &&
+					len(d.Options) == 2 && d.Options[1].Description == "raw" && *d.Settings.MaxChoices == 2 &&
+					d.Settings.ClosesAt != nil && d.Settings.OpensAt == nil
+			},
+		},
+		{
+			name:   "yaml",
+			format: FormatYAML,
+			body:   "polls:\n  - title: Lunch\n    anonymous: true\n    options:\n      - Pizza\n      - label: Sushi\n        image_url: https://example.com/sushi.png\n",
+			check: func(defs []service.PollDefinition) bool {
+				d := defs[0]
+				return len(defs) == 1 && d.Settings.Anonymous && d.Options[0].Label == "Pizza" &&
+					d.Options[1].ImageURL == "https://example.com/sushi.png"
+			},
+		},
+		{
+			name:   "csv",
+			format: FormatCSV,
+			body:   "Title,option,anonymous,option,min_choices,option\nLunch,Pizza,true,Sushi,1,\nDinner,Soup,,,,\n",
+			check: func(defs []service.PollDefinition) bool {
+				return len(defs) == 2 && defs[0].Title == "Lunch" && len(defs[0].Options) == 2 &&
+					defs[0].Settings.Anonymous && defs[0].Settings.MinChoices == 1 && len(defs[1].Options) == 1
+			},
+		},
+		{
+			name:   "empty csv",
+			format: FormatCSV,
+			body:   "",
+			check:  func(defs []service.PollDefinition) bool { return len(defs) == 0 },
+		},
+		{
+			name:     "invalid values are reported per poll",
+			format:   FormatJSON,
+			body:     `{"polls":[{"title":"a","opens_at":"tomorrow"},{"title":"b"},{"title":"c","closes_at":"soon"}]}`,
+			code:     "invalid_import",
+			rejected: []string{"polls[0].opens_at", "polls[2].closes_at"},
+		},
+		{
+			name:     "invalid csv cells",
+			format:   FormatCSV,
+			body:     "title,anonymous,max_choices\nLunch,maybe,two\n",
+			code:     "invalid_import",
+			rejected: []string{"polls[0].anonymous", "polls[0].max_choices"},
+		},
+		{name: "unknown json field", format: FormatJSON, body: `{"polls":[{"titel":"a"}]}`, code: "invalid_body"},
+		{name: "unknown yaml field", format: FormatYAML, body: "polls:\n  - titel: a\n", code: "invalid_body"},
+		{name: "unknown csv column", format: FormatCSV, body: "title,colour\n", code: "invalid_body"},
+		{name: "repeated csv column", format: FormatCSV, body: "title,Title\n", code: "invalid_body"},
+		{name: "malformed json", format: FormatJSON, body: `{"polls":`, code: "invalid_body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defs, err := Parse(tt.format, strings.NewReader(tt.body))
+			if tt.check != nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !tt.check(defs) {
+					t.Fatalf("definitions = %+v", defs)
+				}
+				return
+			}
+
+			var svcErr *service.Error
+			if !errors.As(err, &svcErr) || svcErr.Kind != service.KindValidation || svcErr.Code != tt.code {
+				t.Fatalf("err = %v, want a %s validation error", err, tt.code)
+			}
+			var fields []string
+			for _, f := range svcErr.Fields {
+				fields = append(fields, f.Field)
+			}
+			if !slices.Equal(fields, tt.rejected) {
+				t.Errorf("rejected fields = %v, want %v", fields, tt.rejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package mail
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer writes each message to a .eml file in a directory, for
+// deployments where another process picks mail up, and for inspecting mail
+// by hand
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer creates a mailer that writes messages into dir
+func NewFileMailer(dir, from string) *FileMailer {
+	return &FileMailer{dir: dir, from: from}
+}
+
+func (m *FileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := render(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	// Names sort by the time messages were sent
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000Z"), hex.EncodeToString(suffix))
+
+	// Write through a temporary file, so readers never pick up a partial message
+	tmp, err := os.CreateTemp(m.dir, ".mail-*")
+	if err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(m.dir, name)); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	return nil
+}
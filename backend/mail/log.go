@@ -0,0 +1,42 @@
+package mail
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync"
+)
+
+// logMailerCapacity is how many of the latest messages a LogMailer keeps
+const logMailerCapacity = 100
+
+// LogMailer keeps the messages it is given in memory and logs them, so
+// development and tests can read the links they contain without a mail server
+type LogMailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewLogMailer creates a mailer that keeps messages in memory
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	m.mu.Lock()
+	m.messages = append(m.messages, msg)
+	if len(m.messages) > logMailerCapacity {
+		m.messages = slices.Delete(m.messages, 0, len(m.messages)-logMailerCapacity)
+	}
+	m.mu.Unlock()
+
+	log.Printf("Mail to %s: %s\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// Messages returns the kept messages, oldest first
+func (m *LogMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.messages)
+}
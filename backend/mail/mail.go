@@ -0,0 +1,100 @@
+// Package mail sends the emails of user accounts, such as password reset and
+// email verification links. Messages are plain text; the transport is chosen
+// by configuration so development and tests need no mail server.
+package mail
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"poll-app/config"
+)
+
+// Message is an email to one recipient
+type Message struct {
+	To      string
+	Subject string
+	// Body is plain text
+	Body string
+}
+
+// Mailer sends email
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New returns the mailer selected by the configuration
+func New(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Transport {
+	case config.MailTransportSMTP:
+		return NewSMTPMailer(cfg), nil
+	case config.MailTransportFile:
+		return NewFileMailer(cfg.Dir, cfg.From), nil
+	case config.MailTransportLog:
+		return NewLogMailer(), nil
+	}
+	return nil, fmt.Errorf("unknown mail transport %q", cfg.Transport)
+}
+
+// Discard is a Mailer that drops every message, for processes that send none
+var Discard Mailer = discard{}
+
+type discard struct{}
+
+func (discard) Send(context.Context, Message) error {
+	return nil
+}
+
+// render formats msg as an RFC 5322 message from the given sender
+func render(from string, msg Message, now time.Time) ([]byte, error) {
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	recipient, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient address: %w", err)
+	}
+	// Headers are written as given; a line break would start a header of its own
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, errors.New("subject cannot contain line breaks")
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	domain := sender.Address[strings.LastIndexByte(sender.Address, '@')+1:]
+
+	var b bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&b, "%s: %s\r\n", name, value)
+	}
+	header("From", sender.String())
+	header("To", recipient.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", now.Format(time.RFC1123Z))
+	header("Message-ID", fmt.Sprintf("<%s@%s>", hex.EncodeToString(id), domain))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	b.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&b)
+	if _, err := body.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
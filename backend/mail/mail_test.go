@@ -0,0 +1,110 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parse reads a rendered message and decodes its subject and body
+func parse(t *testing.T, data []byte) (*mail.Message, string, string) {
+	t.Helper()
+	m, err := mail.ReadMessage(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(quotedprintable.NewReader(m.Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m, subject, string(body)
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name  string
+		from  string
+		msg   Message
+		valid bool
+	}{
+		{"plain", "Polls <noreply@example.com>", Message{To: "ann@example.com", Subject: "Reset your password", Body: "Hi,\nfollow the link."}, true},
+		{"non-ASCII subject", "noreply@example.com", Message{To: "ann@example.com", Subject: "Vérifiez votre adresse", Body: "Bonjour"}, true},
+		{"invalid sender", "noreply", Message{To: "ann@example.com", Subject: "s"}, false},
+		{"invalid recipient", "noreply@example.com", Message{To: "ann", Subject: "s"}, false},
+		{"header injection", "noreply@example.com", Message{To: "ann@example.com", Subject: "s\r\nBcc: eve@example.com"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := render(tt.from, tt.msg, time.Now())
+			if (err == nil) != tt.valid {
+				t.Fatalf("render = %v, want valid %v", err, tt.valid)
+			}
+			if !tt.valid {
+				return
+			}
+
+			m, subject, body := parse(t, data)
+			if to, err := m.Header.AddressList("To"); err != nil || to[0].Address != tt.msg.To {
+				t.Errorf("To = %v, %v", to, err)
+			}
+			if !strings.HasSuffix(m.Header.Get("Message-ID"), "@example.com>") {
+				t.Errorf("Message-ID = %q", m.Header.Get("Message-ID"))
+			}
+			if subject != tt.msg.Subject {
+				t.Errorf("subject = %q, want %q", subject, tt.msg.Subject)
+			}
+			if want := strings.ReplaceAll(tt.msg.Body, "\n", "\r\n"); body != want {
+				t.Errorf("body = %q, want %q", body, want)
+			}
+		})
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m := NewFileMailer(dir, "noreply@example.com")
+
+	subjects := []string{"first", "second"}
+	for _, subject := range subjects {
+		if err := m.Send(context.Background(), Message{To: "ann@example.com", Subject: subject, Body: "body"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := m.Send(context.Background(), Message{To: "ann", Subject: "invalid"}); err == nil {
+		t.Fatal("Send of an invalid message succeeded")
+	}
+
+	// Only complete messages are left, named in the order they were sent
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(subjects) {
+		t.Fatalf("mail directory holds %d files, want %d", len(entries), len(subjects))
+	}
+	for i, entry := range entries {
+		if filepath.Ext(entry.Name()) != ".eml" {
+			t.Fatalf("unexpected file %s", entry.Name())
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, subject, _ := parse(t, data); subject != subjects[i] {
+			t.Errorf("file %d has subject %q, want %q", i, subject, subjects[i])
+		}
+	}
+}
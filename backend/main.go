@@ -3,7 +3,14 @@ package main
 import (
 	"os"
 
+	configcmd "poll-app/cmd/config"
+	exportcmd "poll-app/cmd/export"
+	importcmd "poll-app/cmd/import"
+	"poll-app/cmd/keys"
+	"poll-app/cmd/migrate"
 	"poll-app/cmd/server"
+	"poll-app/cmd/worker"
+	"poll-app/config"
 
 	"github.com/spf13/cobra"
 )
@@ -15,7 +22,16 @@ func main() {
 		Long:  "A full-stack poll application backend with authentication, poll management, and voting functionality",
 	}
 
+	rootCmd.PersistentFlags().String("config", "", "Path to a YAML config file (defaults to $POLL_APP_CONFIG)")
+	config.BindFlags(rootCmd)
+
 	rootCmd.AddCommand(server.NewServerCommand())
+	rootCmd.AddCommand(worker.NewWorkerCommand())
+	rootCmd.AddCommand(migrate.NewMigrateCommand())
+	rootCmd.AddCommand(configcmd.NewConfigCommand())
+	rootCmd.AddCommand(exportcmd.NewExportCommand())
+	rootCmd.AddCommand(importcmd.NewImportCommand())
+	rootCmd.AddCommand(keys.NewKeysCommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
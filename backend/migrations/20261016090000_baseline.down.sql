@@ -0,0 +1,12 @@
+-- reverse: create index "vote_user_id_poll_id" to table: "votes"
+DROP INDEX "vote_user_id_poll_id";
+-- reverse: create "votes" table
+DROP TABLE "votes";
+-- reverse: create "polls" table
+DROP TABLE "polls";
+-- reverse: create index "users_username_key" to table: "users"
+DROP INDEX "users_username_key";
+-- reverse: create index "users_email_key" to table: "users"
+DROP INDEX "users_email_key";
+-- reverse: create "users" table
+DROP TABLE "users";
@@ -0,0 +1,12 @@
+-- Create "users" table
+CREATE TABLE "users" ("id" uuid NOT NULL, "email" character varying NOT NULL, "username" character varying NOT NULL, "password" character varying NOT NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- Create index "users_email_key" to table: "users"
+CREATE UNIQUE INDEX "users_email_key" ON "users" ("email");
+-- Create index "users_username_key" to table: "users"
+CREATE UNIQUE INDEX "users_username_key" ON "users" ("username");
+-- Create "polls" table
+CREATE TABLE "polls" ("id" uuid NOT NULL, "title" character varying NOT NULL, "description" character varying NULL, "options" jsonb NOT NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "owner_id" uuid NOT NULL, "user_polls" uuid NULL, PRIMARY KEY ("id"), CONSTRAINT "polls_users_owner" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION, CONSTRAINT "polls_users_polls" FOREIGN KEY ("user_polls") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE SET NULL);
+-- Create "votes" table
+CREATE TABLE "votes" ("id" uuid NOT NULL, "option" character varying NOT NULL, "created_at" timestamptz NOT NULL, "user_id" uuid NOT NULL, "poll_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "votes_polls_poll" FOREIGN KEY ("poll_id") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION, CONSTRAINT "votes_users_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "vote_user_id_poll_id" to table: "votes"
+CREATE UNIQUE INDEX "vote_user_id_poll_id" ON "votes" ("user_id", "poll_id");
@@ -0,0 +1,27 @@
+-- Restore the option labels of polls and votes from the options table
+UPDATE "polls" SET "options" = coalesce((SELECT jsonb_agg("poll_options"."label" ORDER BY "poll_options"."position") FROM "poll_options" WHERE "poll_options"."poll_id" = "polls"."id"), '[]') WHERE "options" IS NULL;
+UPDATE "votes" SET "option" = (SELECT "poll_options"."label" FROM "poll_options" WHERE "poll_options"."id" = "votes"."option_id") WHERE "option" IS NULL;
+-- Votes with no first choice left cannot be expressed by a single label
+DELETE FROM "votes" WHERE "option" IS NULL;
+-- reverse: modify "votes" table
+ALTER TABLE "votes" DROP CONSTRAINT "votes_poll_options_option", DROP COLUMN "option_id", DROP COLUMN "choices", DROP COLUMN "option_ids", ALTER COLUMN "option" SET NOT NULL;
+-- reverse: create index "poll_results_poll_id_key" to table: "poll_results"
+DROP INDEX "poll_results_poll_id_key";
+-- reverse: create "poll_results" table
+DROP TABLE "poll_results";
+-- reverse: create index "participation_user_id_poll_id" to table: "participations"
+DROP INDEX "participation_user_id_poll_id";
+-- reverse: create "participations" table
+DROP TABLE "participations";
+-- reverse: create index "jobrun_job_started_at" to table: "job_runs"
+DROP INDEX "jobrun_job_started_at";
+-- reverse: create "job_runs" table
+DROP TABLE "job_runs";
+-- reverse: create "ballots" table
+DROP TABLE "ballots";
+-- reverse: create index "polloption_poll_id_position" to table: "poll_options"
+DROP INDEX "polloption_poll_id_position";
+-- reverse: create "poll_options" table
+DROP TABLE "poll_options";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "closes_at", DROP COLUMN "opens_at", DROP COLUMN "results_visibility", DROP COLUMN "anonymous", DROP COLUMN "max_choices", DROP COLUMN "min_choices", DROP COLUMN "type", ALTER COLUMN "options" SET NOT NULL;
@@ -0,0 +1,22 @@
+-- Modify "polls" table
+ALTER TABLE "polls" ALTER COLUMN "options" DROP NOT NULL, ADD COLUMN "type" character varying NOT NULL DEFAULT 'single', ADD COLUMN "min_choices" bigint NOT NULL DEFAULT 1, ADD COLUMN "max_choices" bigint NULL, ADD COLUMN "anonymous" boolean NOT NULL DEFAULT false, ADD COLUMN "results_visibility" character varying NOT NULL DEFAULT 'always', ADD COLUMN "opens_at" timestamptz NULL, ADD COLUMN "closes_at" timestamptz NULL;
+-- Create "poll_options" table
+CREATE TABLE "poll_options" ("id" uuid NOT NULL, "label" character varying NOT NULL, "description" character varying NULL, "position" bigint NOT NULL, "image_url" character varying NULL, "created_at" timestamptz NOT NULL, "poll_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "poll_options_polls_poll" FOREIGN KEY ("poll_id") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "polloption_poll_id_position" to table: "poll_options"
+CREATE INDEX "polloption_poll_id_position" ON "poll_options" ("poll_id", "position");
+-- Create "ballots" table
+CREATE TABLE "ballots" ("id" uuid NOT NULL, "option_ids" jsonb NULL, "option" character varying NULL, "choices" jsonb NULL, "poll_id" uuid NOT NULL, "option_id" uuid NULL, PRIMARY KEY ("id"), CONSTRAINT "ballots_poll_options_option" FOREIGN KEY ("option_id") REFERENCES "poll_options" ("id") ON UPDATE NO ACTION ON DELETE SET NULL, CONSTRAINT "ballots_polls_poll" FOREIGN KEY ("poll_id") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create "job_runs" table
+CREATE TABLE "job_runs" ("id" uuid NOT NULL, "job" character varying NOT NULL, "instance" character varying NOT NULL, "status" character varying NOT NULL DEFAULT 'running', "affected" bigint NOT NULL DEFAULT 0, "error" character varying NULL, "started_at" timestamptz NOT NULL, "finished_at" timestamptz NULL, PRIMARY KEY ("id"));
+-- Create index "jobrun_job_started_at" to table: "job_runs"
+CREATE INDEX "jobrun_job_started_at" ON "job_runs" ("job", "started_at");
+-- Create "participations" table
+CREATE TABLE "participations" ("id" uuid NOT NULL, "created_at" timestamptz NOT NULL, "user_id" uuid NOT NULL, "poll_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "participations_polls_poll" FOREIGN KEY ("poll_id") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION, CONSTRAINT "participations_users_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "participation_user_id_poll_id" to table: "participations"
+CREATE UNIQUE INDEX "participation_user_id_poll_id" ON "participations" ("user_id", "poll_id");
+-- Create "poll_results" table
+CREATE TABLE "poll_results" ("id" uuid NOT NULL, "counts" jsonb NOT NULL, "voters" bigint NOT NULL, "ranked" jsonb NULL, "finalized_at" timestamptz NOT NULL, "poll_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "poll_results_polls_result" FOREIGN KEY ("poll_id") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "poll_results_poll_id_key" to table: "poll_results"
+CREATE UNIQUE INDEX "poll_results_poll_id_key" ON "poll_results" ("poll_id");
+-- Modify "votes" table
+ALTER TABLE "votes" ALTER COLUMN "option" DROP NOT NULL, ADD COLUMN "option_ids" jsonb NULL, ADD COLUMN "choices" jsonb NULL, ADD COLUMN "option_id" uuid NULL, ADD CONSTRAINT "votes_poll_options_option" FOREIGN KEY ("option_id") REFERENCES "poll_options" ("id") ON UPDATE NO ACTION ON DELETE SET NULL;
@@ -0,0 +1,2 @@
+-- Modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
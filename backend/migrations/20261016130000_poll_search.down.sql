@@ -0,0 +1,8 @@
+-- reverse: create index "poll_search_vector" to table: "polls"
+DROP INDEX "poll_search_vector";
+-- reverse: create index "poll_closes_at_id" to table: "polls"
+DROP INDEX "poll_closes_at_id";
+-- reverse: create index "poll_created_at_id" to table: "polls"
+DROP INDEX "poll_created_at_id";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "search_vector";
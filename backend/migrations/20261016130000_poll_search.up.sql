@@ -0,0 +1,10 @@
+-- Modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "search_vector" tsvector NULL;
+-- Create index "poll_created_at_id" to table: "polls"
+CREATE INDEX "poll_created_at_id" ON "polls" ("created_at", "id");
+-- Create index "poll_closes_at_id" to table: "polls"
+CREATE INDEX "poll_closes_at_id" ON "polls" ("closes_at", "id");
+-- Create index "poll_search_vector" to table: "polls"
+CREATE INDEX "poll_search_vector" ON "polls" USING GIN ("search_vector");
+-- Backfill search vectors of existing polls
+UPDATE "polls" SET "search_vector" = to_tsvector('english', "polls"."title" || ' ' || coalesce("polls"."description", '') || ' ' || coalesce((SELECT string_agg("poll_options"."label" || ' ' || coalesce("poll_options"."description", ''), ' ') FROM "poll_options" WHERE "poll_options"."poll_id" = "polls"."id"), ''));
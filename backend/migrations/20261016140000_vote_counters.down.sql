@@ -0,0 +1,8 @@
+-- reverse: create index "vote_poll_id_option_id" to table: "votes"
+DROP INDEX "vote_poll_id_option_id";
+-- reverse: create index "poll_voter_count_id" to table: "polls"
+DROP INDEX "poll_voter_count_id";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "voter_count";
+-- reverse: modify "poll_options" table
+ALTER TABLE "poll_options" DROP COLUMN "vote_count";
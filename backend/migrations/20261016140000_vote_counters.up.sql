@@ -0,0 +1,11 @@
+-- Modify "poll_options" table
+ALTER TABLE "poll_options" ADD COLUMN "vote_count" bigint NOT NULL DEFAULT 0;
+-- Modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "voter_count" bigint NOT NULL DEFAULT 0;
+-- Create index "poll_voter_count_id" to table: "polls"
+CREATE INDEX "poll_voter_count_id" ON "polls" ("voter_count", "id");
+-- Create index "vote_poll_id_option_id" to table: "votes"
+CREATE INDEX "vote_poll_id_option_id" ON "votes" ("poll_id", "option_id");
+-- Backfill the counters from existing votes and ballots
+UPDATE "poll_options" SET "vote_count" = (SELECT COUNT(*) FROM (SELECT "votes"."option_id", "votes"."option_ids" FROM "votes" WHERE "votes"."poll_id" = "poll_options"."poll_id" UNION ALL SELECT "ballots"."option_id", "ballots"."option_ids" FROM "ballots" WHERE "ballots"."poll_id" = "poll_options"."poll_id") AS "b" WHERE CASE WHEN (SELECT "polls"."type" FROM "polls" WHERE "polls"."id" = "poll_options"."poll_id") = 'approval' THEN "b"."option_ids" @> to_jsonb("poll_options"."id") ELSE "b"."option_id" = "poll_options"."id" END);
+UPDATE "polls" SET "voter_count" = (SELECT COUNT(*) FROM "votes" WHERE "votes"."poll_id" = "polls"."id") + (SELECT COUNT(*) FROM "participations" WHERE "participations"."poll_id" = "polls"."id");
@@ -0,0 +1,10 @@
+-- reverse: create index "webhookdelivery_status_next_attempt_at" to table: "webhook_deliveries"
+DROP INDEX "webhookdelivery_status_next_attempt_at";
+-- reverse: create index "webhookdelivery_endpoint_id_created_at_id" to table: "webhook_deliveries"
+DROP INDEX "webhookdelivery_endpoint_id_created_at_id";
+-- reverse: create "webhook_deliveries" table
+DROP TABLE "webhook_deliveries";
+-- reverse: create index "webhookendpoint_owner_id" to table: "webhook_endpoints"
+DROP INDEX "webhookendpoint_owner_id";
+-- reverse: create "webhook_endpoints" table
+DROP TABLE "webhook_endpoints";
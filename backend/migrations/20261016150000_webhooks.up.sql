@@ -0,0 +1,10 @@
+-- Create "webhook_endpoints" table
+CREATE TABLE "webhook_endpoints" ("id" uuid NOT NULL, "url" character varying NOT NULL, "description" character varying NULL, "secret" character varying NOT NULL, "events" jsonb NOT NULL, "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "owner_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "webhook_endpoints_users_owner" FOREIGN KEY ("owner_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "webhookendpoint_owner_id" to table: "webhook_endpoints"
+CREATE INDEX "webhookendpoint_owner_id" ON "webhook_endpoints" ("owner_id");
+-- Create "webhook_deliveries" table
+CREATE TABLE "webhook_deliveries" ("id" uuid NOT NULL, "event_id" uuid NOT NULL, "event" character varying NOT NULL, "payload" jsonb NOT NULL, "status" character varying NOT NULL DEFAULT 'pending', "attempts" bigint NOT NULL DEFAULT 0, "next_attempt_at" timestamptz NOT NULL, "last_attempt_at" timestamptz NULL, "response_status" bigint NULL, "last_error" character varying NULL, "created_at" timestamptz NOT NULL, "endpoint_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "webhook_deliveries_webhook_endpoints_endpoint" FOREIGN KEY ("endpoint_id") REFERENCES "webhook_endpoints" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "webhookdelivery_endpoint_id_created_at_id" to table: "webhook_deliveries"
+CREATE INDEX "webhookdelivery_endpoint_id_created_at_id" ON "webhook_deliveries" ("endpoint_id", "created_at", "id");
+-- Create index "webhookdelivery_status_next_attempt_at" to table: "webhook_deliveries"
+CREATE INDEX "webhookdelivery_status_next_attempt_at" ON "webhook_deliveries" ("status", "next_attempt_at");
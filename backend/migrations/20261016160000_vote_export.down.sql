@@ -0,0 +1,2 @@
+-- reverse: create index "vote_poll_id_created_at_id" to table: "votes"
+DROP INDEX "vote_poll_id_created_at_id";
@@ -0,0 +1,2 @@
+-- Create index "vote_poll_id_created_at_id" to table: "votes"
+CREATE INDEX "vote_poll_id_created_at_id" ON "votes" ("poll_id", "created_at", "id");
@@ -0,0 +1,4 @@
+-- reverse: create index "poll_owner_id_external_id" to table: "polls"
+DROP INDEX "poll_owner_id_external_id";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "external_id";
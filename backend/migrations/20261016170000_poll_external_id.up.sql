@@ -0,0 +1,4 @@
+-- Modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "external_id" character varying NULL;
+-- Create index "poll_owner_id_external_id" to table: "polls"
+CREATE UNIQUE INDEX "poll_owner_id_external_id" ON "polls" ("owner_id", "external_id");
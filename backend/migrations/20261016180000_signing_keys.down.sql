@@ -0,0 +1,2 @@
+-- reverse: create "signing_keys" table
+DROP TABLE "signing_keys";
@@ -0,0 +1,4 @@
+-- reverse: create index "securityevent_user_id_created_at" to table: "security_events"
+DROP INDEX "securityevent_user_id_created_at";
+-- reverse: create "security_events" table
+DROP TABLE "security_events";
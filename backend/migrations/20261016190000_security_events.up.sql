@@ -0,0 +1,4 @@
+-- Create "security_events" table
+CREATE TABLE "security_events" ("id" uuid NOT NULL, "type" character varying NOT NULL, "session_id" character varying NULL, "ip_address" character varying NULL, "user_agent" character varying NULL, "created_at" timestamptz NOT NULL, "user_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "security_events_users_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "securityevent_user_id_created_at" to table: "security_events"
+CREATE INDEX "securityevent_user_id_created_at" ON "security_events" ("user_id", "created_at");
@@ -0,0 +1,12 @@
+-- reverse: create index "usertoken_user_id_purpose" to table: "user_tokens"
+DROP INDEX "usertoken_user_id_purpose";
+-- reverse: create index "usertoken_expires_at" to table: "user_tokens"
+DROP INDEX "usertoken_expires_at";
+-- reverse: create index "user_tokens_token_hash_key" to table: "user_tokens"
+DROP INDEX "user_tokens_token_hash_key";
+-- reverse: create "user_tokens" table
+DROP TABLE "user_tokens";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "email_verified_at";
+-- reverse: modify "polls" table
+ALTER TABLE "polls" DROP COLUMN "require_verified_email";
@@ -0,0 +1,12 @@
+-- Modify "polls" table
+ALTER TABLE "polls" ADD COLUMN "require_verified_email" boolean NOT NULL DEFAULT false;
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "email_verified_at" timestamptz NULL;
+-- Create "user_tokens" table
+CREATE TABLE "user_tokens" ("id" uuid NOT NULL, "purpose" character varying NOT NULL, "token_hash" character varying NOT NULL, "email" character varying NOT NULL, "expires_at" timestamptz NOT NULL, "used_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "user_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "user_tokens_users_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "user_tokens_token_hash_key" to table: "user_tokens"
+CREATE UNIQUE INDEX "user_tokens_token_hash_key" ON "user_tokens" ("token_hash");
+-- Create index "usertoken_expires_at" to table: "user_tokens"
+CREATE INDEX "usertoken_expires_at" ON "user_tokens" ("expires_at");
+-- Create index "usertoken_user_id_purpose" to table: "user_tokens"
+CREATE INDEX "usertoken_user_id_purpose" ON "user_tokens" ("user_id", "purpose");
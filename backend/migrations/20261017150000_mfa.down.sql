@@ -0,0 +1,6 @@
+-- reverse: create index "recoverycode_user_id_code_hash" to table: "recovery_codes"
+DROP INDEX "recoverycode_user_id_code_hash";
+-- reverse: create "recovery_codes" table
+DROP TABLE "recovery_codes";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP COLUMN "mfa_last_step", DROP COLUMN "mfa_enabled_at", DROP COLUMN "mfa_secret";
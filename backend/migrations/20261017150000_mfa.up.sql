@@ -0,0 +1,6 @@
+-- Modify "users" table
+ALTER TABLE "users" ADD COLUMN "mfa_secret" character varying NULL, ADD COLUMN "mfa_enabled_at" timestamptz NULL, ADD COLUMN "mfa_last_step" bigint NULL;
+-- Create "recovery_codes" table
+CREATE TABLE "recovery_codes" ("id" uuid NOT NULL, "code_hash" character varying NOT NULL, "used_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "user_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "recovery_codes_users_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "recoverycode_user_id_code_hash" to table: "recovery_codes"
+CREATE UNIQUE INDEX "recoverycode_user_id_code_hash" ON "recovery_codes" ("user_id", "code_hash");
@@ -0,0 +1,4 @@
+-- Move the pending ballots back before dropping their table
+INSERT INTO "ballots" ("id", "option_ids", "poll_id", "option_id") SELECT "id", "option_ids", "poll_id", "option_id" FROM "pending_ballots";
+-- reverse: create "pending_ballots" table
+DROP TABLE "pending_ballots";
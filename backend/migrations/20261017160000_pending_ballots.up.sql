@@ -0,0 +1,6 @@
+-- Create "pending_ballots" table
+CREATE TABLE "pending_ballots" ("id" uuid NOT NULL, "option_ids" jsonb NOT NULL, "poll_id" uuid NOT NULL, "option_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "pending_ballots_poll_options_option" FOREIGN KEY ("option_id") REFERENCES "poll_options" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION, CONSTRAINT "pending_ballots_polls_poll" FOREIGN KEY ("poll_id") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Rewrite the existing ballots in random order, so they no longer share a transaction or their place in the table with their participations. The old row versions stay on disk until the table is vacuumed.
+CREATE TEMPORARY TABLE "shuffled_ballots" ON COMMIT DROP AS SELECT * FROM "ballots" ORDER BY random();
+DELETE FROM "ballots";
+INSERT INTO "ballots" SELECT * FROM "shuffled_ballots";
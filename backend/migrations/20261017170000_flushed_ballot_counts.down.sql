@@ -0,0 +1,6 @@
+-- Count pending secret ballots again, as they were counted when cast
+UPDATE "poll_options" SET "vote_count" = (SELECT COUNT(*) FROM (SELECT "votes"."option_id", "votes"."option_ids" FROM "votes" WHERE "votes"."poll_id" = "poll_options"."poll_id" UNION ALL SELECT "ballots"."option_id", "ballots"."option_ids" FROM "ballots" WHERE "ballots"."poll_id" = "poll_options"."poll_id" UNION ALL SELECT "pending_ballots"."option_id", "pending_ballots"."option_ids" FROM "pending_ballots" WHERE "pending_ballots"."poll_id" = "poll_options"."poll_id") AS "b" WHERE CASE WHEN (SELECT "polls"."type" FROM "polls" WHERE "polls"."id" = "poll_options"."poll_id") = 'approval' THEN "b"."option_ids" @> to_jsonb("poll_options"."id") ELSE "b"."option_id" = "poll_options"."id" END);
+UPDATE "polls" SET "voter_count" = (SELECT COUNT(*) FROM "votes" WHERE "votes"."poll_id" = "polls"."id") + (SELECT COUNT(*) FROM "participations" WHERE "participations"."poll_id" = "polls"."id");
+-- reverse: modify "participations" table
+ALTER TABLE "participations" ADD COLUMN "created_at" timestamptz NOT NULL DEFAULT now();
+ALTER TABLE "participations" ALTER COLUMN "created_at" DROP DEFAULT;
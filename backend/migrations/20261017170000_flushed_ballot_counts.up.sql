@@ -0,0 +1,5 @@
+-- Modify "participations" table
+ALTER TABLE "participations" DROP COLUMN "created_at";
+-- Recount the votes with only flushed secret ballots, which are the only ones counted from now on
+UPDATE "poll_options" SET "vote_count" = (SELECT COUNT(*) FROM (SELECT "votes"."option_id", "votes"."option_ids" FROM "votes" WHERE "votes"."poll_id" = "poll_options"."poll_id" UNION ALL SELECT "ballots"."option_id", "ballots"."option_ids" FROM "ballots" WHERE "ballots"."poll_id" = "poll_options"."poll_id") AS "b" WHERE CASE WHEN (SELECT "polls"."type" FROM "polls" WHERE "polls"."id" = "poll_options"."poll_id") = 'approval' THEN "b"."option_ids" @> to_jsonb("poll_options"."id") ELSE "b"."option_id" = "poll_options"."id" END);
+UPDATE "polls" SET "voter_count" = (SELECT COUNT(*) FROM "votes" WHERE "votes"."poll_id" = "polls"."id") + (SELECT COUNT(*) FROM "ballots" WHERE "ballots"."poll_id" = "polls"."id");
@@ -0,0 +1,2 @@
+-- reverse: create "poll_invitations" table
+DROP TABLE "poll_invitations";
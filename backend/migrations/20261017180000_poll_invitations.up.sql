@@ -0,0 +1,6 @@
+-- Create "poll_invitations" table
+CREATE TABLE "poll_invitations" ("id" uuid NOT NULL, "email" character varying NOT NULL, "status" character varying NOT NULL DEFAULT 'pending', "expires_at" timestamptz NOT NULL, "reminded_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "poll_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "poll_invitations_polls_poll" FOREIGN KEY ("poll_id") REFERENCES "polls" ("id") ON UPDATE NO ACTION ON DELETE NO ACTION);
+-- Create index "pollinvitation_poll_id_email" to table: "poll_invitations"
+CREATE UNIQUE INDEX "pollinvitation_poll_id_email" ON "poll_invitations" ("poll_id", "email");
+-- Create index "pollinvitation_status_expires_at" to table: "poll_invitations"
+CREATE INDEX "pollinvitation_status_expires_at" ON "poll_invitations" ("status", "expires_at");
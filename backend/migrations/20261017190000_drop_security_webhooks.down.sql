@@ -0,0 +1 @@
+-- reverse: nothing to undo; endpoints are not subscribed to the removed event again
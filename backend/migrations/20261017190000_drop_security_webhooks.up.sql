@@ -0,0 +1,2 @@
+-- Reused refresh tokens are reported to the user by email rather than to webhook endpoints
+UPDATE "webhook_endpoints" SET "events" = "events" - 'security.refresh_token_reused' WHERE "events" ? 'security.refresh_token_reused';
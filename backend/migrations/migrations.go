@@ -0,0 +1,12 @@
+// Package migrations holds the versioned SQL migrations of the database schema.
+// Files are generated from the ent schema with `poll-app migrate diff` and use
+// the golang-migrate layout: <version>_<name>.up.sql and <version>_<name>.down.sql.
+// atlas.sum guards the directory against edited or out-of-order files.
+package migrations
+
+import "embed"
+
+// FS holds the migration files and their integrity sum
+//
+//go:embed *.sql atlas.sum
+var FS embed.FS
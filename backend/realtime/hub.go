@@ -0,0 +1,226 @@
+// Package realtime serves the WebSocket API, over which clients follow several
+// polls, vote and see who else is watching through a single connection.
+// Updates reach every replica through the events broker; presence and the
+// per-user connection limit are kept in Redis so they hold across replicas.
+package realtime
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"poll-app/config"
+	"poll-app/events"
+	"poll-app/service"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// connectionsKeyPrefix names the set of a user's open connections
+	connectionsKeyPrefix = "ws-connections:"
+	// viewersKeyPrefix names the set of users viewing a poll
+	viewersKeyPrefix = "poll-viewers:"
+	// leaseTTL is how long a connection or viewer counts after its replica last
+	// refreshed it, so entries of crashed replicas expire
+	leaseTTL = 90 * time.Second
+	// leaseRefreshInterval is how often live connections and viewers are refreshed
+	leaseRefreshInterval = 30 * time.Second
+)
+
+var (
+	// ErrTooManyConnections is returned when a user already has the maximum number of open connections
+	ErrTooManyConnections = service.NewTooManyRequestsError("too_many_connections", "too many open connections")
+	// errTooManySubscriptions is returned when a connection already follows the maximum number of polls
+	errTooManySubscriptions = service.NewTooManyRequestsError("too_many_subscriptions", "too many subscribed polls")
+)
+
+// admitScript adds a connection to the user's set unless the set, without
+// expired connections, is already full. It returns 1 if the connection was added.
+var admitScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', ARGV[1])
+if redis.call('ZCARD', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[2], ARGV[4])
+redis.call('PEXPIRE', KEYS[1], ARGV[5])
+return 1
+`)
+
+// Service is the business logic behind the WebSocket API
+type Service interface {
+	service.PollService
+	service.VoteService
+}
+
+// Hub tracks the WebSocket sessions of this replica
+type Hub struct {
+	service Service
+	broker  *events.Broker
+	rdb     *redis.Client
+	cfg     config.WebSocketConfig
+
+	mu       sync.Mutex
+	sessions map[*Session]struct{}
+	// viewers counts the sessions of each user following each poll
+	viewers map[uuid.UUID]map[uuid.UUID]int
+}
+
+// NewHub creates a hub. Run must be running to keep its connections and
+// viewers from expiring.
+func NewHub(svc Service, broker *events.Broker, rdb *redis.Client, cfg config.WebSocketConfig) *Hub {
+	return &Hub{
+		service:  svc,
+		broker:   broker,
+		rdb:      rdb,
+		cfg:      cfg,
+		sessions: make(map[*Session]struct{}),
+		viewers:  make(map[uuid.UUID]map[uuid.UUID]int),
+	}
+}
+
+// Admit reserves a connection for the user, or returns ErrTooManyConnections
+// if the user has too many open already. The session must be served or released.
+func (h *Hub) Admit(ctx context.Context, userID uuid.UUID) (*Session, error) {
+	s := newSession(h, userID)
+
+	now := time.Now()
+	admitted, err := admitScript.Run(ctx, h.rdb,
+		[]string{connectionsKeyPrefix + userID.String()},
+		now.UnixMilli(), now.Add(leaseTTL).UnixMilli(), h.cfg.MaxConnectionsPerUser, s.id.String(), leaseTTL.Milliseconds(),
+	).Int()
+	if err != nil {
+		return nil, err
+	}
+	if admitted == 0 {
+		return nil, ErrTooManyConnections
+	}
+
+	h.mu.Lock()
+	h.sessions[s] = struct{}{}
+	h.mu.Unlock()
+
+	return s, nil
+}
+
+// release frees the connection reserved for the session
+func (h *Hub) release(ctx context.Context, s *Session) {
+	h.mu.Lock()
+	delete(h.sessions, s)
+	h.mu.Unlock()
+
+	if err := h.rdb.ZRem(ctx, connectionsKeyPrefix+s.userID.String(), s.id.String()).Err(); err != nil {
+		log.Printf("Failed to release connection of user %s: %v", s.userID, err)
+	}
+}
+
+// Run refreshes the connections and viewers of this replica until ctx is done
+func (h *Hub) Run(ctx context.Context) {
+	ticker := time.NewTicker(leaseRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := h.refresh(ctx); err != nil {
+				log.Printf("Failed to refresh WebSocket connections: %v", err)
+			}
+		}
+	}
+}
+
+// refresh extends the leases of every connection and viewer of this replica
+func (h *Hub) refresh(ctx context.Context) error {
+	expiry := float64(time.Now().Add(leaseTTL).UnixMilli())
+	pipe := h.rdb.Pipeline()
+
+	h.mu.Lock()
+	for s := range h.sessions {
+		key := connectionsKeyPrefix + s.userID.String()
+		pipe.ZAdd(ctx, key, redis.Z{Score: expiry, Member: s.id.String()})
+		pipe.PExpire(ctx, key, leaseTTL)
+	}
+	for pollID, users := range h.viewers {
+		key := viewersKeyPrefix + pollID.String()
+		for userID := range users {
+			pipe.ZAdd(ctx, key, redis.Z{Score: expiry, Member: userID.String()})
+		}
+		pipe.PExpire(ctx, key, leaseTTL)
+	}
+	h.mu.Unlock()
+
+	if pipe.Len() == 0 {
+		return nil
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// join records the user as viewing the poll, announces the new number of
+// viewers and returns it
+func (h *Hub) join(ctx context.Context, pollID, userID uuid.UUID) (int, error) {
+	h.mu.Lock()
+	if h.viewers[pollID] == nil {
+		h.viewers[pollID] = make(map[uuid.UUID]int)
+	}
+	h.viewers[pollID][userID]++
+	h.mu.Unlock()
+
+	key := viewersKeyPrefix + pollID.String()
+	pipe := h.rdb.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(time.Now().Add(leaseTTL).UnixMilli()), Member: userID.String()})
+	pipe.PExpire(ctx, key, leaseTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return h.announceViewers(ctx, pollID)
+}
+
+// leave undoes a join. The user stays a viewer while another session of this
+// replica follows the poll. A session of the same user on another replica is
+// counted again when that replica next refreshes its viewers.
+func (h *Hub) leave(ctx context.Context, pollID, userID uuid.UUID) {
+	h.mu.Lock()
+	users := h.viewers[pollID]
+	users[userID]--
+	remaining := users[userID]
+	if remaining <= 0 {
+		delete(users, userID)
+	}
+	if len(users) == 0 {
+		delete(h.viewers, pollID)
+	}
+	h.mu.Unlock()
+
+	if remaining > 0 {
+		return
+	}
+
+	if err := h.rdb.ZRem(ctx, viewersKeyPrefix+pollID.String(), userID.String()).Err(); err != nil {
+		log.Printf("Failed to remove viewer of poll %s: %v", pollID, err)
+		return
+	}
+	if _, err := h.announceViewers(ctx, pollID); err != nil {
+		log.Printf("Failed to announce viewers of poll %s: %v", pollID, err)
+	}
+}
+
+// announceViewers counts the current viewers of a poll and announces the count
+func (h *Hub) announceViewers(ctx context.Context, pollID uuid.UUID) (int, error) {
+	key := viewersKeyPrefix + pollID.String()
+	pipe := h.rdb.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().UnixMilli(), 10))
+	card := pipe.ZCard(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	viewers := int(card.Val())
+	return viewers, h.broker.Announce(ctx, pollID, events.TypePresence, events.Presence{Viewers: viewers})
+}
@@ -0,0 +1,274 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"poll-app/api"
+	"poll-app/converter"
+	"poll-app/ent"
+	"poll-app/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	// sendBuffer is how many messages may wait for a slow client before it is disconnected
+	sendBuffer = 64
+	// maxMessageSize is the largest request a client may send
+	maxMessageSize = 4096
+	// pingInterval is how often idle clients are pinged
+	pingInterval = 30 * time.Second
+	// idleTimeout disconnects clients that stop answering pings
+	idleTimeout = 2*pingInterval + 15*time.Second
+	// writeTimeout disconnects clients that stop reading
+	writeTimeout = 10 * time.Second
+	// cleanupTimeout bounds the Redis calls made after a session ends
+	cleanupTimeout = 5 * time.Second
+)
+
+// errInvalidMessage is returned for requests that cannot be decoded or have an unknown type
+var errInvalidMessage = service.NewValidationError("invalid_message", "invalid message")
+
+// Session is one client's WebSocket connection. Requests are handled one at a
+// time in the order they arrive, so a client sending faster than it is
+// answered is slowed down by TCP flow control. Updates are queued without
+// waiting; a client that lets sendBuffer messages pile up is disconnected.
+type Session struct {
+	hub    *Hub
+	id     uuid.UUID
+	userID uuid.UUID
+	conn   *websocket.Conn
+	out    chan api.RealtimeMessage
+
+	ctx         context.Context
+	cancel      context.CancelFunc
+	closeOnce   sync.Once
+	closeCode   int
+	closeReason string
+
+	mu      sync.Mutex
+	watches map[uuid.UUID]*watch
+	wg      sync.WaitGroup
+}
+
+func newSession(hub *Hub, userID uuid.UUID) *Session {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Session{
+		hub:     hub,
+		id:      uuid.New(),
+		userID:  userID,
+		out:     make(chan api.RealtimeMessage, sendBuffer),
+		ctx:     ctx,
+		cancel:  cancel,
+		watches: make(map[uuid.UUID]*watch),
+	}
+}
+
+// Release frees the connection reserved for the session. Serve releases it
+// when done, so it only needs to be called for sessions that are not served.
+func (s *Session) Release() {
+	s.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	s.hub.release(ctx, s)
+}
+
+// Serve runs the session on conn until either side closes it
+func (s *Session) Serve(conn *websocket.Conn) {
+	s.conn = conn
+	conn.SetReadLimit(maxMessageSize)
+	// Any frame from the client, pongs included, shows it is still there
+	conn.SetReadDeadline(time.Now().Add(idleTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(idleTimeout))
+	})
+
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		s.writeLoop()
+	}()
+
+	s.readLoop()
+	s.close(websocket.CloseNormalClosure, "")
+	<-writerDone
+
+	// Watches stop with the session and clean up after themselves
+	s.wg.Wait()
+	s.Release()
+}
+
+// close ends the session with the given close code; only the first call counts
+func (s *Session) close(code int, reason string) {
+	s.closeOnce.Do(func() {
+		s.closeCode = code
+		s.closeReason = reason
+		s.cancel()
+	})
+}
+
+func (s *Session) readLoop() {
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if errors.Is(err, websocket.ErrReadLimit) {
+			s.close(websocket.CloseMessageTooBig, "message too big")
+			return
+		}
+		if err != nil {
+			return
+		}
+		s.conn.SetReadDeadline(time.Now().Add(idleTimeout))
+
+		var req api.RealtimeRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			s.send(errorMessage(errInvalidMessage))
+			continue
+		}
+		s.handle(req)
+	}
+}
+
+func (s *Session) writeLoop() {
+	defer s.conn.Close()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		var err error
+		select {
+		case <-s.ctx.Done():
+			message := websocket.FormatCloseMessage(s.closeCode, s.closeReason)
+			s.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(writeTimeout))
+			return
+		case <-ping.C:
+			err = s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout))
+		case msg := <-s.out:
+			var data []byte
+			if data, err = json.Marshal(msg); err != nil {
+				break
+			}
+			s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			err = s.conn.WriteMessage(websocket.TextMessage, data)
+		}
+
+		if err != nil {
+			s.close(websocket.CloseInternalServerErr, "")
+			return
+		}
+	}
+}
+
+// send queues a reply, waiting for room in the queue
+func (s *Session) send(msg api.RealtimeMessage) {
+	select {
+	case s.out <- msg:
+	case <-s.ctx.Done():
+	}
+}
+
+// push queues an update, disconnecting the client if it is too far behind
+func (s *Session) push(msg api.RealtimeMessage) {
+	select {
+	case s.out <- msg:
+	default:
+		s.close(websocket.CloseTryAgainLater, "client is too slow")
+	}
+}
+
+// handle answers one request
+func (s *Session) handle(req api.RealtimeRequest) {
+	pollID := uuid.UUID(req.PollId)
+
+	var (
+		reply api.RealtimeMessage
+		w     *watch
+		err   error
+	)
+	switch req.Type {
+	case api.RealtimeRequestTypeSubscribe:
+		reply, w, err = s.subscribe(pollID)
+	case api.RealtimeRequestTypeUnsubscribe:
+		s.unsubscribe(pollID)
+	case api.RealtimeRequestTypeVote:
+		var choices []uuid.UUID
+		if req.Choices != nil {
+			for _, choice := range *req.Choices {
+				choices = append(choices, uuid.UUID(choice))
+			}
+		}
+		var vote *ent.Vote
+		if vote, err = s.hub.service.VoteOnPoll(s.ctx, s.userID, pollID, choices); err == nil {
+			response := converter.VoteToResponse(vote)
+			reply.Vote = &response
+		}
+	case api.RealtimeRequestTypeRetract:
+		err = s.hub.service.DeleteVote(s.ctx, s.userID, pollID)
+	default:
+		err = errInvalidMessage
+	}
+
+	if err != nil {
+		reply = errorMessage(err)
+	} else {
+		reply.Type = api.RealtimeMessageTypeOk
+	}
+	reply.Id = req.Id
+	reply.PollId = &req.PollId
+	s.send(reply)
+
+	// Updates follow the reply that holds the snapshot they apply to
+	if w != nil {
+		s.wg.Add(1)
+		go w.run()
+	}
+}
+
+// pollView returns the poll as the user sees it and whether its results are visible
+func (s *Session) pollView(p *ent.Poll) (api.PollResponse, bool, error) {
+	resultsVisible, err := s.hub.service.CanViewResults(s.ctx, p, s.userID)
+	if err != nil {
+		return api.PollResponse{}, false, err
+	}
+	hasVoted, err := s.hub.service.HasVoted(s.ctx, p, s.userID)
+	if err != nil {
+		return api.PollResponse{}, false, err
+	}
+
+	response := converter.PollToResponse(p, resultsVisible)
+	response.HasVoted = &hasVoted
+	return response, resultsVisible, nil
+}
+
+// errorMessage describes a failed request. Details of unexpected failures are
+// logged instead of sent.
+func errorMessage(err error) api.RealtimeMessage {
+	e := service.AsError(err)
+	if e.Kind == service.KindInternal || e.Kind == service.KindUnavailable {
+		log.Printf("WebSocket request failed: %v", err)
+	}
+
+	body := &api.RealtimeError{Code: e.Code, Message: e.Message}
+	if len(e.Fields) > 0 {
+		fields := make([]api.FieldError, 0, len(e.Fields))
+		for _, f := range e.Fields {
+			fields = append(fields, api.FieldError{Field: f.Field, Code: f.Code, Message: f.Message})
+		}
+		body.Errors = &fields
+	}
+	return api.RealtimeMessage{Type: api.RealtimeMessageTypeError, Error: body}
+}
+
+// pollID returns the ID of a poll for a message
+func pollID(id uuid.UUID) *openapi_types.UUID {
+	apiID := openapi_types.UUID(id)
+	return &apiID
+}
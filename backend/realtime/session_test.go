@@ -0,0 +1,156 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"poll-app/api"
+	"poll-app/config"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+)
+
+// newTestHub returns a hub backed by an in-memory Redis
+func newTestHub(t *testing.T, maxConnections int) *Hub {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { rdb.Close() })
+	return NewHub(nil, nil, rdb, config.WebSocketConfig{MaxConnectionsPerUser: maxConnections})
+}
+
+// dial serves a session of a new user and connects to it
+func dial(t *testing.T, hub *Hub) *websocket.Conn {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, err := hub.Admit(r.Context(), uuid.New())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			session.Release()
+			return
+		}
+		session.Serve(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestSessionMessages(t *testing.T) {
+	tests := []struct {
+		name      string
+		send      func(conn *websocket.Conn) error
+		wantError string
+		wantClose int
+	}{
+		{
+			name:      "malformed request",
+			send:      func(conn *websocket.Conn) error { return conn.WriteMessage(websocket.TextMessage, []byte("{")) },
+			wantError: "invalid_message",
+		},
+		{
+			name: "unknown request type",
+			send: func(conn *websocket.Conn) error {
+				return conn.WriteMessage(websocket.TextMessage, []byte(`{"id":"1","type":"shout","poll_id":"`+uuid.NewString()+`"}`))
+			},
+			wantError: "invalid_message",
+		},
+		{
+			name: "message too big",
+			send: func(conn *websocket.Conn) error {
+				return conn.WriteMessage(websocket.TextMessage, make([]byte, maxMessageSize+1))
+			},
+			wantClose: websocket.CloseMessageTooBig,
+		},
+		{
+			name: "fragmented message too big",
+			send: func(conn *websocket.Conn) error {
+				w, err := conn.NextWriter(websocket.TextMessage)
+				if err != nil {
+					return err
+				}
+				for i := 0; i < 3; i++ {
+					if _, err := w.Write(make([]byte, maxMessageSize/2)); err != nil {
+						return err
+					}
+				}
+				return w.Close()
+			},
+			wantClose: websocket.CloseMessageTooBig,
+		},
+		{
+			name: "client closes",
+			send: func(conn *websocket.Conn) error {
+				return conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			},
+			wantClose: websocket.CloseNormalClosure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := dial(t, newTestHub(t, 1))
+			if err := tt.send(conn); err != nil {
+				t.Fatalf("failed to send: %v", err)
+			}
+
+			var msg api.RealtimeMessage
+			err := conn.ReadJSON(&msg)
+			if tt.wantClose != 0 {
+				var closeErr *websocket.CloseError
+				if !errors.As(err, &closeErr) || closeErr.Code != tt.wantClose {
+					t.Fatalf("read = %v, want close code %d", err, tt.wantClose)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if msg.Type != api.RealtimeMessageTypeError || msg.Error == nil || msg.Error.Code != tt.wantError {
+				t.Fatalf("reply = %+v, want error %q", msg, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestHubAdmit(t *testing.T) {
+	ctx := context.Background()
+	hub := newTestHub(t, 2)
+	userID := uuid.New()
+
+	first, err := hub.Admit(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hub.Admit(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hub.Admit(ctx, userID); !errors.Is(err, ErrTooManyConnections) {
+		t.Fatalf("third Admit = %v, want ErrTooManyConnections", err)
+	}
+	if _, err := hub.Admit(ctx, uuid.New()); err != nil {
+		t.Fatalf("Admit of another user = %v", err)
+	}
+
+	first.Release()
+	if _, err := hub.Admit(ctx, userID); err != nil {
+		t.Fatalf("Admit after Release = %v", err)
+	}
+}
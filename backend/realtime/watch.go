@@ -0,0 +1,240 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"poll-app/api"
+	"poll-app/ent"
+	"poll-app/events"
+	"poll-app/service"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// watch forwards the updates of one poll to a session, filtered by what the
+// user may see. Vote counts are sent as the options whose count changed.
+type watch struct {
+	session *Session
+	sub     *events.Subscription
+	stop    chan struct{}
+
+	poll           *ent.Poll
+	resultsVisible bool
+	// counts and voters are the tallies last sent to the client
+	counts map[string]int
+	voters int
+}
+
+// subscribe starts following a poll. The reply holds the poll and its
+// viewers; the returned watch must be started once the reply is queued. It is
+// nil if the poll was already followed.
+func (s *Session) subscribe(pollID uuid.UUID) (api.RealtimeMessage, *watch, error) {
+	s.mu.Lock()
+	_, subscribed := s.watches[pollID]
+	full := len(s.watches) >= s.hub.cfg.MaxSubscriptions
+	s.mu.Unlock()
+	if !subscribed && full {
+		return api.RealtimeMessage{}, nil, errTooManySubscriptions
+	}
+
+	p, err := s.hub.service.GetPollByID(s.ctx, pollID)
+	if err != nil {
+		return api.RealtimeMessage{}, nil, err
+	}
+
+	if subscribed {
+		response, _, err := s.pollView(p)
+		if err != nil {
+			return api.RealtimeMessage{}, nil, err
+		}
+		return api.RealtimeMessage{Poll: &response}, nil, nil
+	}
+
+	// Subscribe before reading the current state so no change falls in between
+	sub := s.hub.broker.Subscribe(pollID)
+	w := &watch{session: s, sub: sub, stop: make(chan struct{})}
+	response, err := w.reset(p)
+	if err != nil {
+		sub.Close()
+		return api.RealtimeMessage{}, nil, err
+	}
+
+	viewers, err := s.hub.join(s.ctx, pollID, s.userID)
+	if err != nil {
+		// The watch cleans up the join when it stops, so undo it here
+		s.hub.leave(s.ctx, pollID, s.userID)
+		sub.Close()
+		return api.RealtimeMessage{}, nil, err
+	}
+
+	s.mu.Lock()
+	s.watches[pollID] = w
+	s.mu.Unlock()
+
+	return api.RealtimeMessage{Poll: &response, Viewers: &viewers}, w, nil
+}
+
+// unsubscribe stops following a poll
+func (s *Session) unsubscribe(pollID uuid.UUID) {
+	s.mu.Lock()
+	w, ok := s.watches[pollID]
+	delete(s.watches, pollID)
+	s.mu.Unlock()
+
+	if ok {
+		close(w.stop)
+	}
+}
+
+// run forwards updates until the watch is stopped, the poll is deleted or the session ends
+func (w *watch) run() {
+	s := w.session
+	defer s.wg.Done()
+	defer w.cleanup()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-w.stop:
+			return
+		case event, ok := <-w.sub.C:
+			if !ok {
+				// Dropped by the broker for falling behind
+				s.close(websocket.CloseTryAgainLater, "client is too slow")
+				return
+			}
+			done, err := w.handle(event)
+			if err != nil {
+				log.Printf("Failed to forward %s event of poll %s: %v", event.Type, event.PollID, err)
+				s.close(websocket.CloseInternalServerErr, "")
+				return
+			}
+			if done {
+				return
+			}
+		}
+	}
+}
+
+// cleanup unsubscribes from the broker and removes the user from the poll's viewers
+func (w *watch) cleanup() {
+	s := w.session
+	w.sub.Close()
+
+	s.mu.Lock()
+	if s.watches[w.poll.ID] == w {
+		delete(s.watches, w.poll.ID)
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+	defer cancel()
+	s.hub.leave(ctx, w.poll.ID, s.userID)
+}
+
+// handle forwards one event and reports whether the watch is over
+func (w *watch) handle(event events.Event) (bool, error) {
+	s := w.session
+
+	switch event.Type {
+	case events.TypeVotes:
+		return false, w.forwardCounts(event)
+	case events.TypeVoted:
+		// Only the user's own vote changes whether they may see the results
+		var voted events.Voted
+		if err := json.Unmarshal(event.Data, &voted); err != nil {
+			return false, fmt.Errorf("failed to decode voted event: %w", err)
+		}
+		if voted.UserID != s.userID {
+			return false, nil
+		}
+		visible, err := s.hub.service.CanViewResults(s.ctx, w.poll, s.userID)
+		if err != nil {
+			return false, err
+		}
+		w.resultsVisible = visible
+	case events.TypePoll, events.TypeStatus:
+		p, err := s.hub.service.GetPollByID(s.ctx, w.poll.ID)
+		if errors.Is(err, service.ErrPollNotFound) {
+			s.push(api.RealtimeMessage{Type: api.RealtimeMessageTypeDeleted, PollId: pollID(w.poll.ID)})
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		response, err := w.reset(p)
+		if err != nil {
+			return false, err
+		}
+		s.push(api.RealtimeMessage{Type: api.RealtimeMessageType(event.Type), PollId: pollID(p.ID), Poll: &response})
+	case events.TypePresence:
+		var presence events.Presence
+		if err := json.Unmarshal(event.Data, &presence); err != nil {
+			return false, fmt.Errorf("failed to decode presence: %w", err)
+		}
+		s.push(api.RealtimeMessage{Type: api.RealtimeMessageTypePresence, PollId: pollID(w.poll.ID), Viewers: &presence.Viewers})
+	case events.TypeDeleted:
+		s.push(api.RealtimeMessage{Type: api.RealtimeMessageTypeDeleted, PollId: pollID(w.poll.ID)})
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// forwardCounts sends the counts that changed since the client last saw them
+func (w *watch) forwardCounts(event events.Event) error {
+	s := w.session
+
+	if !w.resultsVisible {
+		return nil
+	}
+
+	var counts events.Counts
+	if err := json.Unmarshal(event.Data, &counts); err != nil {
+		return fmt.Errorf("failed to decode vote counts: %w", err)
+	}
+
+	changed := make(map[string]int)
+	for optionID, count := range counts.Counts {
+		if last, ok := w.counts[optionID]; !ok || last != count {
+			changed[optionID] = count
+		}
+	}
+	if len(changed) == 0 && counts.Voters == w.voters {
+		return nil
+	}
+
+	w.counts = counts.Counts
+	w.voters = counts.Voters
+	s.push(api.RealtimeMessage{
+		Type:   api.RealtimeMessageTypeVotes,
+		PollId: pollID(w.poll.ID),
+		Counts: &changed,
+		Voters: &counts.Voters,
+	})
+	return nil
+}
+
+// reset takes p as the current state of the poll and returns it as the user sees it
+func (w *watch) reset(p *ent.Poll) (api.PollResponse, error) {
+	response, resultsVisible, err := w.session.pollView(p)
+	if err != nil {
+		return api.PollResponse{}, err
+	}
+
+	w.poll = p
+	w.resultsVisible = resultsVisible
+	w.counts, w.voters = nil, 0
+	if resultsVisible {
+		counts := service.PollVoteCounts(p)
+		w.counts, w.voters = counts.Counts, counts.Voters
+	}
+	return response, nil
+}
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/usertoken"
+	"poll-app/mail"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// PasswordResetTokenTTL is how long a password reset link can be used
+	PasswordResetTokenTTL = time.Hour
+	// EmailVerificationTokenTTL is how long an email verification link can be used
+	EmailVerificationTokenTTL = 48 * time.Hour
+	// mailTimeout bounds sending one account email
+	mailTimeout = 30 * time.Second
+)
+
+var (
+	// ErrInvalidUserToken is returned for password reset and email verification
+	// tokens that do not exist, were used or replaced, or have expired
+	ErrInvalidUserToken = NewFieldError("token", "invalid_token", "token is invalid or has expired")
+	// ErrEmailAlreadyVerified is returned when requesting verification of a verified address
+	ErrEmailAlreadyVerified = NewConflictError("email_already_verified", "email address is already verified")
+)
+
+// AccountService defines account recovery and email verification
+type AccountService interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) (*ent.User, error)
+	RequestEmailVerification(ctx context.Context, userID uuid.UUID) error
+	VerifyEmail(ctx context.Context, token string) (*ent.User, error)
+}
+
+// RequestPasswordReset mails a password reset link to the user with the
+// email address. It succeeds whether or not there is such a user, so callers
+// cannot tell which addresses are registered.
+func (s *service) RequestPasswordReset(ctx context.Context, email string) error {
+	if email == "" {
+		return NewFieldError("email", "required", "email is required")
+	}
+
+	user, err := s.storage.GetUserByEmail(ctx, email)
+	if ent.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issueUserToken(ctx, user, usertoken.PurposePasswordReset, PasswordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(user.ID, mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Someone asked to reset the password of your account. To choose a new password, open this link within %s:\n\n"+
+			"%s\n\n"+
+			"If you did not ask for this, you can ignore this email; your password stays unchanged.\n",
+			user.Username, formatTTL(PasswordResetTokenTTL), s.link("/reset-password", token)),
+	})
+	return nil
+}
+
+// ResetPassword sets a new password with a token from a password reset email.
+// Since the token proves the user reads mail sent to their address, it also
+// verifies the address. The caller should end the user's sessions.
+func (s *service) ResetPassword(ctx context.Context, token, password string) (*ent.User, error) {
+	if password == "" {
+		return nil, NewFieldError("password", "required", "password is required")
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	var user *ent.User
+	err = s.withTx(ctx, func(tx *service) error {
+		var err error
+		if user, err = tx.useUserToken(ctx, token, usertoken.PurposePasswordReset); err != nil {
+			return err
+		}
+		if user, err = tx.storage.UpdateUserPassword(ctx, user.ID, string(hashedPassword)); err != nil {
+			return err
+		}
+		if user.EmailVerifiedAt == nil {
+			if user, err = tx.storage.SetUserEmailVerified(ctx, user.ID, time.Now()); err != nil {
+				return err
+			}
+		}
+		// Other reset links sent before are void once the password changed
+		_, err = tx.storage.ExpireUserTokens(ctx, user.ID, usertoken.PurposePasswordReset, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RequestEmailVerification mails a link to the user that verifies their email address
+func (s *service) RequestEmailVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	return s.sendEmailVerification(ctx, user)
+}
+
+// VerifyEmail marks the email address a verification token was sent to as verified
+func (s *service) VerifyEmail(ctx context.Context, token string) (*ent.User, error) {
+	var user *ent.User
+	err := s.withTx(ctx, func(tx *service) error {
+		var err error
+		if user, err = tx.useUserToken(ctx, token, usertoken.PurposeEmailVerification); err != nil {
+			return err
+		}
+		if user.EmailVerifiedAt != nil {
+			return nil
+		}
+		user, err = tx.storage.SetUserEmailVerified(ctx, user.ID, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// sendEmailVerification mails a new email verification link to the user
+func (s *service) sendEmailVerification(ctx context.Context, user *ent.User) error {
+	token, err := s.issueUserToken(ctx, user, usertoken.PurposeEmailVerification, EmailVerificationTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	s.sendMail(user.ID, mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"Please confirm that this is your email address by opening this link within %s:\n\n"+
+			"%s\n\n"+
+			"If you did not sign up, you can ignore this email.\n",
+			user.Username, formatTTL(EmailVerificationTokenTTL), s.link("/verify-email", token)),
+	})
+	return nil
+}
+
+// issueUserToken creates a token for the purpose, sent to the user's current
+// address, and voids the tokens issued for it before. Only the token's hash
+// is stored; the returned token is the only copy.
+func (s *service) issueUserToken(ctx context.Context, user *ent.User, purpose usertoken.Purpose, ttl time.Duration) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+
+	err := s.withTx(ctx, func(tx *service) error {
+		now := time.Now()
+		if _, err := tx.storage.ExpireUserTokens(ctx, user.ID, purpose, now); err != nil {
+			return err
+		}
+		_, err := tx.storage.CreateUserToken(ctx, storage.UserTokenInput{
+			UserID:    user.ID,
+			Purpose:   purpose,
+			TokenHash: hashUserToken(token),
+			Email:     user.Email,
+			ExpiresAt: now.Add(ttl),
+		})
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store %s token: %w", purpose, err)
+	}
+	return token, nil
+}
+
+// useUserToken consumes a token for the purpose and returns its user. Tokens
+// sent to an address the user no longer has are rejected.
+func (s *service) useUserToken(ctx context.Context, token string, purpose usertoken.Purpose) (*ent.User, error) {
+	if token == "" {
+		return nil, NewFieldError("token", "required", "token is required")
+	}
+
+	t, err := s.storage.GetUserTokenByHash(ctx, hashUserToken(token))
+	if ent.IsNotFound(err) {
+		return nil, ErrInvalidUserToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t.Purpose != purpose {
+		return nil, ErrInvalidUserToken
+	}
+
+	used, err := s.storage.UseUserToken(ctx, t.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidUserToken
+	}
+
+	user, err := s.storage.GetUserByID(ctx, t.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, t.Email) {
+		return nil, ErrInvalidUserToken
+	}
+	return user, nil
+}
+
+// hashUserToken returns the hash tokens are stored and looked up by. The
+// tokens are random, so a fast hash is enough.
+func hashUserToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// link returns the web app URL of the page at path that takes the token
+func (s *service) link(path, token string) string {
+	return strings.TrimRight(s.appURL, "/") + path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+// sendMail sends an account email in the background, so the request does not
+// wait for the mail server and its timing does not tell whether mail was sent.
+// Failures are logged; the user can ask for another email.
+func (s *service) sendMail(userID uuid.UUID, msg mail.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+		if err := s.mailer.Send(ctx, msg); err != nil {
+			log.Printf("Failed to send %q email to user %s: %v", msg.Subject, userID, err)
+		}
+	}()
+}
+
+// formatTTL describes a token lifetime in words
+func formatTTL(ttl time.Duration) string {
+	if ttl%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%d days", ttl/(24*time.Hour))
+	}
+	if ttl == time.Hour {
+		return "an hour"
+	}
+	return fmt.Sprintf("%d hours", ttl/time.Hour)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+
+	"poll-app/ent"
+	"poll-app/storage"
+)
+
+// Kind classifies a service error by how the caller should react to it
+type Kind int
+
+const (
+	// KindInternal is an unexpected failure; its details are not shown to clients
+	KindInternal Kind = iota
+	// KindValidation means the request is malformed or breaks a business rule
+	KindValidation
+	// KindUnauthenticated means the caller could not be identified
+	KindUnauthenticated
+	// KindForbidden means the caller may not perform the operation
+	KindForbidden
+	// KindNotFound means a resource the operation refers to does not exist
+	KindNotFound
+	// KindConflict means the operation is invalid in the resource's current state
+	KindConflict
+	// KindPreconditionFailed means the resource changed since the caller last read it
+	KindPreconditionFailed
+	// KindPreconditionRequired means the request must say which version of the
+	// resource it was based on
+	KindPreconditionRequired
+	// KindUnavailable means a dependency is temporarily unreachable and the
+	// operation may be retried
+	KindUnavailable
+	// KindTooManyRequests means the caller exceeded a usage limit and may
+	// retry once it is below the limit again
+	KindTooManyRequests
+)
+
+// FieldError describes why a single request field is invalid
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// Error is a domain error. Code is a stable machine-readable identifier that
+// clients can branch on; Message is meant for humans and may change.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches errors of the same kind and code, so errors carrying extra
+// details still match the sentinel they were derived from
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Code == e.Code
+}
+
+// NewValidationError returns a validation error, optionally detailing the invalid fields
+func NewValidationError(code, message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message, Fields: fields}
+}
+
+// NewFieldError returns a validation error about a single request field
+func NewFieldError(field, code, message string) *Error {
+	return NewValidationError(code, message, FieldError{Field: field, Code: code, Message: message})
+}
+
+// NewUnauthenticatedError returns an error for a caller that could not be identified
+func NewUnauthenticatedError(code, message string) *Error {
+	return &Error{Kind: KindUnauthenticated, Code: code, Message: message}
+}
+
+// NewForbiddenError returns an error for an operation the caller may not perform
+func NewForbiddenError(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+// NewNotFoundError returns an error for a missing resource
+func NewNotFoundError(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+// NewConflictError returns an error for an operation the resource's state does not allow
+func NewConflictError(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// NewUnavailableError wraps a failure of a dependency that may be retried
+func NewUnavailableError(err error) *Error {
+	return &Error{Kind: KindUnavailable, Code: "service_unavailable", Message: "service temporarily unavailable", Err: err}
+}
+
+// NewTooManyRequestsError returns an error for a caller that exceeded a usage limit
+func NewTooManyRequestsError(code, message string) *Error {
+	return &Error{Kind: KindTooManyRequests, Code: code, Message: message}
+}
+
+// NewInternalError wraps an unexpected failure
+func NewInternalError(err error) *Error {
+	return &Error{Kind: KindInternal, Code: "internal_error", Message: "internal server error", Err: err}
+}
+
+var (
+	// ErrPollNotFound is returned when the referenced poll does not exist
+	ErrPollNotFound = NewNotFoundError("poll_not_found", "poll not found")
+	// ErrOptionNotFound is returned when the referenced option is not one of the poll's options
+	ErrOptionNotFound = NewNotFoundError("option_not_found", "option not found")
+	// ErrVoteNotFound is returned when the caller has no vote on the poll
+	ErrVoteNotFound = NewNotFoundError("vote_not_found", "vote not found")
+	// ErrUserNotFound is returned when the referenced user does not exist
+	ErrUserNotFound = NewNotFoundError("user_not_found", "user not found")
+	// ErrAlreadyVoted is returned when voting twice on the same poll
+	ErrAlreadyVoted = NewConflictError("already_voted", "user has already voted on this poll")
+	// ErrChoiceLimitsLocked is returned when tightening the choice limits of a poll that has votes
+	ErrChoiceLimitsLocked = NewConflictError("choice_limits_locked", "min_choices cannot be raised nor max_choices lowered once the poll has votes")
+	// ErrPollModified is returned when editing a poll that changed since the caller read it
+	ErrPollModified = &Error{Kind: KindPreconditionFailed, Code: "poll_modified", Message: "poll was modified since it was read"}
+	// ErrInvalidCredentials is returned when logging in with a wrong email or password
+	ErrInvalidCredentials = NewUnauthenticatedError("invalid_credentials", "invalid email or password")
+)
+
+// AsError converts any error to an *Error. Errors from the storage layer and
+// its dependencies are classified by their type; anything unrecognized is
+// treated as internal.
+func AsError(err error) *Error {
+	var serviceErr *Error
+	if errors.As(err, &serviceErr) {
+		return serviceErr
+	}
+
+	var netErr net.Error
+	_, unique := storage.UniqueViolation(err)
+	switch {
+	case ent.IsNotFound(err):
+		return &Error{Kind: KindNotFound, Code: "not_found", Message: "resource not found", Err: err}
+	case ent.IsConstraintError(err), unique:
+		return &Error{Kind: KindConflict, Code: "conflict", Message: "the request conflicts with the current state of the resource", Err: err}
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, driver.ErrBadConn), errors.As(err, &netErr):
+		return NewUnavailableError(err)
+	}
+	return NewInternalError(err)
+}
+
+// pollNotFound maps a storage not-found error for a poll to ErrPollNotFound
+func pollNotFound(err error) error {
+	if ent.IsNotFound(err) {
+		return ErrPollNotFound
+	}
+	return err
+}
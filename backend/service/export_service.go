@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/export"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
+
+// exportPageSize is how many ballots are read at a time while exporting
+const exportPageSize = 1000
+
+// PollExport is a poll about to be exported. It is returned once the caller
+// is known to be allowed the export, so problems can be reported before any
+// of the export is written.
+type PollExport struct {
+	Poll    *ent.Poll
+	storage storage.Storage
+}
+
+// ExportPoll prepares the export of a poll's results on behalf of its owner
+func (s *service) ExportPoll(ctx context.Context, pollID, ownerID uuid.UUID) (*PollExport, error) {
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+
+	if p.OwnerID != ownerID {
+		return nil, NewForbiddenError("not_poll_owner", "only poll owner can export the poll")
+	}
+
+	return &PollExport{Poll: p, storage: s.storage}, nil
+}
+
+// ExportAnyPoll prepares the export of a poll's results regardless of who
+// owns it. It is meant for operators, such as the export command.
+func (s *service) ExportAnyPoll(ctx context.Context, pollID uuid.UUID) (*PollExport, error) {
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+
+	return &PollExport{Poll: p, storage: s.storage}, nil
+}
+
+// Encode writes the poll's per-option totals and, unless the poll is
+// anonymous, its ballots to enc. Ballots are read a page at a time, so
+// polls of any size are streamed.
+func (e *PollExport) Encode(ctx context.Context, enc export.Encoder) error {
+	p := e.Poll
+	counts := PollVoteCounts(p)
+
+	summary := export.Poll{
+		ID:         p.ID,
+		Title:      p.Title,
+		Type:       string(p.Type),
+		Status:     PollStatus(p, time.Now()),
+		Anonymous:  p.Anonymous,
+		Voters:     counts.Voters,
+		ExportedAt: time.Now(),
+	}
+	labels := make(map[uuid.UUID]string, len(p.Edges.Options))
+	for _, opt := range p.Edges.Options {
+		labels[opt.ID] = opt.Label
+		summary.Options = append(summary.Options, export.Option{
+			ID:    opt.ID,
+			Label: opt.Label,
+			Votes: counts.Counts[opt.ID.String()],
+		})
+	}
+
+	if err := enc.WritePoll(summary); err != nil {
+		return err
+	}
+
+	// Ballots of anonymous polls are not linked to voters, so only totals are exported
+	if !p.Anonymous {
+		if err := e.encodeBallots(ctx, enc, labels); err != nil {
+			return err
+		}
+	}
+
+	return enc.Close()
+}
+
+// encodeBallots writes every ballot of the poll to enc, oldest first
+func (e *PollExport) encodeBallots(ctx context.Context, enc export.Encoder, labels map[uuid.UUID]string) error {
+	var after *storage.VoteCursor
+	for {
+		votes, err := e.storage.ListVotesByPoll(ctx, e.Poll.ID, after, exportPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, v := range votes {
+			ballot := export.Ballot{
+				Choices: make([]string, 0, len(v.OptionIds)),
+				VotedAt: v.CreatedAt,
+			}
+			if v.Edges.User != nil {
+				ballot.Username = v.Edges.User.Username
+			}
+			for _, choice := range v.OptionIds {
+				ballot.Choices = append(ballot.Choices, labels[choice])
+			}
+
+			if err := enc.WriteBallot(ballot); err != nil {
+				return err
+			}
+		}
+
+		if len(votes) < exportPageSize {
+			return nil
+		}
+		last := votes[len(votes)-1]
+		after = &storage.VoteCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"poll-app/ent"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxImportPolls is how many polls one import may define
+	MaxImportPolls = 500
+	// maxExternalIDLength bounds external IDs of imported polls
+	maxExternalIDLength = 255
+)
+
+// Outcomes of importing a poll
+const (
+	// ImportStatusCreated means the poll was created
+	ImportStatusCreated = "created"
+	// ImportStatusPending means a dry run found the poll would be created
+	ImportStatusPending = "pending"
+	// ImportStatusExists means the owner already has a poll with the external ID
+	ImportStatusExists = "exists"
+)
+
+// ErrImportConflict is returned when a concurrent import created some of the polls first
+var ErrImportConflict = NewConflictError("import_conflict", "some polls were created by a concurrent import; retry the import")
+
+// PollDefinition describes a poll to import
+type PollDefinition struct {
+	ExternalID  string
+	Title       string
+	Description string
+	Options     []OptionInput
+	Settings    PollSettings
+}
+
+// ImportResult reports the outcome of an import, in the order of its definitions
+type ImportResult struct {
+	DryRun bool
+	Polls  []ImportedPoll
+}
+
+// ImportedPoll is the outcome of importing one poll definition
+type ImportedPoll struct {
+	ExternalID string
+	Status     string
+	// Poll is the created or existing poll; nil in dry runs for polls that
+	// would be created
+	Poll *ent.Poll
+}
+
+// ImportPolls creates the defined polls on behalf of the owner in one
+// transaction. Every definition is validated first, and any invalid one fails
+// the whole import with errors on fields named after their position, such as
+// polls[2].title. Polls whose external ID the owner already used are skipped,
+// so an import can be re-run. A dry run validates and reports without
+// creating anything.
+func (s *service) ImportPolls(ctx context.Context, ownerID uuid.UUID, definitions []PollDefinition, dryRun bool) (*ImportResult, error) {
+	if len(definitions) == 0 {
+		return nil, NewFieldError("polls", "required", "at least one poll is required")
+	}
+	if len(definitions) > MaxImportPolls {
+		return nil, NewFieldError("polls", "too_many", fmt.Sprintf("at most %d polls can be imported at once", MaxImportPolls))
+	}
+
+	if _, err := s.storage.GetUserByID(ctx, ownerID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	var fields []FieldError
+	seen := make(map[string]int, len(definitions))
+	externalIDs := make([]string, 0, len(definitions))
+	for i := range definitions {
+		def := &definitions[i]
+		prefix := fmt.Sprintf("polls[%d]", i)
+
+		def.Settings.ExternalID = &def.ExternalID
+		if err := preparePoll(def.Title, def.Options, &def.Settings); err != nil {
+			fields = append(fields, prefixFields(prefix, err)...)
+			continue
+		}
+
+		if first, ok := seen[def.ExternalID]; ok {
+			fields = append(fields, FieldError{
+				Field:   prefix + ".external_id",
+				Code:    "duplicate_value",
+				Message: fmt.Sprintf("external_id is also used by polls[%d]", first),
+			})
+			continue
+		}
+		seen[def.ExternalID] = i
+		externalIDs = append(externalIDs, def.ExternalID)
+	}
+	if len(fields) > 0 {
+		return nil, NewValidationError("invalid_import", "some polls are invalid", fields...)
+	}
+
+	existing, err := s.storage.GetPollsByExternalIDs(ctx, ownerID, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	byExternalID := make(map[string]*ent.Poll, len(existing))
+	for _, p := range existing {
+		byExternalID[*p.ExternalID] = p
+	}
+
+	result := &ImportResult{DryRun: dryRun, Polls: make([]ImportedPoll, len(definitions))}
+	for i, def := range definitions {
+		result.Polls[i] = ImportedPoll{ExternalID: def.ExternalID, Status: ImportStatusPending}
+		if p, ok := byExternalID[def.ExternalID]; ok {
+			result.Polls[i].Status = ImportStatusExists
+			result.Polls[i].Poll = p
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	err = s.withTx(ctx, func(tx *service) error {
+		for i, def := range definitions {
+			if result.Polls[i].Status == ImportStatusExists {
+				continue
+			}
+
+			created, err := tx.CreatePoll(ctx, def.Title, def.Description, def.Options, def.Settings, ownerID)
+			if err != nil {
+				return err
+			}
+			result.Polls[i].Status = ImportStatusCreated
+			result.Polls[i].Poll = created
+		}
+		return nil
+	})
+	if _, ok := storage.UniqueViolation(err); ok {
+		return nil, ErrImportConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validateExternalID checks the external ID of an imported poll
+func validateExternalID(externalID string) error {
+	if externalID == "" {
+		return NewFieldError("external_id", "required", "external_id is required")
+	}
+	if utf8.RuneCountInString(externalID) > maxExternalIDLength {
+		return NewFieldError("external_id", "too_long", fmt.Sprintf("external_id must be at most %d characters", maxExternalIDLength))
+	}
+	return nil
+}
+
+// prefixFields returns the field errors of err with prefix prepended to their
+// field names. Errors about no particular field are reported on prefix itself.
+func prefixFields(prefix string, err error) []FieldError {
+	serviceErr := AsError(err)
+	if len(serviceErr.Fields) == 0 {
+		return []FieldError{{Field: prefix, Code: serviceErr.Code, Message: serviceErr.Message}}
+	}
+
+	fields := make([]FieldError, 0, len(serviceErr.Fields))
+	for _, f := range serviceErr.Fields {
+		f.Field = prefix + "." + f.Field
+		fields = append(fields, f)
+	}
+	return fields
+}
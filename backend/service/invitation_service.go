@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/mail"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// InvitationTTL is how long an invitation stays pending, unless the poll closes first
+	InvitationTTL = 7 * 24 * time.Hour
+	// InvitationReminderLead is how long before an invitation expires its invitee is reminded
+	InvitationReminderLead = 24 * time.Hour
+	// maxInvitations bounds the addresses invited in one request
+	maxInvitations = 100
+	// invitationReminderBatch bounds the reminders sent in one job run
+	invitationReminderBatch = 100
+)
+
+// InvitationService defines inviting people to vote on polls by email
+type InvitationService interface {
+	InviteToPoll(ctx context.Context, pollID, ownerID uuid.UUID, emails []string) ([]*ent.PollInvitation, error)
+	ListPollInvitations(ctx context.Context, pollID, ownerID uuid.UUID) ([]*ent.PollInvitation, error)
+	ExpireInvitations(ctx context.Context) (int, error)
+	SendInvitationReminders(ctx context.Context) (int, error)
+}
+
+// InviteToPoll mails an invitation to vote on the poll to each address that
+// was not invited yet and returns the new invitations. Invitations expire
+// after InvitationTTL or when the poll closes, whichever comes first.
+func (s *service) InviteToPoll(ctx context.Context, pollID, ownerID uuid.UUID, emails []string) ([]*ent.PollInvitation, error) {
+	if len(emails) == 0 {
+		return nil, NewFieldError("emails", "required", "at least one email address is required")
+	}
+	if len(emails) > maxInvitations {
+		return nil, NewFieldError("emails", "too_many", fmt.Sprintf("at most %d email addresses can be invited at once", maxInvitations))
+	}
+	var fields []FieldError
+	for i, email := range emails {
+		if !validEmail(email) {
+			fields = append(fields, FieldError{Field: fmt.Sprintf("emails[%d]", i), Code: "invalid_email", Message: "must be a valid email address"})
+		}
+	}
+	if len(fields) > 0 {
+		return nil, NewValidationError("invalid_email", "every email must be a valid email address", fields...)
+	}
+
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+	if p.OwnerID != ownerID {
+		return nil, NewForbiddenError("not_poll_owner", "only poll owner can invite voters")
+	}
+	now := time.Now()
+	if PollStatus(p, now) == PollStatusClosed {
+		return nil, ErrPollClosed
+	}
+
+	expiresAt := now.Add(InvitationTTL)
+	if p.ClosesAt != nil && p.ClosesAt.Before(expiresAt) {
+		expiresAt = *p.ClosesAt
+	}
+
+	invitations, err := s.storage.CreatePollInvitations(ctx, pollID, emails, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sent in the background, so inviting many does not wait for the mail server
+	go func() {
+		for _, inv := range invitations {
+			if err := s.sendInvitation(inv, p); err != nil {
+				log.Printf("Failed to send invitation %s: %v", inv.ID, err)
+			}
+		}
+	}()
+	return invitations, nil
+}
+
+func (s *service) ListPollInvitations(ctx context.Context, pollID, ownerID uuid.UUID) ([]*ent.PollInvitation, error) {
+	isOwner, err := s.IsPollOwner(ctx, pollID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if !isOwner {
+		return nil, NewForbiddenError("not_poll_owner", "only poll owner can list invitations")
+	}
+
+	return s.storage.ListPollInvitations(ctx, pollID)
+}
+
+// ExpireInvitations expires the pending invitations that ran out or whose
+// poll closed, and returns how many it expired
+func (s *service) ExpireInvitations(ctx context.Context) (int, error) {
+	return s.storage.ExpirePollInvitations(ctx, time.Now())
+}
+
+// SendInvitationReminders reminds the invitees whose invitation expires
+// within InvitationReminderLead, once, and returns how many it reminded.
+// Invitees who already voted with an account under the invited address are
+// not reminded. A reminder that fails to send is retried on the next run.
+func (s *service) SendInvitationReminders(ctx context.Context) (int, error) {
+	now := time.Now()
+	invitations, err := s.storage.ListPollInvitationsToRemind(ctx, now, now.Add(InvitationReminderLead), invitationReminderBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	reminded := 0
+	for _, inv := range invitations {
+		voted, err := s.invitationAnswered(ctx, inv)
+		if err != nil {
+			return reminded, fmt.Errorf("invitation %s: %w", inv.ID, err)
+		}
+		if !voted {
+			if err := s.sendInvitationReminder(ctx, inv, inv.Edges.Poll); err != nil {
+				return reminded, fmt.Errorf("invitation %s: %w", inv.ID, err)
+			}
+			reminded++
+		}
+		// Answered invitations are marked too, so they are not checked again
+		if err := s.storage.MarkPollInvitationReminded(ctx, inv.ID, now); err != nil {
+			return reminded, fmt.Errorf("invitation %s: %w", inv.ID, err)
+		}
+	}
+
+	return reminded, nil
+}
+
+// invitationAnswered reports whether the user with the invited address has voted on the poll
+func (s *service) invitationAnswered(ctx context.Context, inv *ent.PollInvitation) (bool, error) {
+	user, err := s.storage.GetUserByEmail(ctx, inv.Email)
+	if ent.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return s.HasVoted(ctx, inv.Edges.Poll, user.ID)
+}
+
+// sendInvitation mails an invitation to vote on p
+func (s *service) sendInvitation(inv *ent.PollInvitation, p *ent.Poll) error {
+	ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+	defer cancel()
+	return s.mailer.Send(ctx, mail.Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("You are invited to vote on %q", p.Title),
+		Body: fmt.Sprintf("Hi,\n\n"+
+			"You are invited to vote on the poll %q. To vote, open this link by %s:\n\n"+
+			"%s\n\n"+
+			"If you do not want to vote, you can ignore this email.\n",
+			p.Title, inv.ExpiresAt.UTC().Format(invitationTimeLayout), s.pollLink(p.ID)),
+	})
+}
+
+// sendInvitationReminder mails a reminder that an invitation to vote on p expires soon
+func (s *service) sendInvitationReminder(ctx context.Context, inv *ent.PollInvitation, p *ent.Poll) error {
+	ctx, cancel := context.WithTimeout(ctx, mailTimeout)
+	defer cancel()
+	return s.mailer.Send(ctx, mail.Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("Reminder: vote on %q", p.Title),
+		Body: fmt.Sprintf("Hi,\n\n"+
+			"Your invitation to vote on the poll %q expires on %s. To vote, open this link:\n\n"+
+			"%s\n\n"+
+			"If you already voted or do not want to, you can ignore this email.\n",
+			p.Title, inv.ExpiresAt.UTC().Format(invitationTimeLayout), s.pollLink(p.ID)),
+	})
+}
+
+// invitationTimeLayout formats expiry times in invitation emails
+const invitationTimeLayout = "Mon, 2 Jan 2006 15:04 MST"
+
+// pollLink returns the web app URL of the poll's page
+func (s *service) pollLink(pollID uuid.UUID) string {
+	return strings.TrimRight(s.appURL, "/") + "/polls/" + pollID.String()
+}
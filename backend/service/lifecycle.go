@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/events"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
+
+// Poll lifecycle statuses, derived from the voting window
+const (
+	PollStatusScheduled = storage.PollStatusScheduled
+	PollStatusOpen      = storage.PollStatusOpen
+	PollStatusClosed    = storage.PollStatusClosed
+)
+
+var (
+	// ErrPollNotOpenYet is returned when voting on a poll before it opens
+	ErrPollNotOpenYet = NewConflictError("poll_not_open", "poll is not open for voting yet")
+	// ErrPollClosed is returned when voting on a poll after it has closed
+	ErrPollClosed = NewConflictError("poll_closed", "poll is closed")
+	// ErrCloseBeforeOpen is returned when closing a scheduled poll before it opens
+	ErrCloseBeforeOpen = NewConflictError("poll_not_open", "a scheduled poll cannot be closed before it opens")
+)
+
+// PollStatus returns the lifecycle status of a poll at the given time
+func PollStatus(p *ent.Poll, now time.Time) string {
+	if p.ClosesAt != nil && !now.Before(*p.ClosesAt) {
+		return PollStatusClosed
+	}
+	if p.OpensAt != nil && now.Before(*p.OpensAt) {
+		return PollStatusScheduled
+	}
+	return PollStatusOpen
+}
+
+// checkPollOpen returns a distinct error when the poll is outside its voting window
+func checkPollOpen(p *ent.Poll) error {
+	switch PollStatus(p, time.Now()) {
+	case PollStatusScheduled:
+		return ErrPollNotOpenYet
+	case PollStatusClosed:
+		return ErrPollClosed
+	}
+	return nil
+}
+
+// validateSchedule checks that a poll opens before it closes
+func validateSchedule(opensAt, closesAt *time.Time) error {
+	if opensAt != nil && closesAt != nil && !opensAt.Before(*closesAt) {
+		return NewFieldError("opens_at", "invalid_schedule", "opens_at must be before closes_at")
+	}
+	return nil
+}
+
+// ClosePoll closes the poll now on behalf of the owner and freezes its
+// results in the same transaction, so no vote or edit can come in between.
+// Scheduled polls cannot be closed before they open.
+func (s *service) ClosePoll(ctx context.Context, pollID, ownerID uuid.UUID) (*ent.Poll, error) {
+	var closed *ent.Poll
+	err := s.withTx(ctx, func(tx *service) error {
+		if err := tx.storage.LockPoll(ctx, pollID); err != nil {
+			return err
+		}
+
+		p, err := tx.storage.GetPollByID(ctx, pollID)
+		if err != nil {
+			return pollNotFound(err)
+		}
+
+		// Permission check: Only poll owner can close the poll
+		if p.OwnerID != ownerID {
+			return NewForbiddenError("not_poll_owner", "only poll owner can close the poll")
+		}
+
+		now := time.Now()
+		switch PollStatus(p, now) {
+		case PollStatusClosed:
+			return ErrPollClosed
+		case PollStatusScheduled:
+			return ErrCloseBeforeOpen
+		}
+
+		if _, err := tx.storage.UpdatePoll(ctx, pollID, nil, storage.PollUpdate{ClosesAt: &now}); err != nil {
+			return err
+		}
+		if err := tx.flushLastBallots(ctx, p); err != nil {
+			return err
+		}
+		if closed, err = tx.storage.GetPollByID(ctx, pollID); err != nil {
+			return err
+		}
+		closed.Edges.Result, err = tx.recordResults(ctx, closed)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, pollID, events.TypeStatus, events.StatusChange{Status: PollStatusClosed})
+	return closed, nil
+}
+
+// ensureFinalized records the frozen results of a closed poll that has none yet
+// and returns the poll with its result loaded. Open polls are returned unchanged.
+// Polls closed manually are finalized as they close; this catches up on polls
+// whose closing time passed. Nothing changes the ballots or options of a
+// closed poll, so the results are the same as at its closing time. The request
+// that records the results announces the closing, so it must not run inside a
+// transaction.
+func (s *service) ensureFinalized(ctx context.Context, p *ent.Poll) (*ent.Poll, error) {
+	if p.Edges.Result != nil || PollStatus(p, time.Now()) != PollStatusClosed {
+		return p, nil
+	}
+
+	var (
+		result   *ent.PollResult
+		recorded bool
+	)
+	err := s.withTx(ctx, func(tx *service) error {
+		// Votes that were cast as the poll closed commit first, and a
+		// concurrent request may have finalized the poll meanwhile
+		if err := tx.storage.LockPoll(ctx, p.ID); err != nil {
+			return err
+		}
+		if err := tx.flushLastBallots(ctx, p); err != nil {
+			return err
+		}
+		current, err := tx.storage.GetPollByID(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+		if current.Edges.Result != nil {
+			result = current.Edges.Result
+			return nil
+		}
+
+		result, err = tx.recordResults(ctx, current)
+		recorded = err == nil
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize poll results: %w", err)
+	}
+	if recorded {
+		s.publish(ctx, p.ID, events.TypeStatus, events.StatusChange{Status: PollStatusClosed})
+	}
+
+	p.Edges.Result = result
+	return p, nil
+}
+
+// flushLastBallots moves the pending ballots of a closed anonymous poll to the
+// ballots table, however few, so they are counted in its frozen results. It
+// must run in the transaction that freezes them.
+func (s *service) flushLastBallots(ctx context.Context, p *ent.Poll) error {
+	if !p.Anonymous {
+		return nil
+	}
+	_, err := s.storage.FlushBallots(ctx, p.ID, 1)
+	return err
+}
+
+// recordResults freezes the current tallies of a closed poll and enqueues its
+// poll.closed webhook deliveries. It must run in a transaction.
+func (s *service) recordResults(ctx context.Context, p *ent.Poll) (*ent.PollResult, error) {
+	counts := liveVoteCounts(p)
+
+	var ranked json.RawMessage
+	if p.Type == poll.TypeRanked {
+		result, err := s.tabulateRanked(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if ranked, err = json.Marshal(result); err != nil {
+			return nil, fmt.Errorf("failed to encode ranked results: %w", err)
+		}
+	}
+
+	result, err := s.storage.CreatePollResult(ctx, p.ID, counts.Counts, counts.Voters, ranked)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.enqueuePollWebhook(ctx, WebhookEventPollClosed, p, &webhookResults{Counts: counts.Counts, Voters: counts.Voters}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FinalizeClosedPolls freezes the results of every poll whose closing time has
+// passed and returns how many polls were finalized
+func (s *service) FinalizeClosedPolls(ctx context.Context) (int, error) {
+	polls, err := s.storage.ListPollsToFinalize(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	finalized := 0
+	for _, p := range polls {
+		if _, err := s.ensureFinalized(ctx, p); err != nil {
+			return finalized, fmt.Errorf("poll %s: %w", p.ID, err)
+		}
+		finalized++
+	}
+
+	return finalized, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"poll-app/ent"
+)
+
+func TestPollStatus(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		opensAt  *time.Time
+		closesAt *time.Time
+		want     string
+		err      error
+	}{
+		{"unscheduled", nil, nil, PollStatusOpen, nil},
+		{"opened", &before, nil, PollStatusOpen, nil},
+		{"opens later", &after, nil, PollStatusScheduled, ErrPollNotOpenYet},
+		{"opens now", &now, nil, PollStatusOpen, nil},
+		{"closes later", nil, &after, PollStatusOpen, nil},
+		{"closes now", nil, &now, PollStatusClosed, ErrPollClosed},
+		{"closed", &before, &before, PollStatusClosed, ErrPollClosed},
+		{"window", &before, &after, PollStatusOpen, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ent.Poll{OpensAt: tt.opensAt, ClosesAt: tt.closesAt}
+			if got := PollStatus(p, now); got != tt.want {
+				t.Errorf("PollStatus = %q, want %q", got, tt.want)
+			}
+
+			// checkPollOpen uses the current time, so shift the window to it
+			shift := time.Since(now)
+			if tt.opensAt != nil {
+				opensAt := tt.opensAt.Add(shift)
+				p.OpensAt = &opensAt
+			}
+			if tt.closesAt != nil {
+				closesAt := tt.closesAt.Add(shift)
+				p.ClosesAt = &closesAt
+			}
+			if err := checkPollOpen(p); !errors.Is(err, tt.err) {
+				t.Errorf("checkPollOpen = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateSchedule(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		opensAt  *time.Time
+		closesAt *time.Time
+		valid    bool
+	}{
+		{"none", nil, nil, true},
+		{"opens only", &now, nil, true},
+		{"closes only", nil, &now, true},
+		{"opens before closing", &now, &later, true},
+		{"opens as it closes", &now, &now, false},
+		{"opens after closing", &later, &now, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSchedule(tt.opensAt, tt.closesAt)
+			if (err == nil) != tt.valid {
+				t.Fatalf("validateSchedule = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/totp"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes a user gets at a time
+	recoveryCodeCount = 10
+	// recoveryCodeLength is the number of characters of a recovery code,
+	// excluding the separator, each carrying five bits
+	recoveryCodeLength = 10
+	// recoveryCodeAlphabet is the lowercase base32 alphabet, which has no 0 or
+	// 1 to confuse with o and l
+	recoveryCodeAlphabet = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+var (
+	// ErrMFAAlreadyEnabled is returned when enrolling a user who has two-factor authentication on
+	ErrMFAAlreadyEnabled = NewConflictError("mfa_already_enabled", "two-factor authentication is already enabled")
+	// ErrMFANotEnabled is returned when managing two-factor authentication of a user who has it off
+	ErrMFANotEnabled = NewConflictError("mfa_not_enabled", "two-factor authentication is not enabled")
+	// ErrMFANotEnrolling is returned when confirming two-factor authentication before enrolling
+	ErrMFANotEnrolling = NewConflictError("mfa_not_enrolling", "start enrolling in two-factor authentication first")
+	// ErrInvalidMFACode is returned when signing in with a wrong, expired or already used code
+	ErrInvalidMFACode = NewUnauthenticatedError("invalid_mfa_code", "invalid authentication code")
+	// ErrReauthenticationFailed is returned when the password or code confirming a sensitive change is wrong
+	ErrReauthenticationFailed = NewForbiddenError("reauthentication_failed", "password or authentication code is incorrect")
+)
+
+// MFAEnrollment is a started two-factor authentication enrolment. The secret
+// is added to an authenticator app, usually by scanning the provisioning URI
+// as a QR code.
+type MFAEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// MFAStatus describes a user's two-factor authentication
+type MFAStatus struct {
+	Enabled   bool
+	EnabledAt *time.Time
+	// RecoveryCodesRemaining is the number of unused recovery codes
+	RecoveryCodesRemaining int
+}
+
+// MFAService defines two-factor authentication with TOTP codes and recovery codes
+type MFAService interface {
+	GetMFAStatus(ctx context.Context, userID uuid.UUID) (*MFAStatus, error)
+	EnrollMFA(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error)
+	ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableMFA(ctx context.Context, userID uuid.UUID, password, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, password, code string) ([]string, error)
+	VerifyMFA(ctx context.Context, userID uuid.UUID, code string) (*ent.User, error)
+}
+
+// MFAEnabled reports whether signing in as the user takes a second factor
+func MFAEnabled(user *ent.User) bool {
+	return user.MfaEnabledAt != nil && user.MfaSecret != nil
+}
+
+func (s *service) GetMFAStatus(ctx context.Context, userID uuid.UUID) (*MFAStatus, error) {
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !MFAEnabled(user) {
+		return &MFAStatus{}, nil
+	}
+
+	remaining, err := s.storage.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &MFAStatus{Enabled: true, EnabledAt: user.MfaEnabledAt, RecoveryCodesRemaining: remaining}, nil
+}
+
+// EnrollMFA starts enrolling the user with a new secret. Codes are not asked
+// for until ConfirmMFA proves the secret was added to an authenticator app.
+// Enrolling again before confirming replaces the secret.
+func (s *service) EnrollMFA(ctx context.Context, userID uuid.UUID) (*MFAEnrollment, error) {
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if MFAEnabled(user) {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.storage.SetUserMFASecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &MFAEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, s.mfaIssuer, user.Email),
+	}, nil
+}
+
+// ConfirmMFA enables two-factor authentication with a code generated from
+// the enrolled secret and returns the user's recovery codes, which are not
+// shown again
+func (s *service) ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if code == "" {
+		return nil, NewFieldError("code", "required", "code is required")
+	}
+
+	var codes []string
+	err := s.withTx(ctx, func(tx *service) error {
+		user, err := tx.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if MFAEnabled(user) {
+			return ErrMFAAlreadyEnabled
+		}
+		if user.MfaSecret == nil {
+			return ErrMFANotEnrolling
+		}
+
+		step, ok := totp.Validate(*user.MfaSecret, code, time.Now())
+		if !ok {
+			return NewFieldError("code", "invalid_mfa_code", "code is invalid or has expired; check the authenticator app's clock")
+		}
+		if _, err := tx.storage.EnableUserMFA(ctx, userID, time.Now(), step); err != nil {
+			return err
+		}
+
+		codes, err = tx.replaceRecoveryCodes(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// DisableMFA turns two-factor authentication off and deletes the recovery
+// codes. The user confirms it with their password and a current code.
+func (s *service) DisableMFA(ctx context.Context, userID uuid.UUID, password, code string) error {
+	return s.withTx(ctx, func(tx *service) error {
+		if _, err := tx.reauthenticate(ctx, userID, password, code); err != nil {
+			return err
+		}
+		if _, err := tx.storage.DisableUserMFA(ctx, userID); err != nil {
+			return err
+		}
+		_, err := tx.storage.DeleteRecoveryCodes(ctx, userID)
+		return err
+	})
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, used or not.
+// The user confirms it with their password and a current code.
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, password, code string) ([]string, error) {
+	var codes []string
+	err := s.withTx(ctx, func(tx *service) error {
+		if _, err := tx.reauthenticate(ctx, userID, password, code); err != nil {
+			return err
+		}
+		var err error
+		codes, err = tx.replaceRecoveryCodes(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// VerifyMFA checks the second factor of a sign-in, either a TOTP code or a
+// recovery code, and uses it up
+func (s *service) VerifyMFA(ctx context.Context, userID uuid.UUID, code string) (*ent.User, error) {
+	if code == "" {
+		return nil, NewFieldError("code", "required", "code is required")
+	}
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !MFAEnabled(user) {
+		return nil, ErrMFANotEnabled
+	}
+
+	ok, err := s.useMFACode(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	return user, nil
+}
+
+// reauthenticate checks that the caller of a sensitive change knows the
+// user's password and has their second factor, which uses the code up
+func (s *service) reauthenticate(ctx context.Context, userID uuid.UUID, password, code string) (*ent.User, error) {
+	if password == "" || code == "" {
+		var fields []FieldError
+		for _, f := range []struct{ name, value string }{{"password", password}, {"code", code}} {
+			if f.value == "" {
+				fields = append(fields, FieldError{Field: f.name, Code: "required", Message: f.name + " is required"})
+			}
+		}
+		return nil, NewValidationError("required", "password and code are required", fields...)
+	}
+
+	user, err := s.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !MFAEnabled(user) {
+		return nil, ErrMFANotEnabled
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+		return nil, ErrReauthenticationFailed
+	}
+	ok, err := s.useMFACode(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrReauthenticationFailed
+	}
+	return user, nil
+}
+
+// useMFACode accepts a TOTP code, unless a code of the same or a later step
+// was accepted already, or an unused recovery code, and reports whether it did
+func (s *service) useMFACode(ctx context.Context, user *ent.User, code string) (bool, error) {
+	code = normalizeMFACode(code)
+	if isTOTPCode(code) {
+		step, ok := totp.Validate(*user.MfaSecret, code, time.Now())
+		if !ok {
+			return false, nil
+		}
+		return s.storage.UseUserMFAStep(ctx, user.ID, step)
+	}
+	if len(code) != recoveryCodeLength {
+		return false, nil
+	}
+	return s.storage.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code), time.Now())
+}
+
+// replaceRecoveryCodes gives the user new recovery codes and returns them
+// formatted for display. Only their hashes are stored.
+func (s *service) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	b := make([]byte, recoveryCodeLength)
+	for i := range codes {
+		if _, err := rand.Read(b); err != nil {
+			return nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		// 256 is a multiple of the alphabet size, so every character is equally likely
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+		code := string(b)
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hashes[i] = hashRecoveryCode(code)
+	}
+
+	if err := s.storage.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// normalizeMFACode drops the separators users may type and ignores case
+func normalizeMFACode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-':
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+}
+
+// isTOTPCode reports whether a normalized code is a TOTP code rather than a
+// recovery code
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// hashRecoveryCode returns the hash a normalized recovery code is stored by.
+// The codes carry 50 random bits, too many to guess, so a fast hash is enough.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+package service
+
+import "testing"
+
+func TestMFACodeKinds(t *testing.T) {
+	tests := []struct {
+		name       string
+		code       string
+		normalized string
+		totp       bool
+	}{
+		{"totp code", "123456", "123456", true},
+		{"spaced totp code", "123 456", "123456", true},
+		{"recovery code", "abcde-fghij", "abcdefghij", false},
+		{"uppercase recovery code", "ABCDE FGHIJ", "abcdefghij", false},
+		{"short code", "12345", "12345", false},
+		{"digits and letters", "12345a", "12345a", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := normalizeMFACode(tt.code)
+			if normalized != tt.normalized {
+				t.Fatalf("normalizeMFACode(%q) = %q, want %q", tt.code, normalized, tt.normalized)
+			}
+			if got := isTOTPCode(normalized); got != tt.totp {
+				t.Fatalf("isTOTPCode(%q) = %v, want %v", normalized, got, tt.totp)
+			}
+		})
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	if hashRecoveryCode(normalizeMFACode("ABCDE-FGHIJ")) != hashRecoveryCode("abcdefghij") {
+		t.Fatal("a recovery code typed differently hashes differently")
+	}
+	if hashRecoveryCode("abcdefghij") == hashRecoveryCode("abcdefghik") {
+		t.Fatal("different recovery codes hash the same")
+	}
+}
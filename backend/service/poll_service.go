@@ -3,124 +3,536 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/events"
+	"poll-app/storage"
 
 	"github.com/google/uuid"
 )
 
+// OptionInput describes an option of a created or updated poll. A zero ID
+// adds a new option; otherwise it names the existing option to keep.
+type OptionInput struct {
+	ID          uuid.UUID
+	Label       string
+	Description string
+	ImageURL    string
+}
+
+// PollSettings holds the voting rules of a new poll. Zero fields take their
+// defaults.
+type PollSettings struct {
+	Type                 poll.Type
+	Anonymous            bool
+	ResultsVisibility    poll.ResultsVisibility
+	RequireVerifiedEmail bool
+	MinChoices           int
+	MaxChoices           *int
+	OpensAt              *time.Time
+	ClosesAt             *time.Time
+	// ExternalID identifies an imported poll to its owner; nil for polls
+	// created one by one
+	ExternalID *string
+}
+
+// PollUpdate holds changes to a poll. Nil fields are left unchanged, and the
+// Clear flags remove an optional value. Options, when non-nil, replace the
+// poll's option list.
+type PollUpdate struct {
+	Title                *string
+	Description          *string
+	Options              []OptionInput
+	ResultsVisibility    *poll.ResultsVisibility
+	RequireVerifiedEmail *bool
+	MinChoices           *int
+	MaxChoices           *int
+	ClearMaxChoices      bool
+	OpensAt              *time.Time
+	ClearOpensAt         bool
+	ClosesAt             *time.Time
+	ClearClosesAt        bool
+}
+
+// storageOptions converts option inputs to their storage form
+func storageOptions(options []OptionInput) []storage.OptionInput {
+	if options == nil {
+		return nil
+	}
+	inputs := make([]storage.OptionInput, 0, len(options))
+	for _, opt := range options {
+		inputs = append(inputs, storage.OptionInput(opt))
+	}
+	return inputs
+}
+
+// storageUpdate converts a poll update to its storage form
+func storageUpdate(update PollUpdate) storage.PollUpdate {
+	return storage.PollUpdate{
+		Title:                update.Title,
+		Description:          update.Description,
+		Options:              storageOptions(update.Options),
+		ResultsVisibility:    update.ResultsVisibility,
+		RequireVerifiedEmail: update.RequireVerifiedEmail,
+		MinChoices:           update.MinChoices,
+		MaxChoices:           update.MaxChoices,
+		ClearMaxChoices:      update.ClearMaxChoices,
+		OpensAt:              update.OpensAt,
+		ClearOpensAt:         update.ClearOpensAt,
+		ClosesAt:             update.ClosesAt,
+		ClearClosesAt:        update.ClearClosesAt,
+	}
+}
+
 // PollService defines poll-related business logic
 type PollService interface {
-	CreatePoll(ctx context.Context, title, description string, options []string, ownerID uuid.UUID) (*ent.Poll, error)
+	CreatePoll(ctx context.Context, title, description string, options []OptionInput, settings PollSettings, ownerID uuid.UUID) (*ent.Poll, error)
 	GetPollByID(ctx context.Context, id uuid.UUID) (*ent.Poll, error)
-	ListPolls(ctx context.Context) ([]*ent.Poll, error)
-	UpdatePoll(ctx context.Context, pollID, ownerID uuid.UUID, title, description string, options []string) (*ent.Poll, error)
-	DeletePoll(ctx context.Context, pollID, ownerID uuid.UUID) error
+	ListPolls(ctx context.Context, filter storage.PollFilter, page storage.PollPage) (*storage.PollList, error)
+	UpdatePoll(ctx context.Context, pollID, ownerID uuid.UUID, versions []int, update PollUpdate) (*ent.Poll, error)
+	DeletePoll(ctx context.Context, pollID, ownerID uuid.UUID, versions []int) error
+	ClosePoll(ctx context.Context, pollID, ownerID uuid.UUID) (*ent.Poll, error)
+	FinalizeClosedPolls(ctx context.Context) (int, error)
+	CanViewResults(ctx context.Context, p *ent.Poll, viewerID uuid.UUID) (bool, error)
+	HasVoted(ctx context.Context, p *ent.Poll, userID uuid.UUID) (bool, error)
 	IsPollOwner(ctx context.Context, pollID, userID uuid.UUID) (bool, error)
+	ExportPoll(ctx context.Context, pollID, ownerID uuid.UUID) (*PollExport, error)
+	ExportAnyPoll(ctx context.Context, pollID uuid.UUID) (*PollExport, error)
+	ImportPolls(ctx context.Context, ownerID uuid.UUID, definitions []PollDefinition, dryRun bool) (*ImportResult, error)
+}
+
+func (s *service) CreatePoll(ctx context.Context, title, description string, options []OptionInput, settings PollSettings, ownerID uuid.UUID) (*ent.Poll, error) {
+	if err := preparePoll(title, options, &settings); err != nil {
+		return nil, err
+	}
+
+	// Validate owner exists
+	if _, err := s.storage.GetUserByID(ctx, ownerID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	// The poll and its webhook deliveries are recorded together
+	var created *ent.Poll
+	err := s.withTx(ctx, func(tx *service) error {
+		var err error
+		if created, err = tx.storage.CreatePoll(ctx, title, description, storageOptions(options), storage.PollSettings(settings), ownerID); err != nil {
+			return err
+		}
+		return tx.enqueuePollWebhook(ctx, WebhookEventPollCreated, created, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
 }
 
-func (s *service) CreatePoll(ctx context.Context, title, description string, options []string, ownerID uuid.UUID) (*ent.Poll, error) {
+// preparePoll validates a new poll and fills in the defaults of its settings
+func preparePoll(title string, options []OptionInput, settings *PollSettings) error {
 	if title == "" {
-		return nil, errors.New("title is required")
+		return NewFieldError("title", "required", "title is required")
 	}
 
-	if len(options) < 2 {
-		return nil, errors.New("poll must have at least 2 options")
+	if err := validateOptions(options); err != nil {
+		return err
 	}
 
-	// Validate owner exists
-	if _, err := s.storage.GetUserByID(ctx, ownerID); err != nil {
-		return nil, errors.New("owner not found")
+	if settings.Type == "" {
+		settings.Type = poll.TypeSingle
+	}
+	if err := poll.TypeValidator(settings.Type); err != nil {
+		return NewFieldError("type", "invalid_value", "invalid poll type")
+	}
+
+	if settings.ResultsVisibility == "" {
+		settings.ResultsVisibility = poll.ResultsVisibilityAlways
+	}
+	if err := poll.ResultsVisibilityValidator(settings.ResultsVisibility); err != nil {
+		return NewFieldError("results_visibility", "invalid_value", "invalid results visibility")
+	}
+
+	// Single-choice ballots always carry exactly one option
+	if settings.Type == poll.TypeSingle {
+		one := 1
+		settings.MinChoices = 1
+		settings.MaxChoices = &one
+	}
+	if settings.MinChoices == 0 {
+		settings.MinChoices = 1
+	}
+	if err := validateChoiceLimits(settings.MinChoices, settings.MaxChoices, len(options)); err != nil {
+		return err
 	}
 
-	return s.storage.CreatePoll(ctx, title, description, options, ownerID)
+	if err := validateSchedule(settings.OpensAt, settings.ClosesAt); err != nil {
+		return err
+	}
+
+	if settings.ExternalID != nil {
+		if err := validateExternalID(*settings.ExternalID); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (s *service) GetPollByID(ctx context.Context, id uuid.UUID) (*ent.Poll, error) {
-	return s.storage.GetPollByID(ctx, id)
+	p, err := s.storage.GetPollByID(ctx, id)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+
+	// Polls whose closing time has passed get their results frozen on first read
+	return s.ensureFinalized(ctx, p)
+}
+
+// Page sizes of poll listings
+const (
+	DefaultPollPageSize = 20
+	MaxPollPageSize     = 100
+)
+
+// ErrInvalidCursor is returned when a listing cursor does not belong to the requested sort
+var ErrInvalidCursor = NewFieldError("cursor", "invalid_cursor", "cursor is invalid or belongs to another sort order")
+
+// ListPolls returns a page of the polls matching the filter. A zero sort lists
+// the newest polls first and a zero limit returns DefaultPollPageSize polls.
+func (s *service) ListPolls(ctx context.Context, filter storage.PollFilter, page storage.PollPage) (*storage.PollList, error) {
+	if page.Sort == "" {
+		page.Sort = storage.PollSortNewest
+	}
+	switch page.Sort {
+	case storage.PollSortNewest, storage.PollSortMostVotes, storage.PollSortClosingSoon:
+	default:
+		return nil, NewFieldError("sort", "invalid_value", "invalid sort order")
+	}
+
+	if page.Limit == 0 {
+		page.Limit = DefaultPollPageSize
+	}
+	if page.Limit < 1 || page.Limit > MaxPollPageSize {
+		return nil, NewFieldError("limit", "out_of_range", fmt.Sprintf("limit must be between 1 and %d", MaxPollPageSize))
+	}
+
+	if cursor := page.After; cursor != nil {
+		valid := cursor.Sort == page.Sort
+		switch page.Sort {
+		case storage.PollSortNewest:
+			valid = valid && cursor.CreatedAt != nil
+		case storage.PollSortMostVotes:
+			valid = valid && cursor.Voters != nil
+		case storage.PollSortClosingSoon:
+			valid = valid && cursor.ClosesAt != nil
+		}
+		if !valid {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	switch filter.Status {
+	case "", PollStatusScheduled, PollStatusOpen, PollStatusClosed:
+	default:
+		return nil, NewFieldError("status", "invalid_value", "invalid poll status")
+	}
+	if filter.CreatedAfter != nil && filter.CreatedBefore != nil && !filter.CreatedAfter.Before(*filter.CreatedBefore) {
+		return nil, NewFieldError("created_after", "invalid_range", "created_after must be before created_before")
+	}
+	if filter.Now.IsZero() {
+		filter.Now = time.Now()
+	}
+
+	return s.storage.ListPolls(ctx, filter, page)
 }
 
-func (s *service) ListPolls(ctx context.Context) ([]*ent.Poll, error) {
-	return s.storage.ListPolls(ctx)
+// UpdatePoll applies the update on behalf of the owner. If versions is
+// non-empty the poll must still be at one of them, so edits based on a stale
+// copy fail with ErrPollModified instead of overwriting newer changes.
+func (s *service) UpdatePoll(ctx context.Context, pollID, ownerID uuid.UUID, versions []int, update PollUpdate) (*ent.Poll, error) {
+	// Removing votes of dropped options and replacing the options succeed or fail together
+	var updated *ent.Poll
+	err := s.withTx(ctx, func(tx *service) error {
+		var err error
+		updated, err = tx.updatePoll(ctx, pollID, ownerID, versions, update)
+		return err
+	})
+	if errors.Is(err, storage.ErrPollVersionMismatch) {
+		return nil, ErrPollModified
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, pollID, events.TypePoll, events.PollChange{Version: updated.Version})
+	return updated, nil
 }
 
-func (s *service) UpdatePoll(ctx context.Context, pollID, ownerID uuid.UUID, title, description string, options []string) (*ent.Poll, error) {
+func (s *service) updatePoll(ctx context.Context, pollID, ownerID uuid.UUID, versions []int, update PollUpdate) (*ent.Poll, error) {
 	// Permission check: Only poll owner can update the poll
 	isOwner, err := s.IsPollOwner(ctx, pollID, ownerID)
 	if err != nil {
 		return nil, err
 	}
 	if !isOwner {
-		return nil, errors.New("only poll owner can update the poll")
+		return nil, NewForbiddenError("not_poll_owner", "only poll owner can update the poll")
+	}
+
+	if update.Title != nil && *update.Title == "" {
+		return nil, NewFieldError("title", "required", "title is required")
 	}
 
 	// Validate options if provided
-	if len(options) > 0 && len(options) < 2 {
-		return nil, errors.New("poll must have at least 2 options")
+	options := update.Options
+	if options != nil {
+		if err := validateOptions(options); err != nil {
+			return nil, err
+		}
 	}
 
-	// If options are being updated, identify and clean up votes for removed options
-	if len(options) > 0 {
-		// Get current poll to compare options
-		currentPoll, err := s.storage.GetPollByID(ctx, pollID)
-		if err != nil {
+	if update.ResultsVisibility != nil {
+		if err := poll.ResultsVisibilityValidator(*update.ResultsVisibility); err != nil {
+			return nil, NewFieldError("results_visibility", "invalid_value", "invalid results visibility")
+		}
+	}
+
+	// Edits take turns with votes and closing the poll
+	if err := s.storage.LockPoll(ctx, pollID); err != nil {
+		return nil, err
+	}
+
+	// Get current poll to compare options and choice limits
+	currentPoll, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+	closed := PollStatus(currentPoll, time.Now()) == PollStatusClosed
+
+	// The ballots of a closed poll are final, and so are the options and
+	// choice limits they were cast under
+	if closed && (options != nil || update.MinChoices != nil || update.MaxChoices != nil || update.ClearMaxChoices) {
+		return nil, ErrPollClosed
+	}
+
+	// Fail early on a stale version; the storage write checks it again
+	if len(versions) > 0 && !slices.Contains(versions, currentPoll.Version) {
+		return nil, ErrPollModified
+	}
+
+	if currentPoll.Type == poll.TypeSingle && (update.MinChoices != nil || update.MaxChoices != nil || update.ClearMaxChoices) {
+		return nil, NewValidationError("choice_limits_fixed", "choice limits cannot be changed on single-choice polls")
+	}
+
+	// Validate the resulting choice limits against the resulting options
+	minChoices := currentPoll.MinChoices
+	if update.MinChoices != nil {
+		minChoices = *update.MinChoices
+	}
+	maxChoices := currentPoll.MaxChoices
+	if update.ClearMaxChoices {
+		maxChoices = nil
+	} else if update.MaxChoices != nil {
+		maxChoices = update.MaxChoices
+	}
+	optionCount := len(currentPoll.Edges.Options)
+	if options != nil {
+		optionCount = len(options)
+	}
+	if err := validateChoiceLimits(minChoices, maxChoices, optionCount); err != nil {
+		return nil, err
+	}
+
+	// Ballots already cast met the old limits and must stay valid
+	if currentPoll.VoterCount > 0 && choiceLimitsTightened(currentPoll, minChoices, maxChoices, optionCount) {
+		return nil, ErrChoiceLimitsLocked
+	}
+
+	// The voting window of a closed poll is final
+	if update.OpensAt != nil || update.ClosesAt != nil || update.ClearOpensAt || update.ClearClosesAt {
+		if closed {
+			return nil, ErrPollClosed
+		}
+
+		opensAt := currentPoll.OpensAt
+		if update.ClearOpensAt {
+			opensAt = nil
+		} else if update.OpensAt != nil {
+			opensAt = update.OpensAt
+		}
+		closesAt := currentPoll.ClosesAt
+		if update.ClearClosesAt {
+			closesAt = nil
+		} else if update.ClosesAt != nil {
+			closesAt = update.ClosesAt
+		}
+		if err := validateSchedule(opensAt, closesAt); err != nil {
 			return nil, err
 		}
+	}
 
-		// Find removed options
-		oldOptionsMap := make(map[string]bool)
-		for _, opt := range currentPoll.Options {
-			oldOptionsMap[opt] = true
+	// If options are being updated, identify and clean up votes for removed options.
+	// Options are matched by ID, so renamed and reordered options keep their votes.
+	if options != nil {
+		kept := make(map[uuid.UUID]bool, len(options))
+		for _, opt := range options {
+			if opt.ID == uuid.Nil {
+				continue
+			}
+			if !hasOption(currentPoll, opt.ID) {
+				return nil, ErrOptionNotFound
+			}
+			if kept[opt.ID] {
+				return nil, NewFieldError("options", "duplicate_option", "option listed more than once")
+			}
+			kept[opt.ID] = true
 		}
 
-		var removedOptions []string
-		for _, oldOpt := range currentPoll.Options {
-			found := false
-			for _, newOpt := range options {
-				if oldOpt == newOpt {
-					found = true
-					break
-				}
+		var removedOptions []uuid.UUID
+		for _, opt := range currentPoll.Edges.Options {
+			if !kept[opt.ID] {
+				removedOptions = append(removedOptions, opt.ID)
 			}
-			if !found {
-				removedOptions = append(removedOptions, oldOpt)
+		}
+
+		// Secret ballots cannot be removed without also removing their
+		// participation, which is not linked to them
+		if len(removedOptions) > 0 && currentPoll.Anonymous {
+			voters, err := s.storage.CountParticipationsByPoll(ctx, pollID)
+			if err != nil {
+				return nil, err
+			}
+			if voters > 0 {
+				return nil, NewConflictError("anonymous_poll_has_votes", "options cannot be removed from an anonymous poll that has votes")
 			}
 		}
 
-		// Delete votes for removed options
+		// Take removed options out of the votes, wherever they are ranked
 		if len(removedOptions) > 0 {
-			if err := s.storage.DeleteVotesByPollAndOptions(ctx, pollID, removedOptions); err != nil {
+			if err := s.storage.RemoveOptionsFromVotes(ctx, pollID, removedOptions, minChoices); err != nil {
 				return nil, err
 			}
 		}
 	}
 
-	return s.storage.UpdatePoll(ctx, pollID, title, description, options)
+	if _, err := s.storage.UpdatePoll(ctx, pollID, versions, storageUpdate(update)); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.enqueuePollWebhook(ctx, WebhookEventPollUpdated, updated, nil); err != nil {
+		return nil, err
+	}
+	return updated, nil
 }
 
-func (s *service) DeletePoll(ctx context.Context, pollID, ownerID uuid.UUID) error {
+// DeletePoll deletes the poll on behalf of the owner. If versions is non-empty
+// the poll must still be at one of them, or ErrPollModified is returned.
+func (s *service) DeletePoll(ctx context.Context, pollID, ownerID uuid.UUID, versions []int) error {
+	err := s.withTx(ctx, func(tx *service) error {
+		return tx.deletePoll(ctx, pollID, ownerID, versions)
+	})
+	if errors.Is(err, storage.ErrPollVersionMismatch) {
+		return ErrPollModified
+	}
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, pollID, events.TypeDeleted, struct{}{})
+	return nil
+}
+
+func (s *service) deletePoll(ctx context.Context, pollID, ownerID uuid.UUID, versions []int) error {
 	// Permission check: Only poll owner can delete the poll
 	isOwner, err := s.IsPollOwner(ctx, pollID, ownerID)
 	if err != nil {
 		return err
 	}
 	if !isOwner {
-		return errors.New("only poll owner can delete the poll")
+		return NewForbiddenError("not_poll_owner", "only poll owner can delete the poll")
 	}
 
-	// Delete all votes associated with this poll first to avoid foreign key constraint violation
+	// Delete all votes, results and invitations associated with this poll first to avoid foreign key constraint violation
 	if err := s.storage.DeleteVotesByPoll(ctx, pollID); err != nil {
 		return err
 	}
+	if err := s.storage.DeleteBallotsByPoll(ctx, pollID); err != nil {
+		return err
+	}
+	if err := s.storage.DeletePollResultByPoll(ctx, pollID); err != nil {
+		return err
+	}
+	if err := s.storage.DeletePollInvitationsByPoll(ctx, pollID); err != nil {
+		return err
+	}
 
-	return s.storage.DeletePoll(ctx, pollID)
+	return s.storage.DeletePoll(ctx, pollID, versions)
 }
 
 func (s *service) IsPollOwner(ctx context.Context, pollID, userID uuid.UUID) (bool, error) {
 	poll, err := s.storage.GetPollByID(ctx, pollID)
 	if err != nil {
-		return false, err
+		return false, pollNotFound(err)
 	}
 
 	return poll.OwnerID == userID, nil
 }
+
+// validateOptions checks that a poll has at least two options with distinct, non-empty labels
+func validateOptions(options []OptionInput) error {
+	if len(options) < 2 {
+		return NewFieldError("options", "too_few_options", "poll must have at least 2 options")
+	}
+
+	seen := make(map[string]bool, len(options))
+	for _, opt := range options {
+		if opt.Label == "" {
+			return NewFieldError("options", "required", "option label is required")
+		}
+		if seen[opt.Label] {
+			return NewFieldError("options", "duplicate_option", "option labels must be unique")
+		}
+		seen[opt.Label] = true
+	}
+	return nil
+}
+
+// choiceLimitsTightened reports whether the new choice limits could reject
+// ballots cast under the poll's current ones. Ballots never hold more choices
+// than the poll has options, so lowering max_choices to the option count
+// remaining after an edit does not tighten it.
+func choiceLimitsTightened(p *ent.Poll, minChoices int, maxChoices *int, optionCount int) bool {
+	oldMax := optionCount
+	if p.MaxChoices != nil && *p.MaxChoices < oldMax {
+		oldMax = *p.MaxChoices
+	}
+	newMax := optionCount
+	if maxChoices != nil {
+		newMax = *maxChoices
+	}
+	return minChoices > p.MinChoices || newMax < oldMax
+}
+
+// validateChoiceLimits checks that the per-ballot choice limits fit the number of options
+func validateChoiceLimits(minChoices int, maxChoices *int, optionCount int) error {
+	if minChoices < 1 {
+		return NewFieldError("min_choices", "out_of_range", "min_choices must be at least 1")
+	}
+	if minChoices > optionCount {
+		return NewFieldError("min_choices", "out_of_range", "min_choices cannot exceed the number of options")
+	}
+	if maxChoices != nil {
+		if *maxChoices < minChoices {
+			return NewFieldError("max_choices", "out_of_range", "max_choices cannot be less than min_choices")
+		}
+		if *maxChoices > optionCount {
+			return NewFieldError("max_choices", "out_of_range", "max_choices cannot exceed the number of options")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+
+	"github.com/google/uuid"
+)
+
+// testPoll returns a poll of the type with n options and the choice limits
+func testPoll(pollType poll.Type, n, minChoices int, maxChoices *int) *ent.Poll {
+	p := &ent.Poll{ID: uuid.New(), Type: pollType, MinChoices: minChoices, MaxChoices: maxChoices}
+	for i := 0; i < n; i++ {
+		p.Edges.Options = append(p.Edges.Options, &ent.PollOption{ID: uuid.New(), PollID: p.ID, Position: i})
+	}
+	return p
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+// fieldErrorCode returns the code of the first field error of err
+func fieldErrorCode(err error) string {
+	var svcErr *Error
+	if !errors.As(err, &svcErr) {
+		return ""
+	}
+	if len(svcErr.Fields) > 0 {
+		return svcErr.Fields[0].Code
+	}
+	return svcErr.Code
+}
+
+func TestValidateBallot(t *testing.T) {
+	single := testPoll(poll.TypeSingle, 3, 1, intPtr(1))
+	approval := testPoll(poll.TypeApproval, 4, 2, intPtr(3))
+	ranked := testPoll(poll.TypeRanked, 3, 1, nil)
+	opt := func(p *ent.Poll, i int) uuid.UUID { return p.Edges.Options[i].ID }
+
+	tests := []struct {
+		name    string
+		poll    *ent.Poll
+		choices []uuid.UUID
+		code    string
+	}{
+		{"single choice", single, []uuid.UUID{opt(single, 0)}, ""},
+		{"single with two choices", single, []uuid.UUID{opt(single, 0), opt(single, 1)}, "invalid_choice_count"},
+		{"approval within limits", approval, []uuid.UUID{opt(approval, 0), opt(approval, 2)}, ""},
+		{"approval below min", approval, []uuid.UUID{opt(approval, 0)}, "invalid_choice_count"},
+		{"approval above max", approval, []uuid.UUID{opt(approval, 0), opt(approval, 1), opt(approval, 2), opt(approval, 3)}, "invalid_choice_count"},
+		{"ranked without max", ranked, []uuid.UUID{opt(ranked, 2), opt(ranked, 0), opt(ranked, 1)}, ""},
+		{"duplicate choice", ranked, []uuid.UUID{opt(ranked, 0), opt(ranked, 0)}, "duplicate_option"},
+		{"option of another poll", ranked, []uuid.UUID{opt(single, 0)}, "invalid_option"},
+		{"nil option", ranked, []uuid.UUID{uuid.Nil}, "required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBallot(tt.poll, tt.choices)
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if got := fieldErrorCode(err); got != tt.code {
+				t.Fatalf("error code = %q (%v), want %q", got, err, tt.code)
+			}
+		})
+	}
+}
+
+func TestValidateChoiceLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		minChoices int
+		maxChoices *int
+		options    int
+		code       string
+	}{
+		{"defaults", 1, nil, 2, ""},
+		{"min equals options", 3, nil, 3, ""},
+		{"min below one", 0, nil, 3, "out_of_range"},
+		{"min above options", 4, nil, 3, "out_of_range"},
+		{"max below min", 2, intPtr(1), 3, "out_of_range"},
+		{"max above options", 1, intPtr(4), 3, "out_of_range"},
+		{"max equals options", 1, intPtr(3), 3, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChoiceLimits(tt.minChoices, tt.maxChoices, tt.options)
+			if got := fieldErrorCode(err); got != tt.code {
+				t.Fatalf("error code = %q (%v), want %q", got, err, tt.code)
+			}
+		})
+	}
+}
+
+func TestChoiceLimitsTightened(t *testing.T) {
+	tests := []struct {
+		name       string
+		poll       *ent.Poll
+		minChoices int
+		maxChoices *int
+		options    int
+		want       bool
+	}{
+		{"unchanged", testPoll(poll.TypeApproval, 4, 2, intPtr(3)), 2, intPtr(3), 4, false},
+		{"min lowered", testPoll(poll.TypeApproval, 4, 2, intPtr(3)), 1, intPtr(3), 4, false},
+		{"min raised", testPoll(poll.TypeApproval, 4, 2, intPtr(3)), 3, intPtr(3), 4, true},
+		{"max raised", testPoll(poll.TypeApproval, 4, 2, intPtr(3)), 2, intPtr(4), 4, false},
+		{"max lowered", testPoll(poll.TypeApproval, 4, 2, intPtr(3)), 2, intPtr(2), 4, true},
+		{"max removed", testPoll(poll.TypeApproval, 4, 2, intPtr(3)), 2, nil, 4, false},
+		{"max set where there was none", testPoll(poll.TypeRanked, 4, 1, nil), 1, intPtr(3), 4, true},
+		{"max set to the option count", testPoll(poll.TypeRanked, 4, 1, nil), 1, intPtr(4), 4, false},
+		{"max lowered with removed options", testPoll(poll.TypeApproval, 4, 1, intPtr(4)), 1, intPtr(3), 3, false},
+		{"max lowered below remaining options", testPoll(poll.TypeApproval, 4, 1, intPtr(4)), 1, intPtr(2), 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := choiceLimitsTightened(tt.poll, tt.minChoices, tt.maxChoices, tt.options); got != tt.want {
+				t.Fatalf("choiceLimitsTightened = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreparePoll(t *testing.T) {
+	options := []OptionInput{{Label: "a"}, {Label: "b"}, {Label: "c"}}
+
+	tests := []struct {
+		name     string
+		title    string
+		options  []OptionInput
+		settings PollSettings
+		code     string
+		want     PollSettings
+	}{
+		{
+			name:    "defaults",
+			title:   "t",
+			options: options,
+			want:    PollSettings{Type: poll.TypeSingle, ResultsVisibility: poll.ResultsVisibilityAlways, MinChoices: 1, MaxChoices: intPtr(1)},
+		},
+		{
+			name:     "single choice limits are fixed",
+			title:    "t",
+			options:  options,
+			settings: PollSettings{Type: poll.TypeSingle, MinChoices: 2, MaxChoices: intPtr(3)},
+			want:     PollSettings{Type: poll.TypeSingle, ResultsVisibility: poll.ResultsVisibilityAlways, MinChoices: 1, MaxChoices: intPtr(1)},
+		},
+		{
+			name:     "approval keeps its limits",
+			title:    "t",
+			options:  options,
+			settings: PollSettings{Type: poll.TypeApproval, MaxChoices: intPtr(2)},
+			want:     PollSettings{Type: poll.TypeApproval, ResultsVisibility: poll.ResultsVisibilityAlways, MinChoices: 1, MaxChoices: intPtr(2)},
+		},
+		{name: "missing title", options: options, code: "required"},
+		{name: "one option", title: "t", options: options[:1], code: "too_few_options"},
+		{name: "duplicate labels", title: "t", options: []OptionInput{{Label: "a"}, {Label: "a"}}, code: "duplicate_option"},
+		{name: "unknown type", title: "t", options: options, settings: PollSettings{Type: "plurality"}, code: "invalid_value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := tt.settings
+			err := preparePoll(tt.title, tt.options, &settings)
+			if got := fieldErrorCode(err); got != tt.code {
+				t.Fatalf("error code = %q (%v), want %q", got, err, tt.code)
+			}
+			if tt.code != "" {
+				return
+			}
+			if settings.Type != tt.want.Type || settings.ResultsVisibility != tt.want.ResultsVisibility || settings.MinChoices != tt.want.MinChoices {
+				t.Errorf("settings = %+v, want %+v", settings, tt.want)
+			}
+			if (settings.MaxChoices == nil) != (tt.want.MaxChoices == nil) ||
+				(settings.MaxChoices != nil && *settings.MaxChoices != *tt.want.MaxChoices) {
+				t.Errorf("max choices = %v, want %v", settings.MaxChoices, tt.want.MaxChoices)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+
+	"github.com/google/uuid"
+)
+
+// ErrNotRankedPoll is returned when requesting instant-runoff results of a poll that is not ranked
+var ErrNotRankedPoll = NewValidationError("not_ranked_poll", "poll is not a ranked poll")
+
+// RankedRound describes a single instant-runoff tabulation round. Options are
+// named by their ID.
+type RankedRound struct {
+	Round      int            `json:"round"`
+	Counts     map[string]int `json:"counts"`
+	Eliminated []string       `json:"eliminated,omitempty"`
+	Exhausted  int            `json:"exhausted"`
+}
+
+// RankedResult holds the full instant-runoff outcome of a ranked poll
+type RankedResult struct {
+	Rounds       []RankedRound `json:"rounds"`
+	Winner       string        `json:"winner,omitempty"`
+	Tied         []string      `json:"tied,omitempty"`
+	TotalBallots int           `json:"total_ballots"`
+	Exhausted    int           `json:"exhausted"`
+}
+
+// GetRankedResults tabulates a ranked poll using instant-runoff voting
+func (s *service) GetRankedResults(ctx context.Context, pollID, viewerID uuid.UUID) (*RankedResult, error) {
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+
+	if p.Type != poll.TypeRanked {
+		return nil, ErrNotRankedPoll
+	}
+
+	p, err = s.ensureFinalized(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkResultsVisible(ctx, p, viewerID); err != nil {
+		return nil, err
+	}
+
+	// Closed polls report their frozen results
+	if p.Edges.Result != nil && p.Edges.Result.Ranked != nil {
+		var result RankedResult
+		if err := json.Unmarshal(p.Edges.Result.Ranked, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode ranked results: %w", err)
+		}
+		return &result, nil
+	}
+
+	return s.tabulateRanked(ctx, p)
+}
+
+// tabulateRanked runs the instant-runoff count over the live ballots of a poll
+func (s *service) tabulateRanked(ctx context.Context, p *ent.Poll) (*RankedResult, error) {
+	if p.Anonymous {
+		secret, err := s.storage.GetBallotChoicesByPoll(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		ballots := make([][]string, 0, len(secret))
+		for _, choices := range secret {
+			ballots = append(ballots, ballotKeys(choices))
+		}
+
+		return TabulateInstantRunoff(optionKeys(p), ballots), nil
+	}
+
+	votes, err := s.storage.GetVotesByPoll(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ballots := make([][]string, 0, len(votes))
+	for _, v := range votes {
+		ballots = append(ballots, ballotKeys(v.OptionIds))
+	}
+
+	return TabulateInstantRunoff(optionKeys(p), ballots), nil
+}
+
+// TabulateInstantRunoff runs an instant-runoff count over the given ballots.
+// Each round every ballot counts for its highest-ranked continuing option.
+// A candidate holding a strict majority of the non-exhausted ballots wins;
+// otherwise all candidates sharing the lowest tally are eliminated together.
+// If every continuing candidate is tied, the result is a tie between them.
+// Without any ballot counting for an option nobody wins and nobody ties.
+// Choices that are not among options are ignored.
+func TabulateInstantRunoff(options []string, ballots [][]string) *RankedResult {
+	result := &RankedResult{TotalBallots: len(ballots)}
+	if len(ballots) == 0 {
+		return result
+	}
+
+	continuing := make(map[string]bool, len(options))
+	for _, opt := range options {
+		continuing[opt] = true
+	}
+
+	for round := 1; len(continuing) > 0; round++ {
+		counts := make(map[string]int, len(continuing))
+		for opt := range continuing {
+			counts[opt] = 0
+		}
+
+		exhausted := 0
+		for _, ballot := range ballots {
+			if top, ok := topContinuingChoice(ballot, continuing); ok {
+				counts[top]++
+			} else {
+				exhausted++
+			}
+		}
+
+		current := RankedRound{
+			Round:     round,
+			Counts:    counts,
+			Exhausted: exhausted,
+		}
+		result.Exhausted = exhausted
+
+		active := len(ballots) - exhausted
+		if active == 0 {
+			result.Rounds = append(result.Rounds, current)
+			return result
+		}
+		leader, lowest := leaderAndLowest(counts)
+
+		// A single remaining candidate, or a strict majority, ends the count
+		if len(continuing) == 1 || counts[leader]*2 > active {
+			result.Rounds = append(result.Rounds, current)
+			result.Winner = leader
+			return result
+		}
+
+		// Eliminating every continuing candidate would leave nobody, so it is a tie
+		if len(lowest) == len(continuing) {
+			result.Rounds = append(result.Rounds, current)
+			result.Tied = lowest
+			return result
+		}
+
+		for _, opt := range lowest {
+			delete(continuing, opt)
+		}
+		current.Eliminated = lowest
+		result.Rounds = append(result.Rounds, current)
+	}
+
+	return result
+}
+
+// topContinuingChoice returns the highest-ranked choice still in the count
+func topContinuingChoice(ballot []string, continuing map[string]bool) (string, bool) {
+	for _, choice := range ballot {
+		if continuing[choice] {
+			return choice, true
+		}
+	}
+	return "", false
+}
+
+// leaderAndLowest returns the candidate with the most votes and the sorted
+// list of candidates sharing the lowest tally
+func leaderAndLowest(counts map[string]int) (string, []string) {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	leader := ""
+	var lowest []string
+	for _, name := range names {
+		if leader == "" || counts[name] > counts[leader] {
+			leader = name
+		}
+		switch {
+		case len(lowest) == 0 || counts[name] < counts[lowest[0]]:
+			lowest = []string{name}
+		case counts[name] == counts[lowest[0]]:
+			lowest = append(lowest, name)
+		}
+	}
+
+	return leader, lowest
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTabulateInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []string
+		ballots [][]string
+
+		winner     string
+		tied       []string
+		eliminated [][]string
+		exhausted  int
+	}{
+		{
+			name:    "no ballots",
+			options: []string{"a", "b"},
+		},
+		{
+			name:       "only unknown choices",
+			options:    []string{"a", "b"},
+			ballots:    [][]string{{"x"}, {"y", "z"}},
+			eliminated: [][]string{nil},
+			exhausted:  2,
+		},
+		{
+			name:       "majority in the first round",
+			options:    []string{"a", "b", "c"},
+			ballots:    [][]string{{"a"}, {"a", "b"}, {"b"}},
+			winner:     "a",
+			eliminated: [][]string{nil},
+		},
+		{
+			name:       "single option",
+			options:    []string{"a"},
+			ballots:    [][]string{{"a"}},
+			winner:     "a",
+			eliminated: [][]string{nil},
+		},
+		{
+			name:       "transfers after elimination",
+			options:    []string{"a", "b", "c"},
+			ballots:    [][]string{{"a"}, {"a"}, {"b", "a"}, {"b"}, {"c", "b"}},
+			winner:     "b",
+			eliminated: [][]string{{"c"}, nil},
+		},
+		{
+			name:       "lowest candidates eliminated together",
+			options:    []string{"a", "b", "c", "d"},
+			ballots:    [][]string{{"a"}, {"a"}, {"b"}, {"b"}, {"c", "a"}, {"d", "a"}},
+			winner:     "a",
+			eliminated: [][]string{{"c", "d"}, nil},
+		},
+		{
+			name:       "tie after ballots are exhausted",
+			options:    []string{"a", "b", "c"},
+			ballots:    [][]string{{"a"}, {"a"}, {"b"}, {"c"}, {"c"}},
+			tied:       []string{"a", "c"},
+			eliminated: [][]string{{"b"}, nil},
+			exhausted:  1,
+		},
+		{
+			name:       "every option tied",
+			options:    []string{"a", "b"},
+			ballots:    [][]string{{"a"}, {"b"}},
+			tied:       []string{"a", "b"},
+			eliminated: [][]string{nil},
+		},
+		{
+			name:       "unknown choices are skipped",
+			options:    []string{"a", "b"},
+			ballots:    [][]string{{"x", "a"}, {"b"}, {"a"}},
+			winner:     "a",
+			eliminated: [][]string{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TabulateInstantRunoff(tt.options, tt.ballots)
+
+			if result.Winner != tt.winner {
+				t.Errorf("winner = %q, want %q", result.Winner, tt.winner)
+			}
+			if !slices.Equal(result.Tied, tt.tied) {
+				t.Errorf("tied = %v, want %v", result.Tied, tt.tied)
+			}
+			if result.TotalBallots != len(tt.ballots) {
+				t.Errorf("total ballots = %d, want %d", result.TotalBallots, len(tt.ballots))
+			}
+			if result.Exhausted != tt.exhausted {
+				t.Errorf("exhausted = %d, want %d", result.Exhausted, tt.exhausted)
+			}
+
+			if len(result.Rounds) != len(tt.eliminated) {
+				t.Fatalf("got %d rounds, want %d", len(result.Rounds), len(tt.eliminated))
+			}
+			for i, round := range result.Rounds {
+				if round.Round != i+1 {
+					t.Errorf("round %d numbered %d", i+1, round.Round)
+				}
+				if !slices.Equal(round.Eliminated, tt.eliminated[i]) {
+					t.Errorf("round %d eliminated %v, want %v", i+1, round.Eliminated, tt.eliminated[i])
+				}
+				total := round.Exhausted
+				for _, count := range round.Counts {
+					total += count
+				}
+				if total != len(tt.ballots) {
+					t.Errorf("round %d accounts for %d ballots, want %d", i+1, total, len(tt.ballots))
+				}
+			}
+		})
+	}
+}
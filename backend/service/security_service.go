@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/securityevent"
+	"poll-app/mail"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
+
+// Page sizes of security event lists
+const (
+	DefaultSecurityEventPageSize = 50
+	MaxSecurityEventPageSize     = 200
+)
+
+// SecurityService defines operations on the security events of users
+type SecurityService interface {
+	RecordSecurityEvent(ctx context.Context, event storage.SecurityEventInput) (*ent.SecurityEvent, error)
+	ListSecurityEvents(ctx context.Context, userID uuid.UUID, limit int) ([]*ent.SecurityEvent, error)
+}
+
+// RecordSecurityEvent records an event on the user's account and emails the
+// user about the events that need their attention
+func (s *service) RecordSecurityEvent(ctx context.Context, event storage.SecurityEventInput) (*ent.SecurityEvent, error) {
+	created, err := s.storage.CreateSecurityEvent(ctx, event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record %s security event: %w", event.Type, err)
+	}
+
+	if created.Type == securityevent.TypeRefreshTokenReused {
+		// The event is recorded whether or not the user can be mailed
+		user, err := s.storage.GetUserByID(ctx, created.UserID)
+		if err != nil {
+			log.Printf("Failed to load user %s to report a reused refresh token: %v", created.UserID, err)
+			return created, nil
+		}
+		s.sendMail(user.ID, refreshTokenReusedMessage(user, created))
+	}
+	return created, nil
+}
+
+// refreshTokenReusedMessage tells the user that one of their sessions was
+// ended because its refresh token was presented again, as described by event
+func refreshTokenReusedMessage(user *ent.User, event *ent.SecurityEvent) mail.Message {
+	// The user agent is left out, as whoever sent the token chose it
+	from := ""
+	if event.IPAddress != "" {
+		from = " from " + event.IPAddress
+	}
+
+	return mail.Message{
+		To:      user.Email,
+		Subject: "One of your sessions was signed out",
+		Body: fmt.Sprintf("Hi %s,\n\n"+
+			"On %s, a sign-in token of one of your sessions was used again%s after it had been replaced. "+
+			"This suggests someone copied it, so the session was signed out and you may need to sign in again on that device.\n\n"+
+			"If you do not recognize this, change your password and sign out of your other sessions.\n",
+			user.Username, event.CreatedAt.UTC().Format(time.RFC1123), from),
+	}
+}
+
+// ListSecurityEvents returns the user's latest security events, newest first
+func (s *service) ListSecurityEvents(ctx context.Context, userID uuid.UUID, limit int) ([]*ent.SecurityEvent, error) {
+	if limit == 0 {
+		limit = DefaultSecurityEventPageSize
+	}
+	if limit < 1 || limit > MaxSecurityEventPageSize {
+		return nil, NewFieldError("limit", "out_of_range", fmt.Sprintf("limit must be between 1 and %d", MaxSecurityEventPageSize))
+	}
+
+	return s.storage.ListSecurityEventsByUser(ctx, userID, limit)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"poll-app/ent"
+)
+
+func TestRefreshTokenReusedMessage(t *testing.T) {
+	user := &ent.User{Email: "a@example.com", Username: "alice"}
+	event := &ent.SecurityEvent{
+		IPAddress: "203.0.113.7",
+		UserAgent: "Visit https://evil.example to keep your account",
+		CreatedAt: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+	}
+
+	msg := refreshTokenReusedMessage(user, event)
+	if msg.To != user.Email {
+		t.Errorf("To = %q, want %q", msg.To, user.Email)
+	}
+	for _, want := range []string{"alice", "203.0.113.7", "17 Oct 2026 12:00:00"} {
+		if !strings.Contains(msg.Body, want) {
+			t.Errorf("body %q does not contain %q", msg.Body, want)
+		}
+	}
+	// The sender of the token chose the user agent
+	if strings.Contains(msg.Body, "evil.example") {
+		t.Errorf("body %q contains the user agent", msg.Body)
+	}
+}
@@ -1,20 +1,91 @@
 package service
 
-import "poll-app/storage"
+import (
+	"context"
+	"log"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/events"
+	"poll-app/mail"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
 
 // Service defines the interface for business logic operations
 type Service interface {
 	UserService
 	PollService
 	VoteService
+	WebhookService
+	SecurityService
+	AccountService
+	MFAService
+	InvitationService
 }
 
 // service implements the Service interface
 type service struct {
 	storage storage.Storage
+	events  events.Publisher
+	mailer  mail.Mailer
+	// appURL is the address of the web app that links in emails point to
+	appURL string
+	// mfaIssuer names the app in authenticator apps
+	mfaIssuer string
+}
+
+// NewService creates a new service instance that announces poll changes to
+// publisher and sends account and invitation emails, linking to the web app
+// at appURL, with mailer. Authenticator apps list accounts under mfaIssuer.
+func NewService(storage storage.Storage, publisher events.Publisher, mailer mail.Mailer, appURL, mfaIssuer string) Service {
+	return &service{storage: storage, events: publisher, mailer: mailer, appURL: appURL, mfaIssuer: mfaIssuer}
+}
+
+// withTx runs fn with a service whose storage operations all take part in one
+// transaction, committed only if fn succeeds
+func (s *service) withTx(ctx context.Context, fn func(tx *service) error) error {
+	return s.storage.WithTx(ctx, func(tx storage.Storage) error {
+		return fn(&service{storage: tx, events: s.events, mailer: s.mailer, appURL: s.appURL, mfaIssuer: s.mfaIssuer})
+	})
+}
+
+// publish announces a poll change. Events must only be published once the
+// change is committed; a failure is logged rather than failing the write,
+// since watchers resynchronize on their next event or reconnect.
+func (s *service) publish(ctx context.Context, pollID uuid.UUID, eventType string, data any) {
+	if err := s.events.Publish(ctx, pollID, eventType, data); err != nil {
+		log.Printf("Failed to publish %s event for poll %s: %v", eventType, pollID, err)
+	}
+}
+
+// publishVoteCounts announces the current vote counts of a poll after voterID
+// cast or retracted their vote. The counts of an anonymous poll only change
+// when its ballots are flushed, so they are announced only if countsChanged.
+func (s *service) publishVoteCounts(ctx context.Context, pollID, voterID uuid.UUID, countsChanged bool) {
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		log.Printf("Failed to load vote counts of poll %s: %v", pollID, err)
+		return
+	}
+
+	// Only the voter's own streams need to check whether the results are
+	// visible to them now. Announced first, so they know before the counts
+	// arrive; it is not recorded, so the voter is not kept with the counts.
+	if p.ResultsVisibility == poll.ResultsVisibilityAfterVote {
+		if err := s.events.Announce(ctx, pollID, events.TypeVoted, events.Voted{UserID: voterID}); err != nil {
+			log.Printf("Failed to announce %s event for poll %s: %v", events.TypeVoted, pollID, err)
+		}
+	}
+
+	if countsChanged {
+		s.publishCounts(ctx, p)
+	}
 }
 
-// NewService creates a new service instance
-func NewService(storage storage.Storage) Service {
-	return &service{storage: storage}
+// publishCounts announces the current vote counts of p
+func (s *service) publishCounts(ctx context.Context, p *ent.Poll) {
+	counts := liveVoteCounts(p)
+	s.publish(ctx, p.ID, events.TypeVotes, events.Counts{Counts: counts.Counts, Voters: counts.Voters})
 }
@@ -2,15 +2,25 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"log"
+	"net/mail"
+	"strings"
 
 	"poll-app/ent"
+	"poll-app/storage"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	// ErrEmailTaken is returned when signing up with an email that is already registered
+	ErrEmailTaken = NewConflictError("email_taken", "user with this email already exists")
+	// ErrUsernameTaken is returned when signing up with a username that is already taken
+	ErrUsernameTaken = NewConflictError("username_taken", "user with this username already exists")
+)
+
 // UserService defines user-related business logic
 type UserService interface {
 	CreateUser(ctx context.Context, email, username, password string) (*ent.User, error)
@@ -21,16 +31,27 @@ type UserService interface {
 func (s *service) CreateUser(ctx context.Context, email, username, password string) (*ent.User, error) {
 	// Validate inputs
 	if email == "" || username == "" || password == "" {
-		return nil, errors.New("email, username, and password are required")
+		var fields []FieldError
+		for _, f := range []struct{ name, value string }{{"email", email}, {"username", username}, {"password", password}} {
+			if f.value == "" {
+				fields = append(fields, FieldError{Field: f.name, Code: "required", Message: f.name + " is required"})
+			}
+		}
+		return nil, NewValidationError("required", "email, username, and password are required", fields...)
+	}
+
+	// Verification links are mailed to the address, so it must be deliverable
+	if !validEmail(email) {
+		return nil, NewFieldError("email", "invalid_email", "email must be a valid email address")
 	}
 
 	// Check if user already exists
 	if _, err := s.storage.GetUserByEmail(ctx, email); err == nil {
-		return nil, errors.New("user with this email already exists")
+		return nil, ErrEmailTaken
 	}
 
 	if _, err := s.storage.GetUserByUsername(ctx, username); err == nil {
-		return nil, errors.New("user with this username already exists")
+		return nil, ErrUsernameTaken
 	}
 
 	// Hash password
@@ -39,28 +60,58 @@ func (s *service) CreateUser(ctx context.Context, email, username, password stri
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
-	// Create user
-	return s.storage.CreateUser(ctx, email, username, string(hashedPassword))
+	// Create user. The checks above race with concurrent sign-ups, which the
+	// unique indexes on email and username catch.
+	user, err := s.storage.CreateUser(ctx, email, username, string(hashedPassword))
+	if constraint, ok := storage.UniqueViolation(err); ok {
+		if strings.Contains(constraint, "email") {
+			return nil, ErrEmailTaken
+		}
+		return nil, ErrUsernameTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The account works without a verified address, so a failure to send the
+	// link is only logged; the user can ask for another one
+	if err := s.sendEmailVerification(ctx, user); err != nil {
+		log.Printf("Failed to send email verification to user %s: %v", user.ID, err)
+	}
+	return user, nil
 }
 
 func (s *service) Login(ctx context.Context, email, password string) (*ent.User, error) {
 	if email == "" || password == "" {
-		return nil, errors.New("email and password are required")
+		return nil, NewValidationError("required", "email and password are required")
 	}
 
 	user, err := s.storage.GetUserByEmail(ctx, email)
+	if ent.IsNotFound(err) {
+		return nil, ErrInvalidCredentials
+	}
 	if err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, err
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return nil, errors.New("invalid email or password")
+		return nil, ErrInvalidCredentials
 	}
 
 	return user, nil
 }
 
 func (s *service) GetUserByID(ctx context.Context, id uuid.UUID) (*ent.User, error) {
-	return s.storage.GetUserByID(ctx, id)
+	user, err := s.storage.GetUserByID(ctx, id)
+	if ent.IsNotFound(err) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// validEmail reports whether email is a bare email address, without a display name
+func validEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
 }
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+
+	"github.com/google/uuid"
+)
+
+// ErrResultsHidden is returned when the poll's results visibility policy hides its results from the caller
+var ErrResultsHidden = NewForbiddenError("results_hidden", "results of this poll are not visible to you")
+
+// ResultsVisible reports whether a viewer may see the counts and voters of a poll.
+// viewerID is uuid.Nil for unauthenticated callers, and hasVoted reports whether
+// the viewer has voted on the poll. Owners can always see their poll's results.
+func ResultsVisible(p *ent.Poll, viewerID uuid.UUID, hasVoted bool) bool {
+	if viewerID != uuid.Nil && viewerID == p.OwnerID {
+		return true
+	}
+
+	closed := PollStatus(p, time.Now()) == PollStatusClosed
+
+	switch p.ResultsVisibility {
+	case poll.ResultsVisibilityAfterVote:
+		return hasVoted || closed
+	case poll.ResultsVisibilityAfterClose:
+		return closed
+	case poll.ResultsVisibilityOwnerOnly:
+		return false
+	default:
+		return true
+	}
+}
+
+func (s *service) CanViewResults(ctx context.Context, p *ent.Poll, viewerID uuid.UUID) (bool, error) {
+	// Only the after_vote policy depends on whether the viewer voted
+	hasVoted := false
+	if p.ResultsVisibility == poll.ResultsVisibilityAfterVote && viewerID != uuid.Nil && !ResultsVisible(p, viewerID, false) {
+		voted, err := s.HasVoted(ctx, p, viewerID)
+		if err != nil {
+			return false, err
+		}
+		hasVoted = voted
+	}
+
+	return ResultsVisible(p, viewerID, hasVoted), nil
+}
+
+// HasVoted reports whether the user has cast a vote or secret ballot on the poll
+func (s *service) HasVoted(ctx context.Context, p *ent.Poll, userID uuid.UUID) (bool, error) {
+	var err error
+	if p.Anonymous {
+		_, err = s.storage.GetParticipationByUserAndPoll(ctx, userID, p.ID)
+	} else {
+		_, err = s.storage.GetVoteByUserAndPoll(ctx, userID, p.ID)
+	}
+
+	if ent.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkResultsVisible returns ErrResultsHidden if the viewer may not see the poll's results
+func (s *service) checkResultsVisible(ctx context.Context, p *ent.Poll, viewerID uuid.UUID) error {
+	visible, err := s.CanViewResults(ctx, p, viewerID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return ErrResultsHidden
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+
+	"github.com/google/uuid"
+)
+
+func TestResultsVisible(t *testing.T) {
+	ownerID := uuid.New()
+	closed := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		visibility poll.ResultsVisibility
+		closed     bool
+		// want is whether results are visible to the owner, a voter, a user
+		// who did not vote and an unauthenticated caller
+		want [4]bool
+	}{
+		{poll.ResultsVisibilityAlways, false, [4]bool{true, true, true, true}},
+		{poll.ResultsVisibilityAfterVote, false, [4]bool{true, true, false, false}},
+		{poll.ResultsVisibilityAfterVote, true, [4]bool{true, true, true, true}},
+		{poll.ResultsVisibilityAfterClose, false, [4]bool{true, false, false, false}},
+		{poll.ResultsVisibilityAfterClose, true, [4]bool{true, true, true, true}},
+		{poll.ResultsVisibilityOwnerOnly, true, [4]bool{true, false, false, false}},
+	}
+
+	for _, tt := range tests {
+		name := string(tt.visibility)
+		if tt.closed {
+			name += " closed"
+		}
+		t.Run(name, func(t *testing.T) {
+			p := &ent.Poll{OwnerID: ownerID, ResultsVisibility: tt.visibility}
+			if tt.closed {
+				p.ClosesAt = &closed
+			}
+
+			viewers := []struct {
+				id    uuid.UUID
+				voted bool
+			}{{ownerID, false}, {uuid.New(), true}, {uuid.New(), false}, {uuid.Nil, false}}
+			for i, v := range viewers {
+				if got := ResultsVisible(p, v.id, v.voted); got != tt.want[i] {
+					t.Errorf("viewer %d: ResultsVisible = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
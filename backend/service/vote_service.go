@@ -2,53 +2,141 @@ package service
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"log"
+	"time"
 
 	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/storage"
 
 	"github.com/google/uuid"
 )
 
+var (
+	// ErrVotersSecret is returned when listing the voters of an anonymous poll
+	ErrVotersSecret = NewForbiddenError("voters_secret", "voters of anonymous polls are secret")
+	// ErrVoteNotRetractable is returned when retracting a secret ballot, which cannot be found again
+	ErrVoteNotRetractable = NewForbiddenError("vote_not_retractable", "votes on anonymous polls cannot be retracted")
+	// ErrEmailNotVerified is returned when voting on a poll that requires a verified email address without one
+	ErrEmailNotVerified = NewForbiddenError("email_not_verified", "this poll requires a verified email address to vote")
+)
+
 // VoteService defines vote-related business logic
 type VoteService interface {
-	VoteOnPoll(ctx context.Context, userID, pollID uuid.UUID, option string) (*ent.Vote, error)
-	GetVoteCounts(ctx context.Context, pollID uuid.UUID) (map[string]int, error)
-	GetVotersByOption(ctx context.Context, pollID uuid.UUID, option string) ([]*ent.User, error)
+	VoteOnPoll(ctx context.Context, userID, pollID uuid.UUID, choices []uuid.UUID) (*ent.Vote, error)
+	GetVoteCounts(ctx context.Context, pollID, viewerID uuid.UUID) (*VoteCounts, error)
+	GetVotersByOption(ctx context.Context, pollID, optionID, viewerID uuid.UUID, after *uuid.UUID, limit int) (*Voters, error)
 	DeleteVote(ctx context.Context, userID, pollID uuid.UUID) error
+	GetRankedResults(ctx context.Context, pollID, viewerID uuid.UUID) (*RankedResult, error)
+	FlushBallots(ctx context.Context) (int, error)
 }
 
-func (s *service) VoteOnPoll(ctx context.Context, userID, pollID uuid.UUID, option string) (*ent.Vote, error) {
-	if option == "" {
-		return nil, errors.New("option is required")
+// BallotBatchSize is how many secret ballots of a poll are moved from pending
+// to the ballots table together while the poll is open. Each batch hides which
+// of its voters cast which ballot; after closing, the rest go in a last batch.
+const BallotBatchSize = 10
+
+func (s *service) VoteOnPoll(ctx context.Context, userID, pollID uuid.UUID, choices []uuid.UUID) (*ent.Vote, error) {
+	var (
+		vote      *ent.Vote
+		anonymous bool
+	)
+	err := s.withTx(ctx, func(tx *service) error {
+		var err error
+		vote, anonymous, err = tx.voteOnPoll(ctx, userID, pollID, choices)
+		return err
+	})
+
+	// A concurrent vote by the same user passed the check too; the unique
+	// index on votes and participations lets only one of them commit
+	if _, ok := storage.UniqueViolation(err); ok {
+		return nil, ErrAlreadyVoted
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The ballot is pending until enough others were cast with it; the
+	// worker retries batches this misses. Until then the counts stay as they
+	// were, so they cannot tell when the voter cast which ballot.
+	countsChanged := true
+	if anonymous {
+		flushed, err := s.storage.FlushBallots(ctx, pollID, BallotBatchSize)
+		if err != nil {
+			log.Printf("Failed to flush ballots of poll %s: %v", pollID, err)
+		}
+		countsChanged = flushed > 0
+	}
+
+	s.publishVoteCounts(ctx, pollID, userID, countsChanged)
+	return vote, nil
+}
+
+// voteOnPoll casts the vote and reports whether the poll is anonymous
+func (s *service) voteOnPoll(ctx context.Context, userID, pollID uuid.UUID, choices []uuid.UUID) (*ent.Vote, bool, error) {
+	if len(choices) == 0 {
+		return nil, false, NewFieldError("option_id", "required", "option is required")
+	}
+
+	// Voting takes turns with closing the poll, so no vote lands after its results are frozen
+	if err := s.storage.LockPoll(ctx, pollID); err != nil {
+		return nil, false, err
 	}
 
 	// Get poll to validate option exists
-	poll, err := s.storage.GetPollByID(ctx, pollID)
+	p, err := s.storage.GetPollByID(ctx, pollID)
 	if err != nil {
-		return nil, errors.New("poll not found")
+		return nil, false, pollNotFound(err)
 	}
 
-	// Validate option is in poll options
-	validOption := false
-	for _, opt := range poll.Options {
-		if opt == option {
-			validOption = true
-			break
+	if err := checkPollOpen(p); err != nil {
+		return nil, false, err
+	}
+
+	if p.RequireVerifiedEmail {
+		voter, err := s.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, false, err
 		}
+		if voter.EmailVerifiedAt == nil {
+			return nil, false, ErrEmailNotVerified
+		}
+	}
+
+	if err := validateBallot(p, choices); err != nil {
+		return nil, false, err
+	}
+
+	var vote *ent.Vote
+	if p.Anonymous {
+		vote, err = s.voteAnonymously(ctx, userID, p, choices)
+	} else {
+		vote, err = s.voteOpenly(ctx, userID, p, choices)
+	}
+	if err != nil {
+		return nil, false, err
 	}
-	if !validOption {
-		return nil, errors.New("invalid option for this poll")
+
+	if err := s.enqueueVoteWebhook(ctx, WebhookEventVoteCast, pollID, userID, choices); err != nil {
+		return nil, false, err
 	}
+	return vote, p.Anonymous, nil
+}
+
+// voteOpenly records a ballot attributed to the voter
+func (s *service) voteOpenly(ctx context.Context, userID uuid.UUID, p *ent.Poll, choices []uuid.UUID) (*ent.Vote, error) {
+	pollID := p.ID
 
 	// Permission check: User can only vote once per poll (enforced by checking existing vote)
 	// Check if user already voted
 	existingVote, err := s.storage.GetVoteByUserAndPoll(ctx, userID, pollID)
 	if err == nil && existingVote != nil {
-		// Check if existing vote is for a valid option
-		validExistingOption := false
-		for _, opt := range poll.Options {
-			if opt == existingVote.Option {
-				validExistingOption = true
+		// Check if existing vote is for valid options only
+		validExistingOption := true
+		for _, choice := range existingVote.OptionIds {
+			if !hasOption(p, choice) {
+				validExistingOption = false
 				break
 			}
 		}
@@ -58,77 +146,298 @@ func (s *service) VoteOnPoll(ctx context.Context, userID, pollID uuid.UUID, opti
 				return nil, err
 			}
 		} else {
-			return nil, errors.New("user has already voted on this poll")
+			return nil, ErrAlreadyVoted
 		}
 	}
 
 	// Create vote
-	return s.storage.CreateVote(ctx, userID, pollID, option)
+	return s.storage.CreateVote(ctx, userID, pollID, choices)
 }
 
-func (s *service) GetVoteCounts(ctx context.Context, pollID uuid.UUID) (map[string]int, error) {
-	// Validate poll exists
-	_, err := s.storage.GetPollByID(ctx, pollID)
+// voteAnonymously casts a secret ballot. The returned vote is a receipt for the
+// voter only; it is identified by the participation record, never by the ballot.
+func (s *service) voteAnonymously(ctx context.Context, userID uuid.UUID, p *ent.Poll, choices []uuid.UUID) (*ent.Vote, error) {
+	if _, err := s.storage.GetParticipationByUserAndPoll(ctx, userID, p.ID); err == nil {
+		return nil, ErrAlreadyVoted
+	}
+
+	participation, err := s.storage.CreateAnonymousVote(ctx, userID, p.ID, choices)
 	if err != nil {
-		return nil, errors.New("poll not found")
+		return nil, err
 	}
 
-	return s.storage.GetVoteCountsByPoll(ctx, pollID)
+	return &ent.Vote{
+		ID:        participation.ID,
+		UserID:    userID,
+		PollID:    p.ID,
+		OptionID:  choices[0],
+		OptionIds: choices,
+		CreatedAt: time.Now(),
+	}, nil
 }
 
-func (s *service) GetVotersByOption(ctx context.Context, pollID uuid.UUID, option string) ([]*ent.User, error) {
-	// Validate poll exists
-	poll, err := s.storage.GetPollByID(ctx, pollID)
+// FlushBallots moves pending secret ballots to the ballots table: full
+// batches of open polls and whatever is left of closed ones, and announces
+// the new counts. It returns how many ballots were moved.
+func (s *service) FlushBallots(ctx context.Context) (int, error) {
+	now := time.Now()
+	pollIDs, err := s.storage.ListPollsToFlushBallots(ctx, BallotBatchSize, now)
 	if err != nil {
-		return nil, errors.New("poll not found")
+		return 0, err
 	}
 
-	// Validate option is in poll options
-	validOption := false
-	for _, opt := range poll.Options {
-		if opt == option {
-			validOption = true
-			break
+	flushed := 0
+	for _, pollID := range pollIDs {
+		p, err := s.storage.GetPollByID(ctx, pollID)
+		if err != nil {
+			return flushed, fmt.Errorf("poll %s: %w", pollID, err)
+		}
+
+		// No more ballots join those of a closed poll
+		minBatch := BallotBatchSize
+		if PollStatus(p, now) == PollStatusClosed {
+			minBatch = 1
+		}
+		n, err := s.storage.FlushBallots(ctx, pollID, minBatch)
+		if err != nil {
+			return flushed, fmt.Errorf("poll %s: %w", pollID, err)
+		}
+		flushed += n
+
+		if n > 0 {
+			if p, err = s.storage.GetPollByID(ctx, pollID); err != nil {
+				log.Printf("Failed to load vote counts of poll %s: %v", pollID, err)
+				continue
+			}
+			s.publishCounts(ctx, p)
+		}
+	}
+
+	return flushed, nil
+}
+
+// validateBallot checks that the choices form a valid ballot for the poll type and choice limits
+func validateBallot(p *ent.Poll, choices []uuid.UUID) error {
+	if p.Type == poll.TypeSingle && len(choices) != 1 {
+		return NewFieldError("choices", "invalid_choice_count", "exactly one option must be chosen for this poll")
+	}
+
+	if len(choices) < p.MinChoices {
+		return NewFieldError("choices", "invalid_choice_count", fmt.Sprintf("at least %d options must be chosen for this poll", p.MinChoices))
+	}
+	if p.MaxChoices != nil && len(choices) > *p.MaxChoices {
+		return NewFieldError("choices", "invalid_choice_count", fmt.Sprintf("at most %d options may be chosen for this poll", *p.MaxChoices))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(choices))
+	for _, choice := range choices {
+		if choice == uuid.Nil {
+			return NewFieldError("choices", "required", "option is required")
+		}
+
+		if !hasOption(p, choice) {
+			return NewFieldError("choices", "invalid_option", "invalid option for this poll")
 		}
+
+		if seen[choice] {
+			return NewFieldError("choices", "duplicate_option", "option chosen more than once")
+		}
+		seen[choice] = true
 	}
-	if !validOption {
-		return nil, errors.New("option not found")
+
+	return nil
+}
+
+// hasOption reports whether optionID is one of the poll's current options
+func hasOption(p *ent.Poll, optionID uuid.UUID) bool {
+	for _, opt := range p.Edges.Options {
+		if opt.ID == optionID {
+			return true
+		}
 	}
+	return false
+}
 
-	votes, err := s.storage.GetVotesByPollAndOption(ctx, pollID, option)
+// optionKeys returns the IDs of the poll's options as tally keys, in display order
+func optionKeys(p *ent.Poll) []string {
+	keys := make([]string, 0, len(p.Edges.Options))
+	for _, opt := range p.Edges.Options {
+		keys = append(keys, opt.ID.String())
+	}
+	return keys
+}
+
+// ballotKeys returns the choices of a ballot as tally keys
+func ballotKeys(choices []uuid.UUID) []string {
+	keys := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		keys = append(keys, choice.String())
+	}
+	return keys
+}
+
+// tallyByKey keys per-option tallies by option ID string, dropping options
+// that were since removed from the poll
+func tallyByKey(p *ent.Poll, counts map[uuid.UUID]int) map[string]int {
+	tally := make(map[string]int, len(counts))
+	for optionID, count := range counts {
+		if hasOption(p, optionID) {
+			tally[optionID.String()] = count
+		}
+	}
+	return tally
+}
+
+// VoteCounts holds the tallies of a poll, keyed by option ID. For approval polls
+// Counts holds approvals per option, otherwise the number of first choices per option.
+type VoteCounts struct {
+	Counts map[string]int
+	Voters int
+}
+
+func (s *service) GetVoteCounts(ctx context.Context, pollID, viewerID uuid.UUID) (*VoteCounts, error) {
+	// Validate poll exists
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+
+	p, err = s.ensureFinalized(ctx, p)
 	if err != nil {
 		return nil, err
 	}
 
-	users := make([]*ent.User, 0, len(votes))
-	for _, vote := range votes {
-		user, err := vote.QueryUser().Only(ctx)
-		if err != nil {
-			continue
-		}
-		users = append(users, user)
+	if err := s.checkResultsVisible(ctx, p, viewerID); err != nil {
+		return nil, err
+	}
+
+	return PollVoteCounts(p), nil
+}
+
+// PollVoteCounts returns the tallies of a poll: the frozen results of a
+// finalized poll, otherwise its live vote counters
+func PollVoteCounts(p *ent.Poll) *VoteCounts {
+	if p.Edges.Result != nil {
+		return &VoteCounts{Counts: p.Edges.Result.Counts, Voters: p.Edges.Result.Voters}
+	}
+	return liveVoteCounts(p)
+}
+
+// liveVoteCounts reads the tallies of a poll from its vote counters. Options
+// removed from the poll take their counters with them.
+func liveVoteCounts(p *ent.Poll) *VoteCounts {
+	counts := make(map[string]int, len(p.Edges.Options))
+	for _, opt := range p.Edges.Options {
+		counts[opt.ID.String()] = opt.VoteCount
+	}
+	return &VoteCounts{Counts: counts, Voters: p.VoterCount}
+}
+
+// Page sizes of voter lists
+const (
+	DefaultVoterPageSize = 100
+	MaxVoterPageSize     = 500
+)
+
+// Voters is a page of the voters of an option
+type Voters struct {
+	Users []*ent.User
+	// Next is the cursor of the following page; nil on the last page
+	Next *uuid.UUID
+}
+
+// GetVotersByOption returns a page of the users whose vote counts for the
+// option, following the user after. A zero limit returns DefaultVoterPageSize voters.
+func (s *service) GetVotersByOption(ctx context.Context, pollID, optionID, viewerID uuid.UUID, after *uuid.UUID, limit int) (*Voters, error) {
+	if limit == 0 {
+		limit = DefaultVoterPageSize
+	}
+	if limit < 1 || limit > MaxVoterPageSize {
+		return nil, NewFieldError("limit", "out_of_range", fmt.Sprintf("limit must be between 1 and %d", MaxVoterPageSize))
+	}
+
+	// Validate poll exists
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return nil, pollNotFound(err)
+	}
+
+	if p.Anonymous {
+		return nil, ErrVotersSecret
+	}
+
+	if err := s.checkResultsVisible(ctx, p, viewerID); err != nil {
+		return nil, err
+	}
+
+	// Validate option is in poll options
+	if !hasOption(p, optionID) {
+		return nil, ErrOptionNotFound
+	}
+
+	// Approval ballots count for every option they include; fetch one extra
+	// voter to tell whether there is a next page
+	users, err := s.storage.ListVotersByOption(ctx, pollID, optionID, p.Type == poll.TypeApproval, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) <= limit {
+		return &Voters{Users: users}, nil
 	}
 
-	return users, nil
+	users = users[:limit]
+	return &Voters{Users: users, Next: &users[limit-1].ID}, nil
 }
 
 func (s *service) DeleteVote(ctx context.Context, userID, pollID uuid.UUID) error {
+	err := s.withTx(ctx, func(tx *service) error {
+		return tx.deleteVote(ctx, userID, pollID)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishVoteCounts(ctx, pollID, userID, true)
+	return nil
+}
+
+func (s *service) deleteVote(ctx context.Context, userID, pollID uuid.UUID) error {
+	if err := s.storage.LockPoll(ctx, pollID); err != nil {
+		return err
+	}
+
 	// Validate poll exists
-	_, err := s.storage.GetPollByID(ctx, pollID)
+	p, err := s.storage.GetPollByID(ctx, pollID)
 	if err != nil {
-		return errors.New("poll not found")
+		return pollNotFound(err)
+	}
+
+	// Votes can only be retracted while the poll is open
+	if err := checkPollOpen(p); err != nil {
+		return err
+	}
+
+	if p.Anonymous {
+		return ErrVoteNotRetractable
 	}
 
 	// Check if vote exists
 	existingVote, err := s.storage.GetVoteByUserAndPoll(ctx, userID, pollID)
+	if ent.IsNotFound(err) {
+		return ErrVoteNotFound
+	}
 	if err != nil {
-		return errors.New("vote not found")
+		return err
 	}
 
 	// Permission check: User can only delete their own vote
 	if existingVote.UserID != userID {
-		return errors.New("unauthorized: can only delete your own vote")
+		return NewForbiddenError("not_vote_owner", "can only delete your own vote")
+	}
+
+	if err := s.storage.DeleteVoteByUserAndPoll(ctx, userID, pollID); err != nil {
+		return err
 	}
 
-	return s.storage.DeleteVoteByUserAndPoll(ctx, userID, pollID)
+	return s.enqueueVoteWebhook(ctx, WebhookEventVoteRetracted, pollID, userID, existingVote.OptionIds)
 }
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
+
+// Webhook event types
+const (
+	WebhookEventPollCreated   = "poll.created"
+	WebhookEventPollUpdated   = "poll.updated"
+	WebhookEventPollClosed    = "poll.closed"
+	WebhookEventVoteCast      = "vote.cast"
+	WebhookEventVoteRetracted = "vote.retracted"
+)
+
+// WebhookEvents lists every webhook event type an endpoint can subscribe to
+var WebhookEvents = []string{
+	WebhookEventPollCreated,
+	WebhookEventPollUpdated,
+	WebhookEventPollClosed,
+	WebhookEventVoteCast,
+	WebhookEventVoteRetracted,
+}
+
+// Page sizes of webhook delivery logs
+const (
+	DefaultWebhookDeliveryPageSize = 50
+	MaxWebhookDeliveryPageSize     = 200
+)
+
+var (
+	// ErrWebhookEndpointNotFound is returned when the endpoint does not exist or belongs to another user
+	ErrWebhookEndpointNotFound = NewNotFoundError("webhook_endpoint_not_found", "webhook endpoint not found")
+	// ErrWebhookDeliveryNotFound is returned when the delivery is not one of the endpoint's
+	ErrWebhookDeliveryNotFound = NewNotFoundError("webhook_delivery_not_found", "webhook delivery not found")
+	// ErrWebhookEndpointInactive is returned when redelivering to a deactivated endpoint
+	ErrWebhookEndpointInactive = NewConflictError("webhook_endpoint_inactive", "webhook endpoint is inactive")
+)
+
+// WebhookService defines webhook endpoint management and delivery log operations
+type WebhookService interface {
+	CreateWebhookEndpoint(ctx context.Context, ownerID uuid.UUID, endpoint storage.WebhookEndpointInput) (*ent.WebhookEndpoint, error)
+	ListWebhookEndpoints(ctx context.Context, ownerID uuid.UUID) ([]*ent.WebhookEndpoint, error)
+	GetWebhookEndpoint(ctx context.Context, id, ownerID uuid.UUID) (*ent.WebhookEndpoint, error)
+	UpdateWebhookEndpoint(ctx context.Context, id, ownerID uuid.UUID, update storage.WebhookEndpointUpdate) (*ent.WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, id, ownerID uuid.UUID) error
+	ListWebhookDeliveries(ctx context.Context, endpointID, ownerID uuid.UUID, status *webhookdelivery.Status, after *uuid.UUID, limit int) (*WebhookDeliveries, error)
+	RedeliverWebhook(ctx context.Context, endpointID, deliveryID, ownerID uuid.UUID) (*ent.WebhookDelivery, error)
+}
+
+// WebhookDeliveries is a page of an endpoint's delivery log
+type WebhookDeliveries struct {
+	Deliveries []*ent.WebhookDelivery
+	// Next is the cursor of the following page; nil on the last page
+	Next *uuid.UUID
+}
+
+// CreateWebhookEndpoint registers an endpoint for the owner's poll events.
+// The secret is generated; the returned endpoint is the only place it is shown.
+func (s *service) CreateWebhookEndpoint(ctx context.Context, ownerID uuid.UUID, endpoint storage.WebhookEndpointInput) (*ent.WebhookEndpoint, error) {
+	if err := validateWebhookURL(endpoint.URL); err != nil {
+		return nil, err
+	}
+	if err := validateWebhookEvents(endpoint.Events); err != nil {
+		return nil, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Secret = secret
+
+	return s.storage.CreateWebhookEndpoint(ctx, ownerID, endpoint)
+}
+
+func (s *service) ListWebhookEndpoints(ctx context.Context, ownerID uuid.UUID) ([]*ent.WebhookEndpoint, error) {
+	return s.storage.ListWebhookEndpointsByOwner(ctx, ownerID)
+}
+
+// GetWebhookEndpoint returns the endpoint if it belongs to the owner. Endpoints
+// of other users are reported as not found.
+func (s *service) GetWebhookEndpoint(ctx context.Context, id, ownerID uuid.UUID) (*ent.WebhookEndpoint, error) {
+	endpoint, err := s.storage.GetWebhookEndpointByID(ctx, id)
+	if ent.IsNotFound(err) {
+		return nil, ErrWebhookEndpointNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.OwnerID != ownerID {
+		return nil, ErrWebhookEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+func (s *service) UpdateWebhookEndpoint(ctx context.Context, id, ownerID uuid.UUID, update storage.WebhookEndpointUpdate) (*ent.WebhookEndpoint, error) {
+	if _, err := s.GetWebhookEndpoint(ctx, id, ownerID); err != nil {
+		return nil, err
+	}
+
+	if update.URL != nil {
+		if err := validateWebhookURL(*update.URL); err != nil {
+			return nil, err
+		}
+	}
+	if update.Events != nil {
+		if err := validateWebhookEvents(update.Events); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.storage.UpdateWebhookEndpoint(ctx, id, update)
+}
+
+func (s *service) DeleteWebhookEndpoint(ctx context.Context, id, ownerID uuid.UUID) error {
+	if _, err := s.GetWebhookEndpoint(ctx, id, ownerID); err != nil {
+		return err
+	}
+
+	return s.storage.DeleteWebhookEndpoint(ctx, id)
+}
+
+// ListWebhookDeliveries returns a page of the endpoint's delivery log, newest
+// first, following the delivery after. A zero limit returns
+// DefaultWebhookDeliveryPageSize deliveries.
+func (s *service) ListWebhookDeliveries(ctx context.Context, endpointID, ownerID uuid.UUID, status *webhookdelivery.Status, after *uuid.UUID, limit int) (*WebhookDeliveries, error) {
+	if limit == 0 {
+		limit = DefaultWebhookDeliveryPageSize
+	}
+	if limit < 1 || limit > MaxWebhookDeliveryPageSize {
+		return nil, NewFieldError("limit", "out_of_range", fmt.Sprintf("limit must be between 1 and %d", MaxWebhookDeliveryPageSize))
+	}
+	if status != nil {
+		if err := webhookdelivery.StatusValidator(*status); err != nil {
+			return nil, NewFieldError("status", "invalid_value", "invalid delivery status")
+		}
+	}
+
+	if _, err := s.GetWebhookEndpoint(ctx, endpointID, ownerID); err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra delivery to tell whether there is a next page
+	deliveries, err := s.storage.ListWebhookDeliveries(ctx, endpointID, status, after, limit+1)
+	if ent.IsNotFound(err) {
+		return nil, NewFieldError("cursor", "invalid_cursor", "invalid cursor")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) <= limit {
+		return &WebhookDeliveries{Deliveries: deliveries}, nil
+	}
+
+	deliveries = deliveries[:limit]
+	return &WebhookDeliveries{Deliveries: deliveries, Next: &deliveries[limit-1].ID}, nil
+}
+
+// RedeliverWebhook queues the payload of a past delivery again. The new
+// delivery carries the same event ID, so receivers can tell it is a repeat.
+func (s *service) RedeliverWebhook(ctx context.Context, endpointID, deliveryID, ownerID uuid.UUID) (*ent.WebhookDelivery, error) {
+	endpoint, err := s.GetWebhookEndpoint(ctx, endpointID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.Active {
+		return nil, ErrWebhookEndpointInactive
+	}
+
+	delivery, err := s.storage.GetWebhookDeliveryByID(ctx, deliveryID)
+	if ent.IsNotFound(err) || (err == nil && delivery.EndpointID != endpointID) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storage.RedeliverWebhook(ctx, delivery.ID)
+}
+
+// validateWebhookURL checks that webhooks can be posted to rawURL
+func validateWebhookURL(rawURL string) error {
+	if rawURL == "" {
+		return NewFieldError("url", "required", "url is required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return NewFieldError("url", "invalid_url", "url must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// validateWebhookEvents checks that events is a non-empty set of known event types
+func validateWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return NewFieldError("events", "required", "at least one event is required")
+	}
+
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		if !slices.Contains(WebhookEvents, event) {
+			return NewFieldError("events", "invalid_value", fmt.Sprintf("unknown event %q", event))
+		}
+		if seen[event] {
+			return NewFieldError("events", "duplicate_value", fmt.Sprintf("event %q listed more than once", event))
+		}
+		seen[event] = true
+	}
+	return nil
+}
+
+// generateWebhookSecret returns a random signing key
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// webhookEnvelope is the body posted to webhook endpoints
+type webhookEnvelope struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// webhookPoll describes a poll in webhook payloads
+type webhookPoll struct {
+	ID          uuid.UUID       `json:"id"`
+	OwnerID     uuid.UUID       `json:"owner_id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Type        poll.Type       `json:"type"`
+	Status      string          `json:"status"`
+	Version     int             `json:"version"`
+	Anonymous   bool            `json:"anonymous"`
+	OpensAt     *time.Time      `json:"opens_at,omitempty"`
+	ClosesAt    *time.Time      `json:"closes_at,omitempty"`
+	Options     []webhookOption `json:"options"`
+}
+
+// webhookOption describes a poll option in webhook payloads
+type webhookOption struct {
+	ID    uuid.UUID `json:"id"`
+	Label string    `json:"label"`
+}
+
+// webhookResults holds the tallies of a poll in webhook payloads
+type webhookResults struct {
+	Counts map[string]int `json:"counts"`
+	Voters int            `json:"voters"`
+}
+
+// pollEventData is the data of poll.* events
+type pollEventData struct {
+	Poll webhookPoll `json:"poll"`
+	// Results are only sent with poll.closed
+	Results *webhookResults `json:"results,omitempty"`
+}
+
+// voteEventData is the data of vote.* events. Voters of anonymous polls are
+// not identified.
+type voteEventData struct {
+	PollID  uuid.UUID       `json:"poll_id"`
+	UserID  *uuid.UUID      `json:"user_id,omitempty"`
+	Choices []uuid.UUID     `json:"choices,omitempty"`
+	Results *webhookResults `json:"results,omitempty"`
+}
+
+// newVoteEventData describes a vote by userID for vote.* events. On anonymous
+// polls only the poll is named: the tallies after each vote would tell, next
+// to who voted when, how each voter voted.
+func newVoteEventData(p *ent.Poll, userID uuid.UUID, choices []uuid.UUID) voteEventData {
+	data := voteEventData{PollID: p.ID}
+	if p.Anonymous {
+		return data
+	}
+
+	counts := liveVoteCounts(p)
+	data.UserID = &userID
+	data.Choices = choices
+	data.Results = &webhookResults{Counts: counts.Counts, Voters: counts.Voters}
+	return data
+}
+
+// newWebhookPoll describes p for webhook payloads
+func newWebhookPoll(p *ent.Poll) webhookPoll {
+	options := make([]webhookOption, 0, len(p.Edges.Options))
+	for _, opt := range p.Edges.Options {
+		options = append(options, webhookOption{ID: opt.ID, Label: opt.Label})
+	}
+
+	return webhookPoll{
+		ID:          p.ID,
+		OwnerID:     p.OwnerID,
+		Title:       p.Title,
+		Description: p.Description,
+		Type:        p.Type,
+		Status:      PollStatus(p, time.Now()),
+		Version:     p.Version,
+		Anonymous:   p.Anonymous,
+		OpensAt:     p.OpensAt,
+		ClosesAt:    p.ClosesAt,
+		Options:     options,
+	}
+}
+
+// enqueueWebhook records deliveries of an event to the endpoints of the poll
+// owner. It must run in the transaction of the change the event reports.
+func (s *service) enqueueWebhook(ctx context.Context, ownerID uuid.UUID, event string, data any) error {
+	eventID := uuid.New()
+	payload, err := json.Marshal(webhookEnvelope{ID: eventID, Type: event, CreatedAt: time.Now(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s webhook: %w", event, err)
+	}
+
+	_, err = s.storage.EnqueueWebhookEvent(ctx, ownerID, event, eventID, payload)
+	return err
+}
+
+// enqueuePollWebhook records a poll.* event about p
+func (s *service) enqueuePollWebhook(ctx context.Context, event string, p *ent.Poll, results *webhookResults) error {
+	return s.enqueueWebhook(ctx, p.OwnerID, event, pollEventData{Poll: newWebhookPoll(p), Results: results})
+}
+
+// enqueueVoteWebhook records a vote.* event, with the poll's tallies after the change
+func (s *service) enqueueVoteWebhook(ctx context.Context, event string, pollID, userID uuid.UUID, choices []uuid.UUID) error {
+	p, err := s.storage.GetPollByID(ctx, pollID)
+	if err != nil {
+		return err
+	}
+
+	return s.enqueueWebhook(ctx, p.OwnerID, event, newVoteEventData(p, userID, choices))
+}
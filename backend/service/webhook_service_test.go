@@ -0,0 +1,48 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"poll-app/ent/poll"
+
+	"github.com/google/uuid"
+)
+
+func TestNewVoteEventData(t *testing.T) {
+	tests := []struct {
+		name      string
+		anonymous bool
+		want      []string
+	}{
+		{"open poll", false, []string{"choices", "poll_id", "results", "user_id"}},
+		{"anonymous poll", true, []string{"poll_id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := testPoll(poll.TypeSingle, 2, 1, intPtr(1))
+			p.Anonymous = tt.anonymous
+			p.Edges.Options[0].VoteCount = 1
+			p.VoterCount = 1
+
+			b, err := json.Marshal(newVoteEventData(p, uuid.New(), []uuid.UUID{p.Edges.Options[0].ID}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(b, &fields); err != nil {
+				t.Fatal(err)
+			}
+
+			if len(fields) != len(tt.want) {
+				t.Fatalf("payload %s, want only %v", b, tt.want)
+			}
+			for _, name := range tt.want {
+				if _, ok := fields[name]; !ok {
+					t.Errorf("payload %s lacks %s", b, name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/ballot"
+	"poll-app/ent/participation"
+	"poll-app/ent/pendingballot"
+
+	"github.com/google/uuid"
+)
+
+// BallotStorage defines secret-ballot operations for anonymous polls.
+// A vote is stored as a Participation, which records who voted, and a Ballot,
+// which records the choices without any link back to the voter.
+//
+// Rows written in one transaction share its ID, and rows written one after
+// another sit next to each other in the table, so a ballot written with its
+// participation could be matched to the voter by anyone reading the database.
+// Ballots are therefore first stored as pending and later moved to the
+// ballots table by FlushBallots, in batches and in shuffled order. Only then
+// are they counted, so the counts of a poll do not change with each vote.
+type BallotStorage interface {
+	CreateAnonymousVote(ctx context.Context, userID, pollID uuid.UUID, choices []uuid.UUID) (*ent.Participation, error)
+	GetParticipationByUserAndPoll(ctx context.Context, userID, pollID uuid.UUID) (*ent.Participation, error)
+	CountParticipationsByPoll(ctx context.Context, pollID uuid.UUID) (int, error)
+	GetBallotChoicesByPoll(ctx context.Context, pollID uuid.UUID) ([][]uuid.UUID, error)
+	FlushBallots(ctx context.Context, pollID uuid.UUID, minBatch int) (int, error)
+	ListPollsToFlushBallots(ctx context.Context, minBatch int, now time.Time) ([]uuid.UUID, error)
+	DeleteBallotsByPoll(ctx context.Context, pollID uuid.UUID) error
+}
+
+// errBatchTooSmall rolls back a flush that found fewer ballots than asked for
+var errBatchTooSmall = errors.New("too few pending ballots")
+
+// flushPendingBallotsSQL takes every pending ballot of a poll. Concurrent
+// flushes of the poll wait for each other, and the later one finds none.
+const flushPendingBallotsSQL = `DELETE FROM "pending_ballots" WHERE "poll_id" = $1 RETURNING "option_id", "option_ids"`
+
+// pollsToFlushBallotsSQL lists the polls with at least $1 pending ballots, and
+// the polls with any pending ballots that closed by $2
+const pollsToFlushBallotsSQL = `SELECT "pending_ballots"."poll_id" FROM "pending_ballots" JOIN "polls" ON "polls"."id" = "pending_ballots"."poll_id" GROUP BY "pending_ballots"."poll_id" HAVING COUNT(*) >= $1 OR bool_or("polls"."closes_at" <= $2)`
+
+// CreateAnonymousVote records the participation and the pending ballot in one
+// transaction. The ballot is counted once it is flushed.
+func (s *storage) CreateAnonymousVote(ctx context.Context, userID, pollID uuid.UUID, choices []uuid.UUID) (*ent.Participation, error) {
+	var p *ent.Participation
+	err := s.withTx(ctx, func(tx *storage) error {
+		var err error
+		p, err = tx.client.Participation.
+			Create().
+			SetUserID(userID).
+			SetPollID(pollID).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.client.PendingBallot.
+			Create().
+			SetPollID(pollID).
+			SetOptionID(choices[0]).
+			SetOptionIds(choices).
+			Save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (s *storage) GetParticipationByUserAndPoll(ctx context.Context, userID, pollID uuid.UUID) (*ent.Participation, error) {
+	return s.client.Participation.
+		Query().
+		Where(
+			participation.UserID(userID),
+			participation.PollID(pollID),
+		).
+		Only(ctx)
+}
+
+func (s *storage) CountParticipationsByPoll(ctx context.Context, pollID uuid.UUID) (int, error) {
+	return s.client.Participation.
+		Query().
+		Where(participation.PollID(pollID)).
+		Count(ctx)
+}
+
+// GetBallotChoicesByPoll returns the choices of the flushed secret ballots of
+// a poll, in no particular order. Pending ballots are left out like they are
+// from the counts.
+func (s *storage) GetBallotChoicesByPoll(ctx context.Context, pollID uuid.UUID) ([][]uuid.UUID, error) {
+	ballots, err := s.client.Ballot.
+		Query().
+		Where(ballot.PollID(pollID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	choices := make([][]uuid.UUID, 0, len(ballots))
+	for _, b := range ballots {
+		choices = append(choices, voteChoices(b.OptionID, b.OptionIds))
+	}
+	return choices, nil
+}
+
+// FlushBallots moves the pending ballots of a poll to the ballots table in one
+// transaction and in shuffled order, counts them, and returns how many it
+// moved. It moves none unless there are at least minBatch, so every batch
+// mixes the ballots of that many voters.
+func (s *storage) FlushBallots(ctx context.Context, pollID uuid.UUID, minBatch int) (int, error) {
+	var flushed int
+	err := s.withTx(ctx, func(tx *storage) error {
+		pending, err := takePendingBallots(ctx, tx.client, pollID)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 || len(pending) < minBatch {
+			return errBatchTooSmall
+		}
+
+		rand.Shuffle(len(pending), func(i, j int) {
+			pending[i], pending[j] = pending[j], pending[i]
+		})
+
+		builders := make([]*ent.BallotCreate, 0, len(pending))
+		for _, b := range pending {
+			builders = append(builders, tx.client.Ballot.
+				Create().
+				SetPollID(pollID).
+				SetOptionID(b.OptionID).
+				SetOptionIds(b.OptionIds))
+		}
+		if _, err := tx.client.Ballot.CreateBulk(builders...).Save(ctx); err != nil {
+			return err
+		}
+		flushed = len(pending)
+		return recountVotes(ctx, tx.client, pollID)
+	})
+	if errors.Is(err, errBatchTooSmall) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return flushed, nil
+}
+
+// takePendingBallots deletes the pending ballots of a poll and returns them
+func takePendingBallots(ctx context.Context, client *ent.Client, pollID uuid.UUID) ([]*ent.PendingBallot, error) {
+	rows, err := client.QueryContext(ctx, flushPendingBallotsSQL, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []*ent.PendingBallot
+	for rows.Next() {
+		var (
+			b         ent.PendingBallot
+			optionIDs []byte
+		)
+		if err := rows.Scan(&b.OptionID, &optionIDs); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(optionIDs, &b.OptionIds); err != nil {
+			return nil, fmt.Errorf("failed to decode pending ballot: %w", err)
+		}
+		pending = append(pending, &b)
+	}
+	return pending, rows.Err()
+}
+
+// ListPollsToFlushBallots returns the polls that have at least minBatch
+// pending ballots, and the polls that closed by now and have any
+func (s *storage) ListPollsToFlushBallots(ctx context.Context, minBatch int, now time.Time) ([]uuid.UUID, error) {
+	rows, err := s.client.QueryContext(ctx, pollsToFlushBallotsSQL, minBatch, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// DeleteBallotsByPoll removes every ballot, pending ballot and participation of a poll
+func (s *storage) DeleteBallotsByPoll(ctx context.Context, pollID uuid.UUID) error {
+	if _, err := s.client.Ballot.
+		Delete().
+		Where(ballot.PollID(pollID)).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	if _, err := s.client.PendingBallot.
+		Delete().
+		Where(pendingballot.PollID(pollID)).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	_, err := s.client.Participation.
+		Delete().
+		Where(participation.PollID(pollID)).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"poll-app/ent/ballot"
+	"poll-app/ent/pendingballot"
+	"poll-app/ent/poll"
+
+	"github.com/google/uuid"
+)
+
+// castAnonymousVotes casts n anonymous votes for the first option of the poll
+func castAnonymousVotes(t *testing.T, s *storage, pollID, optionID uuid.UUID, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		voter := createTestUser(t, s)
+		if _, err := s.CreateAnonymousVote(context.Background(), voter.ID, pollID, []uuid.UUID{optionID}); err != nil {
+			t.Fatalf("failed to vote: %v", err)
+		}
+	}
+}
+
+func TestFlushBallots(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+
+	tests := []struct {
+		name        string
+		votes       int
+		minBatch    int
+		wantFlushed int
+	}{
+		{"no ballots", 0, 1, 0},
+		{"fewer than the batch", 3, 4, 0},
+		{"exactly the batch", 4, 4, 4},
+		{"more than the batch", 5, 4, 5},
+		{"any ballot", 1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, Anonymous: true, MinChoices: 1}, "a", "b")
+			castAnonymousVotes(t, s, p.ID, p.Edges.Options[0].ID, tt.votes)
+
+			flushed, err := s.FlushBallots(ctx, p.ID, tt.minBatch)
+			if err != nil {
+				t.Fatalf("FlushBallots: %v", err)
+			}
+			if flushed != tt.wantFlushed {
+				t.Errorf("flushed = %d, want %d", flushed, tt.wantFlushed)
+			}
+
+			moved, err := s.client.Ballot.Query().Where(ballot.PollID(p.ID)).Count(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pending, err := s.client.PendingBallot.Query().Where(pendingballot.PollID(p.ID)).Count(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if moved != tt.wantFlushed || pending != tt.votes-tt.wantFlushed {
+				t.Errorf("ballots = %d, pending = %d, want %d and %d", moved, pending, tt.wantFlushed, tt.votes-tt.wantFlushed)
+			}
+
+			// only flushed ballots are tallied and counted
+			choices, err := s.GetBallotChoicesByPoll(ctx, p.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(choices) != tt.wantFlushed {
+				t.Errorf("GetBallotChoicesByPoll returned %d ballots, want %d", len(choices), tt.wantFlushed)
+			}
+			counted, err := s.GetPollByID(ctx, p.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if counted.VoterCount != tt.wantFlushed || counted.Edges.Options[0].VoteCount != tt.wantFlushed {
+				t.Errorf("voters = %d, votes = %d, want %d", counted.VoterCount, counted.Edges.Options[0].VoteCount, tt.wantFlushed)
+			}
+		})
+	}
+}
+
+// TestBallotsUnlinkable checks that a flushed ballot shares no transaction
+// with the participation of its voter
+func TestBallotsUnlinkable(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+	p := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, Anonymous: true, MinChoices: 1}, "a", "b")
+
+	const votes = 6
+	castAnonymousVotes(t, s, p.ID, p.Edges.Options[0].ID, votes)
+	if _, err := s.FlushBallots(ctx, p.ID, votes); err != nil {
+		t.Fatal(err)
+	}
+
+	xmins := func(table string) map[string]int {
+		t.Helper()
+		rows, err := s.client.QueryContext(ctx, `SELECT xmin::text FROM "`+table+`" WHERE "poll_id" = $1`, p.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		seen := make(map[string]int)
+		for rows.Next() {
+			var xmin string
+			if err := rows.Scan(&xmin); err != nil {
+				t.Fatal(err)
+			}
+			seen[xmin]++
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatal(err)
+		}
+		return seen
+	}
+
+	ballots := xmins("ballots")
+	if len(ballots) != 1 {
+		t.Fatalf("ballots were written by %d transactions, want 1", len(ballots))
+	}
+	for xmin := range xmins("participations") {
+		if ballots[xmin] > 0 {
+			t.Fatalf("a participation and the ballots share transaction %s", xmin)
+		}
+	}
+}
+
+func TestListPollsToFlushBallots(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+	now := time.Now()
+	closed := now.Add(-time.Minute)
+
+	full := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, Anonymous: true, MinChoices: 1}, "a", "b")
+	castAnonymousVotes(t, s, full.ID, full.Edges.Options[0].ID, 3)
+	partial := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, Anonymous: true, MinChoices: 1}, "a", "b")
+	castAnonymousVotes(t, s, partial.ID, partial.Edges.Options[0].ID, 2)
+	ended := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, Anonymous: true, MinChoices: 1}, "a", "b")
+	castAnonymousVotes(t, s, ended.ID, ended.Edges.Options[0].ID, 1)
+	if _, err := s.client.Poll.UpdateOneID(ended.ID).SetClosesAt(closed).Save(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err := s.ListPollsToFlushBallots(ctx, 3, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		got[id] = true
+	}
+	if len(ids) != 2 || !got[full.ID] || !got[ended.ID] {
+		t.Fatalf("ListPollsToFlushBallots = %v, want %v and %v", ids, full.ID, ended.ID)
+	}
+}
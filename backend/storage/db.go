@@ -2,52 +2,67 @@ package storage
 
 import (
 	"context"
+	stdsql "database/sql"
 	"fmt"
 	"log"
-	"os"
 	"time"
 
+	"poll-app/config"
 	"poll-app/ent"
 
 	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
 	_ "github.com/lib/pq"
 )
 
-// NewClient creates a new ent database client
-func NewClient() (*ent.Client, error) {
-	// Hardcoded database credentials (using default PostgreSQL setup)
-	const dbname = "postgres"
-	const user = "postgres"
-	const password = "postgres"
+// OpenDB opens a connection pool to the configured PostgreSQL database
+func OpenDB(cfg config.DatabaseConfig) (*stdsql.DB, error) {
+	db, err := stdsql.Open("postgres", cfg.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed opening connection to postgres: %w", err)
+	}
 
-	// Configurable connection settings
-	host := getEnv("POSTGRES_HOST", "localhost")
-	port := getEnv("POSTGRES_PORT", "5432")
-	sslmode := getEnv("POSTGRES_SSLMODE", "disable")
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		host, port, user, password, dbname, sslmode)
+	return db, nil
+}
 
-	client, err := ent.Open(dialect.Postgres, dsn)
+// NewClient creates a new ent database client. It refuses to connect to a
+// database whose schema is not at the version this build expects, unless
+// autoMigrate is set, in which case pending migrations are applied first.
+func NewClient(cfg config.DatabaseConfig, autoMigrate bool) (*ent.Client, error) {
+	db, err := OpenDB(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed opening connection to postgres: %w", err)
+		return nil, err
 	}
 
-	// Run the auto migration tool
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed loading migrations: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
-	if err := client.Schema.Create(ctx); err != nil {
-		return nil, fmt.Errorf("failed creating schema resources: %w", err)
+	if autoMigrate {
+		applied, err := migrator.Up(ctx, 0)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed migrating schema: %w", err)
+		}
+		for _, m := range applied {
+			log.Printf("Applied migration %s_%s", m.Version, m.Description)
+		}
 	}
 
-	log.Println("Database connection established and schema migrated")
-	return client, nil
-}
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+	if err := migrator.Check(ctx); err != nil {
+		db.Close()
+		return nil, err
 	}
-	return defaultValue
+
+	log.Printf("Database connection established at schema version %s", migrator.Latest())
+	return ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, db))), nil
 }
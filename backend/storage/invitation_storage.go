@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/ent/pollinvitation"
+
+	"github.com/google/uuid"
+)
+
+// InvitationStorage defines operations on the email invitations to vote on polls
+type InvitationStorage interface {
+	CreatePollInvitations(ctx context.Context, pollID uuid.UUID, emails []string, expiresAt time.Time) ([]*ent.PollInvitation, error)
+	ListPollInvitations(ctx context.Context, pollID uuid.UUID) ([]*ent.PollInvitation, error)
+	ExpirePollInvitations(ctx context.Context, now time.Time) (int, error)
+	ListPollInvitationsToRemind(ctx context.Context, now, expiringBefore time.Time, limit int) ([]*ent.PollInvitation, error)
+	MarkPollInvitationReminded(ctx context.Context, id uuid.UUID, at time.Time) error
+	DeletePollInvitationsByPoll(ctx context.Context, pollID uuid.UUID) error
+}
+
+// CreatePollInvitations invites the email addresses that are not invited to
+// the poll yet and returns the new invitations. Concurrent invitations of the
+// same address violate the unique index on poll and email.
+func (s *storage) CreatePollInvitations(ctx context.Context, pollID uuid.UUID, emails []string, expiresAt time.Time) ([]*ent.PollInvitation, error) {
+	var created []*ent.PollInvitation
+	err := s.withTx(ctx, func(tx *storage) error {
+		invited, err := tx.client.PollInvitation.
+			Query().
+			Where(
+				pollinvitation.PollID(pollID),
+				pollinvitation.EmailIn(emails...),
+			).
+			Select(pollinvitation.FieldEmail).
+			Strings(ctx)
+		if err != nil {
+			return err
+		}
+
+		builders := make([]*ent.PollInvitationCreate, 0, len(emails))
+		for _, email := range emails {
+			if slices.Contains(invited, email) {
+				continue
+			}
+			invited = append(invited, email)
+			builders = append(builders, tx.client.PollInvitation.
+				Create().
+				SetPollID(pollID).
+				SetEmail(email).
+				SetExpiresAt(expiresAt))
+		}
+		if len(builders) == 0 {
+			return nil
+		}
+
+		created, err = tx.client.PollInvitation.CreateBulk(builders...).Save(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (s *storage) ListPollInvitations(ctx context.Context, pollID uuid.UUID) ([]*ent.PollInvitation, error) {
+	return s.client.PollInvitation.
+		Query().
+		Where(pollinvitation.PollID(pollID)).
+		Order(ent.Asc(pollinvitation.FieldCreatedAt), ent.Asc(pollinvitation.FieldEmail)).
+		All(ctx)
+}
+
+// ExpirePollInvitations expires the pending invitations that have run out or
+// whose poll has closed, and returns how many it expired
+func (s *storage) ExpirePollInvitations(ctx context.Context, now time.Time) (int, error) {
+	return s.client.PollInvitation.
+		Update().
+		Where(
+			pollinvitation.StatusEQ(pollinvitation.StatusPending),
+			pollinvitation.Or(
+				pollinvitation.ExpiresAtLTE(now),
+				pollinvitation.HasPollWith(poll.ClosesAtLTE(now)),
+			),
+		).
+		SetStatus(pollinvitation.StatusExpired).
+		Save(ctx)
+}
+
+// ListPollInvitationsToRemind returns up to limit pending invitations, with
+// their poll, that expire before expiringBefore and have not been reminded,
+// on polls open at now; those expiring soonest come first
+func (s *storage) ListPollInvitationsToRemind(ctx context.Context, now, expiringBefore time.Time, limit int) ([]*ent.PollInvitation, error) {
+	return s.client.PollInvitation.
+		Query().
+		Where(
+			pollinvitation.StatusEQ(pollinvitation.StatusPending),
+			pollinvitation.RemindedAtIsNil(),
+			pollinvitation.ExpiresAtGT(now),
+			pollinvitation.ExpiresAtLT(expiringBefore),
+			pollinvitation.HasPollWith(
+				poll.Or(poll.OpensAtIsNil(), poll.OpensAtLTE(now)),
+				poll.Or(poll.ClosesAtIsNil(), poll.ClosesAtGT(now)),
+			),
+		).
+		WithPoll().
+		Order(ent.Asc(pollinvitation.FieldExpiresAt), ent.Asc(pollinvitation.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
+
+func (s *storage) MarkPollInvitationReminded(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return s.client.PollInvitation.
+		UpdateOneID(id).
+		SetRemindedAt(at).
+		Exec(ctx)
+}
+
+func (s *storage) DeletePollInvitationsByPoll(ctx context.Context, pollID uuid.UUID) error {
+	_, err := s.client.PollInvitation.
+		Delete().
+		Where(pollinvitation.PollID(pollID)).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"poll-app/ent/poll"
+	"poll-app/ent/pollinvitation"
+
+	"github.com/google/uuid"
+)
+
+func TestCreatePollInvitations(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+	p := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, MinChoices: 1}, "a", "b")
+	expiresAt := time.Now().Add(time.Hour)
+
+	if _, err := s.CreatePollInvitations(ctx, p.ID, []string{"a@example.com"}, expiresAt); err != nil {
+		t.Fatal(err)
+	}
+	// Already invited and repeated addresses are skipped
+	created, err := s.CreatePollInvitations(ctx, p.ID, []string{"a@example.com", "b@example.com", "b@example.com"}, expiresAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || created[0].Email != "b@example.com" {
+		t.Fatalf("created %v, want only b@example.com", created)
+	}
+
+	all, err := s.ListPollInvitations(ctx, p.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("listed %d invitations, want 2", len(all))
+	}
+}
+
+func TestPollInvitationExpiryAndReminders(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+	now := time.Now()
+	closed := now.Add(-time.Minute)
+	opensLater := now.Add(time.Hour)
+
+	open := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, MinChoices: 1}, "a", "b")
+	ended := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, MinChoices: 1, ClosesAt: &closed}, "a", "b")
+	scheduled := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, MinChoices: 1, OpensAt: &opensLater}, "a", "b")
+
+	invite := func(pollID uuid.UUID, email string, expiresAt time.Time) {
+		t.Helper()
+		if _, err := s.CreatePollInvitations(ctx, pollID, []string{email}, expiresAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	invite(open.ID, "soon@example.com", now.Add(time.Hour))
+	invite(open.ID, "later@example.com", now.Add(48*time.Hour))
+	invite(open.ID, "expired@example.com", now.Add(-time.Second))
+	invite(ended.ID, "closed@example.com", now.Add(time.Hour))
+	invite(scheduled.ID, "scheduled@example.com", now.Add(time.Hour))
+
+	toRemind, err := s.ListPollInvitationsToRemind(ctx, now, now.Add(24*time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(toRemind) != 1 || toRemind[0].Email != "soon@example.com" || toRemind[0].Edges.Poll == nil {
+		t.Fatalf("to remind %v, want soon@example.com with its poll", toRemind)
+	}
+	if err := s.MarkPollInvitationReminded(ctx, toRemind[0].ID, now); err != nil {
+		t.Fatal(err)
+	}
+	if toRemind, err = s.ListPollInvitationsToRemind(ctx, now, now.Add(24*time.Hour), 10); err != nil || len(toRemind) != 0 {
+		t.Fatalf("to remind after reminding %v (%v), want none", toRemind, err)
+	}
+
+	n, err := s.ExpirePollInvitations(ctx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("expired %d invitations, want 2", n)
+	}
+	expired, err := s.client.PollInvitation.Query().Where(pollinvitation.StatusEQ(pollinvitation.StatusExpired)).Select(pollinvitation.FieldEmail).Strings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(expired)
+	if want := []string{"closed@example.com", "expired@example.com"}; !slices.Equal(expired, want) {
+		t.Errorf("expired %v, want %v", expired, want)
+	}
+}
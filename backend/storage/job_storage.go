@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/jobrun"
+
+	"github.com/google/uuid"
+)
+
+// JobStorage defines background job coordination and run history operations
+type JobStorage interface {
+	WithJobLock(ctx context.Context, job string, fn func(ctx context.Context) error) (bool, error)
+	CreateJobRun(ctx context.Context, job, instance string) (*ent.JobRun, error)
+	FinishJobRun(ctx context.Context, id uuid.UUID, affected int, runErr error) (*ent.JobRun, error)
+	FailInterruptedJobRuns(ctx context.Context, job string) (int, error)
+	GetLastSucceededJobRun(ctx context.Context, job string) (*ent.JobRun, error)
+	ListJobRuns(ctx context.Context, job string, limit int) ([]*ent.JobRun, error)
+	DeleteJobRunsBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// WithJobLock runs fn while holding a Postgres advisory lock derived from the job name.
+// The lock is scoped to a transaction, so it is released when fn returns or the
+// connection is lost. It reports false without running fn if another instance holds the lock.
+func (s *storage) WithJobLock(ctx context.Context, job string, fn func(ctx context.Context) error) (bool, error) {
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to start lock transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", jobLockKey(job))
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire job lock: %w", err)
+	}
+
+	locked := false
+	if rows.Next() {
+		if err := rows.Scan(&locked); err != nil {
+			rows.Close()
+			return false, fmt.Errorf("failed to read job lock: %w", err)
+		}
+	}
+	rows.Close()
+
+	if !locked {
+		return false, nil
+	}
+
+	if err := fn(ctx); err != nil {
+		return true, err
+	}
+
+	return true, tx.Commit()
+}
+
+// jobLockKey maps a job name to a stable advisory lock key
+func jobLockKey(job string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("poll-app:job:" + job))
+	return int64(h.Sum64())
+}
+
+func (s *storage) CreateJobRun(ctx context.Context, job, instance string) (*ent.JobRun, error) {
+	return s.client.JobRun.
+		Create().
+		SetJob(job).
+		SetInstance(instance).
+		Save(ctx)
+}
+
+func (s *storage) FinishJobRun(ctx context.Context, id uuid.UUID, affected int, runErr error) (*ent.JobRun, error) {
+	update := s.client.JobRun.
+		UpdateOneID(id).
+		SetAffected(affected).
+		SetFinishedAt(time.Now())
+
+	if runErr != nil {
+		update = update.SetStatus(jobrun.StatusFailed).SetError(runErr.Error())
+	} else {
+		update = update.SetStatus(jobrun.StatusSucceeded)
+	}
+
+	return update.Save(ctx)
+}
+
+// errJobRunInterrupted is recorded on runs whose worker stopped before they finished
+const errJobRunInterrupted = "the worker stopped before the run finished"
+
+// FailInterruptedJobRuns marks the job's running runs failed. It must be called
+// while holding the job's lock: a worker that crashed mid-run lost the lock with
+// its connection, so no run still marked running can be in progress.
+func (s *storage) FailInterruptedJobRuns(ctx context.Context, job string) (int, error) {
+	return s.client.JobRun.
+		Update().
+		Where(
+			jobrun.Job(job),
+			jobrun.StatusEQ(jobrun.StatusRunning),
+		).
+		SetStatus(jobrun.StatusFailed).
+		SetError(errJobRunInterrupted).
+		SetFinishedAt(time.Now()).
+		Save(ctx)
+}
+
+func (s *storage) GetLastSucceededJobRun(ctx context.Context, job string) (*ent.JobRun, error) {
+	return s.client.JobRun.
+		Query().
+		Where(
+			jobrun.Job(job),
+			jobrun.StatusEQ(jobrun.StatusSucceeded),
+		).
+		Order(ent.Desc(jobrun.FieldStartedAt)).
+		First(ctx)
+}
+
+func (s *storage) ListJobRuns(ctx context.Context, job string, limit int) ([]*ent.JobRun, error) {
+	query := s.client.JobRun.
+		Query().
+		Order(ent.Desc(jobrun.FieldStartedAt)).
+		Limit(limit)
+
+	if job != "" {
+		query = query.Where(jobrun.Job(job))
+	}
+
+	return query.All(ctx)
+}
+
+func (s *storage) DeleteJobRunsBefore(ctx context.Context, before time.Time) (int, error) {
+	return s.client.JobRun.
+		Delete().
+		Where(jobrun.StartedAtLT(before)).
+		Exec(ctx)
+}
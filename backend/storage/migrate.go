@@ -0,0 +1,427 @@
+package storage
+
+import (
+	"context"
+	stdsql "database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/migrations"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// ErrSchemaVersionMismatch is returned when the database schema is not at the
+// version of the newest migration this build ships
+var ErrSchemaVersionMismatch = errors.New("database schema version mismatch")
+
+// migrationLockKey identifies the advisory lock held while migrating, so
+// instances starting at the same time do not apply migrations twice
+const migrationLockKey = 0x706f6c6c6d6967 // "pollmig"
+
+// Migration is a versioned schema migration and, once applied, when it was applied
+type Migration struct {
+	Version     string
+	Description string
+	AppliedAt   *time.Time
+
+	up   string
+	down string
+}
+
+// Migrator applies the embedded versioned migrations and records them in the
+// schema_migrations table
+type Migrator struct {
+	db         *stdsql.DB
+	migrations []*Migration
+}
+
+// NewMigrator loads the embedded migrations after checking them against atlas.sum
+func NewMigrator(db *stdsql.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations reads the up and down files of each migration version
+func loadMigrations(fsys fs.FS) ([]*Migration, error) {
+	if err := validateSum(fsys); err != nil {
+		return nil, err
+	}
+
+	names, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	list := make([]*Migration, 0, len(names))
+	for _, name := range names {
+		base := strings.TrimSuffix(name, ".up.sql")
+		version, description, _ := strings.Cut(base, "_")
+
+		up, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		down, err := fs.ReadFile(fsys, base+".down.sql")
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		list = append(list, &Migration{
+			Version:     version,
+			Description: description,
+			up:          string(up),
+			down:        string(down),
+		})
+	}
+
+	return list, nil
+}
+
+// validateSum checks the migration files against atlas.sum the same way Atlas
+// does when writing them, so hand-edited or missing files are refused
+func validateSum(fsys fs.FS) error {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	files := make([]migrate.File, 0, len(names))
+	for _, name := range names {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		files = append(files, migrate.NewLocalFile(name, b))
+	}
+
+	expected, err := migrate.NewHashFile(files)
+	if err != nil {
+		return err
+	}
+
+	b, err := fs.ReadFile(fsys, migrate.HashFileName)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", migrate.HashFileName, err)
+	}
+	var actual migrate.HashFile
+	if err := actual.UnmarshalText(b); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", migrate.HashFileName, err)
+	}
+
+	if actual.Sum() != expected.Sum() {
+		return fmt.Errorf("migration files do not match %s; migrations must only be changed through `poll-app migrate diff`", migrate.HashFileName)
+	}
+	return nil
+}
+
+// Latest returns the version of the newest migration
+func (m *Migrator) Latest() string {
+	if len(m.migrations) == 0 {
+		return ""
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Status returns every known migration with its applied time, followed by any
+// applied versions this build does not know about
+func (m *Migrator) Status(ctx context.Context) ([]*Migration, error) {
+	if err := m.ensureTable(ctx, m.db); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]*Migration, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		entry := *mig
+		if at, ok := applied[mig.Version]; ok {
+			entry.AppliedAt = &at
+			delete(applied, mig.Version)
+		}
+		status = append(status, &entry)
+	}
+
+	unknown := make([]string, 0, len(applied))
+	for version := range applied {
+		unknown = append(unknown, version)
+	}
+	sort.Strings(unknown)
+	for _, version := range unknown {
+		at := applied[version]
+		status = append(status, &Migration{Version: version, Description: "(unknown to this build)", AppliedAt: &at})
+	}
+
+	return status, nil
+}
+
+// Check returns ErrSchemaVersionMismatch unless every known migration, and no
+// unknown one, has been applied
+func (m *Migrator) Check(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	current := ""
+	pending := 0
+	for _, mig := range status {
+		if mig.AppliedAt == nil {
+			pending++
+			continue
+		}
+		if mig.Version > current {
+			current = mig.Version
+		}
+	}
+
+	if current > m.Latest() {
+		return fmt.Errorf("%w: database is at %s, newer than %s expected by this build", ErrSchemaVersionMismatch, current, m.Latest())
+	}
+	if pending > 0 {
+		if current == "" {
+			current = "none"
+		}
+		return fmt.Errorf("%w: database is at %s, this build expects %s; run `poll-app migrate up`", ErrSchemaVersionMismatch, current, m.Latest())
+	}
+	return nil
+}
+
+// Up applies up to steps pending migrations in order, or all of them when steps
+// is zero. A database whose schema the server created itself, before versioned
+// migrations, is baselined at the first migration, which reproduces that
+// schema. Once the schema is current it runs the data backfills that cannot be
+// written in SQL. It returns the migrations applied.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+	err := m.withLock(ctx, func(conn *stdsql.Conn) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 && len(m.migrations) > 0 {
+			legacy, err := hasLegacySchema(ctx, conn)
+			if err != nil {
+				return err
+			}
+			if legacy {
+				first := m.migrations[0]
+				if _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, description) VALUES ($1, $2)`, first.Version, first.Description); err != nil {
+					return err
+				}
+				log.Printf("Found a schema created before versioned migrations; marked %s_%s as applied", first.Version, first.Description)
+				applied[first.Version] = time.Now()
+			}
+		}
+
+		for _, mig := range m.migrations {
+			if steps > 0 && len(done) == steps {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+
+			if err := m.run(ctx, conn, mig.up, func(tx *stdsql.Tx) error {
+				_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, description) VALUES ($1, $2)`, mig.Version, mig.Description)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %s_%s: %w", mig.Version, mig.Description, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	if err != nil {
+		return done, err
+	}
+
+	// Backfills query the current schema, so a partial run leaves them to the next
+	if err := m.Check(ctx); err != nil {
+		return done, nil
+	}
+
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, m.db)))
+	if _, err := BackfillPollOptions(ctx, client); err != nil {
+		return done, fmt.Errorf("failed backfilling poll options: %w", err)
+	}
+
+	return done, nil
+}
+
+// Down reverts up to steps applied migrations, newest first, and returns them
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var done []*Migration
+	err := m.withLock(ctx, func(conn *stdsql.Conn) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if mig.down == "" {
+				return fmt.Errorf("migration %s_%s has no down file", mig.Version, mig.Description)
+			}
+
+			if err := m.run(ctx, conn, mig.down, func(tx *stdsql.Tx) error {
+				_, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+				return err
+			}); err != nil {
+				return fmt.Errorf("migration %s_%s: %w", mig.Version, mig.Description, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Baseline records every migration up to and including version as applied
+// without running it. Up baselines databases created before versioned
+// migrations by itself; this is for schemas that were brought to a later
+// version by other means.
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]*Migration, error) {
+	known := false
+	for _, mig := range m.migrations {
+		if mig.Version == version {
+			known = true
+		}
+	}
+	if !known {
+		return nil, fmt.Errorf("unknown migration version %s", version)
+	}
+
+	var done []*Migration
+	err := m.withLock(ctx, func(conn *stdsql.Conn) error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, description) VALUES ($1, $2)`, mig.Version, mig.Description); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// hasLegacySchema reports whether the database has the tables the server
+// created on startup before versioned migrations were introduced
+func hasLegacySchema(ctx context.Context, conn *stdsql.Conn) (bool, error) {
+	var exists bool
+	err := conn.QueryRowContext(ctx, `SELECT to_regclass('polls') IS NOT NULL`).Scan(&exists)
+	return exists, err
+}
+
+// run executes a migration file and records the change in one transaction
+func (m *Migrator) run(ctx context.Context, conn *stdsql.Conn, script string, record func(tx *stdsql.Tx) error) error {
+	stmts, err := migrate.Stmts(script)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt.Text); err != nil {
+			return fmt.Errorf("%w\n%s", err, stmt.Text)
+		}
+	}
+	if err := record(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *stdsql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// execer is implemented by both *sql.DB and *sql.Conn
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error)
+}
+
+// ensureTable creates the schema_migrations table if it does not exist yet
+func (m *Migrator) ensureTable(ctx context.Context, db execer) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version character varying PRIMARY KEY,
+		description character varying NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	return nil
+}
+
+// applied returns the applied migration versions and when they were applied
+func (m *Migrator) applied(ctx context.Context) (map[string]time.Time, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[string]time.Time)
+	for rows.Next() {
+		var version string
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
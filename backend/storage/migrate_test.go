@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"io/fs"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/migrations"
+
+	"ariga.io/atlas/sql/migrate"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	list, err := loadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	if len(list) == 0 {
+		t.Fatal("no migrations loaded")
+	}
+	for i, mig := range list {
+		if mig.up == "" || mig.down == "" {
+			t.Errorf("migration %s lacks an up or down script", mig.Version)
+		}
+		if i > 0 && list[i-1].Version >= mig.Version {
+			t.Errorf("migration %s is out of order", mig.Version)
+		}
+	}
+}
+
+func TestValidateSum(t *testing.T) {
+	embedded := fstest.MapFS{}
+	err := fs.WalkDir(migrations.FS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		b, err := fs.ReadFile(migrations.FS, path)
+		embedded[path] = &fstest.MapFile{Data: b}
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edited := fstest.MapFS{}
+	for name, f := range embedded {
+		edited[name] = f
+	}
+	edited["20261016090000_baseline.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+
+	unsummed := fstest.MapFS{}
+	for name, f := range embedded {
+		unsummed[name] = f
+	}
+	unsummed["99990101000000_extra.up.sql"] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+
+	tests := []struct {
+		name  string
+		fsys  fs.FS
+		valid bool
+	}{
+		{"embedded", embedded, true},
+		{"edited file", edited, false},
+		{"file missing from the sum", unsummed, false},
+		{"no sum", fstest.MapFS{"1_a.up.sql": &fstest.MapFile{Data: []byte("SELECT 1;")}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSum(tt.fsys)
+			if (err == nil) != tt.valid {
+				t.Fatalf("validateSum = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+var (
+	createIndexPattern = regexp.MustCompile(`CREATE (UNIQUE )?INDEX "([^"]+)" ON "([^"]+)" (USING \w+ )?\(([^)]*)\)`)
+	dropIndexPattern   = regexp.MustCompile(`DROP INDEX "([^"]+)"`)
+)
+
+// TestMigrationsIndexes checks that the migrated schema has no two indexes on
+// the same columns of a table
+func TestMigrationsIndexes(t *testing.T) {
+	list, err := loadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// index name by table and columns, and the reverse
+	byColumns := make(map[string]string)
+	byName := make(map[string]string)
+	for _, mig := range list {
+		for _, line := range strings.Split(mig.up, "\n") {
+			if m := dropIndexPattern.FindStringSubmatch(line); m != nil {
+				delete(byColumns, byName[m[1]])
+				delete(byName, m[1])
+				continue
+			}
+			m := createIndexPattern.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			key := m[3] + " " + m[4] + m[5]
+			if other, ok := byColumns[key]; ok {
+				t.Errorf("migration %s: index %s duplicates %s on %s", mig.Version, m[2], other, key)
+			}
+			byColumns[key] = m[2]
+			byName[m[2]] = key
+		}
+	}
+}
+
+// TestUpLegacySchema upgrades a database as the server created it before
+// versioned migrations, with polls keeping their options as labels
+func TestUpLegacySchema(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	list, err := loadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts, err := migrate.Stmts(list[0].up)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt.Text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userID, pollID := uuid.New(), uuid.New()
+	for _, q := range []string{
+		`INSERT INTO users (id, email, username, password, created_at, updated_at) VALUES ($1, 'a@example.com', 'a', 'hash', now(), now())`,
+		`INSERT INTO polls (id, title, options, created_at, updated_at, owner_id) VALUES ($2, 'Lunch', '["Pizza", "Sushi"]', now(), now(), $1)`,
+		`INSERT INTO votes (id, option, created_at, user_id, poll_id) VALUES (gen_random_uuid(), 'Sushi', now(), $1, $2)`,
+	} {
+		if _, err := db.ExecContext(ctx, q, userID, pollID); err != nil {
+			t.Fatalf("%s: %v", q, err)
+		}
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, err := migrator.Up(ctx, 0)
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if len(applied) != len(list)-1 {
+		t.Fatalf("applied %d migrations, want all but the baseline", len(applied))
+	}
+	if err := migrator.Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, db)))
+	p, err := client.Poll.Query().Where(poll.ID(pollID)).WithOptions().Only(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Edges.Options) != 2 || p.LegacyOptions != nil || p.VoterCount != 1 {
+		t.Fatalf("poll has %d options, legacy options %v and %d voters", len(p.Edges.Options), p.LegacyOptions, p.VoterCount)
+	}
+	for _, opt := range p.Edges.Options {
+		want := map[string]int{"Pizza": 0, "Sushi": 1}[opt.Label]
+		if opt.VoteCount != want {
+			t.Errorf("option %s has %d votes, want %d", opt.Label, opt.VoteCount, want)
+		}
+	}
+}
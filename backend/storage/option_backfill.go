@@ -0,0 +1,264 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"poll-app/ent"
+	"poll-app/ent/ballot"
+	"poll-app/ent/poll"
+	"poll-app/ent/pollresult"
+	"poll-app/ent/vote"
+
+	"github.com/google/uuid"
+)
+
+// BackfillPollOptions moves polls created before options had their own table
+// onto PollOption rows. Votes, ballots and frozen results are rewritten from
+// option labels to option IDs. Each poll is converted in its own transaction
+// and its legacy labels are cleared, so the backfill is safe to run repeatedly.
+// It returns the number of polls converted.
+func BackfillPollOptions(ctx context.Context, client *ent.Client) (int, error) {
+	ids, err := client.Poll.
+		Query().
+		Where(poll.LegacyOptionsNotNil()).
+		IDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, id := range ids {
+		if err := backfillPoll(ctx, client, id); err != nil {
+			return i, fmt.Errorf("poll %s: %w", id, err)
+		}
+	}
+
+	return len(ids), nil
+}
+
+func backfillPoll(ctx context.Context, client *ent.Client, pollID uuid.UUID) error {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	p, err := tx.Poll.Get(ctx, pollID)
+	if err != nil {
+		return err
+	}
+
+	optionIDs := make(map[string]uuid.UUID, len(p.LegacyOptions))
+	for _, label := range p.LegacyOptions {
+		if _, ok := optionIDs[label]; ok || label == "" {
+			continue
+		}
+		opt, err := tx.PollOption.
+			Create().
+			SetPollID(pollID).
+			SetLabel(label).
+			SetPosition(len(optionIDs)).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		optionIDs[label] = opt.ID
+	}
+
+	if err := backfillVotes(ctx, tx, pollID, optionIDs); err != nil {
+		return err
+	}
+	if err := backfillBallots(ctx, tx, pollID, optionIDs); err != nil {
+		return err
+	}
+	if err := backfillPollResult(ctx, tx, pollID, optionIDs); err != nil {
+		return err
+	}
+
+	if err := tx.Poll.
+		UpdateOneID(pollID).
+		ClearLegacyOptions().
+		Exec(ctx); err != nil {
+		return err
+	}
+	if err := refreshSearchVector(ctx, tx.Client(), pollID); err != nil {
+		return err
+	}
+	if err := recountVotes(ctx, tx.Client(), pollID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func backfillVotes(ctx context.Context, tx *ent.Tx, pollID uuid.UUID, optionIDs map[string]uuid.UUID) error {
+	votes, err := tx.Vote.
+		Query().
+		Where(
+			vote.PollID(pollID),
+			vote.OptionIDIsNil(),
+		).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range votes {
+		choices := mapLegacyChoices(v.LegacyOption, v.LegacyChoices, optionIDs)
+
+		// Votes for options that no longer exist were orphaned already
+		if len(choices) == 0 {
+			if err := tx.Vote.DeleteOneID(v.ID).Exec(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := tx.Vote.
+			UpdateOneID(v.ID).
+			SetOptionID(choices[0]).
+			SetOptionIds(choices).
+			ClearLegacyOption().
+			ClearLegacyChoices().
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func backfillBallots(ctx context.Context, tx *ent.Tx, pollID uuid.UUID, optionIDs map[string]uuid.UUID) error {
+	ballots, err := tx.Ballot.
+		Query().
+		Where(
+			ballot.PollID(pollID),
+			ballot.OptionIDIsNil(),
+		).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range ballots {
+		choices := mapLegacyChoices(b.LegacyOption, b.LegacyChoices, optionIDs)
+
+		// Secret ballots are kept even when unmappable, since their
+		// participation cannot be found to remove it as well
+		update := tx.Ballot.
+			UpdateOneID(b.ID).
+			SetOptionIds(choices).
+			ClearLegacyOption().
+			ClearLegacyChoices()
+		if len(choices) > 0 {
+			update = update.SetOptionID(choices[0])
+		}
+		if err := update.Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mapLegacyChoices converts a ballot of option labels to option IDs, dropping
+// labels that are no longer options. Votes cast before full ballots were
+// stored only carry their single option.
+func mapLegacyChoices(option string, choices []string, optionIDs map[string]uuid.UUID) []uuid.UUID {
+	if len(choices) == 0 {
+		choices = []string{option}
+	}
+
+	ids := make([]uuid.UUID, 0, len(choices))
+	for _, label := range choices {
+		if id, ok := optionIDs[label]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// legacyRankedResult mirrors the stored instant-runoff snapshot, which names
+// options by label before the backfill and by ID after it
+type legacyRankedResult struct {
+	Rounds []struct {
+		Round      int            `json:"round"`
+		Counts     map[string]int `json:"counts"`
+		Eliminated []string       `json:"eliminated,omitempty"`
+		Exhausted  int            `json:"exhausted"`
+	} `json:"rounds"`
+	Winner       string   `json:"winner,omitempty"`
+	Tied         []string `json:"tied,omitempty"`
+	TotalBallots int      `json:"total_ballots"`
+	Exhausted    int      `json:"exhausted"`
+}
+
+func backfillPollResult(ctx context.Context, tx *ent.Tx, pollID uuid.UUID, optionIDs map[string]uuid.UUID) error {
+	result, err := tx.PollResult.
+		Query().
+		Where(pollresult.PollID(pollID)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	update := tx.PollResult.
+		UpdateOneID(result.ID).
+		SetCounts(relabelCounts(result.Counts, optionIDs))
+
+	if result.Ranked != nil {
+		var ranked legacyRankedResult
+		if err := json.Unmarshal(result.Ranked, &ranked); err != nil {
+			return fmt.Errorf("failed to decode ranked results: %w", err)
+		}
+
+		for i := range ranked.Rounds {
+			ranked.Rounds[i].Counts = relabelCounts(ranked.Rounds[i].Counts, optionIDs)
+			ranked.Rounds[i].Eliminated = relabel(ranked.Rounds[i].Eliminated, optionIDs)
+		}
+		if id, ok := optionIDs[ranked.Winner]; ok {
+			ranked.Winner = id.String()
+		} else {
+			ranked.Winner = ""
+		}
+		ranked.Tied = relabel(ranked.Tied, optionIDs)
+
+		encoded, err := json.Marshal(ranked)
+		if err != nil {
+			return fmt.Errorf("failed to encode ranked results: %w", err)
+		}
+		update = update.SetRanked(encoded)
+	}
+
+	return update.Exec(ctx)
+}
+
+// relabelCounts re-keys tallies from option labels to option IDs
+func relabelCounts(counts map[string]int, optionIDs map[string]uuid.UUID) map[string]int {
+	relabeled := make(map[string]int, len(counts))
+	for label, count := range counts {
+		if id, ok := optionIDs[label]; ok {
+			relabeled[id.String()] = count
+		}
+	}
+	return relabeled
+}
+
+// relabel converts option labels to option IDs, dropping unknown labels
+func relabel(labels []string, optionIDs map[string]uuid.UUID) []string {
+	if labels == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if id, ok := optionIDs[label]; ok {
+			ids = append(ids, id.String())
+		}
+	}
+	return ids
+}
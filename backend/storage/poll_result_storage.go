@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+
+	"poll-app/ent"
+	"poll-app/ent/pollresult"
+
+	"github.com/google/uuid"
+)
+
+// PollResultStorage defines operations on frozen poll results
+type PollResultStorage interface {
+	CreatePollResult(ctx context.Context, pollID uuid.UUID, counts map[string]int, voters int, ranked json.RawMessage) (*ent.PollResult, error)
+	GetPollResultByPoll(ctx context.Context, pollID uuid.UUID) (*ent.PollResult, error)
+	DeletePollResultByPoll(ctx context.Context, pollID uuid.UUID) error
+}
+
+func (s *storage) CreatePollResult(ctx context.Context, pollID uuid.UUID, counts map[string]int, voters int, ranked json.RawMessage) (*ent.PollResult, error) {
+	create := s.client.PollResult.
+		Create().
+		SetPollID(pollID).
+		SetCounts(counts).
+		SetVoters(voters)
+
+	if ranked != nil {
+		create = create.SetRanked(ranked)
+	}
+
+	return create.Save(ctx)
+}
+
+func (s *storage) GetPollResultByPoll(ctx context.Context, pollID uuid.UUID) (*ent.PollResult, error) {
+	return s.client.PollResult.
+		Query().
+		Where(pollresult.PollID(pollID)).
+		Only(ctx)
+}
+
+func (s *storage) DeletePollResultByPoll(ctx context.Context, pollID uuid.UUID) error {
+	_, err := s.client.PollResult.
+		Delete().
+		Where(pollresult.PollID(pollID)).
+		Exec(ctx)
+	return err
+}
@@ -2,32 +2,204 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"poll-app/ent"
+	"poll-app/ent/participation"
 	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+	"poll-app/ent/predicate"
+	"poll-app/ent/vote"
 
+	"entgo.io/ent/dialect/sql"
 	"github.com/google/uuid"
 )
 
+// OptionInput describes a poll option. On update, a non-nil ID keeps the
+// existing option (and its votes) while changing its label and details.
+type OptionInput struct {
+	ID          uuid.UUID
+	Label       string
+	Description string
+	ImageURL    string
+}
+
+// PollSettings holds the voting rules of a poll
+type PollSettings struct {
+	Type                 poll.Type
+	Anonymous            bool
+	ResultsVisibility    poll.ResultsVisibility
+	RequireVerifiedEmail bool
+	MinChoices           int
+	MaxChoices           *int
+	OpensAt              *time.Time
+	ClosesAt             *time.Time
+	// ExternalID identifies an imported poll to its owner; nil for polls
+	// created one by one
+	ExternalID *string
+}
+
+// PollUpdate holds changes to a poll. Nil fields are left unchanged, and the
+// Clear flags remove an optional value. Options, when non-nil, replace the
+// poll's option list.
+type PollUpdate struct {
+	Title                *string
+	Description          *string
+	Options              []OptionInput
+	ResultsVisibility    *poll.ResultsVisibility
+	RequireVerifiedEmail *bool
+	MinChoices           *int
+	MaxChoices           *int
+	ClearMaxChoices      bool
+	OpensAt              *time.Time
+	ClearOpensAt         bool
+	ClosesAt             *time.Time
+	ClearClosesAt        bool
+}
+
+// Poll lifecycle statuses, derived from the voting window
+const (
+	PollStatusScheduled = "scheduled"
+	PollStatusOpen      = "open"
+	PollStatusClosed    = "closed"
+)
+
+// PollSort is the order of a poll listing
+type PollSort string
+
+const (
+	// PollSortNewest lists the most recently created polls first
+	PollSortNewest PollSort = "newest"
+	// PollSortMostVotes lists the polls with the most voters first. Polls
+	// whose results may be hidden from anyone rank as if nobody voted, so
+	// their place tells nothing about their counts.
+	PollSortMostVotes PollSort = "most_votes"
+	// PollSortClosingSoon lists polls that have yet to close, soonest first;
+	// polls without a closing time are left out
+	PollSortClosingSoon PollSort = "closing_soon"
+)
+
+// PollFilter narrows a poll listing. Zero fields do not filter.
+type PollFilter struct {
+	OwnerID *uuid.UUID
+	// Status is one of the poll lifecycle statuses at Now
+	Status string
+	Now    time.Time
+	// Polls created in [CreatedAfter, CreatedBefore)
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	VotedBy       *uuid.UUID
+	NotVotedBy    *uuid.UUID
+	// Query is a full-text search over the title, description and options,
+	// in web search syntax
+	Query string
+}
+
+// PollCursor is the position of the last poll of a page in the listing's
+// order. Only the key of the cursor's sort is set.
+type PollCursor struct {
+	Sort      PollSort   `json:"sort"`
+	ID        uuid.UUID  `json:"id"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ClosesAt  *time.Time `json:"closes_at,omitempty"`
+	// Voters is the voter count the most_votes sort ranks the poll by,
+	// which is 0 for polls with hidden results
+	Voters *int `json:"voters,omitempty"`
+}
+
+// PollPage selects a page of a poll listing
+type PollPage struct {
+	Sort  PollSort
+	After *PollCursor
+	Limit int
+}
+
+// PollList is a page of a poll listing
+type PollList struct {
+	Polls []*ent.Poll
+	// Next is the cursor of the following page; nil on the last page
+	Next *PollCursor
+	// Total counts the polls matching the filter up to MaxPollListTotal;
+	// TotalExact is false when there are more
+	Total      int
+	TotalExact bool
+}
+
+// MaxPollListTotal caps how many matching polls a listing counts
+const MaxPollListTotal = 1000
+
+// searchConfig is the Postgres text search configuration of poll search vectors
+const searchConfig = "english"
+
+// ErrPollVersionMismatch is returned by conditional writes when the poll is not
+// at any of the expected versions
+var ErrPollVersionMismatch = errors.New("poll version does not match")
+
 // PollStorage defines poll-related database operations
 type PollStorage interface {
-	CreatePoll(ctx context.Context, title, description string, options []string, ownerID uuid.UUID) (*ent.Poll, error)
+	CreatePoll(ctx context.Context, title, description string, options []OptionInput, settings PollSettings, ownerID uuid.UUID) (*ent.Poll, error)
 	GetPollByID(ctx context.Context, id uuid.UUID) (*ent.Poll, error)
-	ListPolls(ctx context.Context) ([]*ent.Poll, error)
-	UpdatePoll(ctx context.Context, id uuid.UUID, title, description string, options []string) (*ent.Poll, error)
-	DeletePoll(ctx context.Context, id uuid.UUID) error
+	ListPolls(ctx context.Context, filter PollFilter, page PollPage) (*PollList, error)
+	UpdatePoll(ctx context.Context, id uuid.UUID, versions []int, update PollUpdate) (*ent.Poll, error)
+	DeletePoll(ctx context.Context, id uuid.UUID, versions []int) error
 	GetPollsByOwner(ctx context.Context, ownerID uuid.UUID) ([]*ent.Poll, error)
+	GetPollsByExternalIDs(ctx context.Context, ownerID uuid.UUID, externalIDs []string) ([]*ent.Poll, error)
+	ListPollsToFinalize(ctx context.Context, now time.Time) ([]*ent.Poll, error)
+	LockPoll(ctx context.Context, id uuid.UUID) error
 }
 
-func (s *storage) CreatePoll(ctx context.Context, title, description string, options []string, ownerID uuid.UUID) (*ent.Poll, error) {
-	return s.client.Poll.
-		Create().
-		SetTitle(title).
-		SetDescription(description).
-		SetOptions(options).
-		SetOwnerID(ownerID).
-		Save(ctx)
+// CreatePoll creates the poll and its options in one transaction
+func (s *storage) CreatePoll(ctx context.Context, title, description string, options []OptionInput, settings PollSettings, ownerID uuid.UUID) (*ent.Poll, error) {
+	var p *ent.Poll
+	err := s.withTx(ctx, func(tx *storage) error {
+		var err error
+		p, err = tx.client.Poll.
+			Create().
+			SetTitle(title).
+			SetDescription(description).
+			SetType(settings.Type).
+			SetAnonymous(settings.Anonymous).
+			SetResultsVisibility(settings.ResultsVisibility).
+			SetRequireVerifiedEmail(settings.RequireVerifiedEmail).
+			SetMinChoices(settings.MinChoices).
+			SetNillableMaxChoices(settings.MaxChoices).
+			SetNillableOpensAt(settings.OpensAt).
+			SetNillableClosesAt(settings.ClosesAt).
+			SetNillableExternalID(settings.ExternalID).
+			SetOwnerID(ownerID).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+
+		builders := make([]*ent.PollOptionCreate, 0, len(options))
+		for i, opt := range options {
+			builders = append(builders, tx.client.PollOption.
+				Create().
+				SetPollID(p.ID).
+				SetLabel(opt.Label).
+				SetDescription(opt.Description).
+				SetImageURL(opt.ImageURL).
+				SetPosition(i))
+		}
+		if p.Edges.Options, err = tx.client.PollOption.CreateBulk(builders...).Save(ctx); err != nil {
+			return err
+		}
+		return refreshSearchVector(ctx, tx.client, p.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// LockPoll locks the poll's row until the enclosing transaction ends, so votes,
+// edits and the closing of a poll take turns. Outside a transaction it is a no-op.
+func (s *storage) LockPoll(ctx context.Context, id uuid.UUID) error {
+	_, err := s.client.ExecContext(ctx, `SELECT 1 FROM "polls" WHERE "id" = $1 FOR UPDATE`, id)
+	return err
 }
 
 func (s *storage) GetPollByID(ctx context.Context, id uuid.UUID) (*ent.Poll, error) {
@@ -35,42 +207,351 @@ func (s *storage) GetPollByID(ctx context.Context, id uuid.UUID) (*ent.Poll, err
 		Query().
 		Where(poll.ID(id)).
 		WithOwner().
-		WithVotes(func(vq *ent.VoteQuery) {
-			vq.WithUser()
-		}).
+		WithOptions(withOptionsInOrder).
+		WithResult().
 		Only(ctx)
 }
 
-func (s *storage) ListPolls(ctx context.Context) ([]*ent.Poll, error) {
-	return s.client.Poll.
+// ListPolls returns a page of the polls matching the filter
+func (s *storage) ListPolls(ctx context.Context, filter PollFilter, page PollPage) (*PollList, error) {
+	query := s.client.Poll.
 		Query().
+		Where(pollFilterPredicates(filter)...)
+	if page.Sort == PollSortClosingSoon {
+		query = query.Where(poll.ClosesAtGT(filter.Now))
+	}
+
+	// Count before the cursor narrows the query, stopping at the cap
+	ids, err := query.Clone().Limit(MaxPollListTotal + 1).IDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	list := &PollList{Total: min(len(ids), MaxPollListTotal), TotalExact: len(ids) <= MaxPollListTotal}
+
+	if page.After != nil {
+		query = query.Where(afterCursor(page.Sort, page.After, filter.Now))
+	}
+	switch page.Sort {
+	case PollSortMostVotes:
+		query = query.Order(func(s *sql.Selector) {
+			s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+				rankedVoters(s, b, filter.Now)
+				b.WriteString(" DESC")
+			}))
+		}, ent.Desc(poll.FieldID))
+	case PollSortClosingSoon:
+		query = query.Order(ent.Asc(poll.FieldClosesAt), ent.Asc(poll.FieldID))
+	default:
+		query = query.Order(ent.Desc(poll.FieldCreatedAt), ent.Desc(poll.FieldID))
+	}
+
+	// Fetch one extra poll to tell whether there is a next page
+	polls, err := query.
 		WithOwner().
-		Order(ent.Desc(poll.FieldCreatedAt)).
+		WithOptions(withOptionsInOrder).
+		WithResult().
+		Limit(page.Limit + 1).
 		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(polls) <= page.Limit {
+		list.Polls = polls
+		return list, nil
+	}
+
+	list.Polls = polls[:page.Limit]
+	last := list.Polls[len(list.Polls)-1]
+	list.Next = &PollCursor{Sort: page.Sort, ID: last.ID}
+	switch page.Sort {
+	case PollSortMostVotes:
+		voters := rankedVoterCount(last, filter.Now)
+		list.Next.Voters = &voters
+	case PollSortClosingSoon:
+		list.Next.ClosesAt = last.ClosesAt
+	default:
+		list.Next.CreatedAt = &last.CreatedAt
+	}
+	return list, nil
 }
 
-func (s *storage) UpdatePoll(ctx context.Context, id uuid.UUID, title, description string, options []string) (*ent.Poll, error) {
-	update := s.client.Poll.
-		UpdateOneID(id).
-		SetUpdatedAt(time.Now())
+// pollFilterPredicates translates the filter into poll predicates
+func pollFilterPredicates(filter PollFilter) []predicate.Poll {
+	var preds []predicate.Poll
+	if filter.OwnerID != nil {
+		preds = append(preds, poll.OwnerID(*filter.OwnerID))
+	}
+	switch filter.Status {
+	case PollStatusScheduled:
+		preds = append(preds, poll.OpensAtGT(filter.Now))
+	case PollStatusOpen:
+		preds = append(preds,
+			poll.Or(poll.OpensAtIsNil(), poll.OpensAtLTE(filter.Now)),
+			poll.Or(poll.ClosesAtIsNil(), poll.ClosesAtGT(filter.Now)),
+		)
+	case PollStatusClosed:
+		preds = append(preds, poll.ClosesAtLTE(filter.Now))
+	}
+	if filter.CreatedAfter != nil {
+		preds = append(preds, poll.CreatedAtGTE(*filter.CreatedAfter))
+	}
+	if filter.CreatedBefore != nil {
+		preds = append(preds, poll.CreatedAtLT(*filter.CreatedBefore))
+	}
+	if filter.VotedBy != nil {
+		preds = append(preds, votedBy(*filter.VotedBy))
+	}
+	if filter.NotVotedBy != nil {
+		preds = append(preds, poll.Not(votedBy(*filter.NotVotedBy)))
+	}
+	if filter.Query != "" {
+		preds = append(preds, func(s *sql.Selector) {
+			s.Where(sql.P(func(b *sql.Builder) {
+				b.WriteString(s.C(poll.FieldSearchVector)).
+					WriteString(" @@ websearch_to_tsquery(").
+					Arg(searchConfig).
+					Comma().
+					Arg(filter.Query).
+					WriteByte(')')
+			}))
+		})
+	}
+	return preds
+}
+
+// votedBy matches polls the user voted on, openly or anonymously
+func votedBy(userID uuid.UUID) predicate.Poll {
+	return poll.Or(
+		poll.HasVotesWith(vote.UserID(userID)),
+		poll.HasParticipationsWith(participation.UserID(userID)),
+	)
+}
 
-	if title != "" {
-		update = update.SetTitle(title)
+// rankedVoterCount is the voter count the most_votes sort ranks p by at now:
+// its count if its results are visible to everyone, 0 otherwise. rankedVoters
+// computes the same in SQL.
+func rankedVoterCount(p *ent.Poll, now time.Time) int {
+	closed := p.ClosesAt != nil && !p.ClosesAt.After(now)
+	switch {
+	case p.ResultsVisibility == poll.ResultsVisibilityAlways,
+		p.ResultsVisibility != poll.ResultsVisibilityOwnerOnly && closed:
+		return p.VoterCount
+	default:
+		return 0
 	}
-	if description != "" {
-		update = update.SetDescription(description)
+}
+
+// rankedVoters writes the voter count the most_votes sort ranks the selected
+// polls by at now, as rankedVoterCount computes it
+func rankedVoters(s *sql.Selector, b *sql.Builder, now time.Time) {
+	b.WriteString("CASE WHEN ").
+		WriteString(s.C(poll.FieldResultsVisibility)).
+		WriteString(" = ").
+		Arg(poll.ResultsVisibilityAlways.String()).
+		WriteString(" OR (").
+		WriteString(s.C(poll.FieldResultsVisibility)).
+		WriteString(" <> ").
+		Arg(poll.ResultsVisibilityOwnerOnly.String()).
+		WriteString(" AND ").
+		WriteString(s.C(poll.FieldClosesAt)).
+		WriteString(" <= ").
+		Arg(now).
+		WriteString(") THEN ").
+		WriteString(s.C(poll.FieldVoterCount)).
+		WriteString(" ELSE 0 END")
+}
+
+// afterCursor matches the polls that follow the cursor in the sort order at now
+func afterCursor(sort PollSort, cursor *PollCursor, now time.Time) predicate.Poll {
+	switch sort {
+	case PollSortMostVotes:
+		return func(s *sql.Selector) {
+			s.Where(sql.P(func(b *sql.Builder) {
+				b.WriteByte('(')
+				rankedVoters(s, b, now)
+				b.WriteString(", ").
+					WriteString(s.C(poll.FieldID)).
+					WriteString(") < (").
+					Arg(*cursor.Voters).
+					Comma().
+					Arg(cursor.ID).
+					WriteByte(')')
+			}))
+		}
+	case PollSortClosingSoon:
+		return poll.Or(
+			poll.ClosesAtGT(*cursor.ClosesAt),
+			poll.And(poll.ClosesAt(*cursor.ClosesAt), poll.IDGT(cursor.ID)),
+		)
+	default:
+		return poll.Or(
+			poll.CreatedAtLT(*cursor.CreatedAt),
+			poll.And(poll.CreatedAt(*cursor.CreatedAt), poll.IDLT(cursor.ID)),
+		)
 	}
-	if len(options) > 0 {
-		update = update.SetOptions(options)
+}
+
+// refreshSearchVectorSQL rebuilds a poll's search vector from its title,
+// description and options. The poll_search migration backfills existing polls
+// with the same expression.
+const refreshSearchVectorSQL = `UPDATE "polls" SET "search_vector" = to_tsvector($1, "polls"."title" || ' ' || coalesce("polls"."description", '') || ' ' || coalesce((SELECT string_agg("poll_options"."label" || ' ' || coalesce("poll_options"."description", ''), ' ') FROM "poll_options" WHERE "poll_options"."poll_id" = "polls"."id"), '')) WHERE "polls"."id" = $2`
+
+// refreshSearchVector rebuilds the search vector after the poll's text changed
+func refreshSearchVector(ctx context.Context, client *ent.Client, pollID uuid.UUID) error {
+	_, err := client.ExecContext(ctx, refreshSearchVectorSQL, searchConfig, pollID)
+	return err
+}
+
+// UpdatePoll applies the update and increments the poll's version. When
+// options are given, the option list is replaced in the same transaction:
+// options are kept by ID, new ones are created and the rest are deleted; votes
+// for deleted options must be removed beforehand. If versions is non-empty the
+// poll must be at one of them, or ErrPollVersionMismatch is returned.
+func (s *storage) UpdatePoll(ctx context.Context, id uuid.UUID, versions []int, update PollUpdate) (*ent.Poll, error) {
+	var p *ent.Poll
+	err := s.withTx(ctx, func(tx *storage) error {
+		u := tx.client.Poll.
+			UpdateOneID(id).
+			AddVersion(1).
+			SetUpdatedAt(time.Now())
+
+		if len(versions) > 0 {
+			u = u.Where(poll.VersionIn(versions...))
+		}
+		if update.Title != nil {
+			u = u.SetTitle(*update.Title)
+		}
+		if update.Description != nil {
+			u = u.SetDescription(*update.Description)
+		}
+		if update.ResultsVisibility != nil {
+			u = u.SetResultsVisibility(*update.ResultsVisibility)
+		}
+		if update.RequireVerifiedEmail != nil {
+			u = u.SetRequireVerifiedEmail(*update.RequireVerifiedEmail)
+		}
+		if update.MinChoices != nil {
+			u = u.SetMinChoices(*update.MinChoices)
+		}
+		if update.ClearMaxChoices {
+			u = u.ClearMaxChoices()
+		} else if update.MaxChoices != nil {
+			u = u.SetMaxChoices(*update.MaxChoices)
+		}
+		if update.ClearOpensAt {
+			u = u.ClearOpensAt()
+		} else if update.OpensAt != nil {
+			u = u.SetOpensAt(*update.OpensAt)
+		}
+		if update.ClearClosesAt {
+			u = u.ClearClosesAt()
+		} else if update.ClosesAt != nil {
+			u = u.SetClosesAt(*update.ClosesAt)
+		}
+
+		var err error
+		if p, err = u.Save(ctx); err != nil {
+			return tx.versionMismatch(ctx, id, err)
+		}
+
+		if update.Options != nil {
+			if err := replaceOptions(ctx, tx.client, id, update.Options); err != nil {
+				return err
+			}
+		}
+		if update.Title != nil || update.Description != nil || update.Options != nil {
+			return refreshSearchVector(ctx, tx.client, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return update.Save(ctx)
+	return p, nil
 }
 
-func (s *storage) DeletePoll(ctx context.Context, id uuid.UUID) error {
-	return s.client.Poll.
-		DeleteOneID(id).
-		Exec(ctx)
+// replaceOptions makes the poll's options match the given list, in order
+func replaceOptions(ctx context.Context, client *ent.Client, pollID uuid.UUID, options []OptionInput) error {
+	kept := make([]uuid.UUID, 0, len(options))
+	for _, opt := range options {
+		if opt.ID != uuid.Nil {
+			kept = append(kept, opt.ID)
+		}
+	}
+
+	if _, err := client.PollOption.
+		Delete().
+		Where(
+			polloption.PollID(pollID),
+			polloption.IDNotIn(kept...),
+		).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	for i, opt := range options {
+		if opt.ID == uuid.Nil {
+			if _, err := client.PollOption.
+				Create().
+				SetPollID(pollID).
+				SetLabel(opt.Label).
+				SetDescription(opt.Description).
+				SetImageURL(opt.ImageURL).
+				SetPosition(i).
+				Save(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := client.PollOption.
+			UpdateOneID(opt.ID).
+			Where(polloption.PollID(pollID)).
+			SetLabel(opt.Label).
+			SetDescription(opt.Description).
+			SetImageURL(opt.ImageURL).
+			SetPosition(i).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeletePoll deletes the poll and its options in one transaction. If versions
+// is non-empty the poll must be at one of them, or ErrPollVersionMismatch is returned.
+func (s *storage) DeletePoll(ctx context.Context, id uuid.UUID, versions []int) error {
+	return s.withTx(ctx, func(tx *storage) error {
+		if _, err := tx.client.PollOption.
+			Delete().
+			Where(polloption.PollID(id)).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		d := tx.client.Poll.DeleteOneID(id)
+		if len(versions) > 0 {
+			d = d.Where(poll.VersionIn(versions...))
+		}
+		if err := d.Exec(ctx); err != nil {
+			return tx.versionMismatch(ctx, id, err)
+		}
+		return nil
+	})
+}
+
+// versionMismatch tells a conditional write that matched no row because the
+// poll is at another version apart from one where the poll does not exist
+func (s *storage) versionMismatch(ctx context.Context, id uuid.UUID, err error) error {
+	if !ent.IsNotFound(err) {
+		return err
+	}
+	if exists, xerr := s.client.Poll.Query().Where(poll.ID(id)).Exist(ctx); xerr == nil && exists {
+		return ErrPollVersionMismatch
+	}
+	return err
 }
 
 func (s *storage) GetPollsByOwner(ctx context.Context, ownerID uuid.UUID) ([]*ent.Poll, error) {
@@ -78,7 +559,36 @@ func (s *storage) GetPollsByOwner(ctx context.Context, ownerID uuid.UUID) ([]*en
 		Query().
 		Where(poll.OwnerID(ownerID)).
 		WithOwner().
+		WithOptions(withOptionsInOrder).
+		WithResult().
 		Order(ent.Desc(poll.FieldCreatedAt)).
 		All(ctx)
 }
 
+// GetPollsByExternalIDs returns the owner's polls imported with any of the external IDs
+func (s *storage) GetPollsByExternalIDs(ctx context.Context, ownerID uuid.UUID, externalIDs []string) ([]*ent.Poll, error) {
+	return s.client.Poll.
+		Query().
+		Where(
+			poll.OwnerID(ownerID),
+			poll.ExternalIDIn(externalIDs...),
+		).
+		All(ctx)
+}
+
+// ListPollsToFinalize returns polls whose closing time has passed but have no frozen results yet
+func (s *storage) ListPollsToFinalize(ctx context.Context, now time.Time) ([]*ent.Poll, error) {
+	return s.client.Poll.
+		Query().
+		Where(
+			poll.ClosesAtLTE(now),
+			poll.Not(poll.HasResult()),
+		).
+		WithOptions(withOptionsInOrder).
+		All(ctx)
+}
+
+// withOptionsInOrder loads a poll's options in display order
+func withOptionsInOrder(q *ent.PollOptionQuery) {
+	q.Order(ent.Asc(polloption.FieldPosition))
+}
@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"poll-app/ent/poll"
+
+	"github.com/google/uuid"
+)
+
+func TestListPollsMostVotes(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+	now := time.Now()
+	closed := now.Add(-time.Minute)
+	later := now.Add(time.Hour)
+
+	polls := []struct {
+		name       string
+		visibility poll.ResultsVisibility
+		closesAt   *time.Time
+		voters     int
+	}{
+		{"always", poll.ResultsVisibilityAlways, nil, 2},
+		{"owner only", poll.ResultsVisibilityOwnerOnly, &closed, 5},
+		{"after close, closed", poll.ResultsVisibilityAfterClose, &closed, 3},
+		{"after close, open", poll.ResultsVisibilityAfterClose, &later, 4},
+		{"after vote, open", poll.ResultsVisibilityAfterVote, nil, 6},
+	}
+	ids := make(map[string]uuid.UUID)
+	for _, tt := range polls {
+		p := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, MinChoices: 1, ResultsVisibility: tt.visibility, ClosesAt: tt.closesAt}, "a", "b")
+		if err := s.client.Poll.UpdateOneID(p.ID).SetVoterCount(tt.voters).Exec(ctx); err != nil {
+			t.Fatal(err)
+		}
+		ids[tt.name] = p.ID
+	}
+
+	// Polls with hidden results rank last, by ID, and page cursors only
+	// carry counts that are visible
+	hidden := []uuid.UUID{ids["owner only"], ids["after close, open"], ids["after vote, open"]}
+	slices.SortFunc(hidden, func(a, b uuid.UUID) int { return -slices.Compare(a[:], b[:]) })
+	want := append([]uuid.UUID{ids["after close, closed"], ids["always"]}, hidden...)
+	wantVoters := []int{3, 2, 0, 0}
+
+	var (
+		got    []uuid.UUID
+		voters []int
+		after  *PollCursor
+	)
+	for {
+		list, err := s.ListPolls(ctx, PollFilter{Now: now}, PollPage{Sort: PollSortMostVotes, After: after, Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range list.Polls {
+			got = append(got, p.ID)
+		}
+		if list.Next == nil {
+			break
+		}
+		voters = append(voters, *list.Next.Voters)
+		after = list.Next
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("listed %v, want %v", got, want)
+	}
+	if !slices.Equal(voters, wantVoters) {
+		t.Errorf("cursors carry voters %v, want %v", voters, wantVoters)
+	}
+}
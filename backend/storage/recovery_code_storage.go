@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/recoverycode"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCodeStorage defines operations on two-factor authentication recovery codes
+type RecoveryCodeStorage interface {
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// ReplaceRecoveryCodes deletes the user's recovery codes and stores new ones.
+// It should run in a transaction, so the old codes keep working if it fails.
+func (s *storage) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	if _, err := s.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+
+	creates := make([]*ent.RecoveryCodeCreate, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		creates = append(creates, s.client.RecoveryCode.
+			Create().
+			SetUserID(userID).
+			SetCodeHash(hash))
+	}
+	return s.client.RecoveryCode.CreateBulk(creates...).Exec(ctx)
+}
+
+// UseRecoveryCode marks the user's code used if it is still unused, and
+// reports whether it was. Of concurrent uses only one succeeds.
+func (s *storage) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) (bool, error) {
+	n, err := s.client.RecoveryCode.
+		Update().
+		Where(
+			recoverycode.UserID(userID),
+			recoverycode.CodeHash(codeHash),
+			recoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(at).
+		Save(ctx)
+	return n > 0, err
+}
+
+func (s *storage) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.client.RecoveryCode.
+		Query().
+		Where(
+			recoverycode.UserID(userID),
+			recoverycode.UsedAtIsNil(),
+		).
+		Count(ctx)
+}
+
+func (s *storage) DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.client.RecoveryCode.
+		Delete().
+		Where(recoverycode.UserID(userID)).
+		Exec(ctx)
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/securityevent"
+
+	"github.com/google/uuid"
+)
+
+// SecurityEventStorage defines operations on the security events of users
+type SecurityEventStorage interface {
+	CreateSecurityEvent(ctx context.Context, event SecurityEventInput) (*ent.SecurityEvent, error)
+	ListSecurityEventsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*ent.SecurityEvent, error)
+	DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// SecurityEventInput holds a new security event
+type SecurityEventInput struct {
+	UserID    uuid.UUID
+	Type      securityevent.Type
+	SessionID string
+	IPAddress string
+	UserAgent string
+}
+
+func (s *storage) CreateSecurityEvent(ctx context.Context, event SecurityEventInput) (*ent.SecurityEvent, error) {
+	create := s.client.SecurityEvent.
+		Create().
+		SetUserID(event.UserID).
+		SetType(event.Type)
+
+	if event.SessionID != "" {
+		create = create.SetSessionID(event.SessionID)
+	}
+	if event.IPAddress != "" {
+		create = create.SetIPAddress(event.IPAddress)
+	}
+	if event.UserAgent != "" {
+		create = create.SetUserAgent(event.UserAgent)
+	}
+
+	return create.Save(ctx)
+}
+
+// ListSecurityEventsByUser returns the user's latest security events, newest first
+func (s *storage) ListSecurityEventsByUser(ctx context.Context, userID uuid.UUID, limit int) ([]*ent.SecurityEvent, error) {
+	return s.client.SecurityEvent.
+		Query().
+		Where(securityevent.UserID(userID)).
+		Order(ent.Desc(securityevent.FieldCreatedAt), ent.Desc(securityevent.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
+
+func (s *storage) DeleteSecurityEventsBefore(ctx context.Context, before time.Time) (int, error) {
+	return s.client.SecurityEvent.
+		Delete().
+		Where(securityevent.CreatedAtLT(before)).
+		Exec(ctx)
+}
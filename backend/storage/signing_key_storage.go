@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/signingkey"
+)
+
+// SigningKeyStorage defines operations on the token signing keys kept in the database
+type SigningKeyStorage interface {
+	ListSigningKeys(ctx context.Context) ([]*ent.SigningKey, error)
+	CreateSigningKey(ctx context.Context, key SigningKeyInput) (*ent.SigningKey, error)
+	RetireSigningKeys(ctx context.Context, exceptID string, at time.Time) (int, error)
+	DeleteSigningKeysRetiredBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// SigningKeyInput holds a new signing key
+type SigningKeyInput struct {
+	ID         string
+	Algorithm  signingkey.Algorithm
+	PrivateKey []byte
+	CreatedAt  time.Time
+}
+
+// ListSigningKeys returns every signing key, newest first
+func (s *storage) ListSigningKeys(ctx context.Context) ([]*ent.SigningKey, error) {
+	return s.client.SigningKey.
+		Query().
+		Order(ent.Desc(signingkey.FieldCreatedAt), ent.Desc(signingkey.FieldID)).
+		All(ctx)
+}
+
+func (s *storage) CreateSigningKey(ctx context.Context, key SigningKeyInput) (*ent.SigningKey, error) {
+	return s.client.SigningKey.
+		Create().
+		SetID(key.ID).
+		SetAlgorithm(key.Algorithm).
+		SetPrivateKey(key.PrivateKey).
+		SetCreatedAt(key.CreatedAt).
+		Save(ctx)
+}
+
+// RetireSigningKeys marks every key that is not retired yet, other than
+// exceptID, as retired at the given time
+func (s *storage) RetireSigningKeys(ctx context.Context, exceptID string, at time.Time) (int, error) {
+	return s.client.SigningKey.
+		Update().
+		Where(
+			signingkey.IDNEQ(exceptID),
+			signingkey.RetiredAtIsNil(),
+		).
+		SetRetiredAt(at).
+		Save(ctx)
+}
+
+func (s *storage) DeleteSigningKeysRetiredBefore(ctx context.Context, before time.Time) (int, error) {
+	return s.client.SigningKey.
+		Delete().
+		Where(signingkey.RetiredAtLT(before)).
+		Exec(ctx)
+}
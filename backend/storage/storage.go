@@ -1,18 +1,34 @@
 package storage
 
-import "poll-app/ent"
+import (
+	"context"
+
+	"poll-app/ent"
+)
 
 // Storage defines the interface for data access operations
 type Storage interface {
 	UserStorage
 	PollStorage
 	VoteStorage
+	BallotStorage
+	PollResultStorage
+	JobStorage
+	WebhookStorage
+	SigningKeyStorage
+	SecurityEventStorage
+	UserTokenStorage
+	RecoveryCodeStorage
+	InvitationStorage
+	WithTx(ctx context.Context, fn func(tx Storage) error) error
 	Close() error
 }
 
 // storage implements the Storage interface
 type storage struct {
 	client *ent.Client
+	// inTx is set on storages bound to a transaction by WithTx
+	inTx bool
 }
 
 // NewStorage creates a new storage instance
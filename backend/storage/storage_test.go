@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"poll-app/config"
+	"poll-app/ent"
+	"poll-app/ent/poll"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// newTestStorage returns a storage on a freshly migrated schema of the
+// database named by the POSTGRES_* variables, as set by make test. The schema
+// is dropped when the test ends. Tests using it are skipped without a database,
+// except in CI.
+func newTestStorage(t *testing.T) *storage {
+	t.Helper()
+	db := newTestDB(t)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background(), 0); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+
+	client := ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, db)))
+	t.Cleanup(func() { client.Close() })
+	return &storage{client: client}
+}
+
+// newTestDB returns a connection to an empty schema of the database named by
+// the POSTGRES_* variables, which is dropped when the test ends
+func newTestDB(t *testing.T) *stdsql.DB {
+	t.Helper()
+
+	host := os.Getenv("POSTGRES_HOST")
+	if host == "" {
+		// CI must run them, as they are the only check of the SQL
+		if os.Getenv("CI") != "" {
+			t.Fatal("POSTGRES_HOST is not set")
+		}
+		t.Skip("POSTGRES_HOST is not set; run make test to use a database")
+	}
+	port, err := strconv.Atoi(os.Getenv("POSTGRES_PORT"))
+	if err != nil {
+		port = 5432
+	}
+	cfg := config.DatabaseConfig{
+		Host:     host,
+		Port:     port,
+		User:     os.Getenv("POSTGRES_USER"),
+		Password: os.Getenv("POSTGRES_PASSWORD"),
+		Name:     os.Getenv("POSTGRES_DB"),
+		SSLMode:  os.Getenv("POSTGRES_SSLMODE"),
+	}
+
+	ctx := context.Background()
+	admin, err := OpenDB(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := admin.ExecContext(ctx, fmt.Sprintf(`CREATE SCHEMA %q`, schema)); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.ExecContext(ctx, fmt.Sprintf(`DROP SCHEMA %q CASCADE`, schema)); err != nil {
+			t.Errorf("failed to drop schema: %v", err)
+		}
+	})
+
+	// lib/pq passes unknown parameters on to the server as settings
+	db, err := stdsql.Open("postgres", cfg.DSN()+"&search_path="+schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// createTestUser creates a user with a unique name
+func createTestUser(t *testing.T, s *storage) *ent.User {
+	t.Helper()
+	name := "u" + strings.ReplaceAll(uuid.NewString(), "-", "")[:12]
+	u, err := s.CreateUser(context.Background(), name+"@example.com", name, "hash")
+	if err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	return u
+}
+
+// createTestPoll creates a poll with the labelled options, whose results are
+// always visible unless settings say otherwise
+func createTestPoll(t *testing.T, s *storage, ownerID uuid.UUID, settings PollSettings, labels ...string) *ent.Poll {
+	t.Helper()
+	if settings.ResultsVisibility == "" {
+		settings.ResultsVisibility = poll.ResultsVisibilityAlways
+	}
+	options := make([]OptionInput, 0, len(labels))
+	for _, label := range labels {
+		options = append(options, OptionInput{Label: label})
+	}
+	p, err := s.CreatePoll(context.Background(), "poll", "", options, settings, ownerID)
+	if err != nil {
+		t.Fatalf("failed to create poll: %v", err)
+	}
+	return p
+}
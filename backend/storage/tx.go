@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// uniqueViolation is the Postgres SQLSTATE of a unique constraint violation
+const uniqueViolation = "23505"
+
+// WithTx runs fn with a Storage whose operations all take part in one
+// transaction. The transaction commits if fn returns nil and rolls back if it
+// returns an error or panics. Calling WithTx on the Storage passed to fn joins
+// the enclosing transaction rather than starting a new one.
+func (s *storage) WithTx(ctx context.Context, fn func(tx Storage) error) error {
+	return s.withTx(ctx, func(tx *storage) error {
+		return fn(tx)
+	})
+}
+
+// withTx runs fn with a storage bound to a transaction, joining the current one if any
+func (s *storage) withTx(ctx context.Context, fn func(tx *storage) error) (err error) {
+	if s.inTx {
+		return fn(s)
+	}
+
+	tx, err := s.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			tx.Rollback()
+			panic(v)
+		}
+	}()
+
+	if err := fn(&storage{client: tx.Client(), inTx: true}); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rerr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UniqueViolation reports whether err is a unique constraint violation and,
+// if so, the name of the violated constraint or index
+func UniqueViolation(err error) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return pqErr.Constraint, true
+	}
+	return "", false
+}
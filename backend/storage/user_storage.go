@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"poll-app/ent"
 	"poll-app/ent/user"
@@ -15,6 +16,12 @@ type UserStorage interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*ent.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*ent.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*ent.User, error)
+	UpdateUserPassword(ctx context.Context, id uuid.UUID, hashedPassword string) (*ent.User, error)
+	SetUserEmailVerified(ctx context.Context, id uuid.UUID, at time.Time) (*ent.User, error)
+	SetUserMFASecret(ctx context.Context, id uuid.UUID, secret string) (*ent.User, error)
+	EnableUserMFA(ctx context.Context, id uuid.UUID, at time.Time, step int64) (*ent.User, error)
+	DisableUserMFA(ctx context.Context, id uuid.UUID) (*ent.User, error)
+	UseUserMFAStep(ctx context.Context, id uuid.UUID, step int64) (bool, error)
 }
 
 func (s *storage) CreateUser(ctx context.Context, email, username, hashedPassword string) (*ent.User, error) {
@@ -47,3 +54,59 @@ func (s *storage) GetUserByUsername(ctx context.Context, username string) (*ent.
 		Only(ctx)
 }
 
+func (s *storage) UpdateUserPassword(ctx context.Context, id uuid.UUID, hashedPassword string) (*ent.User, error) {
+	return s.client.User.
+		UpdateOneID(id).
+		SetPassword(hashedPassword).
+		Save(ctx)
+}
+
+func (s *storage) SetUserEmailVerified(ctx context.Context, id uuid.UUID, at time.Time) (*ent.User, error) {
+	return s.client.User.
+		UpdateOneID(id).
+		SetEmailVerifiedAt(at).
+		Save(ctx)
+}
+
+// SetUserMFASecret starts enrolling the user in two-factor authentication
+// with a new secret, which is not enabled until confirmed
+func (s *storage) SetUserMFASecret(ctx context.Context, id uuid.UUID, secret string) (*ent.User, error) {
+	return s.client.User.
+		UpdateOneID(id).
+		SetMfaSecret(secret).
+		ClearMfaEnabledAt().
+		ClearMfaLastStep().
+		Save(ctx)
+}
+
+func (s *storage) EnableUserMFA(ctx context.Context, id uuid.UUID, at time.Time, step int64) (*ent.User, error) {
+	return s.client.User.
+		UpdateOneID(id).
+		SetMfaEnabledAt(at).
+		SetMfaLastStep(step).
+		Save(ctx)
+}
+
+func (s *storage) DisableUserMFA(ctx context.Context, id uuid.UUID) (*ent.User, error) {
+	return s.client.User.
+		UpdateOneID(id).
+		ClearMfaSecret().
+		ClearMfaEnabledAt().
+		ClearMfaLastStep().
+		Save(ctx)
+}
+
+// UseUserMFAStep records that a TOTP code of the time step was accepted, and
+// reports whether no code of that step or a later one was accepted before. Of
+// concurrent uses of a code only one succeeds.
+func (s *storage) UseUserMFAStep(ctx context.Context, id uuid.UUID, step int64) (bool, error) {
+	n, err := s.client.User.
+		Update().
+		Where(
+			user.ID(id),
+			user.Or(user.MfaLastStepIsNil(), user.MfaLastStepLT(step)),
+		).
+		SetMfaLastStep(step).
+		Save(ctx)
+	return n > 0, err
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/usertoken"
+
+	"github.com/google/uuid"
+)
+
+// UserTokenStorage defines operations on the single-use tokens mailed to users
+type UserTokenStorage interface {
+	CreateUserToken(ctx context.Context, token UserTokenInput) (*ent.UserToken, error)
+	GetUserTokenByHash(ctx context.Context, tokenHash string) (*ent.UserToken, error)
+	UseUserToken(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	ExpireUserTokens(ctx context.Context, userID uuid.UUID, purpose usertoken.Purpose, at time.Time) (int, error)
+	DeleteUserTokensExpiredBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// UserTokenInput holds a new user token
+type UserTokenInput struct {
+	UserID    uuid.UUID
+	Purpose   usertoken.Purpose
+	TokenHash string
+	Email     string
+	ExpiresAt time.Time
+}
+
+func (s *storage) CreateUserToken(ctx context.Context, token UserTokenInput) (*ent.UserToken, error) {
+	return s.client.UserToken.
+		Create().
+		SetUserID(token.UserID).
+		SetPurpose(token.Purpose).
+		SetTokenHash(token.TokenHash).
+		SetEmail(token.Email).
+		SetExpiresAt(token.ExpiresAt).
+		Save(ctx)
+}
+
+func (s *storage) GetUserTokenByHash(ctx context.Context, tokenHash string) (*ent.UserToken, error) {
+	return s.client.UserToken.
+		Query().
+		Where(usertoken.TokenHash(tokenHash)).
+		Only(ctx)
+}
+
+// UseUserToken marks the token used if it is still unused and unexpired at
+// the given time, and reports whether it was. Of concurrent uses only one succeeds.
+func (s *storage) UseUserToken(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	n, err := s.client.UserToken.
+		Update().
+		Where(
+			usertoken.ID(id),
+			usertoken.UsedAtIsNil(),
+			usertoken.ExpiresAtGT(at),
+		).
+		SetUsedAt(at).
+		Save(ctx)
+	return n > 0, err
+}
+
+// ExpireUserTokens marks every unused token of the user for the purpose as
+// used, so only a token issued afterwards is accepted
+func (s *storage) ExpireUserTokens(ctx context.Context, userID uuid.UUID, purpose usertoken.Purpose, at time.Time) (int, error) {
+	return s.client.UserToken.
+		Update().
+		Where(
+			usertoken.UserID(userID),
+			usertoken.PurposeEQ(purpose),
+			usertoken.UsedAtIsNil(),
+		).
+		SetUsedAt(at).
+		Save(ctx)
+}
+
+func (s *storage) DeleteUserTokensExpiredBefore(ctx context.Context, before time.Time) (int, error) {
+	return s.client.UserToken.
+		Delete().
+		Where(usertoken.ExpiresAtLT(before)).
+		Exec(ctx)
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+
+	"github.com/google/uuid"
+)
+
+// recountOptionVotesSQL recomputes the vote counters of a poll's options from
+// its votes and flushed secret ballots. The flushed_ballot_counts migration
+// recounted existing polls with the same expression.
+const recountOptionVotesSQL = `UPDATE "poll_options" SET "vote_count" = (SELECT COUNT(*) FROM (SELECT "votes"."option_id", "votes"."option_ids" FROM "votes" WHERE "votes"."poll_id" = "poll_options"."poll_id" UNION ALL SELECT "ballots"."option_id", "ballots"."option_ids" FROM "ballots" WHERE "ballots"."poll_id" = "poll_options"."poll_id") AS "b" WHERE CASE WHEN (SELECT "polls"."type" FROM "polls" WHERE "polls"."id" = "poll_options"."poll_id") = 'approval' THEN "b"."option_ids" @> to_jsonb("poll_options"."id") ELSE "b"."option_id" = "poll_options"."id" END) WHERE "poll_options"."poll_id" = $1`
+
+// recountPollVotersSQL recomputes the voter counter of a poll. Every secret
+// ballot is cast by a different voter, and its voter only counts once it is
+// flushed.
+const recountPollVotersSQL = `UPDATE "polls" SET "voter_count" = (SELECT COUNT(*) FROM "votes" WHERE "votes"."poll_id" = "polls"."id") + (SELECT COUNT(*) FROM "ballots" WHERE "ballots"."poll_id" = "polls"."id") WHERE "polls"."id" = $1`
+
+// adjustPollVotersSQL changes the voter counter without touching updated_at,
+// which ent would otherwise refresh on every vote
+const adjustPollVotersSQL = `UPDATE "polls" SET "voter_count" = "voter_count" + $1 WHERE "id" = $2`
+
+// adjustVoteCounts adds delta to the counters of the options the ballot counts
+// for and to the poll's voter counter. It must run in the transaction that
+// records or removes the ballot.
+func (s *storage) adjustVoteCounts(ctx context.Context, pollID uuid.UUID, choices []uuid.UUID, delta int) error {
+	pollType, err := s.client.Poll.
+		Query().
+		Where(poll.ID(pollID)).
+		Select(poll.FieldType).
+		String(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Approval ballots count for every choice, others for their first choice
+	counted := choices
+	if poll.Type(pollType) != poll.TypeApproval && len(choices) > 0 {
+		counted = choices[:1]
+	}
+
+	if len(counted) > 0 {
+		if _, err := s.client.PollOption.
+			Update().
+			Where(
+				polloption.PollID(pollID),
+				polloption.IDIn(counted...),
+			).
+			AddVoteCount(delta).
+			Save(ctx); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.client.ExecContext(ctx, adjustPollVotersSQL, delta, pollID)
+	return err
+}
+
+// recountVotes recomputes the option and voter counters of a poll after votes
+// were removed or secret ballots flushed in bulk
+func recountVotes(ctx context.Context, client *ent.Client, pollID uuid.UUID) error {
+	if _, err := client.ExecContext(ctx, recountOptionVotesSQL, pollID); err != nil {
+		return err
+	}
+	_, err := client.ExecContext(ctx, recountPollVotersSQL, pollID)
+	return err
+}
+
+// voteChoices returns the choices of a vote or ballot, falling back to its
+// first choice for rows recorded before full ballots were stored
+func voteChoices(optionID uuid.UUID, optionIDs []uuid.UUID) []uuid.UUID {
+	if len(optionIDs) > 0 {
+		return optionIDs
+	}
+	if optionID != uuid.Nil {
+		return []uuid.UUID{optionID}
+	}
+	return nil
+}
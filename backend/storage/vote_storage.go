@@ -2,32 +2,52 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"poll-app/ent"
+	"poll-app/ent/predicate"
+	"poll-app/ent/user"
 	"poll-app/ent/vote"
 
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"github.com/google/uuid"
 )
 
 // VoteStorage defines vote-related database operations
 type VoteStorage interface {
-	CreateVote(ctx context.Context, userID, pollID uuid.UUID, option string) (*ent.Vote, error)
+	CreateVote(ctx context.Context, userID, pollID uuid.UUID, choices []uuid.UUID) (*ent.Vote, error)
 	GetVoteByUserAndPoll(ctx context.Context, userID, pollID uuid.UUID) (*ent.Vote, error)
 	GetVotesByPoll(ctx context.Context, pollID uuid.UUID) ([]*ent.Vote, error)
-	GetVotesByPollAndOption(ctx context.Context, pollID uuid.UUID, option string) ([]*ent.Vote, error)
-	GetVoteCountsByPoll(ctx context.Context, pollID uuid.UUID) (map[string]int, error)
+	ListVotesByPoll(ctx context.Context, pollID uuid.UUID, after *VoteCursor, limit int) ([]*ent.Vote, error)
+	ListVotersByOption(ctx context.Context, pollID, optionID uuid.UUID, anyChoice bool, after *uuid.UUID, limit int) ([]*ent.User, error)
 	DeleteVoteByUserAndPoll(ctx context.Context, userID, pollID uuid.UUID) error
-	DeleteVotesByPollAndOptions(ctx context.Context, pollID uuid.UUID, options []string) error
+	RemoveOptionsFromVotes(ctx context.Context, pollID uuid.UUID, optionIDs []uuid.UUID, minChoices int) error
 	DeleteVotesByPoll(ctx context.Context, pollID uuid.UUID) error
 }
 
-func (s *storage) CreateVote(ctx context.Context, userID, pollID uuid.UUID, option string) (*ent.Vote, error) {
-	return s.client.Vote.
-		Create().
-		SetUserID(userID).
-		SetPollID(pollID).
-		SetOption(option).
-		Save(ctx)
+// CreateVote records the vote and counts it in one transaction
+func (s *storage) CreateVote(ctx context.Context, userID, pollID uuid.UUID, choices []uuid.UUID) (*ent.Vote, error) {
+	var v *ent.Vote
+	err := s.withTx(ctx, func(tx *storage) error {
+		var err error
+		v, err = tx.client.Vote.
+			Create().
+			SetUserID(userID).
+			SetPollID(pollID).
+			SetOptionID(choices[0]).
+			SetOptionIds(choices).
+			Save(ctx)
+		if err != nil {
+			return err
+		}
+		return tx.adjustVoteCounts(ctx, pollID, choices, 1)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
 }
 
 func (s *storage) GetVoteByUserAndPoll(ctx context.Context, userID, pollID uuid.UUID) (*ent.Vote, error) {
@@ -44,62 +64,147 @@ func (s *storage) GetVotesByPoll(ctx context.Context, pollID uuid.UUID) ([]*ent.
 	return s.client.Vote.
 		Query().
 		Where(vote.PollID(pollID)).
-		WithUser().
 		All(ctx)
 }
 
-func (s *storage) GetVotesByPollAndOption(ctx context.Context, pollID uuid.UUID, option string) ([]*ent.Vote, error) {
-	return s.client.Vote.
+// VoteCursor is the position of the last vote of a page in the order of
+// ListVotesByPoll. It holds the values the page is sorted by, so it stays
+// valid if that vote is changed or retracted meanwhile.
+type VoteCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// ListVotesByPoll returns a page of the poll's votes with their voters loaded,
+// oldest first. after is the position of the last vote of the previous page.
+func (s *storage) ListVotesByPoll(ctx context.Context, pollID uuid.UUID, after *VoteCursor, limit int) ([]*ent.Vote, error) {
+	query := s.client.Vote.
 		Query().
-		Where(
-			vote.PollID(pollID),
-			vote.Option(option),
-		).
+		Where(vote.PollID(pollID))
+
+	if after != nil {
+		query = query.Where(vote.Or(
+			vote.CreatedAtGT(after.CreatedAt),
+			vote.And(
+				vote.CreatedAt(after.CreatedAt),
+				vote.IDGT(after.ID),
+			),
+		))
+	}
+
+	return query.
 		WithUser().
+		Order(ent.Asc(vote.FieldCreatedAt), ent.Asc(vote.FieldID)).
+		Limit(limit).
 		All(ctx)
 }
 
-func (s *storage) GetVoteCountsByPoll(ctx context.Context, pollID uuid.UUID) (map[string]int, error) {
-	votes, err := s.client.Vote.
-		Query().
-		Where(vote.PollID(pollID)).
-		All(ctx)
-	if err != nil {
-		return nil, err
+// ListVotersByOption returns a page of the users whose vote counts for the
+// option, ordered by user ID. With anyChoice every choice of a vote counts,
+// otherwise only its first choice. after is the last user of the previous page.
+func (s *storage) ListVotersByOption(ctx context.Context, pollID, optionID uuid.UUID, anyChoice bool, after *uuid.UUID, limit int) ([]*ent.User, error) {
+	votes := []predicate.Vote{vote.PollID(pollID), vote.OptionID(optionID)}
+	if anyChoice {
+		votes = []predicate.Vote{
+			vote.PollID(pollID),
+			func(sel *sql.Selector) {
+				sel.Where(sqljson.ValueContains(vote.FieldOptionIds, optionID.String()))
+			},
+		}
 	}
 
-	counts := make(map[string]int)
-	for _, v := range votes {
-		counts[v.Option]++
+	query := s.client.User.
+		Query().
+		Where(user.HasVotesWith(votes...))
+	if after != nil {
+		query = query.Where(user.IDGT(*after))
 	}
 
-	return counts, nil
+	return query.
+		Order(ent.Asc(user.FieldID)).
+		Limit(limit).
+		All(ctx)
 }
 
+// DeleteVoteByUserAndPoll removes the user's vote, if any, and uncounts it in one transaction
 func (s *storage) DeleteVoteByUserAndPoll(ctx context.Context, userID, pollID uuid.UUID) error {
-	_, err := s.client.Vote.
-		Delete().
-		Where(
-			vote.UserID(userID),
-			vote.PollID(pollID),
-		).
-		Exec(ctx)
-	return err
+	return s.withTx(ctx, func(tx *storage) error {
+		v, err := tx.GetVoteByUserAndPoll(ctx, userID, pollID)
+		if ent.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := tx.client.Vote.DeleteOneID(v.ID).Exec(ctx); err != nil {
+			return err
+		}
+		return tx.adjustVoteCounts(ctx, pollID, voteChoices(v.OptionID, v.OptionIds), -1)
+	})
 }
 
-func (s *storage) DeleteVotesByPollAndOptions(ctx context.Context, pollID uuid.UUID, options []string) error {
-	if len(options) == 0 {
+// RemoveOptionsFromVotes takes the options out of every vote that chose any of
+// them, wherever they are ranked, and recounts the poll's votes in one
+// transaction. Votes left with fewer than minChoices choices are deleted so
+// their voters can vote again.
+func (s *storage) RemoveOptionsFromVotes(ctx context.Context, pollID uuid.UUID, optionIDs []uuid.UUID, minChoices int) error {
+	if len(optionIDs) == 0 {
 		return nil
 	}
 
-	_, err := s.client.Vote.
-		Delete().
-		Where(
-			vote.PollID(pollID),
-			vote.OptionIn(options...),
-		).
-		Exec(ctx)
-	return err
+	removed := make(map[uuid.UUID]bool, len(optionIDs))
+	chose := []predicate.Vote{vote.OptionIDIn(optionIDs...)}
+	for _, id := range optionIDs {
+		removed[id] = true
+		chose = append(chose, func(sel *sql.Selector) {
+			sel.Where(sqljson.ValueContains(vote.FieldOptionIds, id.String()))
+		})
+	}
+
+	return s.withTx(ctx, func(tx *storage) error {
+		votes, err := tx.client.Vote.
+			Query().
+			Where(
+				vote.PollID(pollID),
+				vote.Or(chose...),
+			).
+			All(ctx)
+		if err != nil {
+			return err
+		}
+
+		var emptied []uuid.UUID
+		for _, v := range votes {
+			var choices []uuid.UUID
+			for _, id := range voteChoices(v.OptionID, v.OptionIds) {
+				if !removed[id] {
+					choices = append(choices, id)
+				}
+			}
+			if len(choices) == 0 || len(choices) < minChoices {
+				emptied = append(emptied, v.ID)
+				continue
+			}
+			if err := tx.client.Vote.
+				UpdateOneID(v.ID).
+				SetOptionID(choices[0]).
+				SetOptionIds(choices).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		if len(emptied) > 0 {
+			if _, err := tx.client.Vote.
+				Delete().
+				Where(vote.IDIn(emptied...)).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+		return recountVotes(ctx, tx.client, pollID)
+	})
 }
 
 func (s *storage) DeleteVotesByPoll(ctx context.Context, pollID uuid.UUID) error {
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"poll-app/ent"
+	"poll-app/ent/poll"
+	"poll-app/ent/polloption"
+
+	"github.com/google/uuid"
+)
+
+func TestRemoveOptionsFromVotes(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+
+	tests := []struct {
+		name       string
+		pollType   poll.Type
+		minChoices int
+		// ballots and want list choices by option label; a nil want means the
+		// vote is deleted
+		ballots [][]string
+		want    [][]string
+		counts  map[string]int
+	}{
+		{
+			name:     "ranked",
+			pollType: poll.TypeRanked,
+			ballots:  [][]string{{"a", "b", "c"}, {"b", "a"}, {"b"}, {"c"}},
+			want:     [][]string{{"a", "c"}, {"a"}, nil, {"c"}},
+			counts:   map[string]int{"a": 2, "c": 1},
+		},
+		{
+			name:       "approval below min choices",
+			pollType:   poll.TypeApproval,
+			minChoices: 2,
+			ballots:    [][]string{{"a", "b"}, {"a", "b", "c"}, {"a", "c"}},
+			want:       [][]string{nil, {"a", "c"}, {"a", "c"}},
+			counts:     map[string]int{"a": 2, "c": 2},
+		},
+		{
+			name:     "single",
+			pollType: poll.TypeSingle,
+			ballots:  [][]string{{"b"}, {"a"}},
+			want:     [][]string{nil, {"a"}},
+			counts:   map[string]int{"a": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minChoices := max(tt.minChoices, 1)
+			p := createTestPoll(t, s, owner.ID, PollSettings{Type: tt.pollType, MinChoices: minChoices}, "a", "b", "c")
+			ids := make(map[string]uuid.UUID)
+			labels := make(map[uuid.UUID]string)
+			for _, opt := range p.Edges.Options {
+				ids[opt.Label] = opt.ID
+				labels[opt.ID] = opt.Label
+			}
+
+			votes := make([]*ent.Vote, len(tt.ballots))
+			for i, ballot := range tt.ballots {
+				choices := make([]uuid.UUID, len(ballot))
+				for j, label := range ballot {
+					choices[j] = ids[label]
+				}
+				v, err := s.CreateVote(ctx, createTestUser(t, s).ID, p.ID, choices)
+				if err != nil {
+					t.Fatalf("failed to vote: %v", err)
+				}
+				votes[i] = v
+			}
+
+			if err := s.RemoveOptionsFromVotes(ctx, p.ID, []uuid.UUID{ids["b"]}, minChoices); err != nil {
+				t.Fatalf("RemoveOptionsFromVotes: %v", err)
+			}
+
+			for i, v := range votes {
+				got, err := s.client.Vote.Get(ctx, v.ID)
+				if tt.want[i] == nil {
+					if !ent.IsNotFound(err) {
+						t.Errorf("vote %d: err = %v, want it deleted", i, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("vote %d: %v", i, err)
+				}
+				var choices []string
+				for _, id := range got.OptionIds {
+					choices = append(choices, labels[id])
+				}
+				if !slices.Equal(choices, tt.want[i]) || got.OptionID != ids[tt.want[i][0]] {
+					t.Errorf("vote %d = %v (first %s), want %v", i, choices, labels[got.OptionID], tt.want[i])
+				}
+			}
+
+			options, err := s.client.PollOption.Query().Where(polloption.PollID(p.ID)).All(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, opt := range options {
+				if opt.VoteCount != tt.counts[opt.Label] {
+					t.Errorf("option %s has %d votes, want %d", opt.Label, opt.VoteCount, tt.counts[opt.Label])
+				}
+			}
+		})
+	}
+}
+
+func TestListVotesByPoll(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+	owner := createTestUser(t, s)
+
+	tests := []struct {
+		name  string
+		votes int
+		limit int
+		// retract removes the last vote of the first page before the next is read
+		retract bool
+	}{
+		{"one page", 3, 5, false},
+		{"several pages", 7, 3, false},
+		{"cursor vote retracted", 7, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createTestPoll(t, s, owner.ID, PollSettings{Type: poll.TypeSingle, MinChoices: 1}, "a", "b")
+			var want []uuid.UUID
+			for i := 0; i < tt.votes; i++ {
+				voter := createTestUser(t, s)
+				v, err := s.CreateVote(ctx, voter.ID, p.ID, []uuid.UUID{p.Edges.Options[0].ID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				want = append(want, v.ID)
+			}
+
+			var (
+				got   []uuid.UUID
+				after *VoteCursor
+			)
+			for page := 0; ; page++ {
+				votes, err := s.ListVotesByPoll(ctx, p.ID, after, tt.limit)
+				if err != nil {
+					t.Fatalf("page %d: %v", page, err)
+				}
+				for _, v := range votes {
+					got = append(got, v.ID)
+				}
+				if len(votes) < tt.limit {
+					break
+				}
+
+				last := votes[len(votes)-1]
+				after = &VoteCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+				if tt.retract && page == 0 {
+					if err := s.DeleteVoteByUserAndPoll(ctx, last.UserID, p.ID); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+
+			if !slices.Equal(got, want) {
+				t.Fatalf("listed votes %v, want %v", got, want)
+			}
+		})
+	}
+}
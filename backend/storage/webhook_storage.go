@@ -0,0 +1,262 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/ent/webhookendpoint"
+
+	"github.com/google/uuid"
+)
+
+// WebhookStorage defines operations on webhook endpoints and their deliveries
+type WebhookStorage interface {
+	CreateWebhookEndpoint(ctx context.Context, ownerID uuid.UUID, endpoint WebhookEndpointInput) (*ent.WebhookEndpoint, error)
+	GetWebhookEndpointByID(ctx context.Context, id uuid.UUID) (*ent.WebhookEndpoint, error)
+	ListWebhookEndpointsByOwner(ctx context.Context, ownerID uuid.UUID) ([]*ent.WebhookEndpoint, error)
+	UpdateWebhookEndpoint(ctx context.Context, id uuid.UUID, update WebhookEndpointUpdate) (*ent.WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) error
+	EnqueueWebhookEvent(ctx context.Context, ownerID uuid.UUID, event string, eventID uuid.UUID, payload json.RawMessage) (int, error)
+	GetWebhookDeliveryByID(ctx context.Context, id uuid.UUID) (*ent.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, endpointID uuid.UUID, status *webhookdelivery.Status, after *uuid.UUID, limit int) ([]*ent.WebhookDelivery, error)
+	ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*ent.WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, id uuid.UUID, attempt WebhookAttempt) (*ent.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, id uuid.UUID) (*ent.WebhookDelivery, error)
+	DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// WebhookEndpointInput holds the settings of a new webhook endpoint
+type WebhookEndpointInput struct {
+	URL         string
+	Description string
+	Secret      string
+	Events      []string
+}
+
+// WebhookEndpointUpdate holds the settings of a webhook endpoint to change.
+// Nil fields are left unchanged.
+type WebhookEndpointUpdate struct {
+	URL         *string
+	Description *string
+	Events      []string
+	Active      *bool
+}
+
+// WebhookAttempt is the outcome of one attempt to deliver a webhook
+type WebhookAttempt struct {
+	At     time.Time
+	Status webhookdelivery.Status
+	// NextAttemptAt is when a pending delivery is retried
+	NextAttemptAt time.Time
+	// ResponseStatus is nil if the endpoint did not respond
+	ResponseStatus *int
+	Error          string
+}
+
+func (s *storage) CreateWebhookEndpoint(ctx context.Context, ownerID uuid.UUID, endpoint WebhookEndpointInput) (*ent.WebhookEndpoint, error) {
+	create := s.client.WebhookEndpoint.
+		Create().
+		SetOwnerID(ownerID).
+		SetURL(endpoint.URL).
+		SetSecret(endpoint.Secret).
+		SetEvents(endpoint.Events)
+
+	if endpoint.Description != "" {
+		create = create.SetDescription(endpoint.Description)
+	}
+
+	return create.Save(ctx)
+}
+
+func (s *storage) GetWebhookEndpointByID(ctx context.Context, id uuid.UUID) (*ent.WebhookEndpoint, error) {
+	return s.client.WebhookEndpoint.Get(ctx, id)
+}
+
+func (s *storage) ListWebhookEndpointsByOwner(ctx context.Context, ownerID uuid.UUID) ([]*ent.WebhookEndpoint, error) {
+	return s.client.WebhookEndpoint.
+		Query().
+		Where(webhookendpoint.OwnerID(ownerID)).
+		Order(ent.Asc(webhookendpoint.FieldCreatedAt), ent.Asc(webhookendpoint.FieldID)).
+		All(ctx)
+}
+
+func (s *storage) UpdateWebhookEndpoint(ctx context.Context, id uuid.UUID, update WebhookEndpointUpdate) (*ent.WebhookEndpoint, error) {
+	builder := s.client.WebhookEndpoint.UpdateOneID(id)
+
+	if update.URL != nil {
+		builder = builder.SetURL(*update.URL)
+	}
+	if update.Description != nil {
+		builder = builder.SetDescription(*update.Description)
+	}
+	if update.Events != nil {
+		builder = builder.SetEvents(update.Events)
+	}
+	if update.Active != nil {
+		builder = builder.SetActive(*update.Active)
+	}
+
+	return builder.Save(ctx)
+}
+
+// DeleteWebhookEndpoint deletes the endpoint together with its delivery log
+func (s *storage) DeleteWebhookEndpoint(ctx context.Context, id uuid.UUID) error {
+	return s.withTx(ctx, func(tx *storage) error {
+		if _, err := tx.client.WebhookDelivery.
+			Delete().
+			Where(webhookdelivery.EndpointID(id)).
+			Exec(ctx); err != nil {
+			return err
+		}
+		return tx.client.WebhookEndpoint.DeleteOneID(id).Exec(ctx)
+	})
+}
+
+// EnqueueWebhookEvent adds a pending delivery of the event for every active
+// endpoint of the owner that subscribed to it, and returns how many were
+// added. Called in the transaction of the change the event reports, the
+// deliveries are recorded if and only if the change is.
+func (s *storage) EnqueueWebhookEvent(ctx context.Context, ownerID uuid.UUID, event string, eventID uuid.UUID, payload json.RawMessage) (int, error) {
+	endpoints, err := s.client.WebhookEndpoint.
+		Query().
+		Where(
+			webhookendpoint.OwnerID(ownerID),
+			webhookendpoint.Active(true),
+		).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var creates []*ent.WebhookDeliveryCreate
+	for _, endpoint := range endpoints {
+		if !slices.Contains(endpoint.Events, event) {
+			continue
+		}
+		creates = append(creates, s.client.WebhookDelivery.
+			Create().
+			SetEndpointID(endpoint.ID).
+			SetEventID(eventID).
+			SetEvent(event).
+			SetPayload(payload))
+	}
+	if len(creates) == 0 {
+		return 0, nil
+	}
+
+	if err := s.client.WebhookDelivery.CreateBulk(creates...).Exec(ctx); err != nil {
+		return 0, err
+	}
+	return len(creates), nil
+}
+
+func (s *storage) GetWebhookDeliveryByID(ctx context.Context, id uuid.UUID) (*ent.WebhookDelivery, error) {
+	return s.client.WebhookDelivery.Get(ctx, id)
+}
+
+// ListWebhookDeliveries returns a page of the endpoint's deliveries, newest
+// first, optionally only those in the given status. after is the last
+// delivery of the previous page.
+func (s *storage) ListWebhookDeliveries(ctx context.Context, endpointID uuid.UUID, status *webhookdelivery.Status, after *uuid.UUID, limit int) ([]*ent.WebhookDelivery, error) {
+	query := s.client.WebhookDelivery.
+		Query().
+		Where(webhookdelivery.EndpointID(endpointID))
+
+	if status != nil {
+		query = query.Where(webhookdelivery.StatusEQ(*status))
+	}
+
+	if after != nil {
+		last, err := s.client.WebhookDelivery.
+			Query().
+			Where(
+				webhookdelivery.ID(*after),
+				webhookdelivery.EndpointID(endpointID),
+			).
+			Only(ctx)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(webhookdelivery.Or(
+			webhookdelivery.CreatedAtLT(last.CreatedAt),
+			webhookdelivery.And(
+				webhookdelivery.CreatedAt(last.CreatedAt),
+				webhookdelivery.IDLT(last.ID),
+			),
+		))
+	}
+
+	return query.
+		Order(ent.Desc(webhookdelivery.FieldCreatedAt), ent.Desc(webhookdelivery.FieldID)).
+		Limit(limit).
+		All(ctx)
+}
+
+// ListDueWebhookDeliveries returns the pending deliveries whose next attempt
+// is due, oldest first, with their endpoints loaded
+func (s *storage) ListDueWebhookDeliveries(ctx context.Context, now time.Time, limit int) ([]*ent.WebhookDelivery, error) {
+	return s.client.WebhookDelivery.
+		Query().
+		Where(
+			webhookdelivery.StatusEQ(webhookdelivery.StatusPending),
+			webhookdelivery.NextAttemptAtLTE(now),
+		).
+		WithEndpoint().
+		Order(ent.Asc(webhookdelivery.FieldNextAttemptAt)).
+		Limit(limit).
+		All(ctx)
+}
+
+func (s *storage) RecordWebhookAttempt(ctx context.Context, id uuid.UUID, attempt WebhookAttempt) (*ent.WebhookDelivery, error) {
+	update := s.client.WebhookDelivery.
+		UpdateOneID(id).
+		AddAttempts(1).
+		SetLastAttemptAt(attempt.At).
+		SetStatus(attempt.Status).
+		SetNextAttemptAt(attempt.NextAttemptAt)
+
+	if attempt.ResponseStatus != nil {
+		update = update.SetResponseStatus(*attempt.ResponseStatus)
+	} else {
+		update = update.ClearResponseStatus()
+	}
+	if attempt.Error != "" {
+		update = update.SetLastError(attempt.Error)
+	} else {
+		update = update.ClearLastError()
+	}
+
+	return update.Save(ctx)
+}
+
+// RedeliverWebhook queues the payload of a delivery again as a new pending
+// delivery to the same endpoint, keeping the original in the log
+func (s *storage) RedeliverWebhook(ctx context.Context, id uuid.UUID) (*ent.WebhookDelivery, error) {
+	original, err := s.client.WebhookDelivery.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.WebhookDelivery.
+		Create().
+		SetEndpointID(original.EndpointID).
+		SetEventID(original.EventID).
+		SetEvent(original.Event).
+		SetPayload(original.Payload).
+		Save(ctx)
+}
+
+// DeleteWebhookDeliveriesBefore deletes finished deliveries created before the given time
+func (s *storage) DeleteWebhookDeliveriesBefore(ctx context.Context, before time.Time) (int, error) {
+	return s.client.WebhookDelivery.
+		Delete().
+		Where(
+			webhookdelivery.StatusNEQ(webhookdelivery.StatusPending),
+			webhookdelivery.CreatedAtLT(before),
+		).
+		Exec(ctx)
+}
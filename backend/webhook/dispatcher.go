@@ -0,0 +1,177 @@
+// Package webhook delivers the webhook events queued in the outbox to their
+// endpoints
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/storage"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	// MaxAttempts is how many times a delivery is tried before it is dead-lettered
+	MaxAttempts = 10
+	// initialBackoff is the delay before the first retry; it doubles with every attempt
+	initialBackoff = time.Minute
+	// maxBackoff caps the delay between retries
+	maxBackoff = 4 * time.Hour
+	// batchSize is how many due deliveries are sent per run
+	batchSize = 100
+	// concurrency is how many deliveries are in flight at once
+	concurrency = 8
+	// requestTimeout bounds a single delivery, including reading the response
+	requestTimeout = 10 * time.Second
+	// maxErrorLength truncates errors recorded in the delivery log
+	maxErrorLength = 500
+)
+
+// Dispatcher sends due webhook deliveries and records their outcome
+type Dispatcher struct {
+	storage storage.WebhookStorage
+	client  *http.Client
+}
+
+// NewDispatcher creates a dispatcher for the deliveries in storage
+func NewDispatcher(storage storage.WebhookStorage) *Dispatcher {
+	return &Dispatcher{
+		storage: storage,
+		client: &http.Client{
+			Timeout: requestTimeout,
+			// A redirect is reported as a failed delivery rather than followed,
+			// so payloads are never sent to a URL the owner did not register
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// DeliverDue sends a batch of due deliveries and returns how many were attempted
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.storage.ListDueWebhookDeliveries(ctx, time.Now(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *ent.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			d.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt at a delivery and records the outcome
+func (d *Dispatcher) deliver(ctx context.Context, delivery *ent.WebhookDelivery) {
+	now := time.Now()
+	attempt := storage.WebhookAttempt{At: now, Status: webhookdelivery.StatusSucceeded, NextAttemptAt: now}
+
+	endpoint := delivery.Edges.Endpoint
+	if endpoint == nil || !endpoint.Active {
+		attempt.Status = webhookdelivery.StatusDead
+		attempt.Error = "endpoint is inactive"
+	} else if status, err := d.post(ctx, endpoint, delivery, now); err != nil {
+		attempt.ResponseStatus = status
+		attempt.Error = truncate(err.Error(), maxErrorLength)
+		if delivery.Attempts+1 >= MaxAttempts {
+			attempt.Status = webhookdelivery.StatusDead
+		} else {
+			attempt.Status = webhookdelivery.StatusPending
+			attempt.NextAttemptAt = now.Add(Backoff(delivery.Attempts + 1))
+		}
+	} else {
+		attempt.ResponseStatus = status
+	}
+
+	if _, err := d.storage.RecordWebhookAttempt(ctx, delivery.ID, attempt); err != nil {
+		log.Printf("Failed to record attempt of webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// post sends the delivery's payload to the endpoint. It returns the response
+// status, if any, and an error unless the endpoint answered with a 2xx status.
+func (d *Dispatcher) post(ctx context.Context, endpoint *ent.WebhookEndpoint, delivery *ent.WebhookDelivery, now time.Time) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "poll-app-webhooks/1")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, delivery.EventID.String())
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	status := resp.StatusCode
+	if status < 200 || status > 299 {
+		return &status, fmt.Errorf("endpoint responded with status %d", status)
+	}
+	return &status, nil
+}
+
+// Sign returns the signature header value of a payload sent at timestamp. It
+// is the hex HMAC-SHA256 of "<timestamp>.<payload>" keyed with the endpoint
+// secret; receivers recompute it and reject stale timestamps to stop replays.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before retrying a delivery that failed attempts times
+func Backoff(attempts int) time.Duration {
+	delay := initialBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
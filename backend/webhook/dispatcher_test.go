@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/ent/webhookdelivery"
+	"poll-app/storage"
+
+	"github.com/google/uuid"
+)
+
+// fakeWebhookStorage serves one due delivery and records its attempt
+type fakeWebhookStorage struct {
+	storage.WebhookStorage
+
+	delivery *ent.WebhookDelivery
+
+	mu       sync.Mutex
+	attempts []storage.WebhookAttempt
+}
+
+func (s *fakeWebhookStorage) ListDueWebhookDeliveries(context.Context, time.Time, int) ([]*ent.WebhookDelivery, error) {
+	return []*ent.WebhookDelivery{s.delivery}, nil
+}
+
+func (s *fakeWebhookStorage) RecordWebhookAttempt(_ context.Context, id uuid.UUID, attempt storage.WebhookAttempt) (*ent.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts = append(s.attempts, attempt)
+	return &ent.WebhookDelivery{ID: id}, nil
+}
+
+func TestSign(t *testing.T) {
+	// Receivers verify the documented scheme, so its output must not change
+	got := Sign("secret", "1700000000", []byte(`{"a":1}`))
+	want := "v1=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686"
+	if got != want {
+		t.Fatalf("Sign = %q, want %q", got, want)
+	}
+	for _, other := range []string{
+		Sign("other", "1700000000", []byte(`{"a":1}`)),
+		Sign("secret", "1700000001", []byte(`{"a":1}`)),
+		Sign("secret", "1700000000", []byte(`{"a":2}`)),
+	} {
+		if other == got {
+			t.Errorf("signature %q does not depend on every input", other)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{5, 16 * time.Minute},
+		{9, maxBackoff},
+		{100, maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDispatcherDeliver(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int
+		inactive bool
+		want     webhookdelivery.Status
+		retry    bool
+	}{
+		{name: "accepted", status: http.StatusNoContent, want: webhookdelivery.StatusSucceeded},
+		{name: "failed", status: http.StatusInternalServerError, want: webhookdelivery.StatusPending, retry: true},
+		{name: "redirected", status: http.StatusFound, want: webhookdelivery.StatusPending, retry: true},
+		{name: "last attempt failed", status: http.StatusBadGateway, attempts: MaxAttempts - 1, want: webhookdelivery.StatusDead},
+		{name: "inactive endpoint", status: http.StatusOK, inactive: true, want: webhookdelivery.StatusDead},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const secret = "endpoint-secret"
+			payload := []byte(`{"poll_id":"x"}`)
+			eventID := uuid.New()
+
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				body, _ := io.ReadAll(r.Body)
+				timestamp := r.Header.Get(HeaderTimestamp)
+				if r.Header.Get(HeaderSignature) != Sign(secret, timestamp, body) {
+					t.Errorf("signature %q does not match the body", r.Header.Get(HeaderSignature))
+				}
+				if _, err := strconv.ParseInt(timestamp, 10, 64); err != nil {
+					t.Errorf("timestamp %q is not a Unix time", timestamp)
+				}
+				if r.Header.Get(HeaderEvent) != "vote.created" || r.Header.Get(HeaderDelivery) != eventID.String() {
+					t.Errorf("event headers %q %q", r.Header.Get(HeaderEvent), r.Header.Get(HeaderDelivery))
+				}
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(server.Close)
+
+			delivery := &ent.WebhookDelivery{
+				ID:       uuid.New(),
+				Event:    "vote.created",
+				EventID:  eventID,
+				Payload:  payload,
+				Attempts: tt.attempts,
+			}
+			delivery.Edges.Endpoint = &ent.WebhookEndpoint{URL: server.URL, Secret: secret, Active: !tt.inactive}
+			store := &fakeWebhookStorage{delivery: delivery}
+
+			start := time.Now()
+			n, err := NewDispatcher(store).DeliverDue(context.Background())
+			if err != nil || n != 1 {
+				t.Fatalf("DeliverDue = %d, %v", n, err)
+			}
+
+			if wantRequests := map[bool]int{true: 0, false: 1}[tt.inactive]; requests != wantRequests {
+				t.Fatalf("endpoint received %d requests, want %d", requests, wantRequests)
+			}
+			if len(store.attempts) != 1 {
+				t.Fatalf("recorded %d attempts, want 1", len(store.attempts))
+			}
+			attempt := store.attempts[0]
+			if attempt.Status != tt.want {
+				t.Errorf("status = %s, want %s", attempt.Status, tt.want)
+			}
+			if !tt.inactive && (attempt.ResponseStatus == nil || *attempt.ResponseStatus != tt.status) {
+				t.Errorf("response status = %v, want %d", attempt.ResponseStatus, tt.status)
+			}
+			if (attempt.Error == "") != (tt.want == webhookdelivery.StatusSucceeded) {
+				t.Errorf("error = %q", attempt.Error)
+			}
+			if retry := attempt.NextAttemptAt.Sub(start) >= Backoff(tt.attempts+1); retry != tt.retry {
+				t.Errorf("next attempt at %v, want a retry %v", attempt.NextAttemptAt, tt.retry)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"poll-app/auth"
+	"poll-app/service"
+	"poll-app/storage"
+	"poll-app/webhook"
+)
+
+const (
+	// jobRunRetention is how long job run history is kept
+	jobRunRetention = 30 * 24 * time.Hour
+	// webhookDeliveryRetention is how long finished webhook deliveries stay in the delivery log
+	webhookDeliveryRetention = 30 * 24 * time.Hour
+	// securityEventRetention is how long users can look back on security events
+	securityEventRetention = 90 * 24 * time.Hour
+)
+
+// DefaultJobs returns the jobs run by the worker. Signing keys are only
+// rotated if keys is given, which is when tokens are signed with a key ring.
+func DefaultJobs(svc service.Service, store storage.Storage, keys *auth.KeyRotator) []Job {
+	jobs := []Job{
+		{
+			Name:     "finalize-closed-polls",
+			Interval: time.Minute,
+			Run:      svc.FinalizeClosedPolls,
+		},
+		{
+			Name:     "flush-ballots",
+			Interval: time.Minute,
+			Run:      svc.FlushBallots,
+		},
+		{
+			Name:     "expire-invitations",
+			Interval: 5 * time.Minute,
+			Run:      svc.ExpireInvitations,
+		},
+		{
+			Name:     "send-invitation-reminders",
+			Interval: 5 * time.Minute,
+			Run:      svc.SendInvitationReminders,
+		},
+		{
+			Name:     "deliver-webhooks",
+			Interval: 15 * time.Second,
+			Run:      webhook.NewDispatcher(store).DeliverDue,
+		},
+		{
+			Name:     "purge-webhook-deliveries",
+			Interval: 24 * time.Hour,
+			Run: func(ctx context.Context) (int, error) {
+				return store.DeleteWebhookDeliveriesBefore(ctx, time.Now().Add(-webhookDeliveryRetention))
+			},
+		},
+		{
+			Name:     "purge-security-events",
+			Interval: 24 * time.Hour,
+			Run: func(ctx context.Context) (int, error) {
+				return store.DeleteSecurityEventsBefore(ctx, time.Now().Add(-securityEventRetention))
+			},
+		},
+		{
+			// Expired tokens are rejected anyway; used ones expire too
+			Name:     "purge-user-tokens",
+			Interval: time.Hour,
+			Run: func(ctx context.Context) (int, error) {
+				return store.DeleteUserTokensExpiredBefore(ctx, time.Now())
+			},
+		},
+		{
+			Name:     "purge-job-runs",
+			Interval: 24 * time.Hour,
+			Run: func(ctx context.Context) (int, error) {
+				return store.DeleteJobRunsBefore(ctx, time.Now().Add(-jobRunRetention))
+			},
+		},
+	}
+
+	if keys != nil {
+		jobs = append(jobs, Job{
+			Name:     "rotate-signing-keys",
+			Interval: time.Hour,
+			Run:      keys.RotateDue,
+		})
+	}
+
+	return jobs
+}
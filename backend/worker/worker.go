@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"poll-app/ent"
+	"poll-app/storage"
+)
+
+// Job is a unit of time-driven work run by the Scheduler
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Run performs the job and returns the number of records it affected
+	Run func(ctx context.Context) (int, error)
+}
+
+// Scheduler runs jobs on their intervals. Each run happens under a Postgres
+// advisory lock and is skipped when another instance already completed the job
+// within the current interval, so any number of replicas can run a Scheduler.
+type Scheduler struct {
+	storage  storage.JobStorage
+	jobs     []Job
+	instance string
+}
+
+// NewScheduler creates a new scheduler for the given jobs
+func NewScheduler(storage storage.JobStorage, jobs ...Job) *Scheduler {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	return &Scheduler{
+		storage:  storage,
+		jobs:     jobs,
+		instance: fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+	}
+}
+
+// Run starts every job and blocks until ctx is cancelled
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+
+	log.Printf("Worker %s started with %d jobs", s.instance, len(s.jobs))
+	wg.Wait()
+	log.Printf("Worker %s stopped", s.instance)
+}
+
+// loop runs a job immediately and then on every interval tick
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs the job if this instance wins the lock and the job is due
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	_, err := s.storage.WithJobLock(ctx, job.Name, func(ctx context.Context) error {
+		// Holding the lock, any run still marked running was cut short by a crash
+		if n, err := s.storage.FailInterruptedJobRuns(ctx, job.Name); err != nil {
+			return fmt.Errorf("failed to fail interrupted job runs: %w", err)
+		} else if n > 0 {
+			log.Printf("Job %s: marked %d interrupted runs failed", job.Name, n)
+		}
+
+		if !s.isDue(ctx, job) {
+			return nil
+		}
+
+		run, err := s.storage.CreateJobRun(ctx, job.Name, s.instance)
+		if err != nil {
+			return fmt.Errorf("failed to record job run: %w", err)
+		}
+
+		affected, runErr := job.Run(ctx)
+		if _, err := s.storage.FinishJobRun(ctx, run.ID, affected, runErr); err != nil {
+			return fmt.Errorf("failed to record job result: %w", err)
+		}
+
+		if runErr != nil {
+			log.Printf("Job %s failed: %v", job.Name, runErr)
+		} else if affected > 0 {
+			log.Printf("Job %s affected %d records", job.Name, affected)
+		}
+		return nil
+	})
+
+	if err != nil && ctx.Err() == nil {
+		log.Printf("Job %s: %v", job.Name, err)
+	}
+}
+
+// isDue reports whether the job has not succeeded anywhere within its interval.
+// A small margin absorbs ticker jitter so a job is not skipped for a whole interval.
+func (s *Scheduler) isDue(ctx context.Context, job Job) bool {
+	last, err := s.storage.GetLastSucceededJobRun(ctx, job.Name)
+	if ent.IsNotFound(err) {
+		return true
+	}
+	if err != nil {
+		log.Printf("Failed to get last run of job %s: %v", job.Name, err)
+		return false
+	}
+
+	margin := job.Interval / 10
+	return time.Since(last.StartedAt) >= job.Interval-margin
+}
@@ -0,0 +1,122 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"poll-app/ent"
+
+	"github.com/google/uuid"
+)
+
+// fakeJobStorage records job runs in memory
+type fakeJobStorage struct {
+	locked      bool
+	lastSuccess *time.Time
+	// interrupted counts runs left running by a crashed worker
+	interrupted int
+	finished    []error
+	affected    []int
+}
+
+func (s *fakeJobStorage) WithJobLock(ctx context.Context, _ string, fn func(ctx context.Context) error) (bool, error) {
+	if s.locked {
+		return false, nil
+	}
+	return true, fn(ctx)
+}
+
+func (s *fakeJobStorage) CreateJobRun(_ context.Context, job, instance string) (*ent.JobRun, error) {
+	return &ent.JobRun{ID: uuid.New(), Job: job, Instance: instance, StartedAt: time.Now()}, nil
+}
+
+func (s *fakeJobStorage) FinishJobRun(_ context.Context, id uuid.UUID, affected int, runErr error) (*ent.JobRun, error) {
+	s.finished = append(s.finished, runErr)
+	s.affected = append(s.affected, affected)
+	return &ent.JobRun{ID: id}, nil
+}
+
+func (s *fakeJobStorage) FailInterruptedJobRuns(context.Context, string) (int, error) {
+	n := s.interrupted
+	s.interrupted = 0
+	return n, nil
+}
+
+func (s *fakeJobStorage) GetLastSucceededJobRun(_ context.Context, job string) (*ent.JobRun, error) {
+	if s.lastSuccess == nil {
+		return nil, &ent.NotFoundError{}
+	}
+	return &ent.JobRun{Job: job, StartedAt: *s.lastSuccess}, nil
+}
+
+func (s *fakeJobStorage) ListJobRuns(context.Context, string, int) ([]*ent.JobRun, error) {
+	return nil, nil
+}
+
+func (s *fakeJobStorage) DeleteJobRunsBefore(context.Context, time.Time) (int, error) {
+	return 0, nil
+}
+
+func TestSchedulerRunOnce(t *testing.T) {
+	const interval = time.Minute
+	ago := func(d time.Duration) *time.Time {
+		t := time.Now().Add(-d)
+		return &t
+	}
+	errJob := errors.New("job failed")
+
+	tests := []struct {
+		name        string
+		locked      bool
+		lastSuccess *time.Time
+		interrupted int
+		runErr      error
+		wantRun     bool
+	}{
+		{name: "never run", wantRun: true},
+		{name: "run within the interval", lastSuccess: ago(interval / 2)},
+		{name: "run within the jitter margin", lastSuccess: ago(interval - interval/20), wantRun: true},
+		{name: "run an interval ago", lastSuccess: ago(interval), wantRun: true},
+		{name: "locked by another instance", locked: true},
+		{name: "failing job", runErr: errJob, wantRun: true},
+		{name: "interrupted runs", interrupted: 2, wantRun: true},
+		{name: "interrupted runs within the interval", interrupted: 1, lastSuccess: ago(interval / 2)},
+		{name: "interrupted runs locked by another instance", interrupted: 1, locked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeJobStorage{locked: tt.locked, lastSuccess: tt.lastSuccess, interrupted: tt.interrupted}
+			runs := 0
+			job := Job{Name: "job", Interval: interval, Run: func(context.Context) (int, error) {
+				runs++
+				return 3, tt.runErr
+			}}
+
+			NewScheduler(store, job).runOnce(context.Background(), job)
+
+			if (runs == 1) != tt.wantRun || runs > 1 {
+				t.Fatalf("job ran %d times, want run %v", runs, tt.wantRun)
+			}
+			// Only the lock holder may fail runs, as it knows none is in progress
+			wantLeft := 0
+			if tt.locked {
+				wantLeft = tt.interrupted
+			}
+			if store.interrupted != wantLeft {
+				t.Errorf("%d interrupted runs left, want %d", store.interrupted, wantLeft)
+			}
+			if !tt.wantRun {
+				if len(store.finished) != 0 {
+					t.Fatal("a run was recorded for a job that did not run")
+				}
+				return
+			}
+			if len(store.finished) != 1 || !errors.Is(store.finished[0], tt.runErr) || store.affected[0] != 3 {
+				t.Fatalf("recorded runs %v affecting %v, want one with %v affecting 3", store.finished, store.affected, tt.runErr)
+			}
+		})
+	}
+}
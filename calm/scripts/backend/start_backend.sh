@@ -9,6 +9,8 @@
 #   REDIS_PORT - Redis server port (default: 6379)
 #   BACKEND_PORT - Backend service port (default: 8080)
 #   BACKEND_HOST - Backend service bind address (default: 0.0.0.0)
+#   JWT_SECRET_KEY - Secret signing access tokens (default: generated on the first
+#                    deployment and kept in the environment file)
 #
 # Database name, username, and password are hardcoded to: postgres/postgres/postgres
 #
@@ -34,6 +36,9 @@ BACKEND_HOST="${BACKEND_HOST:-0.0.0.0}"
 APP_DIR="/opt/poll-app"
 BIN_DIR="${APP_DIR}/bin"
 SERVICE_NAME="poll-app-backend"
+WORKER_SERVICE_NAME="poll-app-worker"
+# Settings shared by the server, the worker and migrations
+ENV_FILE="/etc/poll-app/backend.env"
 
 echo "Starting backend service..."
 echo "Connecting to PostgreSQL at ${POSTGRES_HOST}:${POSTGRES_PORT}"
@@ -73,7 +78,31 @@ if [ ! -f "${BIN_DIR}/poll-app" ]; then
     exit 1
 fi
 
-# Create systemd service file
+# Keep the JWT secret across deployments, so issued tokens stay valid
+if [ -z "${JWT_SECRET_KEY}" ] && [ -f "$ENV_FILE" ]; then
+    JWT_SECRET_KEY=$(sed -n 's/^JWT_SECRET_KEY=//p' "$ENV_FILE")
+fi
+if [ -z "${JWT_SECRET_KEY}" ]; then
+    echo "Generating JWT secret..."
+    JWT_SECRET_KEY=$(head -c 48 /dev/urandom | base64 | tr -d '\n')
+fi
+
+# Write the environment file, readable by root only
+mkdir -p "$(dirname "$ENV_FILE")"
+install -m 600 /dev/null "$ENV_FILE"
+cat > "$ENV_FILE" <<EOF
+POSTGRES_HOST=${POSTGRES_HOST}
+POSTGRES_PORT=${POSTGRES_PORT}
+POSTGRES_DB=${POSTGRES_DB}
+POSTGRES_USER=${POSTGRES_USER}
+POSTGRES_PASSWORD=${POSTGRES_PASSWORD}
+POSTGRES_SSLMODE=${POSTGRES_SSLMODE}
+REDIS_HOST=${REDIS_HOST}
+REDIS_PORT=${REDIS_PORT}
+JWT_SECRET_KEY=${JWT_SECRET_KEY}
+EOF
+
+# Create systemd service files
 SERVICE_FILE="/etc/systemd/system/${SERVICE_NAME}.service"
 
 cat > "$SERVICE_FILE" <<EOF
@@ -86,21 +115,35 @@ Requires=network.target
 Type=simple
 User=root
 WorkingDirectory=${BIN_DIR}
+EnvironmentFile=${ENV_FILE}
 ExecStart=${BIN_DIR}/poll-app server --host ${BACKEND_HOST} --port ${BACKEND_PORT}
 Restart=always
 RestartSec=10
 StandardOutput=journal
 StandardError=journal
 
-# Environment variables
-Environment="POSTGRES_HOST=${POSTGRES_HOST}"
-Environment="POSTGRES_PORT=${POSTGRES_PORT}"
-Environment="POSTGRES_DB=${POSTGRES_DB}"
-Environment="POSTGRES_USER=${POSTGRES_USER}"
-Environment="POSTGRES_PASSWORD=${POSTGRES_PASSWORD}"
-Environment="POSTGRES_SSLMODE=${POSTGRES_SSLMODE}"
-Environment="REDIS_HOST=${REDIS_HOST}"
-Environment="REDIS_PORT=${REDIS_PORT}"
+[Install]
+WantedBy=multi-user.target
+EOF
+
+WORKER_SERVICE_FILE="/etc/systemd/system/${WORKER_SERVICE_NAME}.service"
+
+cat > "$WORKER_SERVICE_FILE" <<EOF
+[Unit]
+Description=Poll App Background Jobs
+After=network.target redis.service
+Requires=network.target
+
+[Service]
+Type=simple
+User=root
+WorkingDirectory=${BIN_DIR}
+EnvironmentFile=${ENV_FILE}
+ExecStart=${BIN_DIR}/poll-app worker
+Restart=always
+RestartSec=10
+StandardOutput=journal
+StandardError=journal
 
 [Install]
 WantedBy=multi-user.target
@@ -109,7 +152,7 @@ EOF
 # Reload systemd and enable service
 echo "Reloading systemd and enabling service..."
 systemctl daemon-reload
-systemctl enable "${SERVICE_NAME}"
+systemctl enable "${SERVICE_NAME}" "${WORKER_SERVICE_NAME}"
 
 # Configure firewall to allow backend port
 echo "Configuring firewall for port ${BACKEND_PORT}..."
@@ -210,9 +253,25 @@ if [ "$REDIS_READY" = false ]; then
     echo "Warning: Could not verify Redis connectivity, but continuing..."
 fi
 
-# Start the service
-echo "Starting ${SERVICE_NAME}..."
-systemctl start "${SERVICE_NAME}"
+# Bring the database schema up to date; the server and worker refuse to start
+# on an outdated schema. Databases created before versioned migrations are
+# upgraded in place.
+echo "Applying database migrations..."
+(
+    set -a
+    . "$ENV_FILE"
+    set +a
+    cd "${BIN_DIR}"
+    "${BIN_DIR}/poll-app" migrate up
+) || {
+    echo "Error: Failed to apply database migrations"
+    exit 1
+}
+
+# Start the services; restart them so a redeployed binary is picked up
+echo "Starting ${SERVICE_NAME} and ${WORKER_SERVICE_NAME}..."
+systemctl restart "${SERVICE_NAME}"
+systemctl restart "${WORKER_SERVICE_NAME}"
 
 # Wait for service to be ready
 echo "Waiting for service to start..."
@@ -250,6 +309,7 @@ fi
 # Show service status
 echo "Service status:"
 systemctl status "${SERVICE_NAME}" --no-pager -l || true
+systemctl status "${WORKER_SERVICE_NAME}" --no-pager -l || true
 
 echo "Backend service started successfully."
 echo "Service is available at http://${BACKEND_HOST}:${BACKEND_PORT}"
@@ -12,6 +12,7 @@ import {
   type CreatePollRequest,
   type UpdatePollRequest,
   type PollResponse,
+  type PollListResponse,
   type VoteRequest,
   type VoteResponse,
   type VoteCountsResponse,
@@ -132,6 +133,9 @@ export class ApiClient {
   async login(data: LoginRequest): Promise<AuthResponse> {
     try {
       const response = await UsersService.login(data);
+      if ("mfa_required" in response) {
+        throw new ApiError("Two-factor authentication is required", 401);
+      }
       if (response.access_token && response.refresh_token) {
         this.setTokens(response.access_token, response.refresh_token);
       }
@@ -177,9 +181,11 @@ export class ApiClient {
   }
 
   // Poll APIs
-  async listPolls(): Promise<PollResponse[]> {
+  async listPolls(cursor?: string): Promise<PollListResponse> {
     try {
-      return await withTokenRefresh(() => PollsService.listPolls());
+      return await withTokenRefresh(() =>
+        PollsService.listPolls(undefined, undefined, undefined, undefined, undefined, undefined, undefined, undefined, cursor)
+      );
     } catch (error: any) {
       throw this.handleError(error);
     }
@@ -201,17 +207,17 @@ export class ApiClient {
     }
   }
 
-  async updatePoll(id: string, data: UpdatePollRequest): Promise<PollResponse> {
+  async updatePoll(id: string, data: UpdatePollRequest, etag: string): Promise<PollResponse> {
     try {
-      return await withTokenRefresh(() => PollsService.updatePoll(id, data));
+      return await withTokenRefresh(() => PollsService.updatePoll(id, etag, data));
     } catch (error: any) {
       throw this.handleError(error);
     }
   }
 
-  async deletePoll(id: string): Promise<void> {
+  async deletePoll(id: string, etag: string): Promise<void> {
     try {
-      return await withTokenRefresh(() => PollsService.deletePoll(id));
+      return await withTokenRefresh(() => PollsService.deletePoll(id, etag));
     } catch (error: any) {
       throw this.handleError(error);
     }
@@ -234,9 +240,9 @@ export class ApiClient {
     }
   }
 
-  async getVotersByOption(id: string, option: string): Promise<VotersResponse> {
+  async getVotersByOption(id: string, optionId: string, cursor?: string): Promise<VotersResponse> {
     try {
-      return await withTokenRefresh(() => VotesService.getVotersByOption(id, option));
+      return await withTokenRefresh(() => VotesService.getVotersByOption(id, optionId, undefined, cursor));
     } catch (error: any) {
       throw this.handleError(error);
     }
@@ -259,7 +265,7 @@ export class ApiClient {
   private handleError(error: any): ApiError {
     if (error.body) {
       return new ApiError(
-        error.body.error || error.message || "An error occurred",
+        error.body.detail || error.body.title || error.message || "An error occurred",
         error.status || 500,
         error.body
       );
@@ -282,6 +288,9 @@ export type {
   CreatePollRequest,
   UpdatePollRequest,
   PollResponse,
+  PollListResponse,
+  PollOption,
+  PollOptionInput,
   VoteRequest,
   VoteResponse,
   VoteCountsResponse,
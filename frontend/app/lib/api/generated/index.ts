@@ -8,20 +8,69 @@ export { OpenAPI } from './core/OpenAPI';
 export type { OpenAPIConfig } from './core/OpenAPI';
 
 export type { AuthResponse } from './models/AuthResponse';
+export type { CreateInvitationsRequest } from './models/CreateInvitationsRequest';
 export type { CreatePollRequest } from './models/CreatePollRequest';
 export type { CreateUserRequest } from './models/CreateUserRequest';
+export type { CreateWebhookEndpointRequest } from './models/CreateWebhookEndpointRequest';
+export type { EmailVerificationConfirmRequest } from './models/EmailVerificationConfirmRequest';
 export type { Error } from './models/Error';
+export type { FieldError } from './models/FieldError';
+export type { InvitationListResponse } from './models/InvitationListResponse';
+export { InvitationResponse } from './models/InvitationResponse';
+export { JSONWebKey } from './models/JSONWebKey';
+export type { JSONWebKeySet } from './models/JSONWebKeySet';
 export type { LoginRequest } from './models/LoginRequest';
+export type { MFAChallengeResponse } from './models/MFAChallengeResponse';
+export type { MFAConfirmRequest } from './models/MFAConfirmRequest';
+export type { MFAEnrollmentResponse } from './models/MFAEnrollmentResponse';
+export type { MFALoginRequest } from './models/MFALoginRequest';
+export type { MFAReauthenticationRequest } from './models/MFAReauthenticationRequest';
+export type { MFAStatusResponse } from './models/MFAStatusResponse';
+export type { PasswordResetConfirmRequest } from './models/PasswordResetConfirmRequest';
+export type { PasswordResetRequest } from './models/PasswordResetRequest';
+export type { PollImportDefinition } from './models/PollImportDefinition';
+export type { PollImportOption } from './models/PollImportOption';
+export type { PollImportRequest } from './models/PollImportRequest';
+export type { PollImportResponse } from './models/PollImportResponse';
+export type { PollImportResult } from './models/PollImportResult';
+export { PollImportStatus } from './models/PollImportStatus';
+export type { PollListResponse } from './models/PollListResponse';
+export type { PollOption } from './models/PollOption';
+export type { PollOptionInput } from './models/PollOptionInput';
+export type { PollPatch } from './models/PollPatch';
 export type { PollResponse } from './models/PollResponse';
+export { PollStatus } from './models/PollStatus';
+export { PollType } from './models/PollType';
+export type { RankedResultsResponse } from './models/RankedResultsResponse';
+export type { RankedRound } from './models/RankedRound';
+export type { RealtimeError } from './models/RealtimeError';
+export { RealtimeMessage } from './models/RealtimeMessage';
+export { RealtimeRequest } from './models/RealtimeRequest';
+export type { RecoveryCodesResponse } from './models/RecoveryCodesResponse';
 export type { RefreshTokenRequest } from './models/RefreshTokenRequest';
+export { ResultsVisibility } from './models/ResultsVisibility';
+export type { SecurityEventListResponse } from './models/SecurityEventListResponse';
+export type { SecurityEventResponse } from './models/SecurityEventResponse';
+export { SecurityEventType } from './models/SecurityEventType';
+export type { SessionListResponse } from './models/SessionListResponse';
+export type { SessionResponse } from './models/SessionResponse';
 export type { UpdatePollRequest } from './models/UpdatePollRequest';
+export type { UpdateSessionRequest } from './models/UpdateSessionRequest';
+export type { UpdateWebhookEndpointRequest } from './models/UpdateWebhookEndpointRequest';
 export type { UserInfo } from './models/UserInfo';
 export type { VoteCountsResponse } from './models/VoteCountsResponse';
 export type { VoteRequest } from './models/VoteRequest';
 export type { VoteResponse } from './models/VoteResponse';
 export type { VotersResponse } from './models/VotersResponse';
+export type { WebhookDeliveryListResponse } from './models/WebhookDeliveryListResponse';
+export type { WebhookDeliveryResponse } from './models/WebhookDeliveryResponse';
+export { WebhookDeliveryStatus } from './models/WebhookDeliveryStatus';
+export type { WebhookEndpointListResponse } from './models/WebhookEndpointListResponse';
+export type { WebhookEndpointResponse } from './models/WebhookEndpointResponse';
+export { WebhookEventType } from './models/WebhookEventType';
 
 export { HealthService } from './services/HealthService';
 export { PollsService } from './services/PollsService';
 export { UsersService } from './services/UsersService';
 export { VotesService } from './services/VotesService';
+export { WebhooksService } from './services/WebhooksService';
@@ -14,5 +14,9 @@ export type AuthResponse = {
     user_id?: string;
     email?: string;
     username?: string;
+    /**
+     * Whether the user has verified their email address
+     */
+    email_verified?: boolean;
 };
 
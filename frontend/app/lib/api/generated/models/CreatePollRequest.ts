@@ -2,9 +2,41 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { PollOptionInput } from './PollOptionInput';
+import type { PollType } from './PollType';
+import type { ResultsVisibility } from './ResultsVisibility';
 export type CreatePollRequest = {
     title: string;
     description?: string;
-    options: Array<string>;
+    /**
+     * Options in display order
+     */
+    options: Array<PollOptionInput>;
+    type?: PollType;
+    /**
+     * Minimum number of options a ballot must include (ranked and approval polls, defaults to 1)
+     */
+    min_choices?: number;
+    /**
+     * Maximum number of options a ballot may include (ranked and approval polls, defaults to all options)
+     */
+    max_choices?: number;
+    /**
+     * When voting opens (defaults to immediately)
+     */
+    opens_at?: string;
+    /**
+     * When voting closes (defaults to never). Results are frozen once the poll closes
+     */
+    closes_at?: string;
+    /**
+     * Secret ballot: one vote per user is still enforced, but ballots are stored without any link to the voter, voters are never listed and votes cannot be retracted. Vote counts change only when a batch of ballots is tallied, and once more as the poll closes. Cannot be changed after creation
+     */
+    anonymous?: boolean;
+    results_visibility?: ResultsVisibility;
+    /**
+     * Only users with a verified email address may vote
+     */
+    require_verified_email?: boolean;
 };
 
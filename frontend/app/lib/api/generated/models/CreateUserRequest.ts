@@ -6,5 +6,9 @@ export type CreateUserRequest = {
     email: string;
     username: string;
     password: string;
+    /**
+     * Name of the device signing in, shown in the session list
+     */
+    device_name?: string;
 };
 
@@ -0,0 +1,14 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WebhookEventType } from './WebhookEventType';
+export type CreateWebhookEndpointRequest = {
+    /**
+     * http or https URL the events are POSTed to
+     */
+    url: string;
+    description?: string;
+    events: Array<WebhookEventType>;
+};
+
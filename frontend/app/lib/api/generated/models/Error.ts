@@ -2,10 +2,38 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { FieldError } from './FieldError';
+/**
+ * Problem details (RFC 7807), returned with the application/problem+json media type
+ */
 export type Error = {
     /**
-     * Error message
+     * Problem type URI; always about:blank, so title is the HTTP status text
      */
-    error?: string;
+    type: string;
+    /**
+     * Short summary of the HTTP status
+     */
+    title: string;
+    /**
+     * HTTP status code
+     */
+    status: number;
+    /**
+     * Stable machine-readable error code. Clients should branch on this rather than on detail. Codes include invalid_body, invalid_id, required, invalid_value, out_of_range, duplicate_option, too_few_options, invalid_option, invalid_choice_count, invalid_schedule, choice_limits_fixed, not_ranked_poll, authorization_required, invalid_authorization_header, invalid_token, invalid_refresh_token, invalid_credentials, not_poll_owner, not_vote_owner, results_hidden, voters_secret, vote_not_retractable, poll_not_found, option_not_found, vote_not_found, user_not_found, not_found, already_voted, email_taken, username_taken, poll_not_open, poll_closed, anonymous_poll_has_votes, conflict, internal_error and service_unavailable
+     */
+    code: string;
+    /**
+     * Human-readable explanation; may change between releases
+     */
+    detail?: string;
+    /**
+     * Path of the request that failed
+     */
+    instance?: string;
+    /**
+     * Per-field details of validation errors
+     */
+    errors?: Array<FieldError>;
 };
 
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type FieldError = {
+    /**
+     * Name of the invalid request field
+     */
+    field: string;
+    /**
+     * Stable machine-readable error code
+     */
+    code: string;
+    /**
+     * Human-readable explanation
+     */
+    message: string;
+};
+
@@ -0,0 +1,31 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type InvitationResponse = {
+    id: string;
+    email: string;
+    /**
+     * Expired once expires_at passes or the poll closes
+     */
+    status: InvitationResponse.status;
+    expires_at: string;
+    /**
+     * When the invitee was reminded; absent until then
+     */
+    reminded_at?: string;
+    created_at: string;
+};
+
+export namespace InvitationResponse {
+
+    /**
+     * Expired once expires_at passes or the poll closes
+     */
+    export enum status {
+        PENDING = 'pending',
+        EXPIRED = 'expired',
+    }
+
+}
+
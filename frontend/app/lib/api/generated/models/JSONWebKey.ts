@@ -0,0 +1,55 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Public signing key in JSON Web Key format (RFC 7517). RSA keys carry n and e; EC and OKP keys carry crv and x, and EC keys also y
+ */
+export type JSONWebKey = {
+    kty: JSONWebKey.kty;
+    use: string;
+    alg: JSONWebKey.alg;
+    /**
+     * Key ID, the RFC 7638 thumbprint of the key
+     */
+    kid: string;
+    /**
+     * RSA modulus, base64url encoded
+     */
+    n?: string;
+    /**
+     * RSA public exponent, base64url encoded
+     */
+    e?: string;
+    crv?: JSONWebKey.crv;
+    /**
+     * Curve point x coordinate, or the Ed25519 public key, base64url encoded
+     */
+    x?: string;
+    /**
+     * Curve point y coordinate, base64url encoded
+     */
+    y?: string;
+};
+
+export namespace JSONWebKey {
+
+    export enum kty {
+        RSA = 'RSA',
+        EC = 'EC',
+        OKP = 'OKP',
+    }
+
+    export enum alg {
+        RS256 = 'RS256',
+        ES256 = 'ES256',
+        ED_DSA = 'EdDSA',
+    }
+
+    export enum crv {
+        P_256 = 'P-256',
+        ED25519 = 'Ed25519',
+    }
+
+}
+
@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { JSONWebKey } from './JSONWebKey';
+export type JSONWebKeySet = {
+    /**
+     * Keys whose tokens are accepted; empty when tokens are signed with a shared secret
+     */
+    keys: Array<JSONWebKey>;
+};
+
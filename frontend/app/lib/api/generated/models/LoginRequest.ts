@@ -5,5 +5,9 @@
 export type LoginRequest = {
     email: string;
     password: string;
+    /**
+     * Name of the device signing in, shown in the session list
+     */
+    device_name?: string;
 };
 
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type MFAChallengeResponse = {
+    /**
+     * Always true; the sign-in must be completed with a second factor
+     */
+    mfa_required: boolean;
+    /**
+     * Token of the MFA challenge returned by login
+     */
+    mfa_token: string;
+    /**
+     * When the challenge expires and the user must sign in again
+     */
+    expires_at: string;
+};
+
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type MFAConfirmRequest = {
+    /**
+     * Current six-digit code from the authenticator app
+     */
+    code: string;
+};
+
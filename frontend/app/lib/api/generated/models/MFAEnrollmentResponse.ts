@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type MFAEnrollmentResponse = {
+    /**
+     * Base32 TOTP secret, for entering into an authenticator app by hand
+     */
+    secret?: string;
+    /**
+     * otpauth URI to show as a QR code for authenticator apps to scan
+     */
+    provisioning_uri?: string;
+};
+
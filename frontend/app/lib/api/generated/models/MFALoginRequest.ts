@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type MFALoginRequest = {
+    /**
+     * Token of the MFA challenge returned by login
+     */
+    mfa_token: string;
+    /**
+     * Six-digit code from the authenticator app, or an unused recovery code
+     */
+    code: string;
+};
+
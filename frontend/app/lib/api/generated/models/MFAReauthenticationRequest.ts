@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Confirms a sensitive change with the password and a second factor
+ */
+export type MFAReauthenticationRequest = {
+    password: string;
+    /**
+     * Six-digit code from the authenticator app, or an unused recovery code
+     */
+    code: string;
+};
+
@@ -0,0 +1,16 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type MFAStatusResponse = {
+    /**
+     * Whether signing in takes a second factor
+     */
+    enabled?: boolean;
+    enabled_at?: string | null;
+    /**
+     * Number of unused recovery codes
+     */
+    recovery_codes_remaining?: number;
+};
+
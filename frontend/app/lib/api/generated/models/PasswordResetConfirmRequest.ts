@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type PasswordResetConfirmRequest = {
+    /**
+     * Token from the link in the email
+     */
+    token: string;
+    /**
+     * New password
+     */
+    password: string;
+};
+
@@ -0,0 +1,46 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { PollImportOption } from './PollImportOption';
+import type { PollType } from './PollType';
+import type { ResultsVisibility } from './ResultsVisibility';
+export type PollImportDefinition = {
+    /**
+     * Identifies the poll among the owner's imports. A poll whose external ID the owner already used is skipped, so an import can be re-run
+     */
+    external_id: string;
+    title: string;
+    description?: string;
+    /**
+     * Options in display order, as labels or objects
+     */
+    options: Array<(string | PollImportOption)>;
+    type?: PollType;
+    /**
+     * Minimum number of options a ballot must include (ranked and approval polls, defaults to 1)
+     */
+    min_choices?: number;
+    /**
+     * Maximum number of options a ballot may include (ranked and approval polls, defaults to all options)
+     */
+    max_choices?: number;
+    /**
+     * When voting opens (defaults to immediately)
+     */
+    opens_at?: string;
+    /**
+     * When voting closes (defaults to never). Results are frozen once the poll closes
+     */
+    closes_at?: string;
+    /**
+     * Secret ballot: one vote per user is still enforced, but ballots are stored without any link to the voter, voters are never listed and votes cannot be retracted. Cannot be changed after creation
+     */
+    anonymous?: boolean;
+    results_visibility?: ResultsVisibility;
+    /**
+     * Only users with a verified email address may vote
+     */
+    require_verified_email?: boolean;
+};
+
@@ -0,0 +1,21 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { PollImportResult } from './PollImportResult';
+export type PollImportResponse = {
+    dry_run?: boolean;
+    /**
+     * Number of polls created, or that would be created in a dry run
+     */
+    created?: number;
+    /**
+     * Number of polls skipped because they already exist
+     */
+    existing?: number;
+    /**
+     * Outcome of every definition, in file order
+     */
+    polls?: Array<PollImportResult>;
+};
+
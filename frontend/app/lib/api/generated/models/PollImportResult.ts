@@ -0,0 +1,14 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { PollImportStatus } from './PollImportStatus';
+export type PollImportResult = {
+    external_id?: string;
+    status?: PollImportStatus;
+    /**
+     * The created or existing poll; null in dry runs for polls that would be created
+     */
+    poll_id?: string | null;
+};
+
@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * created: the poll was created; pending: a dry run found it would be created; exists: the owner already has a poll with the external ID, which was left unchanged
+ */
+export enum PollImportStatus {
+    CREATED = 'created',
+    PENDING = 'pending',
+    EXISTS = 'exists',
+}
+
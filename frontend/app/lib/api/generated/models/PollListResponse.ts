@@ -0,0 +1,21 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { PollResponse } from './PollResponse';
+export type PollListResponse = {
+    polls: Array<PollResponse>;
+    /**
+     * Cursor of the next page; null on the last page
+     */
+    next_cursor?: string | null;
+    /**
+     * Number of polls matching the filters, counted up to 1000
+     */
+    total_estimate: number;
+    /**
+     * false when more polls match than total_estimate counts
+     */
+    total_exact: boolean;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type PollOption = {
+    id?: string;
+    label?: string;
+    description?: string | null;
+    /**
+     * Zero-based display order within the poll
+     */
+    position?: number;
+    image_url?: string | null;
+};
+
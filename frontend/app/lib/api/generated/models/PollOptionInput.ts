@@ -0,0 +1,14 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type PollOptionInput = {
+    /**
+     * Existing option to keep, with its votes, when updating a poll. Omit to add a new option
+     */
+    id?: string;
+    label: string;
+    description?: string;
+    image_url?: string;
+};
+
@@ -0,0 +1,42 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { PollOptionInput } from './PollOptionInput';
+import type { ResultsVisibility } from './ResultsVisibility';
+/**
+ * JSON Merge Patch (RFC 7396) of a poll. Omitted fields are left unchanged and null removes an optional value. Fields that cannot be cleared, and the immutable type and anonymous fields, are rejected
+ */
+export type PollPatch = {
+    title?: string;
+    /**
+     * Poll description; null clears it
+     */
+    description?: string | null;
+    /**
+     * Complete option list in display order. Options given with an id are renamed or moved in place and keep their votes, options without an id are added, and omitted options are removed. Omitted options are taken out of every vote that chose them, and votes left with no choice or fewer than min_choices are deleted
+     */
+    options?: Array<PollOptionInput>;
+    /**
+     * Minimum number of options a ballot must include (ranked and approval polls, defaults to 1); cannot be raised once the poll has votes
+     */
+    min_choices?: number;
+    /**
+     * Maximum number of options a ballot may include (ranked and approval polls); null allows all options; cannot be lowered once the poll has votes
+     */
+    max_choices?: number | null;
+    /**
+     * When voting opens; null opens the poll immediately
+     */
+    opens_at?: string | null;
+    /**
+     * When voting closes; null means the poll never closes. Results are frozen once the poll closes
+     */
+    closes_at?: string | null;
+    results_visibility?: ResultsVisibility;
+    /**
+     * Only users with a verified email address may vote
+     */
+    require_verified_email?: boolean;
+};
+
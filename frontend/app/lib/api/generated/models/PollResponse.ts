@@ -2,21 +2,71 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { PollOption } from './PollOption';
+import type { PollStatus } from './PollStatus';
+import type { PollType } from './PollType';
+import type { ResultsVisibility } from './ResultsVisibility';
 import type { UserInfo } from './UserInfo';
 export type PollResponse = {
     id?: string;
     title?: string;
     description?: string | null;
-    options?: Array<string>;
+    /**
+     * Options in display order
+     */
+    options?: Array<PollOption>;
+    type?: PollType;
+    /**
+     * Whether the poll uses secret ballots
+     */
+    anonymous?: boolean;
+    results_visibility?: ResultsVisibility;
+    /**
+     * Whether the caller may see this poll's results. When false, vote_counts and voters_by_option are omitted
+     */
+    results_visible?: boolean;
+    /**
+     * Only users with a verified email address may vote
+     */
+    require_verified_email?: boolean;
+    /**
+     * Whether the caller has voted on the poll. Only included in the poll details endpoint for authenticated callers
+     */
+    has_voted?: boolean;
+    min_choices?: number;
+    /**
+     * Maximum number of options per ballot, or null when a ballot may include every option
+     */
+    max_choices?: number | null;
+    status?: PollStatus;
+    opens_at?: string | null;
+    closes_at?: string | null;
+    /**
+     * When the results were frozen; set once the poll has closed
+     */
+    finalized_at?: string | null;
     owner_id?: string;
+    /**
+     * Identifier given by the import file the poll was created from; null for polls created one by one
+     */
+    external_id?: string | null;
     created_at?: string;
     updated_at?: string;
     /**
-     * Map of option to vote count (only included in poll details endpoint)
+     * Incremented on every edit; send it in If-Match, quoted, to edit the poll. Edits also return it as their ETag
+     */
+    version?: number;
+    /**
+     * Map of option ID to vote count. Approval polls count every approval, other polls count first choices. Closed polls report their frozen results
      */
     vote_counts?: Record<string, number>;
     /**
-     * Map of option to list of voters (only included in poll details endpoint)
+     * Number of distinct voters. Present whenever vote_counts is
+     */
+    voters?: number;
+    /**
+     * No longer returned; list the voters of an option with GET /api/polls/{id}/votes/{option_id}
+     * @deprecated
      */
     voters_by_option?: Record<string, Array<UserInfo>>;
 };
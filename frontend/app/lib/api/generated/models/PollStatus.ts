@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Lifecycle status derived from the poll's voting window
+ */
+export enum PollStatus {
+    SCHEDULED = 'scheduled',
+    OPEN = 'open',
+    CLOSED = 'closed',
+}
+
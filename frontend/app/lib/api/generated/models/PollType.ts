@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Poll type. `single` polls accept one option per ballot, `ranked` polls accept an ordered list of options tabulated with instant-runoff voting, and `approval` polls accept a set of approved options
+ */
+export enum PollType {
+    SINGLE = 'single',
+    RANKED = 'ranked',
+    APPROVAL = 'approval',
+}
+
@@ -0,0 +1,23 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { RankedRound } from './RankedRound';
+export type RankedResultsResponse = {
+    poll_id?: string;
+    /**
+     * Winning option ID, or null when the count ends in a tie or no ballots were cast
+     */
+    winner?: string | null;
+    /**
+     * Option IDs tied in the final round when there is no winner
+     */
+    tied?: Array<string>;
+    total_ballots?: number;
+    /**
+     * Ballots exhausted by the final round
+     */
+    exhausted_ballots?: number;
+    rounds?: Array<RankedRound>;
+};
+
@@ -0,0 +1,20 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type RankedRound = {
+    round?: number;
+    /**
+     * Map of continuing option ID to the number of ballots counting for it this round
+     */
+    counts?: Record<string, number>;
+    /**
+     * Option IDs eliminated at the end of this round
+     */
+    eliminated?: Array<string>;
+    /**
+     * Ballots with no continuing options left in this round
+     */
+    exhausted?: number;
+};
+
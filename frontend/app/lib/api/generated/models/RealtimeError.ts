@@ -0,0 +1,14 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { FieldError } from './FieldError';
+/**
+ * Why a WebSocket request failed; code and errors are as in Error
+ */
+export type RealtimeError = {
+    code: string;
+    message: string;
+    errors?: Array<FieldError>;
+};
+
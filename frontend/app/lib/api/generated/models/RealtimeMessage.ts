@@ -0,0 +1,54 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { PollResponse } from './PollResponse';
+import type { RealtimeError } from './RealtimeError';
+import type { VoteResponse } from './VoteResponse';
+/**
+ * Message sent by the server over the WebSocket API. ok and error reply to the request with the same id; the other types are updates of a subscribed poll
+ */
+export type RealtimeMessage = {
+    /**
+     * votes holds the counts that changed since the last update, presence the number of users viewing the poll, poll and status the poll after an edit or when it closes, and deleted ends the subscription
+     */
+    type: RealtimeMessage.type;
+    /**
+     * ID of the request replied to
+     */
+    id?: string;
+    poll_id?: string;
+    poll?: PollResponse;
+    vote?: VoteResponse;
+    /**
+     * Map of option ID to vote count, for the options whose count changed
+     */
+    counts?: Record<string, number>;
+    /**
+     * Number of distinct voters
+     */
+    voters?: number;
+    /**
+     * Number of users viewing the poll
+     */
+    viewers?: number;
+    error?: RealtimeError;
+};
+
+export namespace RealtimeMessage {
+
+    /**
+     * votes holds the counts that changed since the last update, presence the number of users viewing the poll, poll and status the poll after an edit or when it closes, and deleted ends the subscription
+     */
+    export enum type {
+        OK = 'ok',
+        ERROR = 'error',
+        VOTES = 'votes',
+        PRESENCE = 'presence',
+        POLL = 'poll',
+        STATUS = 'status',
+        DELETED = 'deleted',
+    }
+
+}
+
@@ -0,0 +1,37 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Message sent by a client over the WebSocket API
+ */
+export type RealtimeRequest = {
+    /**
+     * Client-chosen request ID, echoed in the reply
+     */
+    id?: string;
+    /**
+     * subscribe starts receiving updates of the poll, unsubscribe stops them, vote casts a ballot and retract deletes it
+     */
+    type: RealtimeRequest.type;
+    poll_id: string;
+    /**
+     * Ballot of a vote request, as in VoteRequest
+     */
+    choices?: Array<string>;
+};
+
+export namespace RealtimeRequest {
+
+    /**
+     * subscribe starts receiving updates of the poll, unsubscribe stops them, vote casts a ballot and retract deletes it
+     */
+    export enum type {
+        SUBSCRIBE = 'subscribe',
+        UNSUBSCRIBE = 'unsubscribe',
+        VOTE = 'vote',
+        RETRACT = 'retract',
+    }
+
+}
+
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type RecoveryCodesResponse = {
+    /**
+     * One-time codes that stand in for an authenticator code. They are shown only once
+     */
+    recovery_codes?: Array<string>;
+};
+
@@ -0,0 +1,14 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * Who may see a poll's counts and voters: everyone (`always`), callers who have voted or anyone once the poll is closed (`after_vote`), anyone once the poll is closed (`after_close`), or only the owner (`owner_only`). The owner can always see results
+ */
+export enum ResultsVisibility {
+    ALWAYS = 'always',
+    AFTER_VOTE = 'after_vote',
+    AFTER_CLOSE = 'after_close',
+    OWNER_ONLY = 'owner_only',
+}
+
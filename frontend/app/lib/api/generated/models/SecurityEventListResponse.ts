@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { SecurityEventResponse } from './SecurityEventResponse';
+export type SecurityEventListResponse = {
+    /**
+     * Security events, newest first
+     */
+    events?: Array<SecurityEventResponse>;
+};
+
@@ -0,0 +1,23 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { SecurityEventType } from './SecurityEventType';
+export type SecurityEventResponse = {
+    id?: string;
+    type?: SecurityEventType;
+    /**
+     * Session the event concerns
+     */
+    session_id?: string | null;
+    /**
+     * Address of the client that caused the event
+     */
+    ip_address?: string | null;
+    /**
+     * User-Agent of the client that caused the event
+     */
+    user_agent?: string | null;
+    created_at?: string;
+};
+
@@ -0,0 +1,11 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * refresh_token_reused: a refresh token was presented again after it was used, which suggests it was stolen; its session was ended and the user was notified by email
+ */
+export enum SecurityEventType {
+    REFRESH_TOKEN_REUSED = 'refresh_token_reused',
+}
+
@@ -0,0 +1,12 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { SessionResponse } from './SessionResponse';
+export type SessionListResponse = {
+    /**
+     * Active sessions, most recently used first
+     */
+    sessions?: Array<SessionResponse>;
+};
+
@@ -0,0 +1,32 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+export type SessionResponse = {
+    id?: string;
+    /**
+     * Device name given at sign-in or set later
+     */
+    name?: string | null;
+    /**
+     * User-Agent of the client that last used the session
+     */
+    user_agent?: string;
+    /**
+     * Address the session was last used from
+     */
+    ip_address?: string;
+    /**
+     * When the user signed in; null for sessions started before sessions were tracked
+     */
+    created_at?: string | null;
+    /**
+     * When the session last refreshed its tokens
+     */
+    last_used_at?: string | null;
+    /**
+     * Whether this is the session of the request's access token
+     */
+    current?: boolean;
+};
+
@@ -2,9 +2,35 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { PollOptionInput } from './PollOptionInput';
+import type { ResultsVisibility } from './ResultsVisibility';
 export type UpdatePollRequest = {
     title?: string;
     description?: string;
-    options?: Array<string>;
+    /**
+     * Complete option list in display order. Options given with an id are renamed or moved in place and keep their votes, options without an id are added, and omitted options are removed. Omitted options are taken out of every vote that chose them, and votes left with no choice or fewer than min_choices are deleted
+     */
+    options?: Array<PollOptionInput>;
+    /**
+     * Minimum number of options a ballot must include (ranked and approval polls, defaults to 1); cannot be raised once the poll has votes
+     */
+    min_choices?: number;
+    /**
+     * Maximum number of options a ballot may include (ranked and approval polls, defaults to all options); cannot be lowered once the poll has votes
+     */
+    max_choices?: number;
+    /**
+     * When voting opens (defaults to immediately)
+     */
+    opens_at?: string;
+    /**
+     * When voting closes (defaults to never). Results are frozen once the poll closes
+     */
+    closes_at?: string;
+    results_visibility?: ResultsVisibility;
+    /**
+     * Only users with a verified email address may vote
+     */
+    require_verified_email?: boolean;
 };
 
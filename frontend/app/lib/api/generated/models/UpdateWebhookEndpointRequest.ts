@@ -0,0 +1,18 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WebhookEventType } from './WebhookEventType';
+export type UpdateWebhookEndpointRequest = {
+    /**
+     * http or https URL the events are POSTed to
+     */
+    url?: string;
+    description?: string;
+    events?: Array<WebhookEventType>;
+    /**
+     * Set to false to stop deliveries to the endpoint
+     */
+    active?: boolean;
+};
+
@@ -5,8 +5,12 @@
 export type VoteCountsResponse = {
     poll_id?: string;
     /**
-     * Map of option to vote count
+     * Map of option ID to vote count. Approval polls count every approval, other polls count first choices
      */
     counts?: Record<string, number>;
+    /**
+     * Number of distinct voters
+     */
+    voters?: number;
 };
 
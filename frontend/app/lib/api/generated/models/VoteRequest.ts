@@ -2,7 +2,14 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+/**
+ * Ballot for a poll. Single-choice polls take `option_id`; ranked polls take `choices` ordered from most to least preferred; approval polls take `choices` as the set of approved options.
+ */
 export type VoteRequest = {
-    option: string;
+    option_id?: string;
+    /**
+     * Option IDs in order of preference (ranked polls) or the approved option IDs (approval polls)
+     */
+    choices?: Array<string>;
 };
 
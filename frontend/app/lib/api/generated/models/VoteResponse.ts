@@ -6,7 +6,14 @@ export type VoteResponse = {
     id?: string;
     user_id?: string;
     poll_id?: string;
-    option?: string;
+    /**
+     * First choice
+     */
+    option_id?: string;
+    /**
+     * Full ballot of option IDs, ordered by preference for ranked polls
+     */
+    choices?: Array<string>;
     created_at?: string;
 };
 
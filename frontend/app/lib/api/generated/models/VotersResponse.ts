@@ -5,7 +5,14 @@
 import type { UserInfo } from './UserInfo';
 export type VotersResponse = {
     poll_id?: string;
-    option?: string;
+    option_id?: string;
+    /**
+     * Voters ordered by user ID
+     */
     voters?: Array<UserInfo>;
+    /**
+     * Cursor of the next page; null on the last page
+     */
+    next_cursor?: string | null;
 };
 
@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WebhookDeliveryResponse } from './WebhookDeliveryResponse';
+export type WebhookDeliveryListResponse = {
+    deliveries?: Array<WebhookDeliveryResponse>;
+    /**
+     * Cursor of the next page; null on the last page
+     */
+    next_cursor?: string | null;
+};
+
@@ -0,0 +1,35 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WebhookDeliveryStatus } from './WebhookDeliveryStatus';
+import type { WebhookEventType } from './WebhookEventType';
+export type WebhookDeliveryResponse = {
+    id?: string;
+    /**
+     * Sent as X-Webhook-Delivery; the same for redeliveries of an event
+     */
+    event_id?: string;
+    event?: WebhookEventType;
+    status?: WebhookDeliveryStatus;
+    attempts?: number;
+    /**
+     * When a pending delivery is tried next
+     */
+    next_attempt_at?: string | null;
+    last_attempt_at?: string | null;
+    /**
+     * HTTP status of the last response; null if the endpoint did not respond
+     */
+    response_status?: number | null;
+    /**
+     * Why the last attempt failed
+     */
+    last_error?: string;
+    /**
+     * The JSON body sent to the endpoint: id, type, created_at and data
+     */
+    payload?: Record<string, any>;
+    created_at?: string;
+};
+
@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * pending deliveries are still being tried; dead deliveries exhausted their attempts or their endpoint was deactivated
+ */
+export enum WebhookDeliveryStatus {
+    PENDING = 'pending',
+    SUCCEEDED = 'succeeded',
+    DEAD = 'dead',
+}
+
@@ -0,0 +1,19 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { WebhookEventType } from './WebhookEventType';
+export type WebhookEndpointResponse = {
+    id?: string;
+    url?: string;
+    description?: string;
+    events?: Array<WebhookEventType>;
+    active?: boolean;
+    /**
+     * Signing secret; only returned when the endpoint is created
+     */
+    secret?: string;
+    created_at?: string;
+    updated_at?: string;
+};
+
@@ -0,0 +1,15 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+/**
+ * poll.created, poll.updated and poll.closed carry the poll; vote.cast and vote.retracted carry the poll ID, the ballot and the tallies after the change; on anonymous polls they carry only the poll ID, so neither the voter nor the ballot can be inferred.
+ */
+export enum WebhookEventType {
+    POLL_CREATED = 'poll.created',
+    POLL_UPDATED = 'poll.updated',
+    POLL_CLOSED = 'poll.closed',
+    VOTE_CAST = 'vote.cast',
+    VOTE_RETRACTED = 'vote.retracted',
+}
+
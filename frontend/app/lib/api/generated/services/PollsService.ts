@@ -2,23 +2,64 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { CreateInvitationsRequest } from '../models/CreateInvitationsRequest';
 import type { CreatePollRequest } from '../models/CreatePollRequest';
+import type { InvitationListResponse } from '../models/InvitationListResponse';
+import type { PollImportRequest } from '../models/PollImportRequest';
+import type { PollImportResponse } from '../models/PollImportResponse';
+import type { PollListResponse } from '../models/PollListResponse';
+import type { PollPatch } from '../models/PollPatch';
 import type { PollResponse } from '../models/PollResponse';
+import type { PollStatus } from '../models/PollStatus';
 import type { UpdatePollRequest } from '../models/UpdatePollRequest';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
 export class PollsService {
     /**
-     * List all polls
-     * Get a list of all polls
-     * @returns PollResponse List of polls
+     * List polls
+     * Get a page of polls matching the filters. Pages are chained with next_cursor; a cursor is only valid with the sort it was issued for and the same filters.
+     * @param q Full-text search over the title, description and option labels. Supports web search syntax: quoted phrases, OR and -word
+     * @param owner Only list polls owned by this user
+     * @param status Only list polls in this lifecycle status
+     * @param createdAfter Only list polls created at or after this time
+     * @param createdBefore Only list polls created before this time
+     * @param voted true lists only polls the caller voted on, false only polls they did not. Requires authentication
+     * @param sort Order of the listing. most_votes ranks polls whose results are not visible to everyone as if nobody voted. closing_soon only lists polls that have yet to close
+     * @param limit Maximum number of polls to return
+     * @param cursor next_cursor of the previous page
+     * @returns PollListResponse Page of polls
      * @throws ApiError
      */
-    public static listPolls(): CancelablePromise<Array<PollResponse>> {
+    public static listPolls(
+        q?: string,
+        owner?: string,
+        status?: PollStatus,
+        createdAfter?: string,
+        createdBefore?: string,
+        voted?: boolean,
+        sort: 'newest' | 'most_votes' | 'closing_soon' = 'newest',
+        limit: number = 20,
+        cursor?: string,
+    ): CancelablePromise<PollListResponse> {
         return __request(OpenAPI, {
             method: 'GET',
             url: '/api/polls',
+            query: {
+                'q': q,
+                'owner': owner,
+                'status': status,
+                'created_after': createdAfter,
+                'created_before': createdBefore,
+                'voted': voted,
+                'sort': sort,
+                'limit': limit,
+                'cursor': cursor,
+            },
+            errors: {
+                400: `Invalid filter, sort, limit or cursor`,
+                401: `voted was given without authentication`,
+            },
         });
     }
     /**
@@ -42,15 +83,47 @@ export class PollsService {
             },
         });
     }
+    /**
+     * Import polls
+     * Create polls from a file of definitions in one transaction (requires authentication). The format is taken from the Content-Type header unless the format parameter is given. Every definition is validated before anything is created; if any is invalid the import fails with 400 and an error for each invalid field, named after the definition's position, such as polls[2].title. CSV files have a header row naming their columns: external_id, title, description, type, anonymous, results_visibility, require_verified_email, min_choices, max_choices, opens_at and closes_at, plus any number of option columns. Each further row defines a poll whose options are its non-empty option cells
+     * @param requestBody
+     * @param dryRun Validate the file and report what would be created without creating anything
+     * @param format Format of the file, overriding the Content-Type header
+     * @returns PollImportResponse Outcome of the import
+     * @throws ApiError
+     */
+    public static importPolls(
+        requestBody: PollImportRequest,
+        dryRun: boolean = false,
+        format?: 'json' | 'yaml' | 'csv',
+    ): CancelablePromise<PollImportResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/poll-imports',
+            query: {
+                'dry_run': dryRun,
+                'format': format,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Unreadable, unsupported or oversized file, or invalid poll definitions`,
+                401: `Unauthorized`,
+                409: `A concurrent import created some of the polls; retry the import`,
+            },
+        });
+    }
     /**
      * Get poll by ID
-     * Get detailed information about a specific poll
+     * Get detailed information about a specific poll. Counts and voters are included only if the poll's results visibility policy allows the caller to see them; authentication is optional
      * @param id Poll ID
+     * @param ifNoneMatch ETag of the poll as last read; the response is 304 Not Modified if it still matches
      * @returns PollResponse Poll details
      * @throws ApiError
      */
     public static getPoll(
         id: string,
+        ifNoneMatch?: string,
     ): CancelablePromise<PollResponse> {
         return __request(OpenAPI, {
             method: 'GET',
@@ -58,21 +131,27 @@ export class PollsService {
             path: {
                 'id': id,
             },
+            headers: {
+                'If-None-Match': ifNoneMatch,
+            },
             errors: {
+                304: `The poll as last read, identified by If-None-Match, is unchanged`,
                 404: `Poll not found`,
             },
         });
     }
     /**
      * Update poll
-     * Update a poll (requires authentication and ownership)
+     * Update a poll (requires authentication and ownership). Empty and omitted fields are left unchanged; use PATCH to clear optional fields. The poll's version must be sent in If-Match so concurrent edits are not overwritten
      * @param id Poll ID
+     * @param ifMatch The poll's version as an entity tag, e.g. "3", as in the version field or the ETag of the last edit, or * to skip the check. The request fails with 412 if the poll has been modified since
      * @param requestBody
      * @returns PollResponse Poll updated successfully
      * @throws ApiError
      */
     public static updatePoll(
         id: string,
+        ifMatch: string,
         requestBody: UpdatePollRequest,
     ): CancelablePromise<PollResponse> {
         return __request(OpenAPI, {
@@ -81,23 +160,69 @@ export class PollsService {
             path: {
                 'id': id,
             },
+            headers: {
+                'If-Match': ifMatch,
+            },
             body: requestBody,
             mediaType: 'application/json',
             errors: {
-                400: `Invalid request or not owner`,
+                400: `Invalid request`,
                 401: `Unauthorized`,
+                403: `Only the poll owner can modify the poll`,
+                404: `Poll not found`,
+                409: `Poll is closed and its options, choice limits or schedule can no longer be changed, options would be removed from an anonymous poll that has votes, or the choice limits would be tightened on a poll that has votes`,
+                412: `The poll was modified since the ETag in If-Match was read`,
+                428: `If-Match header is missing`,
+            },
+        });
+    }
+    /**
+     * Patch poll
+     * Apply a JSON Merge Patch to a poll (requires authentication and ownership). The poll's version must be sent in If-Match so concurrent edits are not overwritten
+     * @param id Poll ID
+     * @param ifMatch The poll's version as an entity tag, e.g. "3", as in the version field or the ETag of the last edit, or * to skip the check. The request fails with 412 if the poll has been modified since
+     * @param requestBody
+     * @returns PollResponse Poll patched successfully
+     * @throws ApiError
+     */
+    public static patchPoll(
+        id: string,
+        ifMatch: string,
+        requestBody: PollPatch,
+    ): CancelablePromise<PollResponse> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/polls/{id}',
+            path: {
+                'id': id,
+            },
+            headers: {
+                'If-Match': ifMatch,
+            },
+            body: requestBody,
+            mediaType: 'application/merge-patch+json',
+            errors: {
+                400: `Invalid patch`,
+                401: `Unauthorized`,
+                403: `Only the poll owner can modify the poll`,
+                404: `Poll not found`,
+                409: `Poll is closed and its options, choice limits or schedule can no longer be changed, options would be removed from an anonymous poll that has votes, or the choice limits would be tightened on a poll that has votes`,
+                412: `The poll was modified since the ETag in If-Match was read`,
+                428: `If-Match header is missing`,
             },
         });
     }
     /**
      * Delete poll
-     * Delete a poll (requires authentication and ownership)
+     * Delete a poll (requires authentication and ownership). The poll's version must be sent in If-Match so a poll that was edited concurrently is not deleted
      * @param id Poll ID
+     * @param ifMatch The poll's version as an entity tag, e.g. "3", as in the version field or the ETag of the last edit, or * to skip the check. The request fails with 412 if the poll has been modified since
      * @returns void
      * @throws ApiError
      */
     public static deletePoll(
         id: string,
+        ifMatch: string,
     ): CancelablePromise<void> {
         return __request(OpenAPI, {
             method: 'DELETE',
@@ -105,9 +230,150 @@ export class PollsService {
             path: {
                 'id': id,
             },
+            headers: {
+                'If-Match': ifMatch,
+            },
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                403: `Only the poll owner can modify the poll`,
+                404: `Poll not found`,
+                412: `The poll was modified since the ETag in If-Match was read`,
+                428: `If-Match header is missing`,
+            },
+        });
+    }
+    /**
+     * Close poll
+     * Close a poll immediately and freeze its results (requires authentication and ownership)
+     * @param id Poll ID
+     * @returns PollResponse Poll closed
+     * @throws ApiError
+     */
+    public static closePoll(
+        id: string,
+    ): CancelablePromise<PollResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/polls/{id}/close',
+            path: {
+                'id': id,
+            },
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                403: `Only the poll owner can modify the poll`,
+                404: `Poll not found`,
+                409: `Poll is already closed, or is scheduled and has not opened yet`,
+            },
+        });
+    }
+    /**
+     * Stream poll events
+     * Stream live updates of a poll as Server-Sent Events. The stream starts with a `poll` event holding the poll as returned by getPoll, then sends `votes` events (VoteCountsResponse) when the counts change and may be seen by the caller, `poll` events (PollResponse) when the poll is edited, `status` events (PollResponse) when it opens or closes, and a final `deleted` event when it is deleted. Events carry an ID; clients reconnecting with Last-Event-ID receive the events they missed instead of a new snapshot while those are retained. Comment lines are sent as heartbeats.
+     * @param id Poll ID
+     * @param lastEventID ID of the last event received, to resume an interrupted stream
+     * @returns string Event stream
+     * @throws ApiError
+     */
+    public static streamPoll(
+        id: string,
+        lastEventID?: string,
+    ): CancelablePromise<string> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/polls/{id}/stream',
+            path: {
+                'id': id,
+            },
+            headers: {
+                'Last-Event-ID': lastEventID,
+            },
             errors: {
-                400: `Invalid request or not owner`,
+                400: `Invalid poll ID`,
+                404: `Poll not found`,
+            },
+        });
+    }
+    /**
+     * Export poll results
+     * Download the per-option totals of a poll and, unless the poll is anonymous, its ballots with voter username and time of voting (requires authentication and ownership). The export is streamed. CSV exports are one table whose record column tells totals from ballots; JSON exports hold poll, totals and ballots members; NDJSON exports have one record per line, each with a record member of poll, total or ballot; XLSX exports have a Totals and a Ballots sheet
+     * @param id Poll ID
+     * @param format File format of the export
+     * @returns binary Poll export, sent as an attachment
+     * @throws ApiError
+     */
+    public static exportPoll(
+        id: string,
+        format: 'csv' | 'json' | 'ndjson' | 'xlsx' = 'csv',
+    ): CancelablePromise<Blob> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/polls/{id}/export',
+            path: {
+                'id': id,
+            },
+            query: {
+                'format': format,
+            },
+            errors: {
+                400: `Invalid poll ID or format`,
                 401: `Unauthorized`,
+                403: `Only the poll owner can export the poll`,
+                404: `Poll not found`,
+            },
+        });
+    }
+    /**
+     * List poll invitations
+     * List the email invitations to vote on a poll (requires authentication and ownership)
+     * @param id Poll ID
+     * @returns InvitationListResponse List of invitations
+     * @throws ApiError
+     */
+    public static listPollInvitations(
+        id: string,
+    ): CancelablePromise<InvitationListResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/polls/{id}/invitations',
+            path: {
+                'id': id,
+            },
+            errors: {
+                400: `Invalid poll ID`,
+                401: `Unauthorized`,
+                403: `Only the poll owner can list invitations`,
+                404: `Poll not found`,
+            },
+        });
+    }
+    /**
+     * Invite voters
+     * Email an invitation to vote on a poll to each address not invited yet (requires authentication and ownership). Invitations expire after 7 days or when the poll closes, whichever comes first; invitees who have not voted are reminded a day before.
+     * @param id Poll ID
+     * @param requestBody
+     * @returns InvitationListResponse The new invitations; addresses already invited are left out
+     * @throws ApiError
+     */
+    public static createPollInvitations(
+        id: string,
+        requestBody: CreateInvitationsRequest,
+    ): CancelablePromise<InvitationListResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/polls/{id}/invitations',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Missing or invalid email addresses`,
+                401: `Unauthorized`,
+                403: `Only the poll owner can invite voters`,
+                404: `Poll not found`,
+                409: `Poll is closed`,
             },
         });
     }
@@ -4,15 +4,30 @@
 /* eslint-disable */
 import type { AuthResponse } from '../models/AuthResponse';
 import type { CreateUserRequest } from '../models/CreateUserRequest';
+import type { EmailVerificationConfirmRequest } from '../models/EmailVerificationConfirmRequest';
+import type { JSONWebKeySet } from '../models/JSONWebKeySet';
 import type { LoginRequest } from '../models/LoginRequest';
+import type { MFAChallengeResponse } from '../models/MFAChallengeResponse';
+import type { MFAConfirmRequest } from '../models/MFAConfirmRequest';
+import type { MFAEnrollmentResponse } from '../models/MFAEnrollmentResponse';
+import type { MFALoginRequest } from '../models/MFALoginRequest';
+import type { MFAReauthenticationRequest } from '../models/MFAReauthenticationRequest';
+import type { MFAStatusResponse } from '../models/MFAStatusResponse';
+import type { PasswordResetConfirmRequest } from '../models/PasswordResetConfirmRequest';
+import type { PasswordResetRequest } from '../models/PasswordResetRequest';
+import type { RecoveryCodesResponse } from '../models/RecoveryCodesResponse';
 import type { RefreshTokenRequest } from '../models/RefreshTokenRequest';
+import type { SecurityEventListResponse } from '../models/SecurityEventListResponse';
+import type { SessionListResponse } from '../models/SessionListResponse';
+import type { SessionResponse } from '../models/SessionResponse';
+import type { UpdateSessionRequest } from '../models/UpdateSessionRequest';
 import type { CancelablePromise } from '../core/CancelablePromise';
 import { OpenAPI } from '../core/OpenAPI';
 import { request as __request } from '../core/request';
 export class UsersService {
     /**
      * Create a new user
-     * Register a new user account
+     * Register a new user account. A link to verify the email address is mailed to it
      * @param requestBody
      * @returns AuthResponse User created successfully
      * @throws ApiError
@@ -26,20 +41,21 @@ export class UsersService {
             body: requestBody,
             mediaType: 'application/json',
             errors: {
-                400: `Invalid request or user already exists`,
+                400: `Invalid request, such as an invalid email address`,
+                409: `Email or username already taken`,
             },
         });
     }
     /**
      * User login
-     * Authenticate user and receive access tokens
+     * Authenticate user and receive access tokens. Users with two-factor authentication get an MFA challenge instead, completed with a code at /api/users/login/mfa
      * @param requestBody
-     * @returns AuthResponse Login successful
+     * @returns any Login successful, or an MFA challenge if two-factor authentication is enabled
      * @throws ApiError
      */
     public static login(
         requestBody: LoginRequest,
-    ): CancelablePromise<AuthResponse> {
+    ): CancelablePromise<(AuthResponse | MFAChallengeResponse)> {
         return __request(OpenAPI, {
             method: 'POST',
             url: '/api/users/login',
@@ -50,9 +66,31 @@ export class UsersService {
             },
         });
     }
+    /**
+     * Complete an MFA login
+     * Complete a login of a user with two-factor authentication with a code from their authenticator app or a recovery code, which can only be used once. A challenge allows 5 attempts; after 10 wrong codes within 15 minutes the user cannot complete logins until the window passes
+     * @param requestBody
+     * @returns AuthResponse Login successful
+     * @throws ApiError
+     */
+    public static completeMfaLogin(
+        requestBody: MFALoginRequest,
+    ): CancelablePromise<AuthResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/login/mfa',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Wrong code (code invalid_mfa_code), or an invalid or expired MFA token (code invalid_mfa_token)`,
+                429: `Too many wrong codes`,
+            },
+        });
+    }
     /**
      * Refresh access token
-     * Get a new access token using a refresh token
+     * Get a new access token using a refresh token. Each refresh token can be used once and is replaced by the one returned. A token presented again within a few seconds of its use returns the same replacement, for clients refreshing from several tabs at once; presented later, it is taken as stolen, the session is ended and the request fails with code refresh_token_reused
      * @param requestBody
      * @returns AuthResponse Token refreshed successfully
      * @throws ApiError
@@ -66,13 +104,13 @@ export class UsersService {
             body: requestBody,
             mediaType: 'application/json',
             errors: {
-                401: `Invalid refresh token`,
+                401: `Invalid refresh token, or a reused one whose session was ended`,
             },
         });
     }
     /**
      * User logout
-     * Logout user and revoke all refresh tokens
+     * End the session of the access token by revoking its refresh token and every access token issued to it. Tokens issued before sessions were tracked end all of the user's sessions and revoke all of their access tokens
      * @returns void
      * @throws ApiError
      */
@@ -86,4 +124,291 @@ export class UsersService {
             },
         });
     }
+    /**
+     * Request a password reset
+     * Mail a link to choose a new password to the account with the email address. The link can be used once within an hour and replaces links sent before. The response is the same whether or not an account has the address
+     * @param requestBody
+     * @returns any Reset link mailed if the account exists
+     * @throws ApiError
+     */
+    public static requestPasswordReset(
+        requestBody: PasswordResetRequest,
+    ): CancelablePromise<any> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/password-reset',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+            },
+        });
+    }
+    /**
+     * Reset a password
+     * Set a new password with the token from a password reset link. This verifies the email address, signs the user out of every session and revokes their access tokens
+     * @param requestBody
+     * @returns void
+     * @throws ApiError
+     */
+    public static resetPassword(
+        requestBody: PasswordResetConfirmRequest,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/password-reset/confirm',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request, or a token that is invalid, used or expired (code invalid_token)`,
+            },
+        });
+    }
+    /**
+     * Verify an email address
+     * Verify the email address an email verification link was sent to. Links are valid for 48 hours and can be used once
+     * @param requestBody
+     * @returns void
+     * @throws ApiError
+     */
+    public static verifyEmail(
+        requestBody: EmailVerificationConfirmRequest,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/email-verification/confirm',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request, or a token that is invalid, used or expired (code invalid_token)`,
+            },
+        });
+    }
+    /**
+     * List sessions
+     * List the devices signed in to the current user's account
+     * @returns SessionListResponse Sessions
+     * @throws ApiError
+     */
+    public static listSessions(): CancelablePromise<SessionListResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/users/me/sessions',
+            errors: {
+                401: `Unauthorized`,
+            },
+        });
+    }
+    /**
+     * End all sessions
+     * Sign out everywhere by revoking the refresh tokens of all sessions, including the current one, and every access token issued so far, including ones issued in the current second
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteAllSessions(): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/users/me/sessions',
+            errors: {
+                401: `Unauthorized`,
+            },
+        });
+    }
+    /**
+     * Rename session
+     * Set the device name of a session
+     * @param id Session ID
+     * @param requestBody
+     * @returns SessionResponse Session renamed
+     * @throws ApiError
+     */
+    public static updateSession(
+        id: string,
+        requestBody: UpdateSessionRequest,
+    ): CancelablePromise<SessionResponse> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/users/me/sessions/{id}',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid name`,
+                401: `Unauthorized`,
+                404: `Session not found`,
+            },
+        });
+    }
+    /**
+     * End session
+     * Sign a device out by revoking the session's refresh token and every access token issued to it
+     * @param id Session ID
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteSession(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/users/me/sessions/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                401: `Unauthorized`,
+                404: `Session not found`,
+            },
+        });
+    }
+    /**
+     * List security events
+     * List recent events on the current user's account that suggest it may be compromised. Events are kept for 90 days
+     * @param limit Maximum number of events to return
+     * @returns SecurityEventListResponse Security events
+     * @throws ApiError
+     */
+    public static listSecurityEvents(
+        limit: number = 50,
+    ): CancelablePromise<SecurityEventListResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/users/me/security-events',
+            query: {
+                'limit': limit,
+            },
+            errors: {
+                400: `Invalid limit`,
+                401: `Unauthorized`,
+            },
+        });
+    }
+    /**
+     * Request email verification
+     * Mail a new link to verify the current user's email address, replacing links sent before
+     * @returns any Verification link mailed
+     * @throws ApiError
+     */
+    public static requestEmailVerification(): CancelablePromise<any> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/me/email-verification',
+            errors: {
+                401: `Unauthorized`,
+                409: `Email address is already verified`,
+            },
+        });
+    }
+    /**
+     * Get two-factor authentication status
+     * @returns MFAStatusResponse Two-factor authentication status
+     * @throws ApiError
+     */
+    public static getMfaStatus(): CancelablePromise<MFAStatusResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/users/me/mfa',
+            errors: {
+                401: `Unauthorized`,
+            },
+        });
+    }
+    /**
+     * Enroll in two-factor authentication
+     * Start enrolling with a new TOTP secret. Two-factor authentication is enabled once a code is confirmed; enrolling again before that replaces the secret
+     * @returns MFAEnrollmentResponse Enrolment started
+     * @throws ApiError
+     */
+    public static enrollMfa(): CancelablePromise<MFAEnrollmentResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/me/mfa',
+            errors: {
+                401: `Unauthorized`,
+                409: `Two-factor authentication is already enabled`,
+            },
+        });
+    }
+    /**
+     * Confirm two-factor authentication
+     * Enable two-factor authentication with a code from the authenticator app the secret was added to, and get recovery codes
+     * @param requestBody
+     * @returns RecoveryCodesResponse Two-factor authentication enabled
+     * @throws ApiError
+     */
+    public static confirmMfa(
+        requestBody: MFAConfirmRequest,
+    ): CancelablePromise<RecoveryCodesResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/me/mfa/confirm',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request or wrong code`,
+                401: `Unauthorized`,
+                409: `Not enrolling, or already enabled`,
+            },
+        });
+    }
+    /**
+     * Disable two-factor authentication
+     * Turn two-factor authentication off and delete the recovery codes, after confirming the password and a code
+     * @param requestBody
+     * @returns void
+     * @throws ApiError
+     */
+    public static disableMfa(
+        requestBody: MFAReauthenticationRequest,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/me/mfa/disable',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                403: `Wrong password or code (code reauthentication_failed)`,
+                409: `Two-factor authentication is not enabled`,
+            },
+        });
+    }
+    /**
+     * Regenerate recovery codes
+     * Replace all recovery codes, used or not, after confirming the password and a code
+     * @param requestBody
+     * @returns RecoveryCodesResponse New recovery codes
+     * @throws ApiError
+     */
+    public static regenerateRecoveryCodes(
+        requestBody: MFAReauthenticationRequest,
+    ): CancelablePromise<RecoveryCodesResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/users/me/mfa/recovery-codes',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid request`,
+                401: `Unauthorized`,
+                403: `Wrong password or code (code reauthentication_failed)`,
+                409: `Two-factor authentication is not enabled`,
+            },
+        });
+    }
+    /**
+     * Get token signing keys
+     * Returns the public keys access and refresh tokens are verified with, as a JSON Web Key Set (RFC 7517)
+     * @returns JSONWebKeySet Public signing keys
+     * @throws ApiError
+     */
+    public static getJWKS(): CancelablePromise<JSONWebKeySet> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/.well-known/jwks.json',
+        });
+    }
 }
@@ -2,6 +2,7 @@
 /* istanbul ignore file */
 /* tslint:disable */
 /* eslint-disable */
+import type { RankedResultsResponse } from '../models/RankedResultsResponse';
 import type { VoteCountsResponse } from '../models/VoteCountsResponse';
 import type { VoteRequest } from '../models/VoteRequest';
 import type { VoteResponse } from '../models/VoteResponse';
@@ -12,7 +13,7 @@ import { request as __request } from '../core/request';
 export class VotesService {
     /**
      * Vote on a poll
-     * Submit a vote for a poll option (requires authentication, one vote per user per poll)
+     * Submit a ballot for a poll (requires authentication, one vote per user per poll). Ranked polls take an ordered list of choices, approval polls a set of choices within the poll's min/max limits.
      * @param id Poll ID
      * @param requestBody
      * @returns VoteResponse Vote submitted successfully
@@ -31,8 +32,11 @@ export class VotesService {
             body: requestBody,
             mediaType: 'application/json',
             errors: {
-                400: `Invalid request or already voted`,
+                400: `Invalid ballot`,
                 401: `Unauthorized`,
+                403: `Poll requires a verified email address (code email_not_verified)`,
+                404: `Poll not found`,
+                409: `Already voted, or poll is not open for voting`,
             },
         });
     }
@@ -54,8 +58,9 @@ export class VotesService {
             },
             errors: {
                 401: `Unauthorized`,
-                403: `Forbidden - can only delete your own vote`,
+                403: `Forbidden - can only delete your own vote, and votes on anonymous polls cannot be retracted`,
                 404: `Poll or vote not found`,
+                409: `Poll is not open for voting`,
             },
         });
     }
@@ -76,32 +81,90 @@ export class VotesService {
                 'id': id,
             },
             errors: {
+                403: `Results are hidden by the poll's results visibility policy`,
+                404: `Poll not found`,
+            },
+        });
+    }
+    /**
+     * Get ranked results
+     * Get the instant-runoff tabulation of a ranked poll, including every elimination round
+     * @param id Poll ID
+     * @returns RankedResultsResponse Round-by-round results
+     * @throws ApiError
+     */
+    public static getRankedResults(
+        id: string,
+    ): CancelablePromise<RankedResultsResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/polls/{id}/results',
+            path: {
+                'id': id,
+            },
+            errors: {
+                400: `Poll is not a ranked poll`,
+                403: `Results are hidden by the poll's results visibility policy`,
                 404: `Poll not found`,
             },
         });
     }
     /**
      * Get voters by option
-     * Get list of users who voted for a specific option
+     * Get a page of the users whose vote counts for a specific option: every approval on approval polls, first choices otherwise
      * @param id Poll ID
-     * @param option Poll option
+     * @param optionId Option ID
+     * @param limit Maximum number of voters to return
+     * @param cursor next_cursor of the previous page
      * @returns VotersResponse List of voters
      * @throws ApiError
      */
     public static getVotersByOption(
         id: string,
-        option: string,
+        optionId: string,
+        limit: number = 100,
+        cursor?: string,
     ): CancelablePromise<VotersResponse> {
         return __request(OpenAPI, {
             method: 'GET',
-            url: '/api/polls/{id}/votes/{option}',
+            url: '/api/polls/{id}/votes/{option_id}',
             path: {
                 'id': id,
-                'option': option,
+                'option_id': optionId,
+            },
+            query: {
+                'limit': limit,
+                'cursor': cursor,
             },
             errors: {
+                400: `Invalid option ID, limit or cursor`,
+                403: `Poll is anonymous, or its results are hidden by the results visibility policy`,
                 404: `Poll or option not found`,
             },
         });
     }
+    /**
+     * Open a WebSocket connection
+     * Upgrade to a WebSocket connection over which the client subscribes to polls, votes and retracts votes, and receives count updates and presence. Clients send RealtimeRequest and receive RealtimeMessage objects as JSON text messages. Subscribing replies with the poll as returned by getPoll and the number of viewers. Browsers, which cannot set headers on WebSocket requests, may pass the access token in the access_token query parameter. Clients that fall too far behind are disconnected with close code 1013 and should reconnect.
+     * @param accessToken Access token, for clients that cannot send the Authorization header
+     * @returns void
+     * @throws ApiError
+     */
+    public static connectRealtime(
+        accessToken?: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/ws',
+            query: {
+                'access_token': accessToken,
+            },
+            errors: {
+                400: `Not a WebSocket handshake`,
+                401: `Unauthorized`,
+                403: `Origin not allowed`,
+                429: `Too many open connections`,
+            },
+        });
+    }
 }
@@ -0,0 +1,186 @@
+/* generated using openapi-typescript-codegen -- do not edit */
+/* istanbul ignore file */
+/* tslint:disable */
+/* eslint-disable */
+import type { CreateWebhookEndpointRequest } from '../models/CreateWebhookEndpointRequest';
+import type { UpdateWebhookEndpointRequest } from '../models/UpdateWebhookEndpointRequest';
+import type { WebhookDeliveryListResponse } from '../models/WebhookDeliveryListResponse';
+import type { WebhookDeliveryResponse } from '../models/WebhookDeliveryResponse';
+import type { WebhookDeliveryStatus } from '../models/WebhookDeliveryStatus';
+import type { WebhookEndpointListResponse } from '../models/WebhookEndpointListResponse';
+import type { WebhookEndpointResponse } from '../models/WebhookEndpointResponse';
+import type { CancelablePromise } from '../core/CancelablePromise';
+import { OpenAPI } from '../core/OpenAPI';
+import { request as __request } from '../core/request';
+export class WebhooksService {
+    /**
+     * List webhook endpoints
+     * List the webhook endpoints of the current user
+     * @returns WebhookEndpointListResponse List of webhook endpoints
+     * @throws ApiError
+     */
+    public static listWebhookEndpoints(): CancelablePromise<WebhookEndpointListResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/webhooks',
+            errors: {
+                401: `Unauthorized`,
+            },
+        });
+    }
+    /**
+     * Create webhook endpoint
+     * Register an endpoint for events on the current user's polls. The response contains the signing secret, which is not shown again
+     * @param requestBody
+     * @returns WebhookEndpointResponse Webhook endpoint created
+     * @throws ApiError
+     */
+    public static createWebhookEndpoint(
+        requestBody: CreateWebhookEndpointRequest,
+    ): CancelablePromise<WebhookEndpointResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/webhooks',
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid URL or events`,
+                401: `Unauthorized`,
+            },
+        });
+    }
+    /**
+     * Get webhook endpoint
+     * Get a webhook endpoint of the current user
+     * @param id Webhook endpoint ID
+     * @returns WebhookEndpointResponse Webhook endpoint
+     * @throws ApiError
+     */
+    public static getWebhookEndpoint(
+        id: string,
+    ): CancelablePromise<WebhookEndpointResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/webhooks/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                400: `Invalid endpoint ID`,
+                401: `Unauthorized`,
+                404: `Webhook endpoint not found`,
+            },
+        });
+    }
+    /**
+     * Update webhook endpoint
+     * Change the URL, description or events of a webhook endpoint, or deactivate it. Omitted fields are left unchanged. Deliveries queued for an inactive endpoint are marked dead
+     * @param id Webhook endpoint ID
+     * @param requestBody
+     * @returns WebhookEndpointResponse Webhook endpoint updated
+     * @throws ApiError
+     */
+    public static updateWebhookEndpoint(
+        id: string,
+        requestBody: UpdateWebhookEndpointRequest,
+    ): CancelablePromise<WebhookEndpointResponse> {
+        return __request(OpenAPI, {
+            method: 'PATCH',
+            url: '/api/webhooks/{id}',
+            path: {
+                'id': id,
+            },
+            body: requestBody,
+            mediaType: 'application/json',
+            errors: {
+                400: `Invalid URL or events`,
+                401: `Unauthorized`,
+                404: `Webhook endpoint not found`,
+            },
+        });
+    }
+    /**
+     * Delete webhook endpoint
+     * Delete a webhook endpoint and its delivery log
+     * @param id Webhook endpoint ID
+     * @returns void
+     * @throws ApiError
+     */
+    public static deleteWebhookEndpoint(
+        id: string,
+    ): CancelablePromise<void> {
+        return __request(OpenAPI, {
+            method: 'DELETE',
+            url: '/api/webhooks/{id}',
+            path: {
+                'id': id,
+            },
+            errors: {
+                400: `Invalid endpoint ID`,
+                401: `Unauthorized`,
+                404: `Webhook endpoint not found`,
+            },
+        });
+    }
+    /**
+     * List webhook deliveries
+     * Get a page of the delivery log of a webhook endpoint, newest first
+     * @param id Webhook endpoint ID
+     * @param status Only return deliveries with this status
+     * @param limit Maximum number of deliveries to return
+     * @param cursor next_cursor of the previous page
+     * @returns WebhookDeliveryListResponse List of deliveries
+     * @throws ApiError
+     */
+    public static listWebhookDeliveries(
+        id: string,
+        status?: WebhookDeliveryStatus,
+        limit: number = 50,
+        cursor?: string,
+    ): CancelablePromise<WebhookDeliveryListResponse> {
+        return __request(OpenAPI, {
+            method: 'GET',
+            url: '/api/webhooks/{id}/deliveries',
+            path: {
+                'id': id,
+            },
+            query: {
+                'status': status,
+                'limit': limit,
+                'cursor': cursor,
+            },
+            errors: {
+                400: `Invalid endpoint ID, status, limit or cursor`,
+                401: `Unauthorized`,
+                404: `Webhook endpoint not found`,
+            },
+        });
+    }
+    /**
+     * Redeliver webhook
+     * Queue the payload of a past delivery again. The new delivery carries the same event ID
+     * @param id Webhook endpoint ID
+     * @param deliveryId Delivery ID
+     * @returns WebhookDeliveryResponse Redelivery queued
+     * @throws ApiError
+     */
+    public static redeliverWebhook(
+        id: string,
+        deliveryId: string,
+    ): CancelablePromise<WebhookDeliveryResponse> {
+        return __request(OpenAPI, {
+            method: 'POST',
+            url: '/api/webhooks/{id}/deliveries/{delivery_id}/redeliver',
+            path: {
+                'id': id,
+                'delivery_id': deliveryId,
+            },
+            errors: {
+                400: `Invalid endpoint or delivery ID`,
+                401: `Unauthorized`,
+                404: `Webhook endpoint or delivery not found`,
+                409: `Webhook endpoint is inactive`,
+            },
+        });
+    }
+}
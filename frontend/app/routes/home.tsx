@@ -27,7 +27,7 @@ export default function Home() {
     try {
       setLoading(true);
       const data = await apiClient.listPolls();
-      setPolls(data);
+      setPolls(data.polls);
     } catch (err: any) {
       setError(err.message || "Failed to load polls");
     } finally {
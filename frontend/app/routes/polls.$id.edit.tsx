@@ -3,6 +3,7 @@ import type { FormEvent } from "react";
 import { useParams, useNavigate } from "react-router";
 import type { Route } from "./+types/polls.$id.edit";
 import { apiClient } from "../lib/api/client";
+import type { PollOptionInput } from "../lib/api/client";
 import { useAuth } from "../lib/contexts/auth";
 import { Navigation } from "../components/Navigation";
 
@@ -19,7 +20,10 @@ export default function EditPoll() {
   const { isAuthenticated, user } = useAuth();
   const [title, setTitle] = useState("");
   const [description, setDescription] = useState("");
-  const [options, setOptions] = useState<string[]>([""]);
+  // Existing options keep their id so renaming or moving them keeps their votes
+  const [options, setOptions] = useState<PollOptionInput[]>([{ label: "" }]);
+  // ETag of the poll as loaded, so edits made elsewhere since are not overwritten
+  const [etag, setEtag] = useState("");
   const [error, setError] = useState<string | null>(null);
   const [loading, setLoading] = useState(true);
   const [saving, setSaving] = useState(false);
@@ -48,7 +52,12 @@ export default function EditPoll() {
 
       setTitle(poll.title || "");
       setDescription(poll.description || "");
-      setOptions(poll.options && poll.options.length > 0 ? poll.options : [""]);
+      setOptions(
+        poll.options && poll.options.length > 0
+          ? poll.options.map((option) => ({ id: option.id, label: option.label || "" }))
+          : [{ label: "" }]
+      );
+      setEtag(`"${poll.version}"`);
     } catch (err: any) {
       setError(err.message || "Failed to load poll");
     } finally {
@@ -57,7 +66,7 @@ export default function EditPoll() {
   };
 
   const addOption = () => {
-    setOptions([...options, ""]);
+    setOptions([...options, { label: "" }]);
   };
 
   const removeOption = (index: number) => {
@@ -68,7 +77,7 @@ export default function EditPoll() {
 
   const updateOption = (index: number, value: string) => {
     const newOptions = [...options];
-    newOptions[index] = value;
+    newOptions[index] = { ...newOptions[index], label: value };
     setOptions(newOptions);
   };
 
@@ -76,7 +85,7 @@ export default function EditPoll() {
     e.preventDefault();
     setError(null);
 
-    const validOptions = options.filter((opt) => opt.trim() !== "");
+    const validOptions = options.filter((opt) => opt.label.trim() !== "");
     if (validOptions.length < 2) {
       setError("Please provide at least 2 options");
       return;
@@ -89,7 +98,7 @@ export default function EditPoll() {
         title,
         description: description || undefined,
         options: validOptions,
-      });
+      }, etag);
       navigate(`/polls/${id}`);
     } catch (err: any) {
       setError(err.response?.error || err.message || "Failed to update poll");
@@ -104,7 +113,7 @@ export default function EditPoll() {
     }
 
     try {
-      await apiClient.deletePoll(id!);
+      await apiClient.deletePoll(id!, etag);
       navigate("/");
     } catch (err: any) {
       // Extract error message from various error formats
@@ -189,10 +198,10 @@ export default function EditPoll() {
             </label>
             <div className="space-y-2">
               {options.map((option, index) => (
-                <div key={index} className="flex gap-2">
+                <div key={option.id || index} className="flex gap-2">
                   <input
                     type="text"
-                    value={option}
+                    value={option.label}
                     onChange={(e) => updateOption(index, e.target.value)}
                     className="flex-1 px-3 py-2 border border-gray-300 dark:border-gray-700 rounded-md text-gray-900 dark:text-white bg-white dark:bg-gray-700 focus:outline-none focus:ring-blue-500 focus:border-blue-500"
                     placeholder={`Option ${index + 1}`}
@@ -3,7 +3,8 @@ import { useParams, Link, useNavigate } from "react-router";
 import type { Route } from "./+types/polls.$id";
 import { apiClient } from "../lib/api/client";
 import { useAuth } from "../lib/contexts/auth";
-import type { PollResponse, UserInfo } from "../lib/api/client";
+import type { PollOption, PollResponse, UserInfo } from "../lib/api/client";
+import { PollType } from "../lib/api/generated";
 import { Navigation } from "../components/Navigation";
 
 export function meta({}: Route.MetaArgs) {
@@ -18,8 +19,11 @@ export default function PollDetail() {
   const navigate = useNavigate();
   const { isAuthenticated, user } = useAuth();
   const [poll, setPoll] = useState<PollResponse | null>(null);
-  const [selectedOption, setSelectedOption] = useState<string>("");
-  const [selectedOptionForVoters, setSelectedOptionForVoters] = useState<string | null>(null);
+  // IDs of the chosen options; ranked polls keep them in order of preference
+  const [selectedOptions, setSelectedOptions] = useState<string[]>([]);
+  const [selectedOptionForVoters, setSelectedOptionForVoters] = useState<PollOption | null>(null);
+  const [voters, setVoters] = useState<UserInfo[]>([]);
+  const [votersCursor, setVotersCursor] = useState<string | null>(null);
   const [loading, setLoading] = useState(true);
   const [voting, setVoting] = useState(false);
   const [reverting, setReverting] = useState(false);
@@ -42,8 +46,20 @@ export default function PollDetail() {
     }
   };
 
+  const isSingleChoice = !poll?.type || poll.type === PollType.SINGLE;
+
+  const toggleOption = (optionId: string) => {
+    if (isSingleChoice) {
+      setSelectedOptions([optionId]);
+    } else if (selectedOptions.includes(optionId)) {
+      setSelectedOptions(selectedOptions.filter((selected) => selected !== optionId));
+    } else {
+      setSelectedOptions([...selectedOptions, optionId]);
+    }
+  };
+
   const handleVote = async () => {
-    if (!selectedOption || !isAuthenticated) {
+    if (selectedOptions.length === 0 || !isAuthenticated) {
       return;
     }
 
@@ -51,9 +67,12 @@ export default function PollDetail() {
     setError(null);
 
     try {
-      await apiClient.voteOnPoll(id!, { option: selectedOption });
+      await apiClient.voteOnPoll(
+        id!,
+        isSingleChoice ? { option_id: selectedOptions[0] } : { choices: selectedOptions }
+      );
       await loadPoll();
-      setSelectedOption("");
+      setSelectedOptions([]);
     } catch (err: any) {
       setError(err.response?.error || err.message || "Failed to vote");
     } finally {
@@ -61,44 +80,28 @@ export default function PollDetail() {
     }
   };
 
-  const handleVoteCountClick = (option: string) => {
-    setSelectedOptionForVoters(option);
-  };
-
-  const isOwner = poll && isAuthenticated && user && poll.owner_id === user.user_id;
-
-  // Check if the current user has voted
-  const hasUserVoted = (): boolean => {
-    if (!poll || !user || !poll.voters_by_option) {
-      return false;
-    }
-    
-    // Check if user's ID appears in any of the voters_by_option arrays
-    for (const option in poll.voters_by_option) {
-      console.log(option, user.user_id);
-      const voters = poll.voters_by_option[option];
-      if (voters.some((voter: UserInfo) => voter.id === user.user_id)) {
-        return true;
-      }
+  const loadVoters = async (option: PollOption, cursor?: string) => {
+    try {
+      const page = await apiClient.getVotersByOption(id!, option.id!, cursor);
+      setVoters((current) => (cursor ? [...current, ...(page.voters || [])] : page.voters || []));
+      setVotersCursor(page.next_cursor || null);
+    } catch (err: any) {
+      setError(err.response?.error || err.message || "Failed to load voters");
     }
-    return false;
   };
 
-  // Get the option the user voted for
-  const getUserVotedOption = (): string | null => {
-    if (!poll || !user || !poll.voters_by_option) {
-      return null;
-    }
-    
-    for (const option in poll.voters_by_option) {
-      const voters = poll.voters_by_option[option];
-      if (voters.some((voter: UserInfo) => voter.id === user.user_id)) {
-        return option;
-      }
+  const handleVoteCountClick = (option: PollOption) => {
+    if (poll?.anonymous) {
+      return;
     }
-    return null;
+    setSelectedOptionForVoters(option);
+    setVoters([]);
+    setVotersCursor(null);
+    loadVoters(option);
   };
 
+  const isOwner = poll && isAuthenticated && user && poll.owner_id === user.user_id;
+
   const handleRevertVote = async () => {
     if (!isAuthenticated || !id) {
       return;
@@ -110,7 +113,8 @@ export default function PollDetail() {
     try {
       await apiClient.deleteVote(id);
       await loadPoll();
-      setSelectedOption("");
+      setSelectedOptions([]);
+      setSelectedOptionForVoters(null);
     } catch (err: any) {
       setError(err.response?.error || err.message || "Failed to revert vote");
     } finally {
@@ -118,8 +122,7 @@ export default function PollDetail() {
     }
   };
 
-  const userHasVoted = hasUserVoted();
-  const userVotedOption = getUserVotedOption();
+  const userHasVoted = poll?.has_voted === true;
 
   if (loading) {
     return (
@@ -149,9 +152,11 @@ export default function PollDetail() {
     return null;
   }
 
-  const totalVotes = poll?.vote_counts
-    ? Object.values(poll.vote_counts).reduce((sum: number, count: number) => sum + count, 0)
-    : 0;
+  const totalVotes =
+    poll.voters ??
+    (poll.vote_counts
+      ? Object.values(poll.vote_counts).reduce((sum: number, count: number) => sum + count, 0)
+      : 0);
 
   return (
     <>
@@ -212,29 +217,42 @@ export default function PollDetail() {
               <h2 className="text-lg font-semibold text-gray-900 dark:text-white mb-4">
                 Cast your votes
               </h2>
+              {poll.type === PollType.RANKED && (
+                <p className="text-sm text-gray-600 dark:text-gray-400 mb-2">
+                  Select options in order of preference.
+                </p>
+              )}
               <div className="space-y-2">
-                {poll.options?.map((option: string) => (
-                  <label
-                    key={option}
-                    className="flex items-center p-3 border border-gray-300 dark:border-gray-700 rounded-md hover:bg-gray-50 dark:hover:bg-gray-700 cursor-pointer"
-                  >
-                    <input
-                      type="radio"
-                      name="option"
-                      value={option}
-                      checked={selectedOption === option}
-                      onChange={(e) => setSelectedOption(e.target.value)}
-                      className="mr-3"
-                    />
-                    <span className="text-gray-900 dark:text-white">
-                      {option}
-                    </span>
-                  </label>
-                ))}
+                {poll.options?.map((option: PollOption) => {
+                  const rank = selectedOptions.indexOf(option.id!);
+                  return (
+                    <label
+                      key={option.id}
+                      className="flex items-center p-3 border border-gray-300 dark:border-gray-700 rounded-md hover:bg-gray-50 dark:hover:bg-gray-700 cursor-pointer"
+                    >
+                      <input
+                        type={isSingleChoice ? "radio" : "checkbox"}
+                        name="option"
+                        value={option.id}
+                        checked={rank !== -1}
+                        onChange={() => toggleOption(option.id!)}
+                        className="mr-3"
+                      />
+                      <span className="text-gray-900 dark:text-white">
+                        {option.label}
+                      </span>
+                      {poll.type === PollType.RANKED && rank !== -1 && (
+                        <span className="ml-auto text-sm text-gray-500 dark:text-gray-400">
+                          #{rank + 1}
+                        </span>
+                      )}
+                    </label>
+                  );
+                })}
               </div>
               <button
                 onClick={handleVote}
-                disabled={!selectedOption || voting}
+                disabled={selectedOptions.length === 0 || voting}
                 className="mt-4 w-full py-2 px-4 bg-blue-600 text-white rounded-md hover:bg-blue-700 disabled:opacity-50 disabled:cursor-not-allowed"
               >
                 {voting ? "Voting..." : "Vote"}
@@ -257,45 +275,37 @@ export default function PollDetail() {
             </div>
           )}
 
-          {userHasVoted && (
+          {poll.vote_counts && (
             <div className="mt-6">
               <div className="flex items-center justify-between mb-4">
                 <h2 className="text-lg font-semibold text-gray-900 dark:text-white">
                   Results ({totalVotes} {totalVotes === 1 ? "vote" : "votes"})
                 </h2>
-                <button
-                  onClick={handleRevertVote}
-                  disabled={reverting}
-                  className="px-4 py-2 text-sm bg-gray-200 dark:bg-gray-700 text-gray-900 dark:text-white rounded-md hover:bg-gray-300 dark:hover:bg-gray-600 disabled:opacity-50 disabled:cursor-not-allowed"
-                >
-                  {reverting ? "Reverting..." : "Change Vote"}
-                </button>
+                {userHasVoted && !poll.anonymous && (
+                  <button
+                    onClick={handleRevertVote}
+                    disabled={reverting}
+                    className="px-4 py-2 text-sm bg-gray-200 dark:bg-gray-700 text-gray-900 dark:text-white rounded-md hover:bg-gray-300 dark:hover:bg-gray-600 disabled:opacity-50 disabled:cursor-not-allowed"
+                  >
+                    {reverting ? "Reverting..." : "Change Vote"}
+                  </button>
+                )}
               </div>
               <div className="space-y-2">
-                {poll.options?.map((option: string) => {
-                  const count = poll?.vote_counts?.[option] || 0;
+                {poll.options?.map((option: PollOption) => {
+                  const count = poll?.vote_counts?.[option.id!] || 0;
                   const percentage =
                     totalVotes > 0 ? Math.round((count / totalVotes) * 100) : 0;
-                  const isUserVotedOption = option === userVotedOption;
 
                   return (
-                    <div key={option} className="relative">
+                    <div key={option.id} className="relative">
                       <button
                         onClick={() => handleVoteCountClick(option)}
-                        className={`w-full text-left p-3 border rounded-md hover:bg-gray-50 dark:hover:bg-gray-700 transition-colors ${
-                          isUserVotedOption
-                            ? "border-green-500 dark:border-green-400 border-2"
-                            : "border-gray-300 dark:border-gray-700"
-                        }`}
+                        className="w-full text-left p-3 border rounded-md hover:bg-gray-50 dark:hover:bg-gray-700 transition-colors border-gray-300 dark:border-gray-700"
                       >
                         <div className="flex items-center justify-between mb-1">
                           <span className="text-gray-900 dark:text-white font-medium">
-                            {option}
-                            {isUserVotedOption && (
-                              <span className="ml-2 text-xs text-green-600 dark:text-green-400">
-                                (Your vote)
-                              </span>
-                            )}
+                            {option.label}
                           </span>
                           <span className="text-gray-600 dark:text-gray-400 text-sm">
                             {count} ({percentage}%)
@@ -320,7 +330,7 @@ export default function PollDetail() {
           <div className="bg-white dark:bg-gray-800 rounded-lg shadow p-6">
             <div className="flex items-center justify-between mb-4">
               <h3 className="text-lg font-semibold text-gray-900 dark:text-white">
-                Voters for "{selectedOptionForVoters}"
+                Voters for "{selectedOptionForVoters.label}"
               </h3>
               <button
                 onClick={() => setSelectedOptionForVoters(null)}
@@ -330,12 +340,12 @@ export default function PollDetail() {
               </button>
             </div>
             <div className="space-y-2">
-              {!poll?.voters_by_option?.[selectedOptionForVoters] || poll.voters_by_option[selectedOptionForVoters].length === 0 ? (
+              {voters.length === 0 ? (
                 <p className="text-gray-500 dark:text-gray-400">
                   No voters yet
                 </p>
               ) : (
-                poll.voters_by_option[selectedOptionForVoters].map((voter: UserInfo) => (
+                voters.map((voter: UserInfo) => (
                   <div
                     key={voter.id}
                     className="p-2 border border-gray-200 dark:border-gray-700 rounded"
@@ -350,6 +360,14 @@ export default function PollDetail() {
                 ))
               )}
             </div>
+            {votersCursor && (
+              <button
+                onClick={() => loadVoters(selectedOptionForVoters, votersCursor)}
+                className="mt-4 text-sm text-blue-600 hover:text-blue-500 dark:text-blue-400"
+              >
+                Load more
+              </button>
+            )}
           </div>
         )}
       </div>
@@ -60,7 +60,7 @@ export default function CreatePoll() {
       const poll = await apiClient.createPoll({
         title,
         description: description || undefined,
-        options: validOptions,
+        options: validOptions.map((label) => ({ label })),
       });
       navigate(`/polls/${poll.id}`);
     } catch (err: any) {